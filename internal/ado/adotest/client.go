@@ -6,11 +6,29 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado"
 )
 
-const tagRefPrefix = "refs/tags/"
+const (
+	tagRefPrefix   = "refs/tags/"
+	headsRefPrefix = "refs/heads/"
+)
+
+// CommentCall records a pull request comment posted through the fake client.
+type CommentCall struct {
+	PRID    int
+	Content string
+}
+
+// UpdateCall records a ref move made through the fake client.
+type UpdateCall struct {
+	Name        string
+	OldObjectID string
+	NewObjectID string
+}
 
 // DeleteCall records a ref deletion request made through the fake client.
 type DeleteCall struct {
@@ -22,14 +40,76 @@ type DeleteCall struct {
 type Client struct {
 	refs       map[string]ado.Ref
 	nextObject int
+	listMu     sync.Mutex
+
+	ListErr       error
+	CreateErr     error
+	DeleteErr     error
+	IdentityErr   error
+	PermissionErr error
+	CommitsErr    error
+	AncestorErr   error
+	CommitErr     error
 
-	ListErr   error
-	CreateErr error
-	DeleteErr error
+	// Identity is returned by GetAuthenticatedIdentity.
+	Identity ado.Identity
+	// Denied lists permissions HasPermission reports as missing; all others are granted.
+	Denied map[ado.Permission]bool
+	// CheckedPermissions records every permission passed to HasPermission.
+	CheckedPermissions []ado.Permission
+	// Commits is returned by ListCommitsBetween regardless of the requested range.
+	Commits []ado.Commit
+	// MissingCommits lists commits CommitExists reports as absent; all others exist.
+	MissingCommits map[string]bool
+	// CommitParents is served by GetCommit as each commit's parents, keyed by commit SHA.
+	CommitParents map[string][]string
+	// CommitMessages is served by GetCommitMessage, keyed by commit SHA; commits without an
+	// entry have an empty message.
+	CommitMessages map[string]string
+	// PRLabels is served by ListPRLabels, keyed by pull request ID.
+	PRLabels map[int][]string
+	// MergeCommits is served by FindPullRequestByMergeCommit, mapping merge commits to pull
+	// request IDs; missing commits return ado.ErrPullRequestNotFound.
+	MergeCommits map[string]int
+	// MergeCommitPRs adds the other pull requests FindPullRequestsByMergeCommit reports for a
+	// merge commit, such as cherry-picks, on top of its MergeCommits entry.
+	MergeCommitPRs map[string][]int
+	// ChangedPaths is served by ListPullRequestChangedPaths, keyed by pull request ID.
+	ChangedPaths map[int][]string
+	// ChangedPathsErr, when set, is returned by ListPullRequestChangedPaths.
+	ChangedPathsErr error
+	// CommentErr, when set, is returned by AddPRComment instead of recording the comment.
+	CommentErr error
+	// TagDates is served by GetTagDate, keyed by tag object ID; missing IDs return ado.ErrRefNotFound.
+	TagDates map[string]time.Time
+	// NotAncestors lists commits IsAncestor reports as unreachable; all others are ancestors.
+	NotAncestors map[string]bool
+	// PullRequests is served by GetPullRequest; missing IDs return ado.ErrPullRequestNotFound.
+	PullRequests map[int]ado.PullRequest
+	// Files is served by GetFileContent, keyed by path; missing paths return ado.ErrFileNotFound.
+	Files map[string]string
+	// FileReads records the commit passed to each GetFileContent call.
+	FileReads []string
+	// PullRequestTitles is served by GetPullRequestTitle; missing IDs return ado.ErrPullRequestNotFound.
+	PullRequestTitles map[int]string
+	// MoveAfterLookup simulates a concurrent writer: after GetRefObjectID answers for a ref
+	// listed here, the ref is moved to the mapped object ID (once).
+	MoveAfterLookup map[string]string
+	// ConcurrentTags simulates a concurrent run: the first create of a tag listed here finds it
+	// already created, as a lightweight tag at the mapped commit.
+	ConcurrentTags map[string]string
+	// HiddenListings simulates ADO's eventual consistency: ListRefsWithPrefix omits each ref
+	// named here, once it exists, from that many listings.
+	HiddenListings map[string]int
+	// RefLookups records every ref name passed to GetRefObjectID.
+	RefLookups []string
 
-	LastPrefix  string
-	CreatedTags []ado.TagSpec
-	DeletedRefs []DeleteCall
+	LastPrefix      string
+	CreatedTags     []ado.TagSpec
+	LightweightTags []string
+	DeletedRefs     []DeleteCall
+	UpdatedRefs     []UpdateCall
+	Comments        []CommentCall
 }
 
 // NewClient creates an empty ADO-shaped fake repository.
@@ -51,6 +131,23 @@ func (c *Client) SeedAnnotatedTag(name, refObjectID, targetObjectID string) {
 	}
 }
 
+// SeedBranch stores a branch ref under refs/heads/.
+func (c *Client) SeedBranch(name, objectID string) {
+	c.ensureRefs()
+	refName := headsRefPrefix + strings.TrimPrefix(strings.TrimSpace(name), headsRefPrefix)
+	c.refs[refName] = ado.Ref{Name: refName, ObjectID: strings.TrimSpace(objectID)}
+}
+
+func (c *Client) applyConcurrentCreate(refName string) {
+	short := strings.TrimPrefix(refName, tagRefPrefix)
+	target, ok := c.ConcurrentTags[short]
+	if !ok {
+		return
+	}
+	delete(c.ConcurrentTags, short)
+	c.refs[refName] = ado.Ref{Name: refName, ObjectID: target}
+}
+
 // Ref returns the current ref state for a tag name or full ref name.
 func (c *Client) Ref(name string) (ado.Ref, bool) {
 	c.ensureRefs()
@@ -58,19 +155,45 @@ func (c *Client) Ref(name string) (ado.Ref, bool) {
 	return ref, ok
 }
 
-// ListRefsWithPrefix returns refs whose names start with the requested prefix.
+// GetRefObjectID returns the current object ID of a tag name or full ref name.
+func (c *Client) GetRefObjectID(_ context.Context, name string) (string, error) {
+	c.ensureRefs()
+	refName := normalizeTagRef(name)
+	c.RefLookups = append(c.RefLookups, refName)
+	ref, ok := c.refs[refName]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ado.ErrRefNotFound, refName)
+	}
+	if moved, ok := c.MoveAfterLookup[refName]; ok {
+		delete(c.MoveAfterLookup, refName)
+		updated := ref
+		updated.ObjectID = moved
+		c.refs[refName] = updated
+	}
+	return ref.ObjectID, nil
+}
+
+// ListRefsWithPrefix returns refs whose names start with the requested prefix. It is safe to
+// call concurrently, as ado.ListRefNamespaces does.
 func (c *Client) ListRefsWithPrefix(_ context.Context, prefix string) ([]ado.Ref, error) {
 	if c.ListErr != nil {
 		return nil, c.ListErr
 	}
+	c.listMu.Lock()
+	defer c.listMu.Unlock()
 	c.ensureRefs()
 	c.LastPrefix = prefix
 
 	names := make([]string, 0, len(c.refs))
 	for name := range c.refs {
-		if strings.HasPrefix(name, prefix) {
-			names = append(names, name)
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		if c.HiddenListings[name] > 0 {
+			c.HiddenListings[name]--
+			continue
 		}
+		names = append(names, name)
 	}
 	sort.Strings(names)
 
@@ -118,8 +241,9 @@ func (c *Client) CreateAnnotatedTag(_ context.Context, spec ado.TagSpec) error {
 	if refName == tagRefPrefix {
 		return errors.New("adotest: tag name is empty")
 	}
+	c.applyConcurrentCreate(refName)
 	if _, exists := c.refs[refName]; exists {
-		return fmt.Errorf("adotest: ref %s already exists", refName)
+		return fmt.Errorf("adotest: %w: %s", ado.ErrRefExists, refName)
 	}
 
 	target := strings.TrimSpace(spec.ObjectID)
@@ -137,14 +261,115 @@ func (c *Client) CreateAnnotatedTag(_ context.Context, spec ado.TagSpec) error {
 	return nil
 }
 
-// FindPullRequestByMergeCommit is not implemented for tag workflow tests.
-func (c *Client) FindPullRequestByMergeCommit(context.Context, string) (int, error) {
-	return 0, errors.New("adotest: pull request queries are not implemented")
+// CreateLightweightTag creates a tag ref pointing directly at objectID and fails if the ref already exists.
+func (c *Client) CreateLightweightTag(_ context.Context, name string, objectID string) error {
+	if c.CreateErr != nil {
+		return c.CreateErr
+	}
+	c.ensureRefs()
+
+	refName := normalizeTagRef(name)
+	if refName == tagRefPrefix {
+		return errors.New("adotest: tag name is empty")
+	}
+	c.applyConcurrentCreate(refName)
+	if _, exists := c.refs[refName]; exists {
+		return fmt.Errorf("adotest: %w: %s", ado.ErrRefExists, refName)
+	}
+	target := strings.TrimSpace(objectID)
+	if target == "" {
+		return errors.New("adotest: tag object id is empty")
+	}
+
+	c.refs[refName] = ado.Ref{Name: refName, ObjectID: target}
+	c.LightweightTags = append(c.LightweightTags, refName)
+	return nil
 }
 
-// ListPRLabels is not implemented for tag workflow tests.
-func (c *Client) ListPRLabels(context.Context, int) ([]string, error) {
-	return nil, errors.New("adotest: pull request labels are not implemented")
+// UpdateRef moves an existing ref to newObjectID only when oldObjectID matches the current ref object ID.
+// The moved ref is lightweight, so it no longer carries a peeled object ID.
+func (c *Client) UpdateRef(_ context.Context, name string, oldObjectID string, newObjectID string) error {
+	if c.CreateErr != nil {
+		return c.CreateErr
+	}
+	c.ensureRefs()
+
+	refName := normalizeTagRef(name)
+	ref, ok := c.refs[refName]
+	if !ok {
+		return fmt.Errorf("adotest: ref %s does not exist", refName)
+	}
+	current := strings.TrimSpace(ref.ObjectID)
+	if current != strings.TrimSpace(oldObjectID) {
+		return fmt.Errorf("adotest: updating %s: old object id %s does not match current ref object id %s", refName, oldObjectID, current)
+	}
+	target := strings.TrimSpace(newObjectID)
+	if target == "" {
+		return errors.New("adotest: new object id is empty")
+	}
+
+	c.refs[refName] = ado.Ref{Name: refName, ObjectID: target}
+	c.UpdatedRefs = append(c.UpdatedRefs, UpdateCall{Name: refName, OldObjectID: oldObjectID, NewObjectID: target})
+	return nil
+}
+
+// FindPullRequestByMergeCommit returns the seeded MergeCommits entry for commitSHA.
+func (c *Client) FindPullRequestByMergeCommit(_ context.Context, commitSHA string) (int, error) {
+	prID, ok := c.MergeCommits[commitSHA]
+	if !ok {
+		return 0, ado.ErrPullRequestNotFound
+	}
+	return prID, nil
+}
+
+// FindPullRequestsByMergeCommit returns the MergeCommits and MergeCommitPRs entries for
+// commitSHA in ascending order.
+func (c *Client) FindPullRequestsByMergeCommit(_ context.Context, commitSHA string) ([]int, error) {
+	var ids []int
+	if prID, ok := c.MergeCommits[commitSHA]; ok {
+		ids = append(ids, prID)
+	}
+	for _, prID := range c.MergeCommitPRs[commitSHA] {
+		if len(ids) == 0 || prID != ids[0] {
+			ids = append(ids, prID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, ado.ErrPullRequestNotFound
+	}
+	sort.Ints(ids)
+	return ids, nil
+}
+
+// ListPullRequestChangedPaths returns the seeded paths for prID, or ChangedPathsErr.
+func (c *Client) ListPullRequestChangedPaths(_ context.Context, prID int) ([]string, error) {
+	if c.ChangedPathsErr != nil {
+		return nil, c.ChangedPathsErr
+	}
+	return c.ChangedPaths[prID], nil
+}
+
+// GetPullRequest returns the seeded pull request for prID.
+func (c *Client) GetPullRequest(_ context.Context, prID int) (ado.PullRequest, error) {
+	pr, ok := c.PullRequests[prID]
+	if !ok {
+		return ado.PullRequest{}, ado.ErrPullRequestNotFound
+	}
+	return pr, nil
+}
+
+// GetPullRequestTitle returns the seeded title for prID.
+func (c *Client) GetPullRequestTitle(_ context.Context, prID int) (string, error) {
+	title, ok := c.PullRequestTitles[prID]
+	if !ok {
+		return "", ado.ErrPullRequestNotFound
+	}
+	return title, nil
+}
+
+// ListPRLabels serves PRLabels; pull requests without an entry have no labels.
+func (c *Client) ListPRLabels(_ context.Context, prID int) ([]string, error) {
+	return c.PRLabels[prID], nil
 }
 
 // AddPRLabel is not implemented for tag workflow tests.
@@ -152,6 +377,101 @@ func (c *Client) AddPRLabel(context.Context, int, string) error {
 	return errors.New("adotest: pull request labels are not implemented")
 }
 
+// AddPRComment records the comment, or returns CommentErr.
+func (c *Client) AddPRComment(_ context.Context, prID int, content string) error {
+	if c.CommentErr != nil {
+		return c.CommentErr
+	}
+	c.Comments = append(c.Comments, CommentCall{PRID: prID, Content: content})
+	return nil
+}
+
+// GetAuthenticatedIdentity returns the configured Identity or IdentityErr.
+func (c *Client) GetAuthenticatedIdentity(context.Context) (ado.Identity, error) {
+	if c.IdentityErr != nil {
+		return ado.Identity{}, c.IdentityErr
+	}
+	return c.Identity, nil
+}
+
+// ListCommitsBetween returns the configured Commits or CommitsErr.
+func (c *Client) ListCommitsBetween(context.Context, string, string) ([]ado.Commit, error) {
+	if c.CommitsErr != nil {
+		return nil, c.CommitsErr
+	}
+	return append([]ado.Commit(nil), c.Commits...), nil
+}
+
+// IsAncestor reports every commit not listed in NotAncestors as reachable.
+func (c *Client) IsAncestor(_ context.Context, ancestor, _ string) (bool, error) {
+	if c.AncestorErr != nil {
+		return false, c.AncestorErr
+	}
+	return !c.NotAncestors[strings.TrimSpace(ancestor)], nil
+}
+
+// CommitExists reports every commit not listed in MissingCommits as present.
+func (c *Client) CommitExists(_ context.Context, commitSHA string) (bool, error) {
+	if c.CommitErr != nil {
+		return false, c.CommitErr
+	}
+	return !c.MissingCommits[strings.TrimSpace(commitSHA)], nil
+}
+
+// GetCommit returns the commit with its CommitParents entry; MissingCommits return
+// ado.ErrCommitNotFound.
+func (c *Client) GetCommit(_ context.Context, commitSHA string) (ado.Commit, error) {
+	if c.CommitErr != nil {
+		return ado.Commit{}, c.CommitErr
+	}
+	commit := strings.TrimSpace(commitSHA)
+	if c.MissingCommits[commit] {
+		return ado.Commit{}, ado.ErrCommitNotFound
+	}
+	return ado.Commit{ID: commit, Parents: append([]string(nil), c.CommitParents[commit]...)}, nil
+}
+
+// GetCommitMessage returns the CommitMessages entry for commitSHA; MissingCommits return
+// ado.ErrCommitNotFound.
+func (c *Client) GetCommitMessage(_ context.Context, commitSHA string) (string, error) {
+	if c.CommitErr != nil {
+		return "", c.CommitErr
+	}
+	commit := strings.TrimSpace(commitSHA)
+	if c.MissingCommits[commit] {
+		return "", ado.ErrCommitNotFound
+	}
+	return c.CommitMessages[commit], nil
+}
+
+// GetTagDate returns the TagDates entry for the tag object.
+func (c *Client) GetTagDate(_ context.Context, tagObjectID string) (time.Time, error) {
+	date, ok := c.TagDates[strings.TrimSpace(tagObjectID)]
+	if !ok {
+		return time.Time{}, fmt.Errorf("%w: tag object %s", ado.ErrRefNotFound, tagObjectID)
+	}
+	return date, nil
+}
+
+// GetFileContent serves Files regardless of commit and records the requested commit.
+func (c *Client) GetFileContent(_ context.Context, path, commitSHA string) ([]byte, error) {
+	c.FileReads = append(c.FileReads, commitSHA)
+	content, ok := c.Files[strings.TrimSpace(path)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ado.ErrFileNotFound, path)
+	}
+	return []byte(content), nil
+}
+
+// HasPermission grants every permission not listed in Denied.
+func (c *Client) HasPermission(_ context.Context, permission ado.Permission) (bool, error) {
+	c.CheckedPermissions = append(c.CheckedPermissions, permission)
+	if c.PermissionErr != nil {
+		return false, c.PermissionErr
+	}
+	return !c.Denied[permission], nil
+}
+
 func (c *Client) ensureRefs() {
 	if c.refs == nil {
 		c.refs = make(map[string]ado.Ref)
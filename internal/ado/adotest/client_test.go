@@ -35,3 +35,37 @@ func TestCreateAnnotatedTagFailsWhenRefExists(t *testing.T) {
 		t.Fatalf("expected duplicate ref creation to fail")
 	}
 }
+
+func TestCreateLightweightTagPointsAtCommit(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient()
+	if err := client.CreateLightweightTag(context.Background(), "v1", "commit-a"); err != nil {
+		t.Fatalf("create lightweight tag: %v", err)
+	}
+	ref, ok := client.Ref("v1")
+	if !ok || ref.ObjectID != "commit-a" || ref.PeeledObjectID != "" {
+		t.Fatalf("unexpected lightweight ref %+v", ref)
+	}
+	if err := client.CreateLightweightTag(context.Background(), "v1", "commit-b"); err == nil {
+		t.Fatalf("expected duplicate ref creation to fail")
+	}
+}
+
+func TestUpdateRefRequiresCurrentRefObjectID(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient()
+	client.SeedAnnotatedTag("v1", "tag-object", "commit-a")
+
+	if err := client.UpdateRef(context.Background(), "v1", "commit-a", "commit-b"); err == nil {
+		t.Fatalf("expected update with peeled commit id to fail")
+	}
+	if err := client.UpdateRef(context.Background(), "v1", "tag-object", "commit-b"); err != nil {
+		t.Fatalf("update ref: %v", err)
+	}
+	ref, _ := client.Ref("v1")
+	if ref.ObjectID != "commit-b" || ref.PeeledObjectID != "" {
+		t.Fatalf("unexpected moved ref %+v", ref)
+	}
+}
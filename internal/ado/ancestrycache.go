@@ -0,0 +1,54 @@
+package ado
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// NewAncestryCacheClient wraps inner so IsAncestor answers a repeated (ancestor, descendant)
+// pair from memory. Commit ancestry never changes, so entries are kept for the life of the
+// client and no write invalidates them; failed checks are not cached and are retried on the
+// next call. With enabled false it returns inner unchanged.
+func NewAncestryCacheClient(inner Client, enabled bool) Client {
+	if !enabled {
+		return inner
+	}
+	return &ancestryCacheClient{Client: inner, entries: make(map[ancestryKey]bool)}
+}
+
+type ancestryCacheClient struct {
+	Client
+
+	mu      sync.Mutex
+	entries map[ancestryKey]bool
+}
+
+// ancestryKey identifies one check by its commit SHAs, lowercased so that the same commit
+// spelled in different case shares an entry.
+type ancestryKey struct {
+	ancestor   string
+	descendant string
+}
+
+func (c *ancestryCacheClient) IsAncestor(ctx context.Context, ancestor, descendant string) (bool, error) {
+	key := ancestryKey{
+		ancestor:   strings.ToLower(strings.TrimSpace(ancestor)),
+		descendant: strings.ToLower(strings.TrimSpace(descendant)),
+	}
+	c.mu.Lock()
+	reachable, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok {
+		return reachable, nil
+	}
+
+	reachable, err := c.Client.IsAncestor(ctx, ancestor, descendant)
+	if err != nil {
+		return reachable, err
+	}
+	c.mu.Lock()
+	c.entries[key] = reachable
+	c.mu.Unlock()
+	return reachable, nil
+}
@@ -0,0 +1,79 @@
+package ado
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type ancestryCountingClient struct {
+	Client
+	checks int
+	err    error
+}
+
+func (c *ancestryCountingClient) IsAncestor(_ context.Context, ancestor, _ string) (bool, error) {
+	c.checks++
+	if c.err != nil {
+		return false, c.err
+	}
+	return ancestor != "unrelated", nil
+}
+
+func TestAncestryCacheClientServesRepeatedChecks(t *testing.T) {
+	t.Parallel()
+
+	inner := &ancestryCountingClient{}
+	client := NewAncestryCacheClient(inner, true)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		ok, err := client.IsAncestor(ctx, "abc123", "tip456")
+		if err != nil || !ok {
+			t.Fatalf("check %d: ok=%v err=%v", i, ok, err)
+		}
+	}
+	// The same pair in another case is still a hit.
+	if _, err := client.IsAncestor(ctx, "ABC123", "TIP456"); err != nil {
+		t.Fatalf("check upper case: %v", err)
+	}
+	if inner.checks != 1 {
+		t.Fatalf("expected one ancestry check to reach the client, got %d", inner.checks)
+	}
+
+	if ok, err := client.IsAncestor(ctx, "unrelated", "tip456"); err != nil || ok {
+		t.Fatalf("check unrelated: ok=%v err=%v", ok, err)
+	}
+	if ok, _ := client.IsAncestor(ctx, "unrelated", "tip456"); ok {
+		t.Fatal("expected the cached negative answer")
+	}
+	if inner.checks != 2 {
+		t.Fatalf("expected another pair to be checked once, got %d checks", inner.checks)
+	}
+}
+
+func TestAncestryCacheClientRetriesFailures(t *testing.T) {
+	t.Parallel()
+
+	inner := &ancestryCountingClient{err: errors.New("boom")}
+	client := NewAncestryCacheClient(inner, true)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.IsAncestor(ctx, "abc123", "tip456"); err == nil {
+			t.Fatalf("check %d: expected the client error", i)
+		}
+	}
+	if inner.checks != 2 {
+		t.Fatalf("expected failed checks to reach the client each time, got %d", inner.checks)
+	}
+}
+
+func TestAncestryCacheClientDisabled(t *testing.T) {
+	t.Parallel()
+
+	inner := &ancestryCountingClient{}
+	if client := NewAncestryCacheClient(inner, false); client != Client(inner) {
+		t.Fatalf("expected the inner client when disabled, got %T", client)
+	}
+}
@@ -3,10 +3,23 @@ package ado
 import (
 	"context"
 	"errors"
+	"time"
 )
 
-// ErrPullRequestNotFound indicates no pull request matched the query.
-var ErrPullRequestNotFound = errors.New("ado: pull request not found")
+var (
+	// ErrPullRequestNotFound indicates no pull request matched the query.
+	ErrPullRequestNotFound = errors.New("ado: pull request not found")
+	// ErrRefNotFound indicates the requested ref does not exist.
+	ErrRefNotFound = errors.New("ado: ref not found")
+	// ErrRefExists indicates a tag or ref could not be created because the name is taken.
+	ErrRefExists = errors.New("ado: ref already exists")
+	// ErrCommitNotFound indicates the requested commit does not exist.
+	ErrCommitNotFound = errors.New("ado: commit not found")
+	// ErrFileNotFound indicates the requested file does not exist at the given version.
+	ErrFileNotFound = errors.New("ado: file not found")
+	// ErrInvalidTaggerEmail indicates the tagger email is not shaped like local@domain.
+	ErrInvalidTaggerEmail = errors.New("ado: invalid tagger email")
+)
 
 // Ref represents a Git ref returned by Azure DevOps.
 type Ref struct {
@@ -33,6 +46,50 @@ type TagSpec struct {
 	TaggerEmail string
 }
 
+// Commit is a commit summary returned by history queries.
+type Commit struct {
+	ID      string
+	Subject string
+	// Parents lists parent commit IDs; only GetCommit fills it.
+	Parents []string
+}
+
+// Identity describes the Azure DevOps identity authenticated by the configured token.
+type Identity struct {
+	DisplayName string
+	Email       string
+}
+
+// PullRequestStatus is the lifecycle state of a pull request.
+type PullRequestStatus string
+
+const (
+	PullRequestStatusActive    PullRequestStatus = "active"
+	PullRequestStatusAbandoned PullRequestStatus = "abandoned"
+	PullRequestStatusCompleted PullRequestStatus = "completed"
+)
+
+// PullRequest is a pull request summary; LastMergeCommit is empty until the merge is computed.
+type PullRequest struct {
+	ID              int
+	Status          PullRequestStatus
+	LastMergeCommit string
+}
+
+// Permission identifies a Git repository permission evaluated for the authenticated token.
+type Permission string
+
+const (
+	// PermissionContribute allows pushing to the repository.
+	PermissionContribute Permission = "contribute"
+	// PermissionForcePush allows rewriting history and deleting branches and tags.
+	PermissionForcePush Permission = "force-push"
+	// PermissionCreateTag allows creating tags.
+	PermissionCreateTag Permission = "create-tag"
+	// PermissionPullRequestContribute allows commenting on and labeling pull requests.
+	PermissionPullRequestContribute Permission = "pull-request-contribute"
+)
+
 // Client describes the Azure DevOps Git operations required by the business logic layer.
 type Client interface {
 	// ListRefsWithPrefix returns refs whose names start with the provided prefix
@@ -42,15 +99,74 @@ type Client interface {
 	// DeleteRef removes the specified ref when the current object ID matches.
 	DeleteRef(ctx context.Context, name string, objectID string) error
 
+	// GetRefObjectID returns the object ID the named ref currently points at: the tag object
+	// for annotated tags, the commit otherwise. Missing refs return ErrRefNotFound.
+	GetRefObjectID(ctx context.Context, name string) (string, error)
+
 	// FindPullRequestByMergeCommit returns the pull request ID whose merge commit equals commitSHA.
 	FindPullRequestByMergeCommit(ctx context.Context, commitSHA string) (int, error)
 
+	// FindPullRequestsByMergeCommit returns every pull request ID whose merge commit equals
+	// commitSHA in ascending order, such as an original and its cherry-pick. No match returns
+	// ErrPullRequestNotFound.
+	FindPullRequestsByMergeCommit(ctx context.Context, commitSHA string) ([]int, error)
+
+	// GetPullRequest returns the pull request with the provided ID.
+	GetPullRequest(ctx context.Context, prID int) (PullRequest, error)
+
+	// GetPullRequestTitle returns the title of the specified pull request.
+	GetPullRequestTitle(ctx context.Context, prID int) (string, error)
+
 	// ListPRLabels returns the labels currently applied to the specified pull request.
 	ListPRLabels(ctx context.Context, prID int) ([]string, error)
 
 	// AddPRLabel adds the provided label to the specified pull request.
 	AddPRLabel(ctx context.Context, prID int, label string) error
 
+	// AddPRComment posts content as a new comment thread on the specified pull request.
+	AddPRComment(ctx context.Context, prID int, content string) error
+
+	// ListPullRequestChangedPaths returns the repository paths (without a leading slash) the pull
+	// request changes relative to its target branch; renames report both paths.
+	ListPullRequestChangedPaths(ctx context.Context, prID int) ([]string, error)
+
 	// CreateAnnotatedTag creates an annotated Git tag in the configured repository.
 	CreateAnnotatedTag(ctx context.Context, spec TagSpec) error
+
+	// CreateLightweightTag creates a tag ref pointing directly at objectID, failing if it already exists.
+	CreateLightweightTag(ctx context.Context, name string, objectID string) error
+
+	// UpdateRef atomically moves a ref from oldObjectID to newObjectID, failing if the ref has changed.
+	UpdateRef(ctx context.Context, name string, oldObjectID string, newObjectID string) error
+
+	// GetAuthenticatedIdentity returns the identity the token authenticates as
+	// (e.g. the build service account behind System.AccessToken).
+	GetAuthenticatedIdentity(ctx context.Context) (Identity, error)
+
+	// ListCommitsBetween returns commits reachable from toCommit but not from fromCommit, newest first.
+	ListCommitsBetween(ctx context.Context, fromCommit, toCommit string) ([]Commit, error)
+
+	// IsAncestor reports whether ancestor is reachable from descendant (both commit SHAs).
+	IsAncestor(ctx context.Context, ancestor, descendant string) (bool, error)
+
+	// CommitExists reports whether commitSHA exists in the repository.
+	CommitExists(ctx context.Context, commitSHA string) (bool, error)
+
+	// GetCommit returns the commit with its parents. Missing commits return ErrCommitNotFound.
+	GetCommit(ctx context.Context, commitSHA string) (Commit, error)
+
+	// GetCommitMessage returns the full message of commitSHA, including its body and footers.
+	// Missing commits return ErrCommitNotFound.
+	GetCommitMessage(ctx context.Context, commitSHA string) (string, error)
+
+	// GetTagDate returns when the annotated tag object tagObjectID was created. Lightweight tags
+	// have no tag object and no date.
+	GetTagDate(ctx context.Context, tagObjectID string) (time.Time, error)
+
+	// GetFileContent returns the content of the file at path as of commitSHA, or the default
+	// branch when commitSHA is empty. Missing files return ErrFileNotFound.
+	GetFileContent(ctx context.Context, path, commitSHA string) ([]byte, error)
+
+	// HasPermission reports whether the token holds the repository permission. It performs no writes.
+	HasPermission(ctx context.Context, permission Permission) (bool, error)
 }
@@ -0,0 +1,44 @@
+package ado
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// ListRefNamespaces lists refs under each prefix concurrently and returns them keyed by prefix.
+// The first failure cancels the remaining listings and is returned; duplicate prefixes are
+// listed once.
+func ListRefNamespaces(ctx context.Context, client Client, prefixes ...string) (map[string][]Ref, error) {
+	unique := make([]string, 0, len(prefixes))
+	seen := make(map[string]bool, len(prefixes))
+	for _, prefix := range prefixes {
+		if !seen[prefix] {
+			seen[prefix] = true
+			unique = append(unique, prefix)
+		}
+	}
+
+	results := make([][]Ref, len(unique))
+	group, groupCtx := errgroup.WithContext(ctx)
+	for i, prefix := range unique {
+		group.Go(func() error {
+			refs, err := client.ListRefsWithPrefix(groupCtx, prefix)
+			if err != nil {
+				return fmt.Errorf("listing %s: %w", prefix, err)
+			}
+			results[i] = refs
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	grouped := make(map[string][]Ref, len(unique))
+	for i, prefix := range unique {
+		grouped[prefix] = results[i]
+	}
+	return grouped, nil
+}
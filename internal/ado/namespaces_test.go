@@ -0,0 +1,84 @@
+package ado
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+type namespaceClient struct {
+	Client
+	refs map[string][]Ref
+	errs map[string]error
+	// arrived is released once every expected listing is in flight, proving they overlap.
+	arrived sync.WaitGroup
+
+	mu    sync.Mutex
+	calls []string
+}
+
+func (c *namespaceClient) ListRefsWithPrefix(ctx context.Context, prefix string) ([]Ref, error) {
+	c.mu.Lock()
+	c.calls = append(c.calls, prefix)
+	c.mu.Unlock()
+
+	c.arrived.Done()
+	done := make(chan struct{})
+	go func() {
+		c.arrived.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		return nil, errors.New("listings did not run concurrently")
+	}
+
+	if err := c.errs[prefix]; err != nil {
+		return nil, err
+	}
+	return c.refs[prefix], nil
+}
+
+func TestListRefNamespacesGroupsByPrefix(t *testing.T) {
+	t.Parallel()
+
+	client := &namespaceClient{refs: map[string][]Ref{
+		"refs/tags/":  {{Name: "refs/tags/v1.0.0"}, {Name: "refs/tags/v1.1.0"}},
+		"refs/heads/": {{Name: "refs/heads/main"}},
+	}}
+	client.arrived.Add(2)
+
+	grouped, err := ListRefNamespaces(context.Background(), client, "refs/tags/", "refs/heads/", "refs/tags/")
+	if err != nil {
+		t.Fatalf("list namespaces: %v", err)
+	}
+
+	if len(client.calls) != 2 {
+		t.Fatalf("expected each namespace listed once, got %v", client.calls)
+	}
+	if tags := grouped["refs/tags/"]; len(tags) != 2 || tags[1].Name != "refs/tags/v1.1.0" {
+		t.Fatalf("unexpected tags %+v", tags)
+	}
+	if heads := grouped["refs/heads/"]; len(heads) != 1 || heads[0].Name != "refs/heads/main" {
+		t.Fatalf("unexpected heads %+v", heads)
+	}
+}
+
+func TestListRefNamespacesReturnsFirstError(t *testing.T) {
+	t.Parallel()
+
+	boom := errors.New("boom")
+	client := &namespaceClient{errs: map[string]error{"refs/heads/": boom}}
+	client.arrived.Add(2)
+
+	grouped, err := ListRefNamespaces(context.Background(), client, "refs/tags/", "refs/heads/")
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected boom, got %v", err)
+	}
+	if grouped != nil {
+		t.Fatalf("expected no results on error, got %+v", grouped)
+	}
+}
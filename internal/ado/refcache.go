@@ -0,0 +1,93 @@
+package ado
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type freshRefsKey struct{}
+
+// WithFreshRefs returns a context under which a ref cache client always lists from ADO and
+// stores the new listing. Callers that poll for a ref another request just wrote, such as tag
+// verification, use it so a cached listing cannot hide the ref for the whole TTL.
+func WithFreshRefs(ctx context.Context) context.Context {
+	return context.WithValue(ctx, freshRefsKey{}, true)
+}
+
+func wantsFreshRefs(ctx context.Context) bool {
+	fresh, _ := ctx.Value(freshRefsKey{}).(bool)
+	return fresh
+}
+
+// NewRefCacheClient wraps inner so ListRefsWithPrefix answers from a listing of the same prefix
+// made less than ttl ago. Every ref write through the client (tag creation, DeleteRef,
+// UpdateRef) clears the cache, so callers always see their own writes; writes made elsewhere
+// can go unseen for up to ttl. A ttl of zero or less returns inner unchanged.
+func NewRefCacheClient(inner Client, ttl time.Duration) Client {
+	if ttl <= 0 {
+		return inner
+	}
+	return &refCacheClient{Client: inner, ttl: ttl, now: time.Now, entries: make(map[string]refCacheEntry)}
+}
+
+type refCacheClient struct {
+	Client
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]refCacheEntry
+}
+
+type refCacheEntry struct {
+	refs     []Ref
+	listedAt time.Time
+}
+
+func (c *refCacheClient) ListRefsWithPrefix(ctx context.Context, prefix string) ([]Ref, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[prefix]
+	c.mu.Unlock()
+	if ok && !wantsFreshRefs(ctx) && c.now().Sub(entry.listedAt) < c.ttl {
+		return append([]Ref(nil), entry.refs...), nil
+	}
+
+	listedAt := c.now()
+	refs, err := c.Client.ListRefsWithPrefix(ctx, prefix)
+	if err != nil {
+		return refs, err
+	}
+	c.mu.Lock()
+	c.entries[prefix] = refCacheEntry{refs: append([]Ref(nil), refs...), listedAt: listedAt}
+	c.mu.Unlock()
+	return refs, nil
+}
+
+func (c *refCacheClient) DeleteRef(ctx context.Context, name string, objectID string) error {
+	defer c.invalidate()
+	return c.Client.DeleteRef(ctx, name, objectID)
+}
+
+func (c *refCacheClient) CreateAnnotatedTag(ctx context.Context, spec TagSpec) error {
+	defer c.invalidate()
+	return c.Client.CreateAnnotatedTag(ctx, spec)
+}
+
+func (c *refCacheClient) CreateLightweightTag(ctx context.Context, name string, objectID string) error {
+	defer c.invalidate()
+	return c.Client.CreateLightweightTag(ctx, name, objectID)
+}
+
+func (c *refCacheClient) UpdateRef(ctx context.Context, name string, oldObjectID string, newObjectID string) error {
+	defer c.invalidate()
+	return c.Client.UpdateRef(ctx, name, oldObjectID, newObjectID)
+}
+
+// invalidate drops every cached listing. A failed write is treated like a successful one,
+// since the ref may have changed before the error came back.
+func (c *refCacheClient) invalidate() {
+	c.mu.Lock()
+	c.entries = make(map[string]refCacheEntry)
+	c.mu.Unlock()
+}
@@ -0,0 +1,106 @@
+package ado
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type countingClient struct {
+	Client
+	listings int
+}
+
+func (c *countingClient) ListRefsWithPrefix(context.Context, string) ([]Ref, error) {
+	c.listings++
+	return []Ref{{Name: "refs/tags/v1.0.0"}}, nil
+}
+
+func (c *countingClient) CreateAnnotatedTag(context.Context, TagSpec) error {
+	return nil
+}
+
+func TestRefCacheClientServesListingsWithinTTL(t *testing.T) {
+	t.Parallel()
+
+	inner := &countingClient{}
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	client := NewRefCacheClient(inner, time.Minute).(*refCacheClient)
+	client.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		refs, err := client.ListRefsWithPrefix(ctx, "refs/tags/")
+		if err != nil || len(refs) != 1 {
+			t.Fatalf("list %d: refs=%v err=%v", i, refs, err)
+		}
+	}
+	if inner.listings != 1 {
+		t.Fatalf("expected one listing within the TTL, got %d", inner.listings)
+	}
+
+	if _, err := client.ListRefsWithPrefix(ctx, "refs/heads/"); err != nil {
+		t.Fatalf("list heads: %v", err)
+	}
+	if inner.listings != 2 {
+		t.Fatalf("expected another prefix to be listed, got %d listings", inner.listings)
+	}
+
+	now = now.Add(time.Minute)
+	if _, err := client.ListRefsWithPrefix(ctx, "refs/tags/"); err != nil {
+		t.Fatalf("list after ttl: %v", err)
+	}
+	if inner.listings != 3 {
+		t.Fatalf("expected an expired listing to be refreshed, got %d listings", inner.listings)
+	}
+}
+
+func TestRefCacheClientInvalidatesOnWrite(t *testing.T) {
+	t.Parallel()
+
+	inner := &countingClient{}
+	client := NewRefCacheClient(inner, time.Hour)
+	ctx := context.Background()
+
+	if _, err := client.ListRefsWithPrefix(ctx, "refs/tags/"); err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if err := client.CreateAnnotatedTag(ctx, TagSpec{Name: "v1.0.1"}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := client.ListRefsWithPrefix(ctx, "refs/tags/"); err != nil {
+		t.Fatalf("list after write: %v", err)
+	}
+	if inner.listings != 2 {
+		t.Fatalf("expected the write to invalidate the cached listing, got %d listings", inner.listings)
+	}
+}
+
+func TestRefCacheClientListsFreshRefsOnRequest(t *testing.T) {
+	t.Parallel()
+
+	inner := &countingClient{}
+	client := NewRefCacheClient(inner, time.Hour)
+	ctx := context.Background()
+
+	if _, err := client.ListRefsWithPrefix(ctx, "refs/tags/v1.0.0"); err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := client.ListRefsWithPrefix(WithFreshRefs(ctx), "refs/tags/v1.0.0"); err != nil {
+			t.Fatalf("fresh list %d: %v", i, err)
+		}
+	}
+	if inner.listings != 3 {
+		t.Fatalf("expected every fresh listing to reach the client, got %d listings", inner.listings)
+	}
+}
+
+func TestNewRefCacheClientDisabledByDefault(t *testing.T) {
+	t.Parallel()
+
+	inner := &countingClient{}
+	if client := NewRefCacheClient(inner, 0); client != Client(inner) {
+		t.Fatalf("expected a zero TTL to return the inner client, got %T", client)
+	}
+}
@@ -4,20 +4,53 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	azuredevops "github.com/microsoft/azure-devops-go-api/azuredevops/v7"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/core"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/identity"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/location"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/security"
+	"go.uber.org/zap"
 )
 
+// commitPageSize bounds each commits batch request.
+const commitPageSize = 100
+
+// gitRepositoriesNamespace is the security namespace holding Git repository permissions.
+var gitRepositoriesNamespace = uuid.MustParse("2e9eb7ed-3c0a-47d4-87c1-0ffdd275fd87")
+
+// gitPermissionBits maps permissions to their bits in the Git repositories namespace.
+var gitPermissionBits = map[Permission]int{
+	PermissionContribute:            4,
+	PermissionForcePush:             8,
+	PermissionCreateTag:             32,
+	PermissionPullRequestContribute: 16384,
+}
+
 // Config controls how the Azure DevOps client connects to the Git API.
 type Config struct {
 	OrganizationURL string
-	Project         string
-	Repository      string
-	Token           string
+	// Project is the project name or its ID; the SDK accepts either and both are passed as-is.
+	Project    string
+	Repository string
+	Token      string
+	// Trace, when set, receives a debug line for every API call.
+	Trace *zap.Logger
+	// Logger, when set, receives advisory warnings such as an unexpected token shape.
+	Logger *zap.Logger
+	// RefCacheTTL, when positive, serves repeated ref listings from memory for that long; see
+	// NewRefCacheClient. Zero disables the cache.
+	RefCacheTTL time.Duration
+	// AncestryCache remembers IsAncestor answers for the life of the client; see
+	// NewAncestryCacheClient.
+	AncestryCache bool
 }
 
 // NewClient constructs a Client backed by the official Azure DevOps Go SDK.
@@ -31,6 +64,13 @@ func NewClient(ctx context.Context, cfg Config) (Client, error) {
 		return nil, err
 	}
 
+	if reason := tokenShapeWarning(trimmed.Token); reason != "" && trimmed.Logger != nil {
+		trimmed.Logger.Warn("token does not look like an Azure DevOps PAT; a 401 may mean the wrong credential type",
+			zap.String("reason", reason),
+			zap.Int("length", len(trimmed.Token)),
+		)
+	}
+
 	connection := azuredevops.NewPatConnection(trimmed.OrganizationURL, trimmed.Token)
 	gitClient, err := git.NewClient(ctx, connection)
 	if err != nil {
@@ -39,16 +79,29 @@ func NewClient(ctx context.Context, cfg Config) (Client, error) {
 
 	project := trimmed.Project
 	repository := trimmed.Repository
+	if isGUID(project) && trimmed.Logger != nil {
+		trimmed.Logger.Debug("project referenced by id", zap.String("project", project))
+	}
 
-	return &sdkClient{
+	var client Client = &sdkClient{
 		git:        gitClient,
+		location:   location.NewClient(ctx, connection),
+		security:   security.NewClient(ctx, connection),
 		project:    &project,
 		repository: &repository,
-	}, nil
+	}
+	if trimmed.Trace != nil {
+		client = NewTracingClient(client, trimmed.Trace, project, repository)
+	}
+	// The caches wrap the tracer, so traces show only the calls that reach ADO.
+	client = NewAncestryCacheClient(client, trimmed.AncestryCache)
+	return NewRefCacheClient(client, trimmed.RefCacheTTL), nil
 }
 
 type sdkClient struct {
 	git        git.Client
+	location   location.Client
+	security   security.Client
 	project    *string
 	repository *string
 }
@@ -94,6 +147,24 @@ func (c *sdkClient) ListRefsWithPrefix(ctx context.Context, prefix string) ([]Re
 	return results, nil
 }
 
+// GetRefObjectID looks up the named ref and returns its unpeeled object ID.
+func (c *sdkClient) GetRefObjectID(ctx context.Context, name string) (string, error) {
+	refName := strings.TrimSpace(name)
+	if refName == "" {
+		return "", errors.New("ado client: ref name is empty")
+	}
+	refs, err := c.ListRefsWithPrefix(ctx, refName)
+	if err != nil {
+		return "", err
+	}
+	for _, ref := range refs {
+		if ref.Name == refName {
+			return strings.TrimSpace(ref.ObjectID), nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrRefNotFound, refName)
+}
+
 // DeleteRef removes a ref when the current object ID matches.
 func (c *sdkClient) DeleteRef(ctx context.Context, name string, objectID string) error {
 	refName := strings.TrimSpace(name)
@@ -136,11 +207,113 @@ func errIfRefDeleteUpdateRejected(results *[]git.GitRefUpdateResult, refName str
 	return nil
 }
 
+// CreateLightweightTag creates a tag ref via a ref update from the zero object ID, which ADO
+// rejects when the ref already exists.
+func (c *sdkClient) CreateLightweightTag(ctx context.Context, name string, objectID string) error {
+	refName := strings.TrimSpace(name)
+	if refName == "" {
+		return errors.New("ado client: ref name is empty")
+	}
+	if !strings.HasPrefix(refName, "refs/") {
+		refName = "refs/tags/" + refName
+	}
+	target := strings.TrimSpace(objectID)
+	if target == "" {
+		return errors.New("ado client: ref object id is empty")
+	}
+	zero := strings.Repeat("0", 40)
+	updates := []git.GitRefUpdate{
+		{
+			Name:        &refName,
+			OldObjectId: &zero,
+			NewObjectId: &target,
+		},
+	}
+	args := git.UpdateRefsArgs{
+		Project:      c.project,
+		RepositoryId: c.repository,
+		RefUpdates:   &updates,
+	}
+	results, err := c.git.UpdateRefs(ctx, args)
+	if err != nil {
+		return fmt.Errorf("creating ref %s: %w", refName, err)
+	}
+	if results == nil || len(*results) != 1 || !derefBool((*results)[0].Success) {
+		if results != nil && len(*results) == 1 && refExistsStatus((*results)[0].UpdateStatus) {
+			return fmt.Errorf("creating ref %s: %w", refName, ErrRefExists)
+		}
+		return fmt.Errorf("creating ref %s rejected", refName)
+	}
+	return nil
+}
+
+// UpdateRef moves a ref with a compare-and-swap ref update.
+func (c *sdkClient) UpdateRef(ctx context.Context, name string, oldObjectID string, newObjectID string) error {
+	refName := strings.TrimSpace(name)
+	if refName == "" {
+		return errors.New("ado client: ref name is empty")
+	}
+	current := strings.TrimSpace(oldObjectID)
+	target := strings.TrimSpace(newObjectID)
+	if current == "" || target == "" {
+		return errors.New("ado client: ref object id is empty")
+	}
+	updates := []git.GitRefUpdate{
+		{
+			Name:        &refName,
+			OldObjectId: &current,
+			NewObjectId: &target,
+		},
+	}
+	args := git.UpdateRefsArgs{
+		Project:      c.project,
+		RepositoryId: c.repository,
+		RefUpdates:   &updates,
+	}
+	results, err := c.git.UpdateRefs(ctx, args)
+	if err != nil {
+		return fmt.Errorf("updating ref %s: %w", refName, err)
+	}
+	if results == nil || len(*results) != 1 || !derefBool((*results)[0].Success) {
+		return fmt.Errorf("updating ref %s rejected", refName)
+	}
+	return nil
+}
+
 // FindPullRequestByMergeCommit returns the PR ID whose merge commit equals commitSHA.
 func (c *sdkClient) FindPullRequestByMergeCommit(ctx context.Context, commitSHA string) (int, error) {
 	commit := strings.TrimSpace(commitSHA)
+	resp, err := c.queryMergeCommit(ctx, commit)
+	if err != nil {
+		return 0, err
+	}
+
+	prID, ok := pullRequestIDFromQuery(commit, resp)
+	if !ok {
+		return 0, ErrPullRequestNotFound
+	}
+	return prID, nil
+}
+
+// FindPullRequestsByMergeCommit returns every PR ID whose merge commit equals commitSHA.
+func (c *sdkClient) FindPullRequestsByMergeCommit(ctx context.Context, commitSHA string) ([]int, error) {
+	commit := strings.TrimSpace(commitSHA)
+	resp, err := c.queryMergeCommit(ctx, commit)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := pullRequestIDsFromQuery(resp)
+	if len(ids) == 0 {
+		return nil, ErrPullRequestNotFound
+	}
+	return ids, nil
+}
+
+// queryMergeCommit runs the last-merge-commit pull request query for commit.
+func (c *sdkClient) queryMergeCommit(ctx context.Context, commit string) (*git.GitPullRequestQuery, error) {
 	if commit == "" {
-		return 0, errors.New("ado client: commit sha is empty")
+		return nil, errors.New("ado client: commit sha is empty")
 	}
 
 	queryType := git.GitPullRequestQueryTypeValues.LastMergeCommit
@@ -160,14 +333,45 @@ func (c *sdkClient) FindPullRequestByMergeCommit(ctx context.Context, commitSHA
 
 	resp, err := c.git.GetPullRequestQuery(ctx, args)
 	if err != nil {
-		return 0, fmt.Errorf("querying pull requests: %w", err)
+		return nil, fmt.Errorf("querying pull requests: %w", err)
+	}
+	return resp, nil
+}
+
+// GetPullRequest returns the pull request summary for prID.
+func (c *sdkClient) GetPullRequest(ctx context.Context, prID int) (PullRequest, error) {
+	args := git.GetPullRequestArgs{
+		Project:       c.project,
+		RepositoryId:  c.repository,
+		PullRequestId: &prID,
 	}
 
-	prID, ok := pullRequestIDFromQuery(commit, resp)
-	if !ok {
-		return 0, ErrPullRequestNotFound
+	pr, err := c.git.GetPullRequest(ctx, args)
+	if err != nil {
+		return PullRequest{}, fmt.Errorf("getting pull request %d: %w", prID, err)
 	}
-	return prID, nil
+	if pr == nil {
+		return PullRequest{}, ErrPullRequestNotFound
+	}
+	return convertPullRequest(prID, pr), nil
+}
+
+// GetPullRequestTitle returns the trimmed title of the pull request.
+func (c *sdkClient) GetPullRequestTitle(ctx context.Context, prID int) (string, error) {
+	args := git.GetPullRequestArgs{
+		Project:       c.project,
+		RepositoryId:  c.repository,
+		PullRequestId: &prID,
+	}
+
+	pr, err := c.git.GetPullRequest(ctx, args)
+	if err != nil {
+		return "", fmt.Errorf("getting pull request %d title: %w", prID, err)
+	}
+	if pr == nil {
+		return "", ErrPullRequestNotFound
+	}
+	return strings.TrimSpace(derefString(pr.Title)), nil
 }
 
 // ListPRLabels returns the labels currently applied to the pull request.
@@ -209,6 +413,99 @@ func (c *sdkClient) AddPRLabel(ctx context.Context, prID int, label string) erro
 	return nil
 }
 
+// AddPRComment opens a closed thread, so the informational comment does not block completion
+// under "resolve all comments" policies.
+func (c *sdkClient) AddPRComment(ctx context.Context, prID int, content string) error {
+	text := strings.TrimSpace(content)
+	if text == "" {
+		return errors.New("ado client: comment is empty")
+	}
+
+	commentType := git.CommentTypeValues.Text
+	status := git.CommentThreadStatusValues.Closed
+	args := git.CreateThreadArgs{
+		Project:       c.project,
+		RepositoryId:  c.repository,
+		PullRequestId: &prID,
+		CommentThread: &git.GitPullRequestCommentThread{
+			Comments: &[]git.Comment{{Content: &text, CommentType: &commentType}},
+			Status:   &status,
+		},
+	}
+
+	if _, err := c.git.CreateThread(ctx, args); err != nil {
+		return fmt.Errorf("creating pull request comment: %w", err)
+	}
+
+	return nil
+}
+
+// ListPullRequestChangedPaths compares the latest iteration against the merge base, paging
+// through the change entries.
+func (c *sdkClient) ListPullRequestChangedPaths(ctx context.Context, prID int) ([]string, error) {
+	iterations, err := c.git.GetPullRequestIterations(ctx, git.GetPullRequestIterationsArgs{
+		Project:       c.project,
+		RepositoryId:  c.repository,
+		PullRequestId: &prID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing pull request %d iterations: %w", prID, err)
+	}
+	latest := 0
+	if iterations != nil {
+		for _, iteration := range *iterations {
+			if iteration.Id != nil && *iteration.Id > latest {
+				latest = *iteration.Id
+			}
+		}
+	}
+	if latest == 0 {
+		return nil, nil
+	}
+
+	var paths []string
+	top, skip, compareTo := 2000, 0, 0
+	for {
+		args := git.GetPullRequestIterationChangesArgs{
+			Project:       c.project,
+			RepositoryId:  c.repository,
+			PullRequestId: &prID,
+			IterationId:   &latest,
+			Top:           &top,
+			Skip:          &skip,
+			CompareTo:     &compareTo,
+		}
+		changes, err := c.git.GetPullRequestIterationChanges(ctx, args)
+		if err != nil {
+			return nil, fmt.Errorf("listing pull request %d changes: %w", prID, err)
+		}
+		if changes == nil {
+			break
+		}
+		if changes.ChangeEntries != nil {
+			for _, entry := range *changes.ChangeEntries {
+				if item, ok := entry.Item.(map[string]any); ok {
+					if value, ok := item["path"].(string); ok && value != "" {
+						paths = append(paths, strings.TrimPrefix(value, "/"))
+					}
+				}
+				if original := derefString(entry.OriginalPath); original != "" {
+					paths = append(paths, strings.TrimPrefix(original, "/"))
+				}
+			}
+		}
+		if changes.NextSkip == nil || *changes.NextSkip == 0 {
+			break
+		}
+		skip = *changes.NextSkip
+		if changes.NextTop != nil && *changes.NextTop > 0 {
+			top = *changes.NextTop
+		}
+	}
+
+	return paths, nil
+}
+
 // CreateAnnotatedTag creates an annotated tag referencing the supplied commit.
 func (c *sdkClient) CreateAnnotatedTag(ctx context.Context, spec TagSpec) error {
 	tag, err := buildAnnotatedTag(spec)
@@ -223,21 +520,312 @@ func (c *sdkClient) CreateAnnotatedTag(ctx context.Context, spec TagSpec) error
 	}
 
 	if _, err := c.git.CreateAnnotatedTag(ctx, args); err != nil {
+		if hasStatus(err, http.StatusConflict) {
+			return fmt.Errorf("creating annotated tag: %w: %s", ErrRefExists, strings.TrimSpace(spec.Name))
+		}
 		return fmt.Errorf("creating annotated tag: %w", err)
 	}
 
 	return nil
 }
 
+// GetAuthenticatedIdentity returns the identity associated with the token via the connection data API.
+func (c *sdkClient) GetAuthenticatedIdentity(ctx context.Context) (Identity, error) {
+	data, err := c.location.GetConnectionData(ctx, location.GetConnectionDataArgs{})
+	if err != nil {
+		return Identity{}, fmt.Errorf("getting connection data: %w", err)
+	}
+	if data == nil || data.AuthenticatedUser == nil {
+		return Identity{}, errors.New("ado client: connection data has no authenticated user")
+	}
+	return convertIdentity(data.AuthenticatedUser), nil
+}
+
+// ListCommitsBetween pages through the commits batch API comparing toCommit against fromCommit.
+func (c *sdkClient) ListCommitsBetween(ctx context.Context, fromCommit, toCommit string) ([]Commit, error) {
+	from := strings.TrimSpace(fromCommit)
+	to := strings.TrimSpace(toCommit)
+	if from == "" || to == "" {
+		return nil, errors.New("ado client: commit range requires both endpoints")
+	}
+
+	versionType := git.GitVersionTypeValues.Commit
+	criteria := git.GitQueryCommitsCriteria{
+		ItemVersion:    &git.GitVersionDescriptor{Version: &from, VersionType: &versionType},
+		CompareVersion: &git.GitVersionDescriptor{Version: &to, VersionType: &versionType},
+	}
+
+	var results []Commit
+	top := commitPageSize
+	for skip := 0; ; skip += commitPageSize {
+		page := skip
+		commits, err := c.git.GetCommitsBatch(ctx, git.GetCommitsBatchArgs{
+			SearchCriteria: &criteria,
+			Project:        c.project,
+			RepositoryId:   c.repository,
+			Skip:           &page,
+			Top:            &top,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("listing commits: %w", err)
+		}
+		if commits == nil {
+			break
+		}
+		results = append(results, convertCommits(*commits)...)
+		if len(*commits) < commitPageSize {
+			break
+		}
+	}
+	return results, nil
+}
+
+// IsAncestor uses the merge-base API: ancestor is reachable from descendant exactly when it is
+// their merge base.
+func (c *sdkClient) IsAncestor(ctx context.Context, ancestor, descendant string) (bool, error) {
+	base := strings.TrimSpace(ancestor)
+	tip := strings.TrimSpace(descendant)
+	if base == "" || tip == "" {
+		return false, errors.New("ado client: ancestry check requires both commits")
+	}
+
+	bases, err := c.git.GetMergeBases(ctx, git.GetMergeBasesArgs{
+		Project:            c.project,
+		RepositoryNameOrId: c.repository,
+		CommitId:           &tip,
+		OtherCommitId:      &base,
+	})
+	if err != nil {
+		return false, fmt.Errorf("getting merge bases: %w", err)
+	}
+	return containsMergeBase(bases, base), nil
+}
+
+// CommitExists fetches the commit and treats a 404 as absent.
+func (c *sdkClient) CommitExists(ctx context.Context, commitSHA string) (bool, error) {
+	commit := strings.TrimSpace(commitSHA)
+	if commit == "" {
+		return false, errors.New("ado client: commit sha is empty")
+	}
+
+	_, err := c.git.GetCommit(ctx, git.GetCommitArgs{
+		CommitId:     &commit,
+		RepositoryId: c.repository,
+		Project:      c.project,
+	})
+	if err == nil {
+		return true, nil
+	}
+	if isNotFound(err) {
+		return false, nil
+	}
+	return false, fmt.Errorf("getting commit %s: %w", commit, err)
+}
+
+// GetCommit fetches commitSHA's metadata, including its parent IDs.
+func (c *sdkClient) GetCommit(ctx context.Context, commitSHA string) (Commit, error) {
+	commit := strings.TrimSpace(commitSHA)
+	if commit == "" {
+		return Commit{}, errors.New("ado client: commit sha is empty")
+	}
+
+	resp, err := c.git.GetCommit(ctx, git.GetCommitArgs{
+		CommitId:     &commit,
+		RepositoryId: c.repository,
+		Project:      c.project,
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return Commit{}, fmt.Errorf("%w: %s", ErrCommitNotFound, commit)
+		}
+		return Commit{}, fmt.Errorf("getting commit %s: %w", commit, err)
+	}
+	if resp == nil {
+		return Commit{}, fmt.Errorf("%w: %s", ErrCommitNotFound, commit)
+	}
+
+	result := Commit{ID: derefString(resp.CommitId)}
+	if result.ID == "" {
+		result.ID = commit
+	}
+	result.Subject = commitSubject(derefString(resp.Comment))
+	if resp.Parents != nil {
+		result.Parents = append([]string(nil), (*resp.Parents)...)
+	}
+	return result, nil
+}
+
+// GetCommitMessage returns the full comment recorded on the commit.
+func (c *sdkClient) GetCommitMessage(ctx context.Context, commitSHA string) (string, error) {
+	commit := strings.TrimSpace(commitSHA)
+	if commit == "" {
+		return "", errors.New("ado client: commit sha is empty")
+	}
+
+	resp, err := c.git.GetCommit(ctx, git.GetCommitArgs{
+		CommitId:     &commit,
+		RepositoryId: c.repository,
+		Project:      c.project,
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return "", fmt.Errorf("%w: %s", ErrCommitNotFound, commit)
+		}
+		return "", fmt.Errorf("getting commit %s message: %w", commit, err)
+	}
+	if resp == nil {
+		return "", fmt.Errorf("%w: %s", ErrCommitNotFound, commit)
+	}
+	return derefString(resp.Comment), nil
+}
+
+// GetTagDate reads the tagger date recorded on an annotated tag object.
+func (c *sdkClient) GetTagDate(ctx context.Context, tagObjectID string) (time.Time, error) {
+	objectID := strings.TrimSpace(tagObjectID)
+	if objectID == "" {
+		return time.Time{}, errors.New("ado client: tag object id is empty")
+	}
+
+	tag, err := c.git.GetAnnotatedTag(ctx, git.GetAnnotatedTagArgs{
+		Project:      c.project,
+		RepositoryId: c.repository,
+		ObjectId:     &objectID,
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return time.Time{}, fmt.Errorf("%w: tag object %s", ErrRefNotFound, objectID)
+		}
+		return time.Time{}, fmt.Errorf("getting annotated tag %s: %w", objectID, err)
+	}
+	if tag == nil || tag.TaggedBy == nil || tag.TaggedBy.Date == nil {
+		return time.Time{}, fmt.Errorf("annotated tag %s has no tagger date", objectID)
+	}
+	return tag.TaggedBy.Date.Time, nil
+}
+
+// GetFileContent downloads the file at path, pinned to commitSHA when one is given.
+func (c *sdkClient) GetFileContent(ctx context.Context, path, commitSHA string) (content []byte, err error) {
+	filePath := strings.TrimSpace(path)
+	if filePath == "" {
+		return nil, errors.New("ado client: file path is empty")
+	}
+
+	args := git.GetItemContentArgs{
+		RepositoryId: c.repository,
+		Project:      c.project,
+		Path:         &filePath,
+	}
+	if commit := strings.TrimSpace(commitSHA); commit != "" {
+		args.VersionDescriptor = &git.GitVersionDescriptor{
+			Version:     &commit,
+			VersionType: &git.GitVersionTypeValues.Commit,
+		}
+	}
+
+	body, err := c.git.GetItemContent(ctx, args)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, fmt.Errorf("%w: %s", ErrFileNotFound, filePath)
+		}
+		return nil, fmt.Errorf("getting file %s: %w", filePath, err)
+	}
+	defer func() {
+		if closeErr := body.Close(); closeErr != nil && err == nil {
+			content, err = nil, fmt.Errorf("closing file %s: %w", filePath, closeErr)
+		}
+	}()
+
+	content, err = io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("reading file %s: %w", filePath, err)
+	}
+	return content, nil
+}
+
+// isNotFound reports whether err is an Azure DevOps 404; the SDK returns WrappedError both by
+// value and by pointer.
+func isNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// hasStatus reports whether err is an Azure DevOps API error with the given HTTP status.
+func hasStatus(err error, status int) bool {
+	var wrapped azuredevops.WrappedError
+	if errors.As(err, &wrapped) {
+		return wrapped.StatusCode != nil && *wrapped.StatusCode == status
+	}
+	var wrappedPtr *azuredevops.WrappedError
+	if errors.As(err, &wrappedPtr) && wrappedPtr != nil {
+		return wrappedPtr.StatusCode != nil && *wrappedPtr.StatusCode == status
+	}
+	return false
+}
+
+// refExistsStatus reports whether a rejected ref creation (an update from the zero object ID)
+// failed because the ref already exists.
+func refExistsStatus(status *git.GitRefUpdateStatus) bool {
+	if status == nil {
+		return false
+	}
+	switch *status {
+	case git.GitRefUpdateStatusValues.StaleOldObjectId, git.GitRefUpdateStatusValues.RefNameConflict:
+		return true
+	}
+	return false
+}
+
+// HasPermission evaluates a repository permission for the calling identity via the security API.
+func (c *sdkClient) HasPermission(ctx context.Context, permission Permission) (bool, error) {
+	bit, ok := gitPermissionBits[permission]
+	if !ok {
+		return false, fmt.Errorf("ado client: unknown permission %q", permission)
+	}
+
+	repo, err := c.git.GetRepository(ctx, git.GetRepositoryArgs{
+		Project:      c.project,
+		RepositoryId: c.repository,
+	})
+	if err != nil {
+		return false, fmt.Errorf("getting repository: %w", err)
+	}
+	token, err := repositorySecurityToken(repo)
+	if err != nil {
+		return false, err
+	}
+
+	results, err := c.security.HasPermissions(ctx, security.HasPermissionsArgs{
+		SecurityNamespaceId: &gitRepositoriesNamespace,
+		Permissions:         &bit,
+		Tokens:              &token,
+	})
+	if err != nil {
+		return false, fmt.Errorf("evaluating %s permission: %w", permission, err)
+	}
+	if results == nil || len(*results) == 0 {
+		return false, fmt.Errorf("ado client: empty permission evaluation for %s", permission)
+	}
+	return (*results)[0], nil
+}
+
 func sanitizeConfig(cfg Config) Config {
 	return Config{
 		OrganizationURL: strings.TrimSpace(cfg.OrganizationURL),
 		Project:         strings.TrimSpace(cfg.Project),
 		Repository:      strings.TrimSpace(cfg.Repository),
 		Token:           strings.TrimSpace(cfg.Token),
+		Trace:           cfg.Trace,
+		Logger:          cfg.Logger,
+		RefCacheTTL:     cfg.RefCacheTTL,
+		AncestryCache:   cfg.AncestryCache,
 	}
 }
 
+// isGUID reports whether value is a GUID, such as a project referenced by its stable ID
+// rather than its name.
+func isGUID(value string) bool {
+	return guidPattern.MatchString(value)
+}
+
 func validateConfig(cfg Config) error {
 	switch {
 	case cfg.OrganizationURL == "":
@@ -290,6 +878,28 @@ func pullRequestIDFromQuery(commit string, response *git.GitPullRequestQuery) (i
 	return 0, false
 }
 
+// pullRequestIDsFromQuery collects the distinct PR IDs across every query result, ascending.
+func pullRequestIDsFromQuery(response *git.GitPullRequestQuery) []int {
+	if response == nil || response.Results == nil {
+		return nil
+	}
+	seen := make(map[int]bool)
+	var ids []int
+	for _, result := range *response.Results {
+		for _, prs := range result {
+			for _, pr := range prs {
+				if pr.PullRequestId == nil || seen[*pr.PullRequestId] {
+					continue
+				}
+				seen[*pr.PullRequestId] = true
+				ids = append(ids, *pr.PullRequestId)
+			}
+		}
+	}
+	sort.Ints(ids)
+	return ids
+}
+
 func firstPRIDFromMap(result map[string][]git.GitPullRequest) (int, bool) {
 	if len(result) == 0 {
 		return 0, false
@@ -328,6 +938,73 @@ func labelNames(defs *[]core.WebApiTagDefinition) []string {
 	return names
 }
 
+func convertIdentity(user *identity.Identity) Identity {
+	name := strings.TrimSpace(derefString(user.CustomDisplayName))
+	if name == "" {
+		name = strings.TrimSpace(derefString(user.ProviderDisplayName))
+	}
+	return Identity{
+		DisplayName: name,
+		Email:       identityAccount(user.Properties),
+	}
+}
+
+// identityAccount extracts the account (email) from identity properties, which the API
+// serializes as {"Account": {"$type": "System.String", "$value": "..."}}.
+func identityAccount(properties any) string {
+	props, ok := properties.(map[string]any)
+	if !ok {
+		return ""
+	}
+	for _, key := range []string{"Account", "Mail"} {
+		entry, ok := props[key].(map[string]any)
+		if !ok {
+			continue
+		}
+		if value, ok := entry["$value"].(string); ok && strings.Contains(value, "@") {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
+
+func containsMergeBase(bases *[]git.GitCommitRef, commit string) bool {
+	if bases == nil {
+		return false
+	}
+	for _, base := range *bases {
+		if strings.EqualFold(strings.TrimSpace(derefString(base.CommitId)), commit) {
+			return true
+		}
+	}
+	return false
+}
+
+func convertCommits(values []git.GitCommitRef) []Commit {
+	commits := make([]Commit, 0, len(values))
+	for _, value := range values {
+		id := strings.TrimSpace(derefString(value.CommitId))
+		if id == "" {
+			continue
+		}
+		commits = append(commits, Commit{ID: id, Subject: commitSubject(derefString(value.Comment))})
+	}
+	return commits
+}
+
+func commitSubject(comment string) string {
+	subject, _, _ := strings.Cut(strings.TrimSpace(comment), "\n")
+	return strings.TrimSpace(subject)
+}
+
+// repositorySecurityToken builds the "repoV2/<projectId>/<repositoryId>" token scoping Git permissions.
+func repositorySecurityToken(repo *git.GitRepository) (string, error) {
+	if repo == nil || repo.Id == nil || repo.Project == nil || repo.Project.Id == nil {
+		return "", errors.New("ado client: repository response is missing project or repository id")
+	}
+	return fmt.Sprintf("repoV2/%s/%s", repo.Project.Id.String(), repo.Id.String()), nil
+}
+
 func derefString(value *string) string {
 	if value == nil {
 		return ""
@@ -358,15 +1035,18 @@ func buildAnnotatedTag(spec TagSpec) (git.GitAnnotatedTag, error) {
 		return git.GitAnnotatedTag{}, err
 	}
 
-	taggerName := strings.TrimSpace(spec.TaggerName)
+	taggerName := normalizeTaggerName(spec.TaggerName)
 	if taggerName == "" {
 		return git.GitAnnotatedTag{}, errors.New("ado client: tagger name is empty")
 	}
 
-	taggerEmail := strings.TrimSpace(spec.TaggerEmail)
-	if taggerEmail == "" {
+	if strings.TrimSpace(spec.TaggerEmail) == "" {
 		return git.GitAnnotatedTag{}, errors.New("ado client: tagger email is empty")
 	}
+	taggerEmail, err := normalizeTaggerEmail(spec.TaggerEmail)
+	if err != nil {
+		return git.GitAnnotatedTag{}, err
+	}
 
 	annotated := git.GitAnnotatedTag{}
 	annotated.Name = &name
@@ -395,3 +1075,17 @@ func convertObjectType(objectType TagObjectType) (*git.GitObjectType, error) {
 		return nil, fmt.Errorf("ado client: unsupported tag object type %q", objectType)
 	}
 }
+
+func convertPullRequest(prID int, pr *git.GitPullRequest) PullRequest {
+	result := PullRequest{ID: prID}
+	if pr.PullRequestId != nil {
+		result.ID = *pr.PullRequestId
+	}
+	if pr.Status != nil {
+		result.Status = PullRequestStatus(*pr.Status)
+	}
+	if pr.LastMergeCommit != nil && pr.LastMergeCommit.CommitId != nil {
+		result.LastMergeCommit = strings.TrimSpace(*pr.LastMergeCommit.CommitId)
+	}
+	return result
+}
@@ -1,9 +1,19 @@
 package ado
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
 	"testing"
 
+	"github.com/google/uuid"
+	azuredevops "github.com/microsoft/azure-devops-go-api/azuredevops/v7"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/core"
 	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/git"
+	"github.com/microsoft/azure-devops-go-api/azuredevops/v7/identity"
 )
 
 func TestConvertGitRefs(t *testing.T) {
@@ -116,3 +126,244 @@ func TestErrIfRefDeleteUpdateRejected(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertIdentity(t *testing.T) {
+	t.Parallel()
+
+	provider := "Project Build Service (org)"
+	custom := ""
+	got := convertIdentity(&identity.Identity{
+		ProviderDisplayName: &provider,
+		CustomDisplayName:   &custom,
+		Properties: map[string]any{
+			"Account": map[string]any{"$type": "System.String", "$value": "build@example.com"},
+		},
+	})
+	if got.DisplayName != provider || got.Email != "build@example.com" {
+		t.Fatalf("unexpected identity conversion: %+v", got)
+	}
+
+	// Service identities often report a non-email account; it must not be used as the email.
+	got = convertIdentity(&identity.Identity{
+		ProviderDisplayName: &provider,
+		Properties: map[string]any{
+			"Account": map[string]any{"$value": "Build\\00000000-0000-0000-0000-000000000000"},
+		},
+	})
+	if got.Email != "" {
+		t.Fatalf("expected empty email for non-email account, got %q", got.Email)
+	}
+}
+
+func TestRepositorySecurityToken(t *testing.T) {
+	t.Parallel()
+
+	projectID := uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	repoID := uuid.MustParse("22222222-2222-2222-2222-222222222222")
+	token, err := repositorySecurityToken(&git.GitRepository{
+		Id:      &repoID,
+		Project: &core.TeamProjectReference{Id: &projectID},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token != "repoV2/11111111-1111-1111-1111-111111111111/22222222-2222-2222-2222-222222222222" {
+		t.Fatalf("unexpected token %q", token)
+	}
+
+	if _, err := repositorySecurityToken(&git.GitRepository{Id: &repoID}); err == nil {
+		t.Fatalf("expected error when project id is missing")
+	}
+}
+
+func TestConvertCommitsUsesSubjectLine(t *testing.T) {
+	t.Parallel()
+
+	id := "abc123"
+	comment := "feat: add thing\n\nLonger body"
+	empty := ""
+	commits := convertCommits([]git.GitCommitRef{
+		{CommitId: &id, Comment: &comment},
+		{CommitId: &empty},
+	})
+	if len(commits) != 1 {
+		t.Fatalf("expected 1 commit, got %d", len(commits))
+	}
+	if commits[0].ID != id || commits[0].Subject != "feat: add thing" {
+		t.Fatalf("unexpected commit conversion: %+v", commits[0])
+	}
+}
+
+func TestContainsMergeBase(t *testing.T) {
+	t.Parallel()
+
+	base := "ABC123"
+	bases := []git.GitCommitRef{{CommitId: &base}}
+	if !containsMergeBase(&bases, "abc123") {
+		t.Fatalf("expected merge base match")
+	}
+	if containsMergeBase(&bases, "def456") || containsMergeBase(nil, "abc123") {
+		t.Fatalf("unexpected merge base match")
+	}
+}
+
+func TestConvertPullRequest(t *testing.T) {
+	t.Parallel()
+
+	id := 17
+	status := git.PullRequestStatusValues.Completed
+	merge := " merge-sha "
+	pr := convertPullRequest(5, &git.GitPullRequest{
+		PullRequestId:   &id,
+		Status:          &status,
+		LastMergeCommit: &git.GitCommitRef{CommitId: &merge},
+	})
+	if pr.ID != 17 || pr.Status != PullRequestStatusCompleted || pr.LastMergeCommit != "merge-sha" {
+		t.Fatalf("unexpected pull request conversion: %+v", pr)
+	}
+
+	if empty := convertPullRequest(5, &git.GitPullRequest{}); empty.ID != 5 || empty.Status != "" || empty.LastMergeCommit != "" {
+		t.Fatalf("expected requested ID and empty fields, got %+v", empty)
+	}
+}
+
+func TestPullRequestIDsFromQuery(t *testing.T) {
+	t.Parallel()
+
+	first, second := 42, 7
+	results := []map[string][]git.GitPullRequest{
+		{"merge-sha": {{PullRequestId: &first}, {}}},
+		{"merge-sha": {{PullRequestId: &second}, {PullRequestId: &first}}},
+	}
+	ids := pullRequestIDsFromQuery(&git.GitPullRequestQuery{Results: &results})
+	if len(ids) != 2 || ids[0] != 7 || ids[1] != 42 {
+		t.Fatalf("expected [7 42], got %v", ids)
+	}
+	if ids := pullRequestIDsFromQuery(&git.GitPullRequestQuery{}); ids != nil {
+		t.Fatalf("expected no ids, got %v", ids)
+	}
+}
+
+func TestIsNotFound(t *testing.T) {
+	t.Parallel()
+
+	notFound := http.StatusNotFound
+	forbidden := http.StatusForbidden
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "wrapped value 404", err: fmt.Errorf("get: %w", azuredevops.WrappedError{StatusCode: &notFound}), want: true},
+		{name: "wrapped pointer 404", err: &azuredevops.WrappedError{StatusCode: &notFound}, want: true},
+		{name: "other status", err: azuredevops.WrappedError{StatusCode: &forbidden}},
+		{name: "no status", err: azuredevops.WrappedError{}},
+		{name: "plain error", err: errors.New("boom")},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := isNotFound(tc.err); got != tc.want {
+				t.Fatalf("isNotFound = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRefExistsStatus(t *testing.T) {
+	t.Parallel()
+
+	stale := git.GitRefUpdateStatusValues.StaleOldObjectId
+	conflict := git.GitRefUpdateStatusValues.RefNameConflict
+	denied := git.GitRefUpdateStatusValues.CreateTagPermissionRequired
+	tests := []struct {
+		name   string
+		status *git.GitRefUpdateStatus
+		want   bool
+	}{
+		{name: "stale old object id", status: &stale, want: true},
+		{name: "ref name conflict", status: &conflict, want: true},
+		{name: "permission denied", status: &denied},
+		{name: "no status"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := refExistsStatus(tc.status); got != tc.want {
+				t.Fatalf("refExistsStatus = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+// recordingGitClient captures the arguments of GetRefs; other calls are not used.
+type recordingGitClient struct {
+	git.Client
+	refsArgs []git.GetRefsArgs
+}
+
+func (c *recordingGitClient) GetRefs(_ context.Context, args git.GetRefsArgs) (*git.GetRefsResponseValue, error) {
+	c.refsArgs = append(c.refsArgs, args)
+	return &git.GetRefsResponseValue{}, nil
+}
+
+func TestProjectIDPassedThrough(t *testing.T) {
+	t.Parallel()
+
+	project := "3f2504e0-4f89-11d3-9a0c-0305e82c3301"
+	repository := "repo"
+	recorder := &recordingGitClient{}
+	client := &sdkClient{git: recorder, project: &project, repository: &repository}
+
+	if _, err := client.ListRefsWithPrefix(context.Background(), "refs/tags/"); err != nil {
+		t.Fatalf("list refs: %v", err)
+	}
+	if len(recorder.refsArgs) != 1 || recorder.refsArgs[0].Project == nil || *recorder.refsArgs[0].Project != project {
+		t.Fatalf("expected project id %s passed unchanged, got %+v", project, recorder.refsArgs)
+	}
+	if !isGUID(project) || isGUID("platform") {
+		t.Fatal("expected only the project id detected as a GUID")
+	}
+}
+
+// contentGitClient serves GetItemContent from body; other calls are not used.
+type contentGitClient struct {
+	git.Client
+	body io.ReadCloser
+}
+
+func (c *contentGitClient) GetItemContent(context.Context, git.GetItemContentArgs) (io.ReadCloser, error) {
+	return c.body, nil
+}
+
+type failingCloser struct {
+	io.Reader
+	err error
+}
+
+func (c failingCloser) Close() error { return c.err }
+
+func TestGetFileContentReportsCloseError(t *testing.T) {
+	t.Parallel()
+
+	project, repository := "project", "repo"
+	closeErr := errors.New("connection reset")
+	body := failingCloser{Reader: strings.NewReader("labels: {}"), err: closeErr}
+	client := &sdkClient{git: &contentGitClient{body: body}, project: &project, repository: &repository}
+
+	content, err := client.GetFileContent(context.Background(), ".aav.yaml", "abc123")
+	if !errors.Is(err, closeErr) {
+		t.Fatalf("expected close error, got %v", err)
+	}
+	if content != nil {
+		t.Fatalf("expected no content with a close error, got %q", content)
+	}
+
+	client.git = &contentGitClient{body: io.NopCloser(strings.NewReader("labels: {}"))}
+	content, err = client.GetFileContent(context.Background(), ".aav.yaml", "abc123")
+	if err != nil || string(content) != "labels: {}" {
+		t.Fatalf("expected content, got %q err=%v", content, err)
+	}
+}
@@ -0,0 +1,63 @@
+package ado
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// normalizeTaggerName trims whitespace and invisible characters (zero-width spaces, BOMs) from
+// both ends and turns control characters such as stray newlines into spaces. Invisible
+// characters inside the name are kept, since joiners are legitimate in some scripts.
+func normalizeTaggerName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return ' '
+		}
+		return r
+	}, name)
+	return strings.TrimFunc(name, func(r rune) bool {
+		return unicode.IsSpace(r) || isInvisible(r)
+	})
+}
+
+// normalizeTaggerEmail removes invisible and control characters, surrounding whitespace, and
+// one pair of surrounding angle brackets ("<bot@example.com>"), then checks the result has a
+// basic local@domain shape.
+func normalizeTaggerEmail(email string) (string, error) {
+	cleaned := strings.Map(func(r rune) rune {
+		if isInvisible(r) || unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, email)
+	cleaned = strings.TrimSpace(cleaned)
+	if strings.HasPrefix(cleaned, "<") && strings.HasSuffix(cleaned, ">") {
+		cleaned = strings.TrimSpace(cleaned[1 : len(cleaned)-1])
+	}
+	if !validEmailShape(cleaned) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidTaggerEmail, email)
+	}
+	return cleaned, nil
+}
+
+// validEmailShape accepts local@domain with no whitespace or brackets, a single @, and a
+// domain that has no empty labels at its ends. It is deliberately looser than RFC 5322.
+func validEmailShape(email string) bool {
+	local, domain, ok := strings.Cut(email, "@")
+	if !ok || local == "" || domain == "" || strings.Contains(domain, "@") {
+		return false
+	}
+	if strings.HasPrefix(domain, ".") || strings.HasSuffix(domain, ".") {
+		return false
+	}
+	return !strings.ContainsFunc(email, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '<' || r == '>'
+	})
+}
+
+// isInvisible reports format characters (Unicode category Cf), which covers zero-width spaces
+// and joiners, word joiners, and byte order marks.
+func isInvisible(r rune) bool {
+	return unicode.Is(unicode.Cf, r)
+}
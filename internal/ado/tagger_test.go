@@ -0,0 +1,72 @@
+package ado
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBuildAnnotatedTagNormalizesTagger(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		tagger    string
+		email     string
+		wantName  string
+		wantEmail string
+	}{
+		{name: "bracketed email", tagger: "Build Bot", email: " <bot@example.com> ", wantName: "Build Bot", wantEmail: "bot@example.com"},
+		{name: "trailing zero-width space", tagger: "Build Bot\u200b", email: "bot@example.com\u200b", wantName: "Build Bot", wantEmail: "bot@example.com"},
+		{name: "byte order mark and newline", tagger: "\ufeffBuild\nBot \r\n", email: "\ufeff<bot@example.com>\n", wantName: "Build Bot", wantEmail: "bot@example.com"},
+		{name: "inner joiner kept in name", tagger: "Ana\u200dMaria", email: "ana@example.com", wantName: "Ana\u200dMaria", wantEmail: "ana@example.com"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tag, err := buildAnnotatedTag(TagSpec{Name: "v1.0.0", ObjectID: "abc", TaggerName: tc.tagger, TaggerEmail: tc.email})
+			if err != nil {
+				t.Fatalf("build: %v", err)
+			}
+			if got := *tag.TaggedBy.Name; got != tc.wantName {
+				t.Fatalf("name: want %q got %q", tc.wantName, got)
+			}
+			if got := *tag.TaggedBy.Email; got != tc.wantEmail {
+				t.Fatalf("email: want %q got %q", tc.wantEmail, got)
+			}
+		})
+	}
+}
+
+func TestBuildAnnotatedTagRejectsInvalidTagger(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		tagger       string
+		email        string
+		wantEmailErr bool
+	}{
+		{name: "name of only invisible characters", tagger: "\u200b\u2060", email: "bot@example.com"},
+		{name: "missing at sign", tagger: "bot", email: "bot.example.com", wantEmailErr: true},
+		{name: "empty domain", tagger: "bot", email: "<bot@>", wantEmailErr: true},
+		{name: "two at signs", tagger: "bot", email: "bot@x@example.com", wantEmailErr: true},
+		{name: "display name form", tagger: "bot", email: "Bot <bot@example.com>", wantEmailErr: true},
+		{name: "domain ends with dot", tagger: "bot", email: "bot@example.", wantEmailErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := buildAnnotatedTag(TagSpec{Name: "v1.0.0", ObjectID: "abc", TaggerName: tc.tagger, TaggerEmail: tc.email})
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if got := errors.Is(err, ErrInvalidTaggerEmail); got != tc.wantEmailErr {
+				t.Fatalf("ErrInvalidTaggerEmail: want %v, got %v (%v)", tc.wantEmailErr, got, err)
+			}
+		})
+	}
+}
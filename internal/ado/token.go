@@ -0,0 +1,39 @@
+package ado
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// guidPattern matches GUIDs such as a project ID, or a client or object ID pasted in place of
+	// a secret.
+	guidPattern = regexp.MustCompile(`^\{?[0-9A-Fa-f]{8}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{12}\}?$`)
+	// tokenCharset covers classic base32 PATs, newer alphanumeric PATs, and JWTs such as
+	// System.AccessToken.
+	tokenCharset = regexp.MustCompile(`^[A-Za-z0-9._~+/=-]+$`)
+)
+
+// minTokenLength is well below any PAT or System.AccessToken length.
+const minTokenLength = 32
+
+// tokenShapeWarning returns a reason when token clearly is not a PAT or pipeline access token,
+// or an empty string otherwise. It is deliberately conservative: only shapes that cannot
+// authenticate against Azure DevOps are reported, and the result never includes the token.
+func tokenShapeWarning(token string) string {
+	lower := strings.ToLower(token)
+	switch {
+	case strings.HasPrefix(lower, "bearer ") || strings.HasPrefix(lower, "basic "):
+		return "token includes an authorization scheme prefix; pass only the token value"
+	case guidPattern.MatchString(token):
+		return "token looks like a GUID, not a personal access token"
+	case strings.ContainsAny(token, " \t\r\n"):
+		return "token contains whitespace"
+	case !tokenCharset.MatchString(token):
+		return "token contains characters not used by personal access tokens"
+	case len(token) < minTokenLength:
+		return "token is shorter than a personal access token"
+	default:
+		return ""
+	}
+}
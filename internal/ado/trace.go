@@ -0,0 +1,182 @@
+package ado
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// NewTracingClient wraps inner so every API call is logged at debug level with
+// its key arguments and outcome. Credentials are never part of the trace.
+func NewTracingClient(inner Client, logger *zap.Logger, project, repository string) Client {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &tracingClient{
+		inner: inner,
+		logger: logger.With(
+			zap.String("project", project),
+			zap.String("repository", repository),
+		),
+	}
+}
+
+type tracingClient struct {
+	inner  Client
+	logger *zap.Logger
+}
+
+func (c *tracingClient) trace(method string, err error, fields ...zap.Field) {
+	fields = append([]zap.Field{zap.String("method", method), zap.Bool("success", err == nil)}, fields...)
+	if err != nil {
+		fields = append(fields, zap.Error(err))
+	}
+	c.logger.Debug("ado api call", fields...)
+}
+
+func (c *tracingClient) ListRefsWithPrefix(ctx context.Context, prefix string) ([]Ref, error) {
+	refs, err := c.inner.ListRefsWithPrefix(ctx, prefix)
+	c.trace("ListRefsWithPrefix", err, zap.String("prefix", prefix), zap.Int("refs", len(refs)))
+	return refs, err
+}
+
+func (c *tracingClient) GetRefObjectID(ctx context.Context, name string) (string, error) {
+	objectID, err := c.inner.GetRefObjectID(ctx, name)
+	c.trace("GetRefObjectID", err, zap.String("ref", name), zap.String("objectId", objectID))
+	return objectID, err
+}
+
+func (c *tracingClient) DeleteRef(ctx context.Context, name string, objectID string) error {
+	err := c.inner.DeleteRef(ctx, name, objectID)
+	c.trace("DeleteRef", err, zap.String("ref", name), zap.String("objectId", objectID))
+	return err
+}
+
+func (c *tracingClient) FindPullRequestByMergeCommit(ctx context.Context, commitSHA string) (int, error) {
+	prID, err := c.inner.FindPullRequestByMergeCommit(ctx, commitSHA)
+	c.trace("FindPullRequestByMergeCommit", err, zap.String("commit", commitSHA), zap.Int("prId", prID))
+	return prID, err
+}
+
+func (c *tracingClient) FindPullRequestsByMergeCommit(ctx context.Context, commitSHA string) ([]int, error) {
+	prIDs, err := c.inner.FindPullRequestsByMergeCommit(ctx, commitSHA)
+	c.trace("FindPullRequestsByMergeCommit", err, zap.String("commit", commitSHA), zap.Ints("prIds", prIDs))
+	return prIDs, err
+}
+
+func (c *tracingClient) GetPullRequest(ctx context.Context, prID int) (PullRequest, error) {
+	pr, err := c.inner.GetPullRequest(ctx, prID)
+	c.trace("GetPullRequest", err, zap.Int("prId", prID), zap.String("status", string(pr.Status)))
+	return pr, err
+}
+
+func (c *tracingClient) GetPullRequestTitle(ctx context.Context, prID int) (string, error) {
+	title, err := c.inner.GetPullRequestTitle(ctx, prID)
+	c.trace("GetPullRequestTitle", err, zap.Int("prId", prID))
+	return title, err
+}
+
+func (c *tracingClient) ListPRLabels(ctx context.Context, prID int) ([]string, error) {
+	labels, err := c.inner.ListPRLabels(ctx, prID)
+	c.trace("ListPRLabels", err, zap.Int("prId", prID), zap.Int("labels", len(labels)))
+	return labels, err
+}
+
+func (c *tracingClient) AddPRLabel(ctx context.Context, prID int, label string) error {
+	err := c.inner.AddPRLabel(ctx, prID, label)
+	c.trace("AddPRLabel", err, zap.Int("prId", prID), zap.String("label", label))
+	return err
+}
+
+func (c *tracingClient) AddPRComment(ctx context.Context, prID int, content string) error {
+	err := c.inner.AddPRComment(ctx, prID, content)
+	c.trace("AddPRComment", err, zap.Int("prId", prID), zap.Int("length", len(content)))
+	return err
+}
+
+func (c *tracingClient) ListPullRequestChangedPaths(ctx context.Context, prID int) ([]string, error) {
+	paths, err := c.inner.ListPullRequestChangedPaths(ctx, prID)
+	c.trace("ListPullRequestChangedPaths", err, zap.Int("prId", prID), zap.Int("paths", len(paths)))
+	return paths, err
+}
+
+func (c *tracingClient) CreateAnnotatedTag(ctx context.Context, spec TagSpec) error {
+	err := c.inner.CreateAnnotatedTag(ctx, spec)
+	c.trace("CreateAnnotatedTag", err, zap.String("tag", spec.Name), zap.String("objectId", spec.ObjectID))
+	return err
+}
+
+func (c *tracingClient) CreateLightweightTag(ctx context.Context, name string, objectID string) error {
+	err := c.inner.CreateLightweightTag(ctx, name, objectID)
+	c.trace("CreateLightweightTag", err, zap.String("tag", name), zap.String("objectId", objectID))
+	return err
+}
+
+func (c *tracingClient) UpdateRef(ctx context.Context, name string, oldObjectID string, newObjectID string) error {
+	err := c.inner.UpdateRef(ctx, name, oldObjectID, newObjectID)
+	c.trace("UpdateRef", err,
+		zap.String("ref", name),
+		zap.String("oldObjectId", oldObjectID),
+		zap.String("newObjectId", newObjectID),
+	)
+	return err
+}
+
+func (c *tracingClient) GetAuthenticatedIdentity(ctx context.Context) (Identity, error) {
+	ident, err := c.inner.GetAuthenticatedIdentity(ctx)
+	c.trace("GetAuthenticatedIdentity", err)
+	return ident, err
+}
+
+func (c *tracingClient) ListCommitsBetween(ctx context.Context, fromCommit, toCommit string) ([]Commit, error) {
+	commits, err := c.inner.ListCommitsBetween(ctx, fromCommit, toCommit)
+	c.trace("ListCommitsBetween", err,
+		zap.String("from", fromCommit),
+		zap.String("to", toCommit),
+		zap.Int("commits", len(commits)),
+	)
+	return commits, err
+}
+
+func (c *tracingClient) GetCommit(ctx context.Context, commitSHA string) (Commit, error) {
+	commit, err := c.inner.GetCommit(ctx, commitSHA)
+	c.trace("GetCommit", err, zap.String("commit", commitSHA), zap.Int("parents", len(commit.Parents)))
+	return commit, err
+}
+
+func (c *tracingClient) GetCommitMessage(ctx context.Context, commitSHA string) (string, error) {
+	message, err := c.inner.GetCommitMessage(ctx, commitSHA)
+	c.trace("GetCommitMessage", err, zap.String("commit", commitSHA), zap.Int("length", len(message)))
+	return message, err
+}
+
+func (c *tracingClient) CommitExists(ctx context.Context, commitSHA string) (bool, error) {
+	exists, err := c.inner.CommitExists(ctx, commitSHA)
+	c.trace("CommitExists", err, zap.String("commit", commitSHA), zap.Bool("exists", exists))
+	return exists, err
+}
+
+func (c *tracingClient) GetTagDate(ctx context.Context, tagObjectID string) (time.Time, error) {
+	date, err := c.inner.GetTagDate(ctx, tagObjectID)
+	c.trace("GetTagDate", err, zap.String("tagObject", tagObjectID), zap.Time("date", date))
+	return date, err
+}
+
+func (c *tracingClient) IsAncestor(ctx context.Context, ancestor, descendant string) (bool, error) {
+	ok, err := c.inner.IsAncestor(ctx, ancestor, descendant)
+	c.trace("IsAncestor", err, zap.String("ancestor", ancestor), zap.String("descendant", descendant))
+	return ok, err
+}
+
+func (c *tracingClient) GetFileContent(ctx context.Context, path, commitSHA string) ([]byte, error) {
+	content, err := c.inner.GetFileContent(ctx, path, commitSHA)
+	c.trace("GetFileContent", err, zap.String("path", path), zap.String("commit", commitSHA), zap.Int("bytes", len(content)))
+	return content, err
+}
+
+func (c *tracingClient) HasPermission(ctx context.Context, permission Permission) (bool, error) {
+	ok, err := c.inner.HasPermission(ctx, permission)
+	c.trace("HasPermission", err, zap.String("permission", string(permission)), zap.Bool("granted", ok))
+	return ok, err
+}
@@ -0,0 +1,103 @@
+package ado
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type stubClient struct {
+	Client
+	labelErr error
+}
+
+func (stubClient) ListRefsWithPrefix(context.Context, string) ([]Ref, error) {
+	return []Ref{{Name: "refs/tags/v1.0.0"}}, nil
+}
+
+func (stubClient) FindPullRequestByMergeCommit(context.Context, string) (int, error) {
+	return 42, nil
+}
+
+func (s stubClient) AddPRLabel(context.Context, int, string) error {
+	return s.labelErr
+}
+
+func (stubClient) CreateAnnotatedTag(context.Context, TagSpec) error {
+	return nil
+}
+
+func TestTracingClientLogsEachCall(t *testing.T) {
+	t.Parallel()
+
+	core, logs := observer.New(zapcore.DebugLevel)
+	client := NewTracingClient(stubClient{labelErr: errors.New("boom")}, zap.New(core), "proj", "repo")
+	ctx := context.Background()
+
+	if _, err := client.ListRefsWithPrefix(ctx, "refs/tags/v"); err != nil {
+		t.Fatalf("ListRefsWithPrefix: %v", err)
+	}
+	if _, err := client.FindPullRequestByMergeCommit(ctx, "abc123"); err != nil {
+		t.Fatalf("FindPullRequestByMergeCommit: %v", err)
+	}
+	if err := client.AddPRLabel(ctx, 42, "semver-minor"); err == nil {
+		t.Fatalf("expected AddPRLabel error")
+	}
+	if err := client.CreateAnnotatedTag(ctx, TagSpec{Name: "v1.1.0", ObjectID: "abc123"}); err != nil {
+		t.Fatalf("CreateAnnotatedTag: %v", err)
+	}
+
+	entries := logs.All()
+	wantMethods := []string{"ListRefsWithPrefix", "FindPullRequestByMergeCommit", "AddPRLabel", "CreateAnnotatedTag"}
+	if len(entries) != len(wantMethods) {
+		t.Fatalf("expected %d trace entries, got %d", len(wantMethods), len(entries))
+	}
+	for i, entry := range entries {
+		fields := entry.ContextMap()
+		if entry.Level != zapcore.DebugLevel {
+			t.Fatalf("entry %d: expected debug level, got %s", i, entry.Level)
+		}
+		if fields["method"] != wantMethods[i] {
+			t.Fatalf("entry %d: expected method %s, got %v", i, wantMethods[i], fields["method"])
+		}
+		if fields["project"] != "proj" || fields["repository"] != "repo" {
+			t.Fatalf("entry %d: missing repo context: %v", i, fields)
+		}
+		wantSuccess := wantMethods[i] != "AddPRLabel"
+		if fields["success"] != wantSuccess {
+			t.Fatalf("entry %d: expected success=%t, got %v", i, wantSuccess, fields["success"])
+		}
+	}
+	if got := entries[0].ContextMap()["prefix"]; got != "refs/tags/v" {
+		t.Fatalf("expected prefix field, got %v", got)
+	}
+	if got := entries[1].ContextMap()["prId"]; got != int64(42) {
+		t.Fatalf("expected prId field, got %v", got)
+	}
+	if got := entries[3].ContextMap()["tag"]; got != "v1.1.0" {
+		t.Fatalf("expected tag field, got %v", got)
+	}
+}
+
+func TestTracingFieldsExcludeToken(t *testing.T) {
+	t.Parallel()
+
+	core, logs := observer.New(zapcore.DebugLevel)
+	client := NewTracingClient(stubClient{}, zap.New(core), "proj", "repo")
+	if _, err := client.ListRefsWithPrefix(context.Background(), "refs/tags/"); err != nil {
+		t.Fatalf("ListRefsWithPrefix: %v", err)
+	}
+
+	for _, entry := range logs.All() {
+		for key := range entry.ContextMap() {
+			if strings.Contains(strings.ToLower(key), "token") {
+				t.Fatalf("trace must not include token fields, found %q", key)
+			}
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/config"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/output"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/tagging"
+)
+
+// runAliasTag handles create-tag --from-tag: the source release's commit is tagged under
+// --tag-name as given, without computing a new version.
+func runAliasTag(cmd *cobra.Command, ctx context.Context, runtime runtimeConfig, f *tagFlagSet, source string) error {
+	resolver := runtime.resolver
+
+	cfg, err := f.resolveAlias(resolver, source)
+	if err != nil {
+		return err
+	}
+	dryRun, err := f.dryRun.Value(resolver)
+	if err != nil {
+		return err
+	}
+
+	if err := runPreflight(ctx, runtime, ado.PermissionCreateTag); err != nil {
+		return err
+	}
+
+	planner := tagplan.NewPlanner(strings.TrimSpace(f.tagPrefix.Value(resolver))).
+		WithPrefixSeparator(strings.TrimSpace(f.prefixSep.Value(resolver))).
+		WithComponent(f.component.Value(resolver))
+	service := tagging.NewService(runtime.client, planner).WithProtectedTags(runtime.protectedTags)
+
+	var plan tagplan.AliasPlan
+	if dryRun {
+		plan, err = service.PlanAlias(ctx, cfg)
+	} else {
+		plan, err = service.CreateAlias(ctx, cfg)
+	}
+	if err != nil {
+		return err
+	}
+
+	log := runtime.logger.With(
+		zap.String("tag", plan.TagName),
+		zap.String("sourceTag", plan.Source.Name),
+		zap.String("commit", plan.Commit),
+	)
+	if dryRun {
+		log.Info("dry run: alias tag not created")
+	} else {
+		log.Info("alias tag created")
+	}
+
+	if runtime.format == output.FormatJSON {
+		payload := output.NewAliasTagResult(plan, dryRun)
+		payload.Operator = runtime.operator
+		if err := output.WriteJSON(cmd.OutOrStdout(), payload); err != nil {
+			return err
+		}
+	} else if err := output.WriteValue(cmd.OutOrStdout(), plan.TagName, !runtime.noNewline); err != nil {
+		return fmt.Errorf("writing tag result: %w", err)
+	}
+	return writeSummary(cmd, runtime, output.AliasTagSummary(plan, dryRun))
+}
+
+// resolveAlias reads the settings used by --from-tag; the version and commit flags do not apply.
+func (f *tagFlagSet) resolveAlias(resolver config.Resolver, source string) (tagging.AliasConfig, error) {
+	name := strings.TrimSpace(f.tagName.Value(resolver))
+	if name == "" {
+		return tagging.AliasConfig{}, fmt.Errorf("%s is required with --%s", flagTagName, flagFromTag)
+	}
+	kind, err := tagging.ParseTagKind(f.tagKind.Value(resolver))
+	if err != nil {
+		return tagging.AliasConfig{}, err
+	}
+	return tagging.AliasConfig{
+		SourceTag:   source,
+		TagName:     name,
+		Message:     strings.TrimSpace(f.message.Value(resolver)),
+		TaggerName:  strings.TrimSpace(f.taggerName.Value(resolver)),
+		TaggerEmail: strings.TrimSpace(f.taggerEmail.Value(resolver)),
+		TagKind:     kind,
+	}, nil
+}
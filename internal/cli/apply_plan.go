@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/output"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/tagging"
+)
+
+func newApplyPlanCommand(rootFlags *rootFlagSet) *cobra.Command {
+	var planFileFlag *stringFlag
+
+	cmd := &cobra.Command{
+		Use:   "apply-plan",
+		Short: "Create the tags recorded by create-tag --plan-only",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			runtime, cleanup, err := buildRuntime(ctx, cmd, rootFlags)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			path := strings.TrimSpace(planFileFlag.Value(runtime.resolver))
+			if path == "" {
+				return fmt.Errorf(requiredFlagFormat, flagPlanFile)
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("reading plan: %w", err)
+			}
+			saved, err := tagging.ParseSavedPlan(data)
+			if err != nil {
+				return fmt.Errorf("%s: %w", path, err)
+			}
+
+			required := []ado.Permission{ado.PermissionCreateTag}
+			if saved.Floating != nil || saved.MinorFloating != nil {
+				required = append(required, ado.PermissionForcePush)
+			}
+			if err := runPreflight(ctx, runtime, required...); err != nil {
+				return err
+			}
+
+			service := tagging.NewService(runtime.client, tagplan.NewPlanner("")).WithProtectedTags(runtime.protectedTags).WithEvents(runtime.events)
+			result, err := service.ApplyPlan(ctx, saved)
+			if err != nil {
+				return err
+			}
+
+			log := runtime.logger.With(
+				zap.String("plan", path),
+				zap.String("tag", result.TagName),
+				zap.String("commit", saved.Commit),
+			)
+			log.Info("planned tag created")
+			if result.Floating.Created {
+				log.Info("floating tag updated", zap.String("floatingTag", result.Floating.TagName), zap.Bool("replaced", result.Floating.DeletedExisting || result.Floating.Moved))
+			}
+			if minor := result.Floating.Minor; minor != nil && minor.Created {
+				log.Info("floating tag updated", zap.String("floatingTag", minor.TagName), zap.Bool("replaced", minor.DeletedExisting || minor.Moved))
+			}
+
+			if runtime.format == output.FormatJSON {
+				payload := output.NewCreateTagResult(result, saved.Commit, false)
+				payload.Operator = runtime.operator
+				if err := output.WriteJSON(cmd.OutOrStdout(), payload); err != nil {
+					return err
+				}
+			} else if err := output.WriteValue(cmd.OutOrStdout(), result.TagName, !runtime.noNewline); err != nil {
+				return fmt.Errorf("writing tag result: %w", err)
+			}
+			return writeSummary(cmd, runtime, output.CreateTagSummary(result, bump.Bump(saved.Bump), saved.Commit, false))
+		},
+	}
+
+	planFileFlag = bindStringFlag(cmd.Flags(), flagPlanFile, flagPlanFile, "", envPlanFile, defaultPlanFile, "Plan written by create-tag --plan-only")
+
+	return cmd
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/output"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/inferbump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/tagging"
+)
+
+func newCompletionCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "completion bash|zsh|fish|powershell",
+		Short: "Generate a shell completion script",
+		Long: `Generate a completion script for the given shell and print it to stdout, e.g.
+
+  source <(aav completion bash)
+  aav completion zsh > "${fpath[1]}/_aav"`,
+		ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+		Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, out := cmd.Root(), cmd.OutOrStdout()
+			var err error
+			switch args[0] {
+			case "bash":
+				err = root.GenBashCompletionV2(out, true)
+			case "zsh":
+				err = root.GenZshCompletion(out)
+			case "fish":
+				err = root.GenFishCompletion(out, true)
+			case "powershell":
+				err = root.GenPowerShellCompletionWithDesc(out)
+			}
+			if err != nil {
+				return fmt.Errorf("writing %s completion: %w", args[0], err)
+			}
+			return nil
+		},
+	}
+}
+
+// completeValues registers a fixed set of suggestions for an enum-like flag. The flag must
+// already be bound on cmd.
+func completeValues(cmd *cobra.Command, flag string, values ...string) {
+	_ = cmd.RegisterFlagCompletionFunc(flag, cobra.FixedCompletions(values, cobra.ShellCompDirectiveNoFileComp))
+}
+
+var (
+	bumpCompletions     = []string{string(bump.BumpMajor), string(bump.BumpMinor), string(bump.BumpPatch), string(bump.BumpNone)}
+	conflictCompletions = []string{string(inferbump.ConflictMax), string(inferbump.ConflictMin), string(inferbump.ConflictError)}
+)
+
+func registerRootCompletions(cmd *cobra.Command) {
+	completeValues(cmd, flagOutput, string(output.FormatText), string(output.FormatJSON), string(output.FormatCSV), string(output.FormatTSV))
+}
+
+func registerTagCompletions(cmd *cobra.Command) {
+	completeValues(cmd, flagTagMode, string(tagplan.ModeRelease), string(tagplan.ModeRC))
+	completeValues(cmd, flagBump, bumpCompletions...)
+	completeValues(cmd, flagDefaultBump, bumpCompletions...)
+	completeValues(cmd, flagFloatLevel, string(tagging.FloatingLevelMajor), string(tagging.FloatingLevelMinor), string(tagging.FloatingLevelBoth))
+	completeValues(cmd, flagFloatingTrack, string(tagplan.FloatingTrackStable), string(tagplan.FloatingTrackAny))
+	completeValues(cmd, flagRCScope, string(tagplan.RCScopeExact), string(tagplan.RCScopeMinor), string(tagplan.RCScopeMajor))
+}
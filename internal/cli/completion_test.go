@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// runRoot executes the root command with args and returns its stdout.
+func runRoot(t *testing.T, args ...string) string {
+	t.Helper()
+
+	cmd := newRootCommand()
+	var stdout, stderr bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetArgs(args)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("aav %s: %v (stderr: %s)", strings.Join(args, " "), err, stderr.String())
+	}
+	return stdout.String()
+}
+
+func TestCompletionBash(t *testing.T) {
+	t.Parallel()
+
+	if out := runRoot(t, "completion", "bash"); !strings.Contains(out, "bash completion V2 for aav") {
+		t.Fatalf("expected a bash completion script, got %d bytes:\n%.200s", len(out), out)
+	}
+}
+
+func TestCompleteTagModeValues(t *testing.T) {
+	t.Parallel()
+
+	out := runRoot(t, "__complete", "create-tag", "--"+flagTagMode, "")
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 3 || lines[0] != "release" || lines[1] != "rc" || lines[2] != ":4" {
+		t.Fatalf("expected release and rc with no file completion, got %q", out)
+	}
+}
@@ -0,0 +1,1001 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/config"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/output"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/inferbump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/releasenotes"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/tagging"
+)
+
+// defaultIntegrationTimeout bounds outbound integration calls unless --integration-timeout says
+// otherwise, so a slow third party cannot hold up the run.
+const defaultIntegrationTimeout = 10 * time.Second
+
+type tagFlagSet struct {
+	mode        *stringFlag
+	bump        *stringFlag
+	defBump     *stringFlag
+	requireBump *boolFlag
+	base        *stringFlag
+	verSource   *stringFlag
+	hotfixBase  *stringFlag
+	commit      *stringFlag
+	message     *stringFlag
+	messageFile *stringFlag
+	taggerName  *stringFlag
+	taggerEmail *stringFlag
+	tagPrefix   *stringFlag
+	useFloating *boolFlag
+	forceFloat  *boolFlag
+	skipCI      *stringFlag
+	skipMarker  *stringFlag
+	tagKind     *stringFlag
+	floatKind   *stringFlag
+	refCheck    *stringFlag
+	onMissing   *stringFlag
+	rcRetry     *boolFlag
+	mergeOnly   *boolFlag
+	once        *boolFlag
+	verifyTag   *boolFlag
+	verifyWait  *stringFlag
+	relBranches *stringSliceFlag
+	rcMinAge    *stringFlag
+	intTimeout  *stringFlag
+	skipPaths   *stringSliceFlag
+	tfOut       *stringFlag
+	dryRun      *boolFlag
+	planOnly    *boolFlag
+	planFile    *stringFlag
+	showDiff    *boolFlag
+	fromIdent   *boolFlag
+	commits     *boolFlag
+	shellOut    *boolFlag
+	annotate    *boolFlag
+	floatTrack  *stringFlag
+	floatLevel  *stringFlag
+	preferPre   *boolFlag
+	downgrade   *boolFlag
+	maxMajor    *intFlag
+	maxMinor    *intFlag
+	maxPatch    *intFlag
+	maxScan     *intFlag
+	prID        *intFlag
+	prefixSep   *stringFlag
+	component   *stringFlag
+	verRange    *stringFlag
+	ignoreTags  *stringSliceFlag
+	preLabel    *stringFlag
+	channel     *stringFlag
+	chanOrder   *stringSliceFlag
+	enforceOrd  *boolFlag
+	rcScope     *stringFlag
+	fromTag     *stringFlag
+	tagName     *stringFlag
+}
+
+type tagRunOptions struct {
+	tagPrefix string
+	prefixSep string
+	component string
+	track     tagplan.FloatingTrack
+	preferPre bool
+	downgrade bool
+	limits    tagplan.Limits
+	verRange  tagplan.VersionRange
+	// ignoreTags lists globs of retracted release tags; see tagplan.Planner.WithIgnoreTags.
+	ignoreTags []string
+	// preLabel, chanOrder, and enforceOrd configure the RC channel; see
+	// tagplan.Planner.WithChannelOrder.
+	preLabel   string
+	chanOrder  []string
+	enforceOrd bool
+	rcScope    tagplan.RCScope
+	tfOut      string
+	dryRun     bool
+	// planFile is set with --plan-only: the run previews like --dry-run and saves the plan
+	// there for apply-plan.
+	planFile     string
+	showDiff     bool
+	fromIdentity bool
+	commits      bool
+	shellOut     bool
+	annotate     bool
+	// taggerNameSet/taggerEmailSet record whether the tagger fields were explicitly configured,
+	// so identity lookup only replaces the built-in defaults.
+	taggerNameSet  bool
+	taggerEmailSet bool
+	// prID, when set without a commit SHA, selects the PR whose merge commit is tagged;
+	// bumpSet records an explicit --bump that overrides the PR's labels.
+	prID    int
+	bumpSet bool
+}
+
+func newTagCommand(rootFlags *rootFlagSet) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create-tag",
+		Short: "Plan the next release or RC tag",
+	}
+
+	tagFlags := bindTagFlags(cmd)
+	registerTagCompletions(cmd)
+
+	cmd.RunE = func(cmd *cobra.Command, _ []string) error {
+		ctx := cmd.Context()
+		if err := tagFlags.validate(validationInputs(rootFlags)); err != nil {
+			return err
+		}
+
+		runtime, cleanup, err := buildRuntime(ctx, cmd, rootFlags)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+
+		if source := strings.TrimSpace(tagFlags.fromTag.Value(runtime.resolver)); source != "" {
+			return runAliasTag(cmd, ctx, runtime, tagFlags, source)
+		}
+
+		prID, err := tagFlags.pullRequestID(runtime.resolver)
+		if err != nil {
+			return err
+		}
+
+		createCfg, err := tagFlags.resolve(runtime.resolver, prID)
+		if err != nil {
+			return err
+		}
+
+		opts, err := tagFlags.runOptions(runtime.resolver)
+		if err != nil {
+			return err
+		}
+		if createCfg.CommitSHA == "" {
+			opts.prID = prID
+		}
+		if !opts.bumpSet && createCfg.HotfixBase == "" && opts.prID == 0 {
+			source := "built-in default"
+			if tagFlags.defBump.base.explicit() {
+				source = "--" + flagDefaultBump
+			}
+			runtime.logger.Info("bump not set; using default", zap.String("bump", createCfg.Bump.String()), zap.String("source", source))
+		}
+		if err := checkShellOut(opts.shellOut, runtime.format); err != nil {
+			return err
+		}
+
+		return runTagCommand(cmd, ctx, runtime, rootFlags, createCfg, opts)
+	}
+
+	return cmd
+}
+
+// runTagPreflight previews the run and checks the permissions writing it needs, so a floating
+// tag that is moved because it was auto-detected still requires Force push up front instead of
+// failing after the version tag is created.
+func runTagPreflight(ctx context.Context, runtime runtimeConfig, createCfg tagging.CreateConfig, preview func(context.Context) (tagplan.Result, error)) error {
+	if !runtime.preflight {
+		return nil
+	}
+	plan, err := preview(ctx)
+	if err != nil {
+		return err
+	}
+	return runPreflight(ctx, runtime, tagging.RequiredPermissions(createCfg, plan)...)
+}
+
+func runTagCommand(cmd *cobra.Command, ctx context.Context, runtime runtimeConfig, rootFlags *rootFlagSet, createCfg tagging.CreateConfig, opts tagRunOptions) error {
+	planner := tagplan.NewPlanner(opts.tagPrefix).
+		WithPrefixSeparator(opts.prefixSep).
+		WithComponent(opts.component).
+		WithFloatingTrack(opts.track).
+		WithLimits(opts.limits).
+		WithVersionRange(opts.verRange).
+		WithIgnoreTags(opts.ignoreTags).
+		WithPreferPrereleaseLine(opts.preferPre).
+		WithAllowDowngrade(opts.downgrade).
+		WithPrereleaseLabel(opts.preLabel).
+		WithChannelOrder(opts.chanOrder, opts.enforceOrd).
+		WithRCScope(opts.rcScope)
+	service := tagging.NewService(runtime.client, planner).WithProtectedTags(runtime.protectedTags).WithEvents(runtime.events)
+
+	if opts.prID > 0 {
+		resolved, err := resolvePullRequestTarget(ctx, &runtime, rootFlags, createCfg, opts)
+		if err != nil {
+			return err
+		}
+		createCfg = resolved
+	} else if err := applyRepoConfig(ctx, &runtime, rootFlags, createCfg.CommitSHA); err != nil {
+		return err
+	}
+
+	if opts.fromIdentity {
+		createCfg = applyIdentityTagger(ctx, runtime.logger, service, createCfg, opts)
+	}
+
+	preview := func(ctx context.Context) (tagplan.Result, error) { return service.Preview(ctx, createCfg) }
+	if err := runTagPreflight(ctx, runtime, createCfg, preview); err != nil {
+		return err
+	}
+
+	var result tagplan.Result
+	var err error
+	if opts.dryRun {
+		result, err = service.Preview(ctx, createCfg)
+	} else {
+		result, err = service.PlanAndCreate(ctx, createCfg)
+	}
+	if err != nil {
+		return err
+	}
+
+	logTagResult(runtime.logger, createCfg, result, opts)
+
+	if opts.planFile != "" && !result.Skipped {
+		saved := tagging.NewSavedPlan(createCfg, result)
+		saved.Operator = runtime.operator
+		if err := output.WriteJSONFile(opts.planFile, saved); err != nil {
+			return fmt.Errorf("writing plan: %w", err)
+		}
+		runtime.logger.Info("plan written; run apply-plan to create the tags", zap.String("path", opts.planFile))
+	}
+
+	if opts.tfOut != "" && !opts.dryRun && !result.Skipped {
+		if err := output.WriteJSONFile(opts.tfOut, output.TerraformValues(result)); err != nil {
+			return fmt.Errorf("writing terraform output: %w", err)
+		}
+		runtime.logger.Debug("terraform output written", zap.String("path", opts.tfOut))
+	}
+
+	var notes *releasenotes.Result
+	if opts.commits && !result.Skipped {
+		notes = collectReleaseNotes(ctx, runtime, createCfg, result)
+	}
+
+	if err := writeTagResult(cmd, runtime, createCfg, result, opts, notes); err != nil {
+		return err
+	}
+	if opts.annotate && !opts.dryRun && !result.Skipped {
+		if err := annotatePipeline(cmd, runtime, output.CreateTagBuildTags(result)); err != nil {
+			return err
+		}
+	}
+	return writeSummary(cmd, runtime, output.CreateTagSummary(result, createCfg.Bump, createCfg.CommitSHA, opts.dryRun))
+}
+
+// collectReleaseNotes lists the commits since the previous release tag. Failures only omit
+// the section so a release is never blocked on history queries.
+func collectReleaseNotes(ctx context.Context, runtime runtimeConfig, createCfg tagging.CreateConfig, result tagplan.Result) *releasenotes.Result {
+	from := result.BaseTag.ObjectID
+	if from == "" {
+		runtime.logger.Warn("commits section omitted", zap.String("reason", "no previous release tag"))
+		return nil
+	}
+
+	notes, err := releasenotes.NewService(runtime.client, runtime.labels).Collect(ctx, releasenotes.Config{
+		FromCommit: from,
+		ToCommit:   createCfg.CommitSHA,
+	})
+	if err != nil {
+		runtime.logger.Warn("commits section omitted", zap.Error(err))
+		return nil
+	}
+	runtime.logger.Debug("commits collected", zap.String("previousTag", result.BaseTag.Name), zap.Int("groups", len(notes.Groups)))
+	return &notes
+}
+
+// resolvePullRequestTarget tags the merge commit of a completed pull request, taking the bump
+// from its labels unless --bump was set explicitly. The repo config is read at the merge commit
+// first, so label names it renames apply to the pull request's labels.
+func resolvePullRequestTarget(ctx context.Context, runtime *runtimeConfig, rootFlags *rootFlagSet, createCfg tagging.CreateConfig, opts tagRunOptions) (tagging.CreateConfig, error) {
+	if runtime.repoConfig != "" {
+		commit, err := inferbump.NewService(runtime.client, runtime.labels).MergeCommit(ctx, opts.prID)
+		if err != nil {
+			return tagging.CreateConfig{}, err
+		}
+		if err := applyRepoConfig(ctx, runtime, rootFlags, commit); err != nil {
+			return tagging.CreateConfig{}, err
+		}
+	}
+
+	pr, err := inferbump.NewService(runtime.client, runtime.labels).ResolvePullRequest(ctx, opts.prID, inferbump.Config{})
+	if err != nil {
+		return tagging.CreateConfig{}, err
+	}
+
+	createCfg.CommitSHA = pr.CommitSHA
+	log := runtime.logger.With(zap.Int("pr", pr.PRID), zap.String("commit", pr.CommitSHA))
+	switch {
+	case opts.bumpSet || createCfg.HotfixBase != "":
+		log.Info("tagging pull request merge commit", zap.String("bump", createCfg.Bump.String()))
+	case pr.Defaulted:
+		createCfg.Bump = pr.Bump
+		log.Warn("tagging pull request merge commit with default bump", zap.String("bump", pr.Bump.String()), zap.String("reason", string(pr.DefaultReason)))
+	default:
+		createCfg.Bump = pr.Bump
+		log.Info("tagging pull request merge commit", zap.String("bump", pr.Bump.String()), zap.Strings("labels", pr.SemverLabels))
+	}
+	return createCfg, nil
+}
+
+// applyIdentityTagger replaces default tagger fields with the token's identity, falling back to
+// the defaults when the lookup fails or returns an incomplete identity.
+func applyIdentityTagger(ctx context.Context, logger *zap.Logger, service tagging.Service, createCfg tagging.CreateConfig, opts tagRunOptions) tagging.CreateConfig {
+	if opts.taggerNameSet && opts.taggerEmailSet {
+		return createCfg
+	}
+
+	lookup := createCfg
+	if !opts.taggerNameSet {
+		lookup.TaggerName = ""
+	}
+	if !opts.taggerEmailSet {
+		lookup.TaggerEmail = ""
+	}
+
+	filled, err := service.FillTaggerFromIdentity(ctx, lookup)
+	if err != nil {
+		logger.Warn("tagger identity lookup failed; using defaults",
+			zap.Error(err),
+			zap.String("tagger", createCfg.TaggerName),
+			zap.String("taggerEmail", createCfg.TaggerEmail),
+		)
+		return createCfg
+	}
+
+	logger.Debug("tagger resolved from authenticated identity",
+		zap.String("tagger", filled.TaggerName),
+		zap.String("taggerEmail", filled.TaggerEmail),
+	)
+	return filled
+}
+
+func logTagResult(logger *zap.Logger, createCfg tagging.CreateConfig, result tagplan.Result, opts tagRunOptions) {
+	log := tagResultLogger(logger, createCfg, result, opts)
+	if result.RCCollisions > 0 {
+		logger.Warn("rc number taken by a concurrent run; replanned", zap.Int("collisions", result.RCCollisions), zap.String("tag", result.TagName))
+	}
+	if result.CommitUnverified != "" {
+		logger.Warn("target commit not verified; creating tag anyway", zap.String("commit", createCfg.CommitSHA), zap.String("reason", result.CommitUnverified))
+	}
+	if result.Skipped && result.SkipReason != "" {
+		log.Info("tag creation skipped", zap.String("reason", result.SkipReason))
+		return
+	}
+	if result.Skipped {
+		log.Warn("commit not found; tag creation skipped")
+		return
+	}
+	logPlanNotices(logger, log, result)
+
+	kind := createCfg.TagKind
+	if kind == "" {
+		kind = tagging.TagKindAnnotated
+	}
+	switch {
+	case result.AlreadyExists:
+		log.Info("tag already exists at commit; not created again")
+	case opts.dryRun:
+		log.Info(fmt.Sprintf("dry run: %s tag not created", kind))
+	default:
+		log.Info(fmt.Sprintf("%s tag created", kind))
+	}
+
+	if !result.FloatingEligible() {
+		return
+	}
+	if createCfg.FloatingLevel.MaintainsMajor() {
+		logFloatingResult(logger, createCfg, result.Floating, "major", opts.dryRun)
+	}
+	if minor := result.Floating.Minor; minor != nil && createCfg.FloatingLevel.MaintainsMinor() {
+		logFloatingResult(logger, createCfg, *minor, "minor", opts.dryRun)
+	}
+}
+
+// tagResultLogger attaches the planned tag and the settings that shaped it, leaving out the
+// ones that do not apply.
+func tagResultLogger(logger *zap.Logger, createCfg tagging.CreateConfig, result tagplan.Result, opts tagRunOptions) *zap.Logger {
+	log := logger.With(
+		zap.String("mode", string(result.Mode)),
+		zap.String("tag", result.TagName),
+		zap.String("releaseBase", result.ReleaseBase.String()),
+		zap.String("baseSource", string(result.BaseSource)),
+		zap.String("targetRelease", result.TargetRelease.String()),
+		zap.String("commit", createCfg.CommitSHA),
+		zap.String("tagger", createCfg.TaggerName),
+	)
+	if createCfg.Message != "" {
+		log = log.With(zap.String("message", createCfg.Message))
+	}
+	if result.Mode == tagplan.ModeRC {
+		log = log.With(zap.Int("rcNumber", result.RCNumber))
+	}
+	if result.VerifyAttempts > 0 {
+		log = log.With(zap.Int("verifyAttempts", result.VerifyAttempts))
+	}
+	if opts.tagPrefix != "" {
+		log = log.With(zap.String("tagPrefix", opts.tagPrefix))
+	}
+	if opts.tagPrefix != "" && opts.prefixSep != "" {
+		log = log.With(zap.String("prefixSeparator", opts.prefixSep))
+	}
+	if opts.component != "" {
+		log = log.With(zap.String("component", opts.component))
+	}
+	if opts.verRange.String() != "" {
+		log = log.With(zap.String("versionRange", opts.verRange.String()))
+	}
+	switch result.BaseSource {
+	case tagplan.BaseSourceHotfix:
+		log = log.With(zap.String("hotfixBase", result.BaseTag.Name))
+	case tagplan.BaseSourcePromoted:
+		log = log.With(zap.String("promotedRC", result.BaseTag.Name))
+	}
+	return log
+}
+
+// logPlanNotices reports what the planner noticed about the existing tags: a higher
+// prerelease line, retracted or duplicate releases, legacy names, and branch collisions.
+func logPlanNotices(logger, log *zap.Logger, result tagplan.Result) {
+	switch {
+	case result.PrereleaseLineUsed:
+		log.Info("finalizing higher prerelease line", zap.String("prerelease", result.HigherPrerelease))
+	case result.HigherPrerelease != "":
+		logger.Warn("a prerelease exists on a higher major; pass --"+flagPreferPreLine+" to finalize it instead",
+			zap.String("prerelease", result.HigherPrerelease), zap.String("tag", result.TagName))
+	}
+	for _, name := range result.IgnoredTags {
+		log.Info("retracted release not used as base", zap.String("ignoredTag", name))
+	}
+	for _, name := range result.DuplicateTags {
+		logger.Warn("ignoring tag that duplicates another tag's version", zap.String("tag", name))
+	}
+	if notice := output.LegacyTagNotice(result.LegacyTags); notice != "" {
+		logger.Warn(notice)
+	}
+	for _, name := range result.BranchCollisions {
+		logger.Warn("tag name matches an existing branch", zap.String("tag", name), zap.String("branch", "refs/heads/"+name))
+	}
+}
+
+// logFloatingResult reports what happened to one floating tag; line is "major" or "minor".
+func logFloatingResult(logger *zap.Logger, createCfg tagging.CreateConfig, f tagplan.FloatingPlan, line string, dryRun bool) {
+	switch {
+	case f.Superseded:
+		logger.Warn("floating tag not moved", zap.String("floatingTag", f.TagName), zap.String("reason", "a newer release exists in this "+line))
+	case f.Enabled && f.AlreadyExists:
+		logger.Info("floating tag already at commit; not updated", zap.String("floatingTag", f.TagName))
+	case f.Enabled:
+		floatingLog := logger.With(zap.String("floatingTag", f.TagName))
+		if f.Regressed {
+			floatingLog.Warn("floating tag moved back to an older commit under --"+flagForceFloating,
+				zap.String("previousCommit", f.PreviousCommit), zap.String("commit", createCfg.CommitSHA))
+		}
+		if createCfg.FloatingSkipCI != "" && createCfg.FloatingSkipCI != tagging.FloatingSkipCIOff {
+			floatingLog = floatingLog.With(zap.String("skipCI", string(createCfg.FloatingSkipCI)))
+		}
+		if f.DeletedExisting || f.Moved || (dryRun && f.Existing.Name != "") {
+			floatingLog = floatingLog.With(zap.Bool("replaced", true))
+		}
+		if createCfg.FloatingTagKind != "" {
+			floatingLog = floatingLog.With(zap.String("kind", string(createCfg.FloatingTagKind)))
+		}
+		if f.AutoDetected && !createCfg.UseFloatingTags {
+			floatingLog = floatingLog.With(
+				zap.Bool("autoEnabled", true),
+				zap.Uint64("detectedMajor", f.AutoDetectedMajor),
+			)
+		}
+		if dryRun {
+			floatingLog.Info("dry run: floating tag not updated")
+		} else {
+			floatingLog.Info("floating tag updated")
+		}
+	case createCfg.UseFloatingTags:
+		logger.Warn("floating tag requested but not applied", zap.String("reason", "floating tags only apply to release mode"))
+	case f.AutoDetected:
+		logger.Info("floating tag usage detected", zap.Uint64("floatingMajor", f.AutoDetectedMajor))
+	}
+}
+
+func writeTagResult(cmd *cobra.Command, runtime runtimeConfig, createCfg tagging.CreateConfig, result tagplan.Result, opts tagRunOptions, notes *releasenotes.Result) error {
+	var diff *tagging.Diff
+	if opts.showDiff {
+		built := tagging.BuildDiff(result, createCfg.CommitSHA)
+		diff = &built
+	}
+
+	if runtime.format == output.FormatJSON {
+		payload := output.NewCreateTagResult(result, createCfg.CommitSHA, opts.dryRun)
+		payload.Diff = diff
+		payload.Commits = notes
+		payload.Operator = runtime.operator
+		return output.WriteJSON(cmd.OutOrStdout(), payload)
+	}
+
+	if result.Skipped && !result.AlreadyReleased {
+		return nil
+	}
+	if opts.shellOut {
+		if err := output.WriteShellAssignments(cmd.OutOrStdout(), output.CreateTagEnv(result)); err != nil {
+			return err
+		}
+	} else if err := output.WriteValue(cmd.OutOrStdout(), result.TagName, !runtime.noNewline); err != nil {
+		return fmt.Errorf("writing tag result: %w", err)
+	}
+	if diff != nil {
+		if err := output.WriteDiff(cmd.ErrOrStderr(), *diff); err != nil {
+			return err
+		}
+	}
+	if notes != nil {
+		if err := output.WriteReleaseNotes(cmd.ErrOrStderr(), result.TagName, *notes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func bindTagFlags(cmd *cobra.Command) *tagFlagSet {
+	fs := cmd.Flags()
+	return &tagFlagSet{
+		mode:        bindStringFlag(fs, flagTagMode, flagTagMode, "", envTagMode, "", "Tag mode to run (release or rc)"),
+		bump:        bindStringFlag(fs, flagBump, flagBump, "", envBump, "", "Bump intent (major, minor, patch, or none to skip tagging); --default-bump applies when unset"),
+		defBump:     bindStringFlag(fs, flagDefaultBump, flagDefaultBump, "", envDefaultBump, string(bump.BumpPatch), "Bump applied when --bump is unset"),
+		requireBump: bindBoolFlag(fs, flagRequireBump, flagRequireBump, "", envRequireBump, false, "Fail when --bump is unset instead of applying --default-bump"),
+		base:        bindStringFlag(fs, flagBaseVersion, flagBaseVersion, "", envBaseVersion, "", "Optional base version to use when no releases exist"),
+		verSource:   bindStringFlag(fs, flagVersionSource, flagVersionSource, "", envVersionSource, "", "URL returning the current version (plain text or JSON {\"version\": ...}) to bump from instead of the highest release tag"),
+		hotfixBase:  bindStringFlag(fs, flagHotfixBase, flagHotfixBase, "", envHotfixBase, "", "Existing release tag to cut a patch hotfix from (forces --bump patch)"),
+		commit:      bindStringFlag(fs, flagCommitSHA, flagCommitSHA, "", envCommit, "", "Commit SHA the tag should reference"),
+		message:     bindStringFlag(fs, flagTagMessage, flagTagMessage, "", envTagMessage, "", "Message stored in the annotated tag"),
+		messageFile: bindStringFlag(fs, flagTagMessageFile, flagTagMessageFile, "", envTagMessageFile, "", "File whose contents are stored as the annotated tag message (--tag-message takes precedence)"),
+		taggerName:  bindStringFlag(fs, flagTaggerName, flagTaggerName, "", envTaggerName, defaultTaggerName, "Name recorded as the tagger"),
+		taggerEmail: bindStringFlag(fs, flagTaggerEmail, flagTaggerEmail, "", envTaggerEmail, defaultTaggerEmail, "Email recorded as the tagger"),
+		tagPrefix:   bindStringFlag(fs, "tag-prefix", "tag-prefix", "", envTagPrefix, "", "String prepended to computed tag names (e.g. 'v')"),
+		prefixSep:   bindStringFlag(fs, flagPrefixSep, flagPrefixSep, "", envPrefixSep, "", "Separator placed between --tag-prefix and the version (e.g. '-' for release-1.2.3)"),
+		component:   bindStringFlag(fs, flagComponent, flagComponent, "", envComponent, "", "Monorepo component whose tags live under '<component>/' (e.g. 'api' for api/v1.2.3)"),
+		useFloating: bindBoolFlag(fs, flagUseFloating, flagUseFloating, "", envUseFloatingTags, false, "Create/maintain floating major refs (v<major>)"),
+		skipCI:      bindStringFlag(fs, flagFloatingSkipCI, flagFloatingSkipCI, "", envFloatingSkipCI, string(tagging.FloatingSkipCIOff), "How floating tag updates avoid re-triggering CI (off, marker, lightweight)"),
+		tagKind:     bindStringFlag(fs, flagTagKind, flagTagKind, "", envTagKind, string(tagging.TagKindAnnotated), "Kind of release/RC tag to create (annotated or lightweight)"),
+		floatKind:   bindStringFlag(fs, flagFloatingKind, flagFloatingKind, "", envFloatingKind, string(tagging.TagKindLightweight), "Kind of floating tag to maintain (annotated or lightweight)"),
+		preferPre:   bindBoolFlag(fs, flagPreferPreLine, flagPreferPreLine, "", envPreferPreLine, false, "In release mode, finalize an unreleased prerelease on a higher major (v2.0.0-rc.3 -> v2.0.0) instead of bumping the stable line"),
+		downgrade:   bindBoolFlag(fs, flagAllowDowngrade, flagAllowDowngrade, "", envAllowDowngrade, false, "Allow a computed version below the highest existing release, e.g. from --version-source-url"),
+		floatLevel:  bindStringFlag(fs, flagFloatLevel, flagFloatLevel, "", envFloatLevel, string(tagging.FloatingLevelMajor), "Floating tags maintained: major (v1), minor (v1.2), or both"),
+		floatTrack:  bindStringFlag(fs, flagFloatingTrack, flagFloatingTrack, "", envFloatingTrack, string(tagplan.FloatingTrackStable), "Tags the floating ref follows (stable, or any to include release candidates)"),
+		maxMajor:    bindIntFlag(fs, flagMaxMajor, flagMaxMajor, "", envMaxMajor, 0, "Fail when the computed major version exceeds this value (0 disables)"),
+		maxMinor:    bindIntFlag(fs, flagMaxMinor, flagMaxMinor, "", envMaxMinor, 0, "Fail when the computed minor version exceeds this value (0 disables)"),
+		prID:        bindIntFlag(fs, flagPRID, flagPRID, "", envPRID, 0, "Tag the merge commit of this completed pull request, inferring the bump from its labels (used when --commit-sha is unset)"),
+		maxPatch:    bindIntFlag(fs, flagMaxPatch, flagMaxPatch, "", envMaxPatch, 0, "Fail when the computed patch version exceeds this value (0 disables)"),
+		maxScan:     bindIntFlag(fs, flagMaxTagsScan, flagMaxTagsScan, "", envMaxTagsScan, 0, "Plan from only the N highest version tags, skipping the rest (0 scans all)"),
+		fromTag:     bindStringFlag(fs, flagFromTag, flagFromTag, "", envFromTag, "", "Existing release tag whose commit --tag-name is created at, instead of computing a version"),
+		tagName:     bindStringFlag(fs, flagTagName, flagTagName, "", envAliasTagName, "", "Literal name of the alias tag created with --from-tag (e.g. 'stable')"),
+		ignoreTags:  bindStringSliceFlag(fs, flagIgnoreTags, flagIgnoreTags, "", envIgnoreTags, nil, "Globs of retracted release tags never used as a base; a matching marker such as v1.3.0-yanked also retracts v1.3.0 (e.g. '*-yanked')"),
+		verRange:    bindStringFlag(fs, flagVersionRange, flagVersionRange, "", envVersionRange, "", "Semver range (e.g. '>=1.0.0 <2.0.0') limiting base releases and computed versions"),
+		refCheck:    bindStringFlag(fs, flagTagRefCheck, flagTagRefCheck, "", envTagRefCheck, string(tagging.RefCheckWarn), "How to handle tag names that match an existing branch (warn, error, off)"),
+		onMissing:   bindStringFlag(fs, flagOnMissingCommit, flagOnMissingCommit, "", envOnMissingCommit, string(tagging.MissingCommitError), "How to handle a target commit that does not exist (error, warn-create, skip)"),
+		rcRetry:     bindBoolFlag(fs, flagRCRetry, flagRCRetry, "", envRCRetry, false, fmt.Sprintf("When the planned RC tag was just created by another run, replan and take the next number (up to %d times)", tagging.MaxRCCollisionRetries)),
+		intTimeout:  bindStringFlag(fs, flagIntTimeout, flagIntTimeout, "", envIntTimeout, defaultIntegrationTimeout.String(), "Upper bound for each outbound integration call such as --"+flagVersionSource+" (Go duration; 0 leaves only the client's own limit)"),
+		rcMinAge:    bindStringFlag(fs, flagRCMinAge, flagRCMinAge, "", envRCMinAge, "", "Refuse to tag a release until its newest RC is at least this old (Go duration, e.g. 24h)"),
+		preLabel:    bindStringFlag(fs, flagPreLabel, flagPreLabel, "", envPreLabel, tagplan.DefaultPrereleaseLabel, "Prerelease channel RC mode tags (e.g. alpha, beta); numbering only counts that channel"),
+		channel:     bindStringFlag(fs, flagPreChannel, flagPreChannel, "", envPreChannel, "", "Alias of --"+flagPreLabel+" that selects the RC channel per run (e.g. alpha for v2.1.0-alpha.1)"),
+		chanOrder:   bindStringSliceFlag(fs, flagChannelOrder, flagChannelOrder, "", envChannelOrder, tagplan.DefaultChannelOrder, "Prerelease channels from least to most mature, used by --"+flagEnforceOrder),
+		rcScope:     bindStringFlag(fs, flagRCScope, flagRCScope, "", envRCScope, string(tagplan.RCScopeExact), "Versions whose RCs share a counter: exact, minor (continue across patches), or major"),
+		enforceOrd:  bindBoolFlag(fs, flagEnforceOrder, flagEnforceOrder, "", envEnforceOrder, false, "In RC mode, refuse a channel ranked below one the target already has (e.g. beta after rc.1)"),
+		once:        bindBoolFlag(fs, flagOnce, flagOnce, "", envOnce, false, "In release mode, succeed without tagging when the commit already carries a release tag, reporting that tag"),
+		verifyTag:   bindBoolFlag(fs, flagVerifyTag, flagVerifyTag, "", envVerifyTag, false, "After creating the tag, list it until it appears at the commit and fail if it does not"),
+		verifyWait:  bindStringFlag(fs, flagVerifyTimeout, flagVerifyTimeout, "", envVerifyTimeout, defaultVerifyTimeout, "How long --"+flagVerifyTag+" waits for the tag (Go duration)"),
+		forceFloat:  bindBoolFlag(fs, flagForceFloating, flagForceFloating, "", envForceFloating, false, "Move the floating tag even when it points at a newer commit than the release"),
+		mergeOnly:   bindBoolFlag(fs, flagRequireMerge, flagRequireMerge, "", envRequireMerge, false, "Refuse to tag a commit with fewer than two parents"),
+		skipPaths:   bindStringSliceFlag(fs, flagSkipIfOnlyPaths, flagSkipIfOnlyPaths, "", envSkipIfOnlyPaths, nil, "Skip the release when the commit's pull request only changes paths matching these globs (e.g. 'docs/**,*.md')"),
+		relBranches: bindStringSliceFlag(fs, flagReleaseBranches, flagReleaseBranches, "", envReleaseBranches, nil, "Only tag commits reachable from a branch matching one of these globs (e.g. 'main,release/*')"),
+		skipMarker:  bindStringFlag(fs, flagSkipCIMarker, flagSkipCIMarker, "", envSkipCIMarker, tagging.DefaultSkipCIMarker, "Marker appended to floating tag messages with --floating-skip-ci marker"),
+		tfOut:       bindStringFlag(fs, flagTFOut, flagTFOut, "", envTFOut, "", "Write the created tag as a Terraform external-data JSON file"),
+		dryRun:      bindBoolFlag(fs, flagDryRun, flagDryRun, "", envDryRun, false, "Plan the tag and floating actions without writing to ADO"),
+		planOnly:    bindBoolFlag(fs, flagPlanOnly, flagPlanOnly, "", envPlanOnly, false, "Plan without writing to ADO and save the plan to --plan-file for a later apply-plan"),
+		planFile:    bindStringFlag(fs, flagPlanFile, flagPlanFile, "", envPlanFile, defaultPlanFile, "Path the --plan-only plan is written to"),
+		showDiff:    bindBoolFlag(fs, flagShowDiff, flagShowDiff, "", envShowDiff, false, "Show the relevant refs before and after the operation"),
+		commits:     bindBoolFlag(fs, flagIncludeCommits, flagIncludeCommits, "", envIncludeCommits, false, "Include the commits since the previous release tag, grouped by bump"),
+		fromIdent:   bindBoolFlag(fs, flagTaggerIdentity, flagTaggerIdentity, "", envTaggerIdentity, false, "Use the token's authenticated identity as the tagger when no tagger name/email is set"),
+		shellOut:    bindBoolFlag(fs, flagShellOut, flagShellOut, "", envShellOut, false, "Print shell-quoted AAV_TAG_NAME=... assignments to stdout for eval"),
+		annotate:    bindBoolFlag(fs, flagPipelineTags, flagPipelineTags, "", envPipelineTags, false, "Tag the Azure Pipelines run with the created tag names; ignored outside a pipeline and on dry runs"),
+	}
+}
+
+func (f *tagFlagSet) runOptions(resolver config.Resolver) (tagRunOptions, error) {
+	opts := tagRunOptions{
+		bumpSet:        f.bump.base.explicit(),
+		taggerNameSet:  f.taggerName.base.explicit(),
+		taggerEmailSet: f.taggerEmail.base.explicit(),
+	}
+	if err := f.plannerOptions(resolver, &opts); err != nil {
+		return tagRunOptions{}, err
+	}
+	if err := f.outputOptions(resolver, &opts); err != nil {
+		return tagRunOptions{}, err
+	}
+	return opts, nil
+}
+
+// plannerOptions reads the settings that shape how the next version is planned: the tag name
+// layout, the floating track, the version guards, and the RC channel.
+func (f *tagFlagSet) plannerOptions(resolver config.Resolver, opts *tagRunOptions) error {
+	opts.tagPrefix = strings.TrimSpace(f.tagPrefix.Value(resolver))
+	opts.prefixSep = strings.TrimSpace(f.prefixSep.Value(resolver))
+	opts.component = strings.Trim(strings.TrimSpace(f.component.Value(resolver)), "/")
+
+	var err error
+	if opts.track, err = tagplan.ParseFloatingTrack(f.floatTrack.Value(resolver)); err != nil {
+		return err
+	}
+	if opts.preferPre, err = f.preferPre.Value(resolver); err != nil {
+		return err
+	}
+	if opts.downgrade, err = f.downgrade.Value(resolver); err != nil {
+		return err
+	}
+	if opts.limits, err = f.versionLimits(resolver); err != nil {
+		return err
+	}
+	if opts.verRange, err = tagplan.ParseVersionRange(f.verRange.Value(resolver)); err != nil {
+		return err
+	}
+	if opts.ignoreTags, err = tagplan.ParseIgnoreTags(f.ignoreTags.Value(resolver)); err != nil {
+		return err
+	}
+	if opts.preLabel, err = tagplan.ParsePrereleaseLabel(f.preLabel.Value(resolver)); err != nil {
+		return err
+	}
+	if opts.chanOrder, err = tagplan.ParseChannelOrder(f.chanOrder.Value(resolver)); err != nil {
+		return err
+	}
+	if opts.enforceOrd, err = f.enforceOrd.Value(resolver); err != nil {
+		return err
+	}
+	opts.rcScope, err = tagplan.ParseRCScope(f.rcScope.Value(resolver))
+	return err
+}
+
+// outputOptions reads the settings that decide what the run writes: dry runs and saved plans,
+// the diff and commits sections, shell assignments, pipeline tags, and Terraform output.
+func (f *tagFlagSet) outputOptions(resolver config.Resolver, opts *tagRunOptions) error {
+	var err error
+	if opts.dryRun, err = f.dryRun.Value(resolver); err != nil {
+		return err
+	}
+	planOnly, err := f.planOnly.Value(resolver)
+	if err != nil {
+		return err
+	}
+	if planOnly {
+		opts.planFile = strings.TrimSpace(f.planFile.Value(resolver))
+		if opts.planFile == "" {
+			return fmt.Errorf(requiredFlagFormat, flagPlanFile)
+		}
+		opts.dryRun = true
+	}
+	if opts.showDiff, err = f.showDiff.Value(resolver); err != nil {
+		return err
+	}
+	if opts.fromIdentity, err = f.fromIdent.Value(resolver); err != nil {
+		return err
+	}
+	if opts.commits, err = f.commits.Value(resolver); err != nil {
+		return err
+	}
+	if opts.shellOut, err = f.shellOut.Value(resolver); err != nil {
+		return err
+	}
+	if opts.annotate, err = f.annotate.Value(resolver); err != nil {
+		return err
+	}
+	opts.tfOut = strings.TrimSpace(f.tfOut.Value(resolver))
+	return nil
+}
+
+// versionLimits reads the --max-major/--max-minor/--max-patch guards; zero disables a guard.
+func (f *tagFlagSet) versionLimits(resolver config.Resolver) (tagplan.Limits, error) {
+	values := make([]uint64, 0, 3)
+	for _, guard := range []struct {
+		name string
+		flag *intFlag
+	}{
+		{name: flagMaxMajor, flag: f.maxMajor},
+		{name: flagMaxMinor, flag: f.maxMinor},
+		{name: flagMaxPatch, flag: f.maxPatch},
+	} {
+		value, err := guard.flag.Value(resolver)
+		if err != nil {
+			return tagplan.Limits{}, err
+		}
+		if value < 0 {
+			return tagplan.Limits{}, fmt.Errorf("%s must not be negative", guard.name)
+		}
+		values = append(values, uint64(value))
+	}
+	return tagplan.Limits{MaxMajor: values[0], MaxMinor: values[1], MaxPatch: values[2]}, nil
+}
+
+// pullRequestID reads --pr-id, which is optional for create-tag.
+func (f *tagFlagSet) pullRequestID(resolver config.Resolver) (int, error) {
+	prID, err := f.prID.Value(resolver)
+	if err != nil {
+		return 0, err
+	}
+	if prID < 0 {
+		return 0, fmt.Errorf("pr-id must be greater than zero")
+	}
+	return prID, nil
+}
+
+// resolve reads the tag settings. When prID is set and no commit SHA is configured, the commit
+// and bump are left for the pull request to supply.
+func (f *tagFlagSet) resolve(resolver config.Resolver, prID int) (tagging.CreateConfig, error) {
+	modeValue := strings.TrimSpace(strings.ToLower(f.mode.Value(resolver)))
+	if modeValue == "" {
+		return tagging.CreateConfig{}, fmt.Errorf(requiredFlagFormat, flagTagMode)
+	}
+	mode, err := parseTagMode(modeValue)
+	if err != nil {
+		return tagging.CreateConfig{}, err
+	}
+
+	commit := commitSHA(resolver, f.commit, prID <= 0)
+	fromPR := commit == "" && prID > 0
+	if commit == "" && !fromPR {
+		return tagging.CreateConfig{}, fmt.Errorf("%s is required (or --%s to tag a pull request's merge commit)", flagCommitSHA, flagPRID)
+	}
+
+	cfg := tagging.CreateConfig{Config: tagging.Config{Mode: mode}, CommitSHA: commit}
+	if err := f.resolveVersion(resolver, &cfg, fromPR); err != nil {
+		return tagging.CreateConfig{}, err
+	}
+	if err := f.resolveTagger(resolver, &cfg); err != nil {
+		return tagging.CreateConfig{}, err
+	}
+	if err := f.resolveFloating(resolver, &cfg); err != nil {
+		return tagging.CreateConfig{}, err
+	}
+	if err := f.resolveGuards(resolver, &cfg); err != nil {
+		return tagging.CreateConfig{}, err
+	}
+	return cfg, nil
+}
+
+// resolveVersion reads how the next version is computed: the bump, the hotfix or base
+// version, an external version source, the tag scan limit, and the RC channel. With fromPR the
+// pull request supplies the bump unless one is set.
+func (f *tagFlagSet) resolveVersion(resolver config.Resolver, cfg *tagging.CreateConfig, fromPR bool) error {
+	cfg.HotfixBase = strings.TrimSpace(f.hotfixBase.Value(resolver))
+	cfg.BaseVersion = strings.TrimSpace(f.base.Value(resolver))
+
+	bumpValue := strings.TrimSpace(f.bump.Value(resolver))
+	cfg.Bump = bump.BumpPatch
+	// A hotfix always bumps patch, and a pull request supplies its own bump unless one is set.
+	if cfg.HotfixBase == "" && (bumpValue != "" || !fromPR) {
+		requireBump, err := f.requireBump.Value(resolver)
+		if err != nil {
+			return err
+		}
+		cfg.Bump, _, err = tagging.ResolveBump(bumpValue, f.defBump.Value(resolver), requireBump)
+		if errors.Is(err, tagging.ErrBumpRequired) {
+			return fmt.Errorf(requiredFlagFormat+" (--%s is set)", flagBump, flagRequireBump)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	maxScan, err := f.maxScan.Value(resolver)
+	if err != nil {
+		return err
+	}
+	if maxScan < 0 {
+		return fmt.Errorf("%s must not be negative", flagMaxTagsScan)
+	}
+	cfg.MaxTagsScan = maxScan
+
+	if url := strings.TrimSpace(f.verSource.Value(resolver)); url != "" {
+		timeout, err := parseIntegrationTimeout(f.intTimeout.Value(resolver))
+		if err != nil {
+			return err
+		}
+		cfg.VersionSource = tagging.NewHTTPVersionSource(url).WithTimeout(timeout)
+	}
+
+	cfg.Channel, err = prereleaseChannel(resolver, f.preLabel, f.channel)
+	return err
+}
+
+// resolveTagger reads the tagger identity, the tag message, and the kind of tag created.
+func (f *tagFlagSet) resolveTagger(resolver config.Resolver, cfg *tagging.CreateConfig) error {
+	cfg.TaggerName = strings.TrimSpace(f.taggerName.Value(resolver))
+	if cfg.TaggerName == "" {
+		return fmt.Errorf(requiredFlagFormat, flagTaggerName)
+	}
+	cfg.TaggerEmail = strings.TrimSpace(f.taggerEmail.Value(resolver))
+	if cfg.TaggerEmail == "" {
+		return fmt.Errorf(requiredFlagFormat, flagTaggerEmail)
+	}
+
+	var err error
+	if cfg.Message, err = tagMessage(resolver, f.message, f.messageFile); err != nil {
+		return err
+	}
+	cfg.TagKind, err = tagging.ParseTagKind(f.tagKind.Value(resolver))
+	return err
+}
+
+// resolveFloating reads how floating tags are maintained alongside a release.
+func (f *tagFlagSet) resolveFloating(resolver config.Resolver, cfg *tagging.CreateConfig) error {
+	var err error
+	if f.useFloating != nil {
+		if cfg.UseFloatingTags, err = f.useFloating.Value(resolver); err != nil {
+			return err
+		}
+	}
+	if cfg.FloatingSkipCI, err = tagging.ParseFloatingSkipCI(f.skipCI.Value(resolver)); err != nil {
+		return err
+	}
+	if cfg.FloatingLevel, err = tagging.ParseFloatingLevel(f.floatLevel.Value(resolver)); err != nil {
+		return err
+	}
+	if cfg.FloatingTagKind, err = f.resolveFloatingKind(resolver, cfg.FloatingSkipCI); err != nil {
+		return err
+	}
+	if cfg.ForceFloating, err = f.forceFloat.Value(resolver); err != nil {
+		return err
+	}
+	cfg.SkipCIMarker = strings.TrimSpace(f.skipMarker.Value(resolver))
+	return nil
+}
+
+// resolveGuards reads the checks that can refuse or skip a tag before it is written, and the
+// verification that follows it.
+func (f *tagFlagSet) resolveGuards(resolver config.Resolver, cfg *tagging.CreateConfig) error {
+	var err error
+	if cfg.RefCheck, err = tagging.ParseRefCheck(f.refCheck.Value(resolver)); err != nil {
+		return err
+	}
+	if cfg.OnMissingCommit, err = tagging.ParseMissingCommitPolicy(f.onMissing.Value(resolver)); err != nil {
+		return err
+	}
+	if cfg.RetryRCOnCollision, err = f.rcRetry.Value(resolver); err != nil {
+		return err
+	}
+	if cfg.RCMinAge, err = parseRCMinAge(f.rcMinAge.Value(resolver)); err != nil {
+		return err
+	}
+	if cfg.RequireMergeCommit, err = f.mergeOnly.Value(resolver); err != nil {
+		return err
+	}
+	if cfg.Once, err = f.once.Value(resolver); err != nil {
+		return err
+	}
+	if cfg.VerifyTag, err = f.verifyTag.Value(resolver); err != nil {
+		return err
+	}
+	if cfg.VerifyTimeout, err = parseVerifyTimeout(f.verifyWait.Value(resolver)); err != nil {
+		return err
+	}
+	cfg.ReleaseBranches = f.relBranches.Value(resolver)
+	cfg.SkipIfOnlyPaths = f.skipPaths.Value(resolver)
+	return nil
+}
+
+// parseRCMinAge reads --rc-min-age; empty disables the cooldown.
+func parseRCMinAge(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	age, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", flagRCMinAge, err)
+	}
+	if age < 0 {
+		return 0, fmt.Errorf("%s must not be negative", flagRCMinAge)
+	}
+	return age, nil
+}
+
+// parseVerifyTimeout reads --verify-tag-timeout; empty uses the default.
+func parseVerifyTimeout(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		value = defaultVerifyTimeout
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", flagVerifyTimeout, err)
+	}
+	if timeout < 0 {
+		return 0, fmt.Errorf("%s must not be negative", flagVerifyTimeout)
+	}
+	return timeout, nil
+}
+
+// parseIntegrationTimeout reads --integration-timeout; empty or zero disables the bound.
+func parseIntegrationTimeout(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", flagIntTimeout, err)
+	}
+	if timeout < 0 {
+		return 0, fmt.Errorf("%s must not be negative", flagIntTimeout)
+	}
+	return timeout, nil
+}
+
+// prereleaseChannel reads --prerelease-channel. It may repeat --prerelease-label but not
+// contradict it.
+func prereleaseChannel(resolver config.Resolver, labelFlag, channelFlag *stringFlag) (string, error) {
+	channel := strings.TrimSpace(channelFlag.Value(resolver))
+	if channel == "" || !labelFlag.base.explicit() {
+		return channel, nil
+	}
+	if label := strings.TrimSpace(labelFlag.Value(resolver)); !strings.EqualFold(label, channel) {
+		return "", fmt.Errorf("--%s %q conflicts with --%s %q", flagPreChannel, channel, flagPreLabel, label)
+	}
+	return channel, nil
+}
+
+// resolveFloatingKind defaults floating tags to lightweight, except that --floating-skip-ci
+// marker needs an annotation to carry the marker and switches the default to annotated.
+func (f *tagFlagSet) resolveFloatingKind(resolver config.Resolver, skipCI tagging.FloatingSkipCI) (tagging.TagKind, error) {
+	if skipCI == tagging.FloatingSkipCIMarker && !f.floatKind.base.explicit() {
+		return tagging.TagKindAnnotated, nil
+	}
+	kind, err := tagging.ParseTagKind(f.floatKind.Value(resolver))
+	if err != nil {
+		return "", err
+	}
+	if skipCI == tagging.FloatingSkipCIMarker && kind == tagging.TagKindLightweight {
+		return "", fmt.Errorf("%s marker requires %s annotated", flagFloatingSkipCI, flagFloatingKind)
+	}
+	return kind, nil
+}
+
+// tagMessage reads --tag-message, falling back to the contents of --tag-message-file. The file
+// is read whenever it is set, so a missing file fails even when --tag-message wins.
+func tagMessage(resolver config.Resolver, message, file *stringFlag) (string, error) {
+	value := strings.TrimSpace(message.Value(resolver))
+	path := strings.TrimSpace(file.Value(resolver))
+	if path == "" {
+		return value, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("reading tag message file: %w", err)
+	}
+	if value == "" {
+		value = tagging.ParseTagMessage(data)
+	}
+	return value, nil
+}
+
+func parseTagMode(value string) (tagplan.Mode, error) {
+	switch strings.ToLower(value) {
+	case string(tagplan.ModeRelease):
+		return tagplan.ModeRelease, nil
+	case string(tagplan.ModeRC):
+		return tagplan.ModeRC, nil
+	default:
+		return "", fmt.Errorf("invalid tag mode %q", value)
+	}
+}
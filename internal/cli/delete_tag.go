@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/output"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/tagging"
+)
+
+// errDeleteNotConfirmed is returned when delete-tag runs without --force and the prompt is not
+// answered with yes.
+var errDeleteNotConfirmed = errors.New("delete not confirmed; pass --" + flagForce + " to delete without prompting")
+
+func newDeleteTagCommand(rootFlags *rootFlagSet) *cobra.Command {
+	var nameFlag *stringFlag
+	var forceFlag *boolFlag
+	var dryRunFlag *boolFlag
+
+	cmd := &cobra.Command{
+		Use:   "delete-tag",
+		Short: "Delete a tag by name",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			runtime, cleanup, err := buildRuntime(ctx, cmd, rootFlags)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			name := strings.TrimSpace(nameFlag.Value(runtime.resolver))
+			if name == "" {
+				return fmt.Errorf(requiredFlagFormat, flagDeleteName)
+			}
+			force, err := forceFlag.Value(runtime.resolver)
+			if err != nil {
+				return err
+			}
+			dryRun, err := dryRunFlag.Value(runtime.resolver)
+			if err != nil {
+				return err
+			}
+
+			// Azure DevOps gates tag deletion behind Force push.
+			if err := runPreflight(ctx, runtime, ado.PermissionForcePush); err != nil {
+				return err
+			}
+
+			service := tagging.NewService(runtime.client, tagplan.NewPlanner("")).WithProtectedTags(runtime.protectedTags)
+			tag, err := service.LookupTag(ctx, name)
+			if err != nil {
+				return err
+			}
+
+			log := runtime.logger.With(
+				zap.String("tag", tag.ShortName()),
+				zap.String("objectId", tag.ObjectID),
+				zap.String("commit", tag.Commit),
+			)
+			if dryRun {
+				log.Info("dry run: tag not deleted")
+			} else {
+				if !force {
+					if err := confirmDelete(cmd.InOrStdin(), cmd.ErrOrStderr(), tag); err != nil {
+						return err
+					}
+				}
+				if err := service.DeleteTag(ctx, tag); err != nil {
+					return err
+				}
+				log.Info("tag deleted")
+			}
+
+			if runtime.format == output.FormatJSON {
+				payload := output.NewDeleteTagResult(tag, dryRun)
+				payload.Operator = runtime.operator
+				if err := output.WriteJSON(cmd.OutOrStdout(), payload); err != nil {
+					return err
+				}
+			} else if err := output.WriteValue(cmd.OutOrStdout(), tag.ShortName(), !runtime.noNewline); err != nil {
+				return fmt.Errorf("writing tag result: %w", err)
+			}
+			return writeSummary(cmd, runtime, output.DeleteTagSummary(tag, dryRun))
+		},
+	}
+
+	fs := cmd.Flags()
+	nameFlag = bindStringFlag(fs, flagDeleteName, flagDeleteName, "", envDeleteName, "", "Tag to delete, as v1.2.3 or refs/tags/v1.2.3")
+	forceFlag = bindBoolFlag(fs, flagForce, flagForce, "", envForce, false, "Delete without asking for confirmation")
+	dryRunFlag = bindBoolFlag(fs, flagDryRun, flagDryRun, "", envDryRun, false, "Look up the tag and report it without deleting it")
+
+	return cmd
+}
+
+// confirmDelete asks on out whether to delete tag and reads the answer from in. Anything but
+// y or yes, including end of input in a non-interactive run, declines.
+func confirmDelete(in io.Reader, out io.Writer, tag tagging.ExistingTag) error {
+	if _, err := fmt.Fprintf(out, "Delete tag %s at %s? [y/N] ", tag.ShortName(), tag.Commit); err != nil {
+		return fmt.Errorf("writing prompt: %w", err)
+	}
+	answer, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("reading confirmation: %w", err)
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return nil
+	default:
+		return errDeleteNotConfirmed
+	}
+}
@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/config"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/output"
+)
+
+func newDoctorCommand(rootFlags *rootFlagSet) *cobra.Command {
+	return &cobra.Command{
+		Use:   "doctor",
+		Short: "Report every global setting with its resolved value and where it came from",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			// doctor helps debug a broken configuration, so it never requires credentials and
+			// never calls Azure DevOps; it only runs the same resolver the other commands use.
+			recorder := config.NewRecorder()
+			resolver := config.NewResolver(zap.NewNop()).WithRecorder(recorder)
+
+			format, err := output.ParseFormat(rootFlags.output.Value(resolver))
+			if err != nil {
+				return err
+			}
+			if err := resolveRootSettings(rootFlags, resolver); err != nil {
+				return err
+			}
+
+			report := output.NewConfigReport(recorder.Resolutions())
+			if format == output.FormatJSON {
+				return output.WriteJSON(cmd.OutOrStdout(), report)
+			}
+			return output.WriteConfigReport(cmd.OutOrStdout(), report)
+		},
+	}
+}
+
+// resolveRootSettings resolves each global flag once so the resolver's recorder sees all of them.
+func resolveRootSettings(flags *rootFlagSet, resolver config.Resolver) error {
+	for _, f := range []*stringFlag{
+		flags.orgURL, flags.project, flags.repo, flags.token, flags.logLevel,
+		flags.labelPref, flags.labelMajor, flags.labelMinor, flags.labelPatch, flags.labelNone,
+		flags.repoName, flags.repoConfig, flags.resultFile, flags.operator, flags.refCache,
+		flags.eventLog,
+	} {
+		_ = f.Value(resolver)
+	}
+	for _, f := range []*stringSliceFlag{
+		flags.logFields, flags.branchMaj, flags.branchMin, flags.branchPatch, flags.protected,
+	} {
+		_ = f.Value(resolver)
+	}
+	for _, f := range []*boolFlag{flags.preflight, flags.traceAPI, flags.quiet, flags.noNewline, flags.strictPref, flags.ancestry} {
+		if _, err := f.Value(resolver); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/config"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/output"
+)
+
+func TestDoctorReportsClientSettings(t *testing.T) {
+	t.Parallel()
+
+	cmd := newRootCommand()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"doctor", "--output", "json", "--ref-cache-ttl", "30s", "--ancestry-cache=false", "--event-log", "events.jsonl"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("doctor: %v", err)
+	}
+
+	var report output.ConfigReport
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("decode report: %v", err)
+	}
+	settings := map[string]output.ConfigSetting{}
+	for _, s := range report.Settings {
+		settings[s.Setting] = s
+	}
+	want := map[string]string{flagRefCacheTTL: "30s", flagAncestryCache: "false", flagEventLog: "events.jsonl"}
+	for setting, value := range want {
+		got, ok := settings[setting]
+		if !ok {
+			t.Fatalf("expected %s in the report, got %+v", setting, report.Settings)
+		}
+		if got.Value != value || got.Source != config.SourceFlag {
+			t.Fatalf("expected %s=%s from the flag, got %+v", setting, value, got)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package cli
+
+import "fmt"
+
+// ExitError asks main to exit with Code instead of the default 1. Err is nil for a successful
+// run that reports its outcome only through the exit code.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("exit status %d", e.Code)
+	}
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
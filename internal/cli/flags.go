@@ -2,7 +2,9 @@ package cli
 
 import (
 	"fmt"
+	"os"
 	"strings"
+	"sync"
 
 	"github.com/spf13/pflag"
 
@@ -14,6 +16,14 @@ type flagBase struct {
 	setting string
 	name    string
 	envKey  string
+	// deprecation is set by deprecate; it is shared by copies made with withDefault.
+	deprecation *deprecation
+}
+
+// deprecation holds the migration hint of a deprecated flag and warns about it once per run.
+type deprecation struct {
+	migration string
+	once      sync.Once
 }
 
 func newFlagBase(fs *pflag.FlagSet, setting, name, envKey string) flagBase {
@@ -27,6 +37,35 @@ func (b flagBase) changed() bool {
 	return b.fs.Changed(b.name)
 }
 
+// explicit reports whether the setting was provided via env or CLI rather than left at its default.
+func (b flagBase) explicit() bool {
+	if b.envKey != "" {
+		if value, ok := os.LookupEnv(b.envKey); ok && strings.TrimSpace(value) != "" {
+			return true
+		}
+	}
+	return b.changed()
+}
+
+// deprecate hides the flag from help and records the migration hint for its replacement. The
+// flag and its env var keep working; the first Value call that sees either one set logs a
+// warning. pflag's MarkDeprecated is not used because it only notices the flag, not the env var.
+func (b *flagBase) deprecate(migration string) {
+	b.deprecation = &deprecation{migration: migration}
+	if b.fs != nil && b.name != "" {
+		_ = b.fs.MarkHidden(b.name)
+	}
+}
+
+func (b flagBase) warnDeprecated(resolver config.Resolver) {
+	if b.deprecation == nil {
+		return
+	}
+	b.deprecation.once.Do(func() {
+		resolver.Deprecated(b.setting, b.envKey, b.changed(), b.deprecation.migration)
+	})
+}
+
 func describeUsage(usage, envKey string) string {
 	trimmed := strings.TrimSpace(usage)
 	if envKey == "" {
@@ -82,6 +121,7 @@ func bindSecretFlag(fs *pflag.FlagSet, setting, name, short, envKey, defaultVal,
 }
 
 func (f *stringFlag) Value(resolver config.Resolver) string {
+	f.base.warnDeprecated(resolver)
 	cliVal := strings.TrimSpace(f.value)
 	if f.isSecret {
 		return resolver.Secret(f.base.setting, f.base.envKey, cliVal, f.base.changed(), f.defaultVal)
@@ -89,6 +129,13 @@ func (f *stringFlag) Value(resolver config.Resolver) string {
 	return resolver.String(f.base.setting, f.base.envKey, cliVal, f.base.changed(), f.defaultVal)
 }
 
+// withDefault returns a copy of the flag that falls back to def instead of its bound default.
+func (f *stringFlag) withDefault(def string) *stringFlag {
+	copied := *f
+	copied.defaultVal = def
+	return &copied
+}
+
 type boolFlag struct {
 	base       flagBase
 	defaultVal bool
@@ -113,6 +160,7 @@ func bindBoolFlag(fs *pflag.FlagSet, setting, name, short, envKey string, defaul
 }
 
 func (f *boolFlag) Value(resolver config.Resolver) (bool, error) {
+	f.base.warnDeprecated(resolver)
 	return resolver.Bool(f.base.setting, f.base.envKey, f.value, f.base.changed(), f.defaultVal)
 }
 
@@ -140,9 +188,21 @@ func bindIntFlag(fs *pflag.FlagSet, setting, name, short, envKey string, default
 }
 
 func (f *intFlag) Value(resolver config.Resolver) (int, error) {
+	f.base.warnDeprecated(resolver)
 	return resolver.Int(f.base.setting, f.base.envKey, f.value, f.base.changed(), f.defaultVal)
 }
 
+// commitSHA resolves a --commit-sha flag in the order flag, AAV_COMMIT_SHA, then, when
+// fromPipeline is set, the pipeline's Build.SourceVersion; see config.Resolver.PipelineCommit.
+func commitSHA(resolver config.Resolver, f *stringFlag, fromPipeline bool) string {
+	f.base.warnDeprecated(resolver)
+	if !fromPipeline {
+		return resolver.CommitSHA(f.base.setting, f.base.envKey, f.value, f.base.changed(), os.Getenv)
+	}
+	commit, _ := resolver.PipelineCommit(f.base.setting, f.base.envKey, f.value, f.base.changed(), os.Getenv)
+	return commit
+}
+
 type stringSliceFlag struct {
 	base       flagBase
 	defaultVal []string
@@ -167,10 +227,18 @@ func bindStringSliceFlag(fs *pflag.FlagSet, setting, name, short, envKey string,
 }
 
 func (f *stringSliceFlag) Value(resolver config.Resolver) []string {
+	f.base.warnDeprecated(resolver)
 	cliVal := sanitizeSliceValues(f.value)
 	return resolver.StringSlice(f.base.setting, f.base.envKey, cliVal, f.base.changed(), f.defaultVal)
 }
 
+// withDefault returns a copy of the flag that falls back to def instead of its bound default.
+func (f *stringSliceFlag) withDefault(def []string) *stringSliceFlag {
+	copied := *f
+	copied.defaultVal = append([]string(nil), def...)
+	return &copied
+}
+
 func sanitizeSliceValues(values []string) []string {
 	if len(values) == 0 {
 		return nil
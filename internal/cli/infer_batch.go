@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/output"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/inferbump"
+)
+
+func newInferBatchCommand(rootFlags *rootFlagSet) *cobra.Command {
+	var commitsFlag *stringSliceFlag
+	var commitsFileFlag *stringFlag
+	var concurrencyFlag *intFlag
+	var strictFlag *boolFlag
+	var conflictFlag *stringFlag
+	var titleFlag *boolFlag
+	var sourcesFlag *stringFlag
+	var combineFlag *stringFlag
+
+	cmd := &cobra.Command{
+		Use:         "infer-bump-batch",
+		Short:       "Infer the bump of many merge commits and report their combined impact",
+		Annotations: tabularOutput,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			runtime, cleanup, err := buildRuntime(ctx, cmd, rootFlags)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			commits := commitsFlag.Value(runtime.resolver)
+			if path := strings.TrimSpace(commitsFileFlag.Value(runtime.resolver)); path != "" {
+				data, err := os.ReadFile(path)
+				if err != nil {
+					return fmt.Errorf("reading commits file: %w", err)
+				}
+				commits = append(commits, inferbump.ParseCommitList(data)...)
+			}
+			if len(commits) == 0 {
+				return fmt.Errorf("%s or --%s is required", flagBatchCommits, flagBatchFile)
+			}
+
+			concurrency, err := concurrencyFlag.Value(runtime.resolver)
+			if err != nil {
+				return err
+			}
+			if concurrency <= 0 {
+				return fmt.Errorf("%s must be greater than zero", flagBatchWorkers)
+			}
+			strict, err := strictFlag.Value(runtime.resolver)
+			if err != nil {
+				return err
+			}
+			policy, err := inferbump.ParseConflictPolicy(conflictFlag.Value(runtime.resolver))
+			if err != nil {
+				return err
+			}
+			fromTitle, err := titleFlag.Value(runtime.resolver)
+			if err != nil {
+				return err
+			}
+			sources, err := inferbump.ParseSources(sourcesFlag.Value(runtime.resolver))
+			if err != nil {
+				return err
+			}
+			combine, err := inferbump.ParseCombineStrategy(combineFlag.Value(runtime.resolver))
+			if err != nil {
+				return err
+			}
+
+			// Commits span history, so the config file is read from the default branch.
+			if err := applyRepoConfig(ctx, &runtime, rootFlags, ""); err != nil {
+				return err
+			}
+
+			batch := inferbump.NewService(runtime.client, runtime.labels).ResolveBatch(ctx, commits, inferbump.Config{
+				Strict:         strict,
+				ConflictPolicy: policy,
+				InferFromTitle: fromTitle,
+				Sources:        sources,
+				Combine:        combine,
+			}, concurrency)
+
+			for _, entry := range batch.Entries {
+				if entry.Err != nil {
+					runtime.logger.Warn("commit not resolved", zap.String("commit", entry.CommitSHA), zap.Error(entry.Err))
+				}
+			}
+			runtime.logger.Info("batch resolved",
+				zap.String("bump", string(batch.Bump)),
+				zap.Int("resolved", batch.Resolved),
+				zap.Int("failed", batch.Failed),
+			)
+
+			result := output.NewInferBatchResult(batch)
+			result.Operator = runtime.operator
+			if runtime.format == output.FormatJSON {
+				return output.WriteJSON(cmd.OutOrStdout(), result)
+			}
+			if runtime.format.Tabular() {
+				return output.WriteTable(cmd.OutOrStdout(), runtime.format, output.InferBatchTable(result))
+			}
+			return output.WriteInferBatch(cmd.OutOrStdout(), result)
+		},
+	}
+
+	fs := cmd.Flags()
+	commitsFlag = bindStringSliceFlag(fs, flagBatchCommits, flagBatchCommits, "", envBatchCommits, nil, "Merge commit SHAs to resolve (comma-separated or repeated)")
+	commitsFileFlag = bindStringFlag(fs, flagBatchFile, flagBatchFile, "", envBatchFile, "", "File listing merge commit SHAs, one per line ('#' starts a comment)")
+	concurrencyFlag = bindIntFlag(fs, flagBatchWorkers, flagBatchWorkers, "", envBatchWorkers, inferbump.DefaultBatchConcurrency, "Commits resolved at once")
+	strictFlag = bindBoolFlag(fs, "strict", "strict", "", envStrict, false, "Report commits without a pull request as errors instead of defaulting them")
+	conflictFlag = bindStringFlag(fs, flagConflictBump, flagConflictBump, "", envConflictBump, string(inferbump.ConflictMax), "Bump applied when semver labels conflict (max, min, error)")
+	completeValues(cmd, flagConflictBump, conflictCompletions...)
+	titleFlag = bindBoolFlag(fs, "infer-from-title", "infer-from-title", "", envInferTitle, false, "Classify the PR title (feat:, fix:, feat!:) when it has no semver labels")
+	titleFlag.base.deprecate(inferTitleMigration)
+	sourcesFlag = bindStringFlag(fs, flagInferSources, flagInferSources, "", envInferSources, "", "Ordered, comma-separated inference sources (labels, title, commit-message); overrides --infer-from-title")
+	combineFlag = bindStringFlag(fs, flagInferCombine, flagInferCombine, "", envInferCombine, string(inferbump.CombineFirst), "How --infer-sources combine (first match wins, or max impact)")
+
+	return cmd
+}
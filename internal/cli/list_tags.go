@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/output"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/tagging"
+)
+
+func newListTagsCommand(rootFlags *rootFlagSet) *cobra.Command {
+	var prefixFlag *stringFlag
+	var separatorFlag *stringFlag
+	var componentFlag *stringFlag
+	var releasesFlag *boolFlag
+	var prereleasesFlag *boolFlag
+	var majorFlag *intFlag
+
+	cmd := &cobra.Command{
+		Use:         "list-tags",
+		Short:       "List the repository's tags sorted by version, including tags that do not parse",
+		Annotations: tabularOutput,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			runtime, cleanup, err := buildRuntime(ctx, cmd, rootFlags)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			var filter tagplan.InventoryFilter
+			if filter.ReleasesOnly, err = releasesFlag.Value(runtime.resolver); err != nil {
+				return err
+			}
+			if filter.PrereleasesOnly, err = prereleasesFlag.Value(runtime.resolver); err != nil {
+				return err
+			}
+			major, err := majorFlag.Value(runtime.resolver)
+			if err != nil {
+				return err
+			}
+			if major < -1 {
+				return fmt.Errorf("--%s must be a major version or -1 for all majors, got %d", flagListMajor, major)
+			}
+			if major >= 0 {
+				filter.Major, filter.HasMajor = uint64(major), true
+			}
+
+			planner := tagplan.NewPlanner(strings.TrimSpace(prefixFlag.Value(runtime.resolver))).
+				WithPrefixSeparator(separatorFlag.Value(runtime.resolver)).
+				WithComponent(componentFlag.Value(runtime.resolver))
+			inventory, err := tagging.NewService(runtime.client, planner).ListTags(ctx, filter)
+			if err != nil {
+				return err
+			}
+			result := output.NewTagListResult(inventory)
+
+			runtime.logger.Info("tags listed",
+				zap.Int("releases", len(result.Releases)),
+				zap.Int("prereleases", len(result.Prereleases)),
+				zap.Int("floating", len(result.Floating)),
+				zap.Int("unparsed", len(result.Unparsed)),
+			)
+
+			result.Operator = runtime.operator
+			if runtime.format == output.FormatJSON {
+				return output.WriteJSON(cmd.OutOrStdout(), result)
+			}
+			if runtime.format.Tabular() {
+				return output.WriteTable(cmd.OutOrStdout(), runtime.format, output.TagListTable(result))
+			}
+			return output.WriteTagList(cmd.OutOrStdout(), result)
+		},
+	}
+
+	fs := cmd.Flags()
+	prefixFlag = bindStringFlag(fs, "tag-prefix", "tag-prefix", "", envTagPrefix, "", "Prefix of the tag names to inspect (e.g. 'v')")
+	separatorFlag = bindStringFlag(fs, flagPrefixSep, flagPrefixSep, "", envPrefixSep, "", "Separator between --tag-prefix and the version")
+	componentFlag = bindStringFlag(fs, flagComponent, flagComponent, "", envComponent, "", "Monorepo component whose tags live under '<component>/'")
+	releasesFlag = bindBoolFlag(fs, flagReleasesOnly, flagReleasesOnly, "", envReleasesOnly, false, "List only stable release tags")
+	prereleasesFlag = bindBoolFlag(fs, flagPrereleasesOnly, flagPrereleasesOnly, "", envPrereleasesOnly, false, "List only prerelease tags such as RCs")
+	majorFlag = bindIntFlag(fs, flagListMajor, flagListMajor, "", envListMajor, -1, "List only versions and floating tags of this major (-1 lists every major)")
+
+	return cmd
+}
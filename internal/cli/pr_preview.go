@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/output"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/prpreview"
+)
+
+func newPRPreviewCommand(rootFlags *rootFlagSet) *cobra.Command {
+	var prIDFlag *intFlag
+	var branchFlag *stringFlag
+	var fullRefFlag *boolFlag
+	var postFlag *boolFlag
+	var prefixFlag *stringFlag
+	var separatorFlag *stringFlag
+	var componentFlag *stringFlag
+
+	cmd := &cobra.Command{
+		Use:   "pr-preview",
+		Short: "Preview the release version a pull request would produce once merged",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			runtime, cleanup, err := buildRuntime(ctx, cmd, rootFlags)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			prID, err := prIDFlag.Value(runtime.resolver)
+			if err != nil {
+				return err
+			}
+			if prID <= 0 {
+				return fmt.Errorf("pr-id must be greater than zero")
+			}
+
+			branch := branchFlag.Value(runtime.resolver)
+			if strings.TrimSpace(branch) == "" {
+				return fmt.Errorf("source-branch is required")
+			}
+
+			matchFullRef, err := fullRefFlag.Value(runtime.resolver)
+			if err != nil {
+				return err
+			}
+			post, err := postFlag.Value(runtime.resolver)
+			if err != nil {
+				return err
+			}
+
+			if post {
+				if err := runPreflight(ctx, runtime, ado.PermissionPullRequestContribute); err != nil {
+					return err
+				}
+			}
+			if err := applyRepoConfig(ctx, &runtime, rootFlags, ""); err != nil {
+				return err
+			}
+
+			planner := tagplan.NewPlanner(strings.TrimSpace(prefixFlag.Value(runtime.resolver))).
+				WithPrefixSeparator(separatorFlag.Value(runtime.resolver)).
+				WithComponent(componentFlag.Value(runtime.resolver))
+			result, err := prpreview.NewService(runtime.client, runtime.branches, runtime.labels, planner).Preview(ctx, prpreview.Config{
+				PRID:         prID,
+				Branch:       branch,
+				MatchFullRef: matchFullRef,
+				Post:         post,
+				Operator:     runtime.operator,
+			})
+			if err != nil {
+				return err
+			}
+
+			log := runtime.logger.With(
+				zap.Int("pr", prID),
+				zap.String("branch", branch),
+				zap.String("bump", result.Bump.String()),
+				zap.String("source", string(result.Source)),
+				zap.String("tag", result.Plan.TagName),
+			)
+			switch {
+			case result.PostError != "":
+				log.Warn("preview comment not posted", zap.String("error", result.PostError))
+			case result.Posted:
+				log.Info("preview comment posted")
+			default:
+				log.Info("release previewed")
+			}
+
+			preview := output.NewPRPreviewResult(result)
+			preview.Operator = runtime.operator
+			if runtime.format == output.FormatJSON {
+				return output.WriteJSON(cmd.OutOrStdout(), preview)
+			}
+			return output.WritePRPreview(cmd.OutOrStdout(), preview)
+		},
+	}
+
+	fs := cmd.Flags()
+	prIDFlag = bindIntFlag(fs, flagPRID, flagPRID, "", envPRID, 0, "Pull request ID to preview")
+	branchFlag = bindStringFlag(fs, "source-branch", "source-branch", "", envSourceBranch, "", "Source branch name for the pull request")
+	fullRefFlag = bindBoolFlag(fs, "match-full-ref", "match-full-ref", "", envMatchFullRef, false, "Match branch prefixes against the full refs/heads/ ref instead of stripping it")
+	postFlag = bindBoolFlag(fs, flagPostComment, flagPostComment, "", envPostComment, false, "Post the preview as a pull request comment (failures are logged, not fatal)")
+	prefixFlag = bindStringFlag(fs, "tag-prefix", "tag-prefix", "", envTagPrefix, "", "String prepended to computed tag names (e.g. 'v')")
+	separatorFlag = bindStringFlag(fs, flagPrefixSep, flagPrefixSep, "", envPrefixSep, "", "Separator placed between --tag-prefix and the version (e.g. '-' for release-1.2.3)")
+	componentFlag = bindStringFlag(fs, flagComponent, flagComponent, "", envComponent, "", "Monorepo component whose tags live under '<component>/' (e.g. 'api' for api/v1.2.3)")
+
+	return cmd
+}
@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/output"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/prpreview"
+)
+
+func newPreviewCommand(rootFlags *rootFlagSet) *cobra.Command {
+	var prIDFlag *intFlag
+	var branchFlag *stringFlag
+	var fullRefFlag *boolFlag
+	var versionFlag *boolFlag
+	var prefixFlag *stringFlag
+	var separatorFlag *stringFlag
+	var componentFlag *stringFlag
+
+	cmd := &cobra.Command{
+		Use:   "preview",
+		Short: "Report what pr-label would decide and the bump a pull request carries, without writing anything",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			runtime, cleanup, err := buildRuntime(ctx, cmd, rootFlags)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			prID, err := prIDFlag.Value(runtime.resolver)
+			if err != nil {
+				return err
+			}
+			if prID <= 0 {
+				return fmt.Errorf("pr-id must be greater than zero")
+			}
+
+			branch := branchFlag.Value(runtime.resolver)
+			if strings.TrimSpace(branch) == "" {
+				return fmt.Errorf("source-branch is required")
+			}
+
+			matchFullRef, err := fullRefFlag.Value(runtime.resolver)
+			if err != nil {
+				return err
+			}
+			withVersion, err := versionFlag.Value(runtime.resolver)
+			if err != nil {
+				return err
+			}
+
+			if err := applyRepoConfig(ctx, &runtime, rootFlags, ""); err != nil {
+				return err
+			}
+
+			planner := tagplan.NewPlanner(strings.TrimSpace(prefixFlag.Value(runtime.resolver))).
+				WithPrefixSeparator(separatorFlag.Value(runtime.resolver)).
+				WithComponent(componentFlag.Value(runtime.resolver))
+			check, err := prpreview.NewService(runtime.client, runtime.branches, runtime.labels, planner).Check(ctx, prpreview.CheckConfig{
+				PRID:         prID,
+				Branch:       branch,
+				MatchFullRef: matchFullRef,
+				WithVersion:  withVersion,
+			})
+			if err != nil {
+				return err
+			}
+
+			log := runtime.logger.With(
+				zap.Int("pr", prID),
+				zap.String("branch", branch),
+				zap.String("branchBump", check.BranchBump.String()),
+				zap.String("expectedLabel", check.ExpectedLabel),
+				zap.String("labelStatus", string(check.LabelStatus)),
+				zap.String("bump", check.Bump.String()),
+				zap.String("source", string(check.Source)),
+			)
+			if check.Plan != nil {
+				log = log.With(zap.String("tag", check.Plan.TagName))
+			}
+			if check.LabelStatus == prpreview.LabelConflict {
+				log.Warn("conflicting semver labels detected", zap.Strings("existing", check.SemverLabels))
+			} else {
+				log.Info("pull request previewed")
+			}
+
+			result := output.NewPreviewResult(check)
+			result.Operator = runtime.operator
+			if runtime.format == output.FormatJSON {
+				return output.WriteJSON(cmd.OutOrStdout(), result)
+			}
+			return output.WritePreview(cmd.OutOrStdout(), result)
+		},
+	}
+
+	fs := cmd.Flags()
+	prIDFlag = bindIntFlag(fs, flagPRID, flagPRID, "", envPRID, 0, "Pull request ID to preview")
+	branchFlag = bindStringFlag(fs, "source-branch", "source-branch", "", envSourceBranch, "", "Source branch name for the pull request")
+	fullRefFlag = bindBoolFlag(fs, "match-full-ref", "match-full-ref", "", envMatchFullRef, false, "Match branch prefixes against the full refs/heads/ ref instead of stripping it")
+	versionFlag = bindBoolFlag(fs, flagWithVersion, flagWithVersion, "", envWithVersion, false, "Also compute the release version the merge would produce")
+	prefixFlag = bindStringFlag(fs, "tag-prefix", "tag-prefix", "", envTagPrefix, "", "String prepended to computed tag names (e.g. 'v')")
+	separatorFlag = bindStringFlag(fs, flagPrefixSep, flagPrefixSep, "", envPrefixSep, "", "Separator placed between --tag-prefix and the version (e.g. '-' for release-1.2.3)")
+	componentFlag = bindStringFlag(fs, flagComponent, flagComponent, "", envComponent, "", "Monorepo component whose tags live under '<component>/' (e.g. 'api' for api/v1.2.3)")
+
+	return cmd
+}
@@ -0,0 +1,165 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/output"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/tagging"
+)
+
+// promoteFlagSet holds the promote-rc settings: the RC to promote and how the release and
+// floating tags are written. Version computation flags do not apply.
+type promoteFlagSet struct {
+	target      *stringFlag
+	tagPrefix   *stringFlag
+	prefixSep   *stringFlag
+	component   *stringFlag
+	message     *stringFlag
+	messageFile *stringFlag
+	taggerName  *stringFlag
+	taggerEmail *stringFlag
+	tagKind     *stringFlag
+	useFloating *boolFlag
+	floatLevel  *stringFlag
+	floatKind   *stringFlag
+	dryRun      *boolFlag
+}
+
+func newPromoteRCCommand(rootFlags *rootFlagSet) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "promote-rc",
+		Short: "Tag the latest RC's commit as its release",
+	}
+
+	fs := cmd.Flags()
+	f := &promoteFlagSet{
+		target:      bindStringFlag(fs, flagTargetVersion, flagTargetVersion, "", envPromoteTarget, "", "Release whose RC is promoted (e.g. '2.1.0'); required when several RC lines are open"),
+		tagPrefix:   bindStringFlag(fs, "tag-prefix", "tag-prefix", "", envTagPrefix, "", "String prepended to tag names (e.g. 'v')"),
+		prefixSep:   bindStringFlag(fs, flagPrefixSep, flagPrefixSep, "", envPrefixSep, "", "Separator placed between --tag-prefix and the version"),
+		component:   bindStringFlag(fs, flagComponent, flagComponent, "", envComponent, "", "Monorepo component whose tags live under '<component>/'"),
+		message:     bindStringFlag(fs, flagTagMessage, flagTagMessage, "", envTagMessage, "", "Message stored in the annotated tag"),
+		messageFile: bindStringFlag(fs, flagTagMessageFile, flagTagMessageFile, "", envTagMessageFile, "", "File whose contents are stored as the annotated tag message (--tag-message takes precedence)"),
+		taggerName:  bindStringFlag(fs, flagTaggerName, flagTaggerName, "", envTaggerName, defaultTaggerName, "Name recorded as the tagger"),
+		taggerEmail: bindStringFlag(fs, flagTaggerEmail, flagTaggerEmail, "", envTaggerEmail, defaultTaggerEmail, "Email recorded as the tagger"),
+		tagKind:     bindStringFlag(fs, flagTagKind, flagTagKind, "", envTagKind, string(tagging.TagKindAnnotated), "Kind of release tag to create (annotated or lightweight)"),
+		useFloating: bindBoolFlag(fs, flagUseFloating, flagUseFloating, "", envUseFloatingTags, false, "Create/maintain floating major refs (v<major>)"),
+		floatLevel:  bindStringFlag(fs, flagFloatLevel, flagFloatLevel, "", envFloatLevel, string(tagging.FloatingLevelMajor), "Floating tags maintained: major (v1), minor (v1.2), or both"),
+		floatKind:   bindStringFlag(fs, flagFloatingKind, flagFloatingKind, "", envFloatingKind, string(tagging.TagKindLightweight), "Kind of floating tag to maintain (annotated or lightweight)"),
+		dryRun:      bindBoolFlag(fs, flagDryRun, flagDryRun, "", envDryRun, false, "Plan the release and floating actions without writing to ADO"),
+	}
+	completeValues(cmd, flagTagKind, string(tagging.TagKindAnnotated), string(tagging.TagKindLightweight))
+	completeValues(cmd, flagFloatingKind, string(tagging.TagKindAnnotated), string(tagging.TagKindLightweight))
+	completeValues(cmd, flagFloatLevel, string(tagging.FloatingLevelMajor), string(tagging.FloatingLevelMinor), string(tagging.FloatingLevelBoth))
+
+	cmd.RunE = func(cmd *cobra.Command, _ []string) error {
+		ctx := cmd.Context()
+		runtime, cleanup, err := buildRuntime(ctx, cmd, rootFlags)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+
+		createCfg, err := f.resolve(runtime)
+		if err != nil {
+			return err
+		}
+		dryRun, err := f.dryRun.Value(runtime.resolver)
+		if err != nil {
+			return err
+		}
+
+		opts := tagRunOptions{
+			tagPrefix: strings.TrimSpace(f.tagPrefix.Value(runtime.resolver)),
+			prefixSep: strings.TrimSpace(f.prefixSep.Value(runtime.resolver)),
+			component: strings.Trim(strings.TrimSpace(f.component.Value(runtime.resolver)), "/"),
+			dryRun:    dryRun,
+		}
+		planner := tagplan.NewPlanner(opts.tagPrefix).
+			WithPrefixSeparator(opts.prefixSep).
+			WithComponent(opts.component)
+		service := tagging.NewService(runtime.client, planner).WithProtectedTags(runtime.protectedTags).WithEvents(runtime.events)
+
+		target := strings.TrimSpace(f.target.Value(runtime.resolver))
+		preview := func(ctx context.Context) (tagplan.Result, error) {
+			return service.PreviewPromotion(ctx, createCfg, target)
+		}
+		if err := runTagPreflight(ctx, runtime, createCfg, preview); err != nil {
+			return err
+		}
+		var result tagplan.Result
+		if dryRun {
+			result, err = service.PreviewPromotion(ctx, createCfg, target)
+		} else {
+			result, err = service.Promote(ctx, createCfg, target)
+		}
+		if err != nil {
+			return err
+		}
+		createCfg.Mode = tagplan.ModeRelease
+		createCfg.CommitSHA = result.BaseTag.ObjectID
+
+		logTagResult(runtime.logger, createCfg, result, opts)
+		if err := writeTagResult(cmd, runtime, createCfg, result, opts, nil); err != nil {
+			return err
+		}
+		return writeSummary(cmd, runtime, output.CreateTagSummary(result, bump.BumpNone, createCfg.CommitSHA, dryRun))
+	}
+
+	return cmd
+}
+
+// resolve reads the tagger and floating settings into a CreateConfig; the commit comes from
+// the promoted RC.
+func (f *promoteFlagSet) resolve(runtime runtimeConfig) (tagging.CreateConfig, error) {
+	resolver := runtime.resolver
+
+	taggerName := strings.TrimSpace(f.taggerName.Value(resolver))
+	if taggerName == "" {
+		return tagging.CreateConfig{}, fmt.Errorf(requiredFlagFormat, flagTaggerName)
+	}
+	taggerEmail := strings.TrimSpace(f.taggerEmail.Value(resolver))
+	if taggerEmail == "" {
+		return tagging.CreateConfig{}, fmt.Errorf(requiredFlagFormat, flagTaggerEmail)
+	}
+
+	message, err := tagMessage(resolver, f.message, f.messageFile)
+	if err != nil {
+		return tagging.CreateConfig{}, err
+	}
+
+	useFloating, err := f.useFloating.Value(resolver)
+	if err != nil {
+		return tagging.CreateConfig{}, err
+	}
+	floatingLevel, err := tagging.ParseFloatingLevel(f.floatLevel.Value(resolver))
+	if err != nil {
+		return tagging.CreateConfig{}, err
+	}
+	tagKind, err := tagging.ParseTagKind(f.tagKind.Value(resolver))
+	if err != nil {
+		return tagging.CreateConfig{}, err
+	}
+	floatingKind, err := tagging.ParseTagKind(f.floatKind.Value(resolver))
+	if err != nil {
+		return tagging.CreateConfig{}, err
+	}
+
+	return tagging.CreateConfig{
+		Config: tagging.Config{
+			Mode:            tagplan.ModeRelease,
+			UseFloatingTags: useFloating,
+			FloatingLevel:   floatingLevel,
+		},
+		Message:         message,
+		TaggerName:      taggerName,
+		TaggerEmail:     taggerEmail,
+		TagKind:         tagKind,
+		FloatingTagKind: floatingKind,
+	}, nil
+}
@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/config"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/branchmap"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/labels"
+)
+
+// applyRepoConfig reads --config-from-repo from the target repository at commit (the default
+// branch when commit is empty) and rebuilds the label and branch resolvers with the file's
+// values as defaults, so env and CLI settings still win. A missing file keeps the current
+// resolvers.
+func applyRepoConfig(ctx context.Context, runtime *runtimeConfig, flags *rootFlagSet, commit string) error {
+	if runtime.repoConfig == "" {
+		return nil
+	}
+
+	log := runtime.logger.With(zap.String("path", runtime.repoConfig), zap.String("commit", commit))
+	content, err := runtime.client.GetFileContent(ctx, runtime.repoConfig, commit)
+	if errors.Is(err, ado.ErrFileNotFound) {
+		log.Debug("repo config not found; using defaults")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("reading repo config: %w", err)
+	}
+
+	file, err := config.ParseRepoFile(content)
+	if err != nil {
+		return fmt.Errorf("%s: %w", runtime.repoConfig, err)
+	}
+	runtime.labels, runtime.branches = buildResolvers(flags, runtime.resolver, file)
+	if err := checkBranchMapping(runtime.logger, runtime.branches, runtime.strictPrefixes); err != nil {
+		return fmt.Errorf("%s: %w", runtime.repoConfig, err)
+	}
+	log.Debug("repo config applied")
+	return nil
+}
+
+// buildResolvers resolves the label and branch settings, using file values in place of the
+// built-in defaults where the file sets them.
+func buildResolvers(flags *rootFlagSet, resolver config.Resolver, file config.RepoFile) (labels.Resolver, branchmap.Resolver) {
+	label := func(f *stringFlag, value string) string {
+		if value != "" {
+			f = f.withDefault(value)
+		}
+		return f.Value(resolver)
+	}
+	prefixes := func(f *stringSliceFlag, values []string) []string {
+		if len(values) > 0 {
+			f = f.withDefault(values)
+		}
+		return f.Value(resolver)
+	}
+
+	prefix := flags.labelPref
+	if file.Labels.Prefix != nil {
+		prefix = prefix.withDefault(*file.Labels.Prefix)
+	}
+	labelResolver := labels.NewResolver(labels.Config{
+		Prefix:     prefix.Value(resolver),
+		MajorLabel: label(flags.labelMajor, file.Labels.Major),
+		MinorLabel: label(flags.labelMinor, file.Labels.Minor),
+		PatchLabel: label(flags.labelPatch, file.Labels.Patch),
+		NoneLabel:  label(flags.labelNone, file.Labels.None),
+	})
+
+	branchResolver := branchmap.NewResolver(branchmap.Mapping{
+		MajorPrefixes: prefixes(flags.branchMaj, file.Branches.Major),
+		MinorPrefixes: prefixes(flags.branchMin, file.Branches.Minor),
+		PatchPrefixes: prefixes(flags.branchPatch, file.Branches.Patch),
+	})
+	return labelResolver, branchResolver
+}
@@ -3,7 +3,9 @@ package cli
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"go.uber.org/zap"
@@ -11,11 +13,12 @@ import (
 	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado"
 	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/config"
 	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/branchmap"
-	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
 	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/labels"
-	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/events"
 	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/logging"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/output"
 	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/inferbump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/preflight"
 	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/prlabel"
 	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/tagging"
 	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/version"
@@ -27,10 +30,12 @@ const (
 	envRepo       = "AAV_REPO"
 	envToken      = "AAV_TOKEN"
 	envLogLevel   = "AAV_LOG_LEVEL"
+	envLogFields  = "AAV_LOG_FIELD_MAP"
 	envLabelPref  = "AAV_LABEL_PREFIX"
 	envLabelMajor = "AAV_LABEL_MAJOR"
 	envLabelMinor = "AAV_LABEL_MINOR"
 	envLabelPatch = "AAV_LABEL_PATCH"
+	envLabelNone  = "AAV_LABEL_NONE"
 
 	envBranchMajor = "AAV_BRANCH_MAJOR_PREFIXES"
 	envBranchMinor = "AAV_BRANCH_MINOR_PREFIXES"
@@ -38,32 +43,229 @@ const (
 
 	envPRID         = "AAV_PR_ID"
 	envSourceBranch = "AAV_SOURCE_BRANCH"
+	envMatchFullRef = "AAV_MATCH_FULL_REF"
+	envPostComment  = "AAV_POST_COMMENT"
+	envOnUnmatched  = "AAV_ON_UNMATCHED"
+	envLabelSettle  = "AAV_LABEL_SETTLE"
 
 	envCommit = "AAV_COMMIT_SHA"
 	envStrict = "AAV_STRICT"
 
+	envConflictBump = "AAV_CONFLICT_BUMP"
+	envLookupRetry  = "AAV_PR_LOOKUP_RETRIES"
+	envLookupDelay  = "AAV_PR_LOOKUP_DELAY"
+	envEnvOut       = "AAV_ENV_OUT"
+	envEnvAppend    = "AAV_ENV_APPEND"
+	envRequireOn    = "AAV_REQUIRE_ON_BRANCH"
+	envInferTitle   = "AAV_INFER_FROM_TITLE"
+	envFailDefault  = "AAV_FAIL_ON_DEFAULT"
+	envInferSources = "AAV_INFER_SOURCES"
+	envInferCombine = "AAV_INFER_COMBINE"
+	envBatchCommits = "AAV_BATCH_COMMITS"
+	envBatchFile    = "AAV_BATCH_COMMITS_FILE"
+	envBatchWorkers = "AAV_BATCH_CONCURRENCY"
+
 	envTagMode         = "AAV_TAG_MODE"
 	envBump            = "AAV_BUMP"
+	envDefaultBump     = "AAV_DEFAULT_BUMP"
+	envRequireBump     = "AAV_REQUIRE_BUMP"
 	envBaseVersion     = "AAV_BASE_VERSION"
+	envVersionSource   = "AAV_VERSION_SOURCE_URL"
 	envTagMessage      = "AAV_TAG_MESSAGE"
+	envTagMessageFile  = "AAV_TAG_MESSAGE_FILE"
 	envTaggerName      = "AAV_TAGGER_NAME"
 	envTaggerEmail     = "AAV_TAGGER_EMAIL"
 	envTagPrefix       = "AAV_TAG_PREFIX"
 	envUseFloatingTags = "AAV_USE_FLOATING_TAGS"
+	envTFOut           = "AAV_TF_OUT"
+	envDryRun          = "AAV_DRY_RUN"
+	envPlanOnly        = "AAV_PLAN_ONLY"
+	envPlanFile        = "AAV_PLAN_FILE"
+	envDeleteStale     = "AAV_DELETE_STALE"
+	envRCTarget        = "AAV_RC_TARGET"
+	envRCStrict        = "AAV_RC_STRICT"
+	envNoSkip          = "AAV_FLOATING_NO_SKIP"
+	envShowDiff        = "AAV_SHOW_DIFF"
+	envOutput          = "AAV_OUTPUT"
+	envPreflight       = "AAV_PREFLIGHT"
+	envTraceAPI        = "AAV_TRACE_API"
+	envQuiet           = "AAV_QUIET"
+	envNoNewline       = "AAV_NO_TRAILING_NEWLINE"
+	envConfigFromRepo  = "AAV_CONFIG_FROM_REPO"
+	envStrictPrefixes  = "AAV_STRICT_BRANCH_PREFIXES"
+	envResultFile      = "AAV_RESULT_FILE"
+	envProtectedTags   = "AAV_PROTECTED_TAGS"
+	envIncludeCommits  = "AAV_INCLUDE_COMMITS"
+	envFloatingSkipCI  = "AAV_FLOATING_SKIP_CI"
+	envSkipCIMarker    = "AAV_SKIP_CI_MARKER"
+	envTagKind         = "AAV_TAG_KIND"
+	envFloatingKind    = "AAV_FLOATING_TAG_KIND"
+	envTagRefCheck     = "AAV_TAG_REF_CHECK"
+	envOnMissingCommit = "AAV_ON_MISSING_COMMIT"
+	envRCRetry         = "AAV_RC_RETRY_ON_COLLISION"
+	envReleaseBranches = "AAV_RELEASE_BRANCHES"
+	envRCMinAge        = "AAV_RC_MIN_AGE"
+	envSkipIfOnlyPaths = "AAV_SKIP_IF_ONLY_PATHS"
+	envRequireMerge    = "AAV_REQUIRE_MERGE_COMMIT"
+	envShellOut        = "AAV_SHELL_OUT"
+	envPipelineTags    = "AAV_PIPELINE_ANNOTATE"
+	envFloatingTrack   = "AAV_FLOATING_TRACK"
+	envPreferPreLine   = "AAV_PREFER_PRERELEASE_LINE"
+	envIgnoreTags      = "AAV_IGNORE_TAGS"
+	envWithVersion     = "AAV_WITH_VERSION"
+	envIntTimeout      = "AAV_INTEGRATION_TIMEOUT"
+	envOnce            = "AAV_ONCE"
+	envAllowDowngrade  = "AAV_ALLOW_DOWNGRADE"
+	envPromoteTarget   = "AAV_PROMOTE_TARGET_VERSION"
+	envDeleteName      = "AAV_DELETE_TAG_NAME"
+	envForce           = "AAV_DELETE_FORCE"
+	envFloatLevel      = "AAV_FLOATING_LEVEL"
+	envRefCacheTTL     = "AAV_REF_CACHE_TTL"
+	envAncestryCache   = "AAV_ANCESTRY_CACHE"
+	envEventLog        = "AAV_EVENT_LOG"
+	envReleasesOnly    = "AAV_LIST_RELEASES_ONLY"
+	envPrereleasesOnly = "AAV_LIST_PRERELEASES_ONLY"
+	envListMajor       = "AAV_LIST_MAJOR"
+	envCommitMsg       = "AAV_COMMIT_MESSAGE_FALLBACK"
+	envRepoName        = "AAV_REPO_DISPLAY_NAME"
+	envStrictConflict  = "AAV_STRICT_CONFLICT"
+	envVerifyTag       = "AAV_VERIFY_TAG"
+	envVerifyTimeout   = "AAV_VERIFY_TAG_TIMEOUT"
+	envRCScope         = "AAV_RC_SCOPE"
+	envBumpTolerance   = "AAV_BUMP_TOLERANCE"
+	envForceFloating   = "AAV_FORCE_FLOATING"
+	envAggregatePRs    = "AAV_AGGREGATE_PRS"
+	envOperator        = "AAV_OPERATOR"
+	envExitOn          = "AAV_EXIT_ON"
+	envPreLabel        = "AAV_PRERELEASE_LABEL"
+	envPreChannel      = "AAV_PRERELEASE_CHANNEL"
+	envChannelOrder    = "AAV_CHANNEL_ORDER"
+	envEnforceOrder    = "AAV_ENFORCE_CHANNEL_ORDER"
+	envMaxMajor        = "AAV_MAX_MAJOR"
+	envMaxMinor        = "AAV_MAX_MINOR"
+	envMaxPatch        = "AAV_MAX_PATCH"
+	envMaxTagsScan     = "AAV_MAX_TAGS_SCAN"
+	envPrefixSep       = "AAV_PREFIX_SEPARATOR"
+	envComponent       = "AAV_COMPONENT"
+	envFromTag         = "AAV_FROM_TAG"
+	envAliasTagName    = "AAV_ALIAS_TAG_NAME"
+	envVersionRange    = "AAV_VERSION_RANGE"
+	envHotfixBase      = "AAV_HOTFIX_BASE"
+	envTaggerIdentity  = "AAV_TAGGER_FROM_IDENTITY"
 	requiredFlagFormat = "%s is required"
 )
 
 const (
-	flagCommitSHA      = "commit-sha"
-	flagTagMode        = "tag-mode"
-	flagBump           = "bump"
-	flagBaseVersion    = "base-version"
-	flagTagMessage     = "tag-message"
-	flagTaggerName     = "tagger-name"
-	flagTaggerEmail    = "tagger-email"
-	flagUseFloating    = "use-floating-tags"
-	defaultTaggerName  = "aav"
-	defaultTaggerEmail = "aav@example.com"
+	flagCommitSHA       = "commit-sha"
+	flagTagMode         = "tag-mode"
+	flagBump            = "bump"
+	flagDefaultBump     = "default-bump"
+	flagRequireBump     = "require-bump"
+	flagBaseVersion     = "base-version"
+	flagVersionSource   = "version-source-url"
+	flagTagMessage      = "tag-message"
+	flagTagMessageFile  = "tag-message-file"
+	flagTaggerName      = "tagger-name"
+	flagTaggerEmail     = "tagger-email"
+	flagUseFloating     = "use-floating-tags"
+	flagTFOut           = "tf-out"
+	flagDryRun          = "dry-run"
+	flagPlanOnly        = "plan-only"
+	flagPlanFile        = "plan-file"
+	flagDeleteStale     = "delete-stale"
+	flagRCTarget        = "target"
+	flagShowDiff        = "show-diff"
+	flagOutput          = "output"
+	flagPreflight       = "preflight"
+	flagTraceAPI        = "trace-api"
+	flagQuiet           = "quiet"
+	flagNoNewline       = "no-trailing-newline"
+	flagConfigFromRepo  = "config-from-repo"
+	flagStrictPrefixes  = "strict-branch-prefixes"
+	flagResultFile      = "result-file"
+	flagProtectedTags   = "protected-tags"
+	flagIncludeCommits  = "include-commits"
+	flagFloatingSkipCI  = "floating-skip-ci"
+	flagSkipCIMarker    = "skip-ci-marker"
+	flagTagKind         = "tag-kind"
+	flagFloatingKind    = "floating-tag-kind"
+	flagTagRefCheck     = "tag-ref-check"
+	flagOnMissingCommit = "on-missing-commit"
+	flagRCRetry         = "rc-retry-on-collision"
+	flagReleaseBranches = "release-branches"
+	flagRCMinAge        = "rc-min-age"
+	flagSkipIfOnlyPaths = "skip-if-only-paths"
+	flagRequireMerge    = "require-merge-commit"
+	flagHotfixBase      = "hotfix-base"
+	flagConflictBump    = "conflict-bump"
+	flagLookupRetries   = "pr-lookup-retries"
+	flagLookupDelay     = "pr-lookup-delay"
+	flagEnvOut          = "env-out"
+	flagShellOut        = "shell-out"
+	flagPipelineTags    = "pipeline-annotate"
+	flagFloatingTrack   = "floating-track"
+	flagPreferPreLine   = "prefer-prerelease-line"
+	flagIgnoreTags      = "ignore-tags"
+	flagWithVersion     = "with-version"
+	flagIntTimeout      = "integration-timeout"
+	flagOnce            = "once"
+	flagAllowDowngrade  = "allow-downgrade"
+	flagTargetVersion   = "target-version"
+	flagDeleteName      = "name"
+	flagForce           = "force"
+	flagFloatLevel      = "floating-level"
+	flagRefCacheTTL     = "ref-cache-ttl"
+	flagAncestryCache   = "ancestry-cache"
+	flagEventLog        = "event-log"
+	flagReleasesOnly    = "releases-only"
+	flagPrereleasesOnly = "prereleases-only"
+	flagListMajor       = "major"
+	flagCommitMsg       = "commit-message-fallback"
+	flagRepoName        = "repo-display-name"
+	flagStrictConflict  = "strict-conflict"
+	flagVerifyTag       = "verify-tag"
+	flagVerifyTimeout   = "verify-tag-timeout"
+	flagRCScope         = "rc-scope"
+	flagBranch          = "branch"
+	flagBumpTolerance   = "bump-tolerance"
+	flagForceFloating   = "force-floating"
+	flagAggregatePRs    = "aggregate-prs"
+	flagOperator        = "operator"
+	flagExitOn          = "exit-on"
+	flagPreLabel        = "prerelease-label"
+	flagPreChannel      = "prerelease-channel"
+	flagChannelOrder    = "channel-order"
+	flagEnforceOrder    = "enforce-channel-order"
+	flagMaxMajor        = "max-major"
+	flagMaxMinor        = "max-minor"
+	flagMaxPatch        = "max-patch"
+	flagMaxTagsScan     = "max-tags-scan"
+	flagPRID            = "pr-id"
+	flagPostComment     = "post-comment"
+	flagLabelSettle     = "label-settle"
+	flagPrefixSep       = "prefix-separator"
+	flagComponent       = "component"
+	flagFromTag         = "from-tag"
+	flagTagName         = "tag-name"
+	flagVersionRange    = "version-range"
+	flagEnvAppend       = "env-append"
+	flagRequireOn       = "require-on-branch"
+	flagFailOnDefault   = "fail-on-default"
+	flagInferSources    = "infer-sources"
+	flagInferCombine    = "infer-combine"
+	flagBatchCommits    = "commits"
+	flagBatchFile       = "commits-file"
+	flagBatchWorkers    = "concurrency"
+	flagTaggerIdentity  = "tagger-from-identity"
+	defaultTaggerName   = "aav"
+	defaultTaggerEmail  = "aav@example.com"
+
+	// defaultStrictLookupRetries applies when --strict is set and --pr-lookup-retries is not, since
+	// a not-yet-indexed merge commit would otherwise fail the run.
+	defaultStrictLookupRetries = 3
+	defaultLookupDelay         = "2s"
+	defaultPlanFile            = "aav-plan.json"
+	defaultVerifyTimeout       = "30s"
 )
 
 // Execute runs the CLI root command with the provided context.
@@ -78,35 +280,64 @@ type rootFlagSet struct {
 	orgURL      *stringFlag
 	project     *stringFlag
 	repo        *stringFlag
+	repoName    *stringFlag
 	token       *stringFlag
 	logLevel    *stringFlag
+	logFields   *stringSliceFlag
 	labelPref   *stringFlag
 	labelMajor  *stringFlag
 	labelMinor  *stringFlag
 	labelPatch  *stringFlag
+	labelNone   *stringFlag
 	branchMaj   *stringSliceFlag
 	branchMin   *stringSliceFlag
 	branchPatch *stringSliceFlag
-}
-
-type tagFlagSet struct {
-	mode        *stringFlag
-	bump        *stringFlag
-	base        *stringFlag
-	commit      *stringFlag
-	message     *stringFlag
-	taggerName  *stringFlag
-	taggerEmail *stringFlag
-	tagPrefix   *stringFlag
-	useFloating *boolFlag
+	output      *stringFlag
+	preflight   *boolFlag
+	traceAPI    *boolFlag
+	quiet       *boolFlag
+	noNewline   *boolFlag
+	repoConfig  *stringFlag
+	strictPref  *boolFlag
+	resultFile  *stringFlag
+	protected   *stringSliceFlag
+	operator    *stringFlag
+	refCache    *stringFlag
+	ancestry    *boolFlag
+	eventLog    *stringFlag
+	// exitCode is set by a command that succeeded but reports its outcome through a non-zero
+	// exit code; the root returns it as an ExitError after the result file is written.
+	exitCode int
 }
 
 type runtimeConfig struct {
-	resolver config.Resolver
-	logger   *zap.Logger
-	client   ado.Client
-	branches branchmap.Resolver
-	labels   labels.Resolver
+	resolver  config.Resolver
+	logger    *zap.Logger
+	client    ado.Client
+	branches  branchmap.Resolver
+	labels    labels.Resolver
+	format    output.Format
+	preflight bool
+	// quiet suppresses the one-line summary written to stderr at the end of a run.
+	quiet bool
+	// noNewline drops the newline after the bare value printed by create-tag, alias-tag,
+	// apply-plan, and infer-bump in text format.
+	noNewline bool
+	// repoConfig is the path of a config file in the target repository that supplies label
+	// and branch defaults; see applyRepoConfig.
+	repoConfig string
+	// strictPrefixes turns unreachable branch prefixes from a warning into an error.
+	strictPrefixes bool
+	// protectedTags lists glob patterns of tags that delete and move paths refuse to touch.
+	protectedTags []string
+	// repoName is the explicit --repo-display-name, prefixed to summary lines; empty when the
+	// logs fall back to --repo.
+	repoName string
+	// operator names who or what started the run; it is attached to every log entry and JSON
+	// result. Empty when neither --operator nor a CI or user variable identifies anyone.
+	operator string
+	// events records the run's structured event stream for --event-log; nil when it is off.
+	events *events.Log
 }
 
 func newRootCommand() *cobra.Command {
@@ -124,16 +355,75 @@ func newRootCommand() *cobra.Command {
 	cmd.SetVersionTemplate("aav {{.Version}}\n")
 
 	flags := bindRootFlags(cmd)
+	registerRootCompletions(cmd)
+	var resultFile *output.ResultFile
+	cmd.PersistentPreRunE = func(cmd *cobra.Command, _ []string) error {
+		if err := checkTabularOutput(cmd, flags); err != nil {
+			return err
+		}
+		path := strings.TrimSpace(flags.resultFile.Value(config.NewResolver(zap.NewNop())))
+		if path == "" {
+			return nil
+		}
+		file, err := output.NewResultFile(path)
+		if err != nil {
+			return err
+		}
+		resultFile = file
+		cmd.SetOut(file.Tee(cmd.OutOrStdout()))
+		return nil
+	}
+	// PersistentPostRunE only runs after a successful RunE, so a failed command never replaces
+	// the result file with partial output.
+	cmd.PersistentPostRunE = func(*cobra.Command, []string) error {
+		if resultFile != nil {
+			if err := resultFile.Commit(); err != nil {
+				return fmt.Errorf("writing result file: %w", err)
+			}
+		}
+		if flags.exitCode != 0 {
+			return &ExitError{Code: flags.exitCode}
+		}
+		return nil
+	}
 	cmd.AddCommand(
 		newPRLabelCommand(flags),
+		newPRPreviewCommand(flags),
+		newPreviewCommand(flags),
 		newInferCommand(flags),
+		newInferBatchCommand(flags),
 		newTagCommand(flags),
+		newStaleRCCommand(flags),
+		newListTagsCommand(flags),
+		newVerifyRCCommand(flags),
+		newVerifyFloatingCommand(flags),
+		newPromoteRCCommand(flags),
+		newDeleteTagCommand(flags),
+		newApplyPlanCommand(flags),
+		newDoctorCommand(flags),
+		newValidateBumpCommand(flags),
 		newVersionCommand(),
+		newCompletionCommand(),
 	)
 
 	return cmd
 }
 
+// tabularAnnotation marks the batch commands that print rows with --output csv or tsv.
+const tabularAnnotation = "aav/tabular-output"
+
+var tabularOutput = map[string]string{tabularAnnotation: "true"}
+
+// checkTabularOutput rejects csv and tsv for commands that have no rows to print. An invalid
+// format is left for buildRuntime to report.
+func checkTabularOutput(cmd *cobra.Command, flags *rootFlagSet) error {
+	_, format := validationInputs(flags)
+	if !format.Tabular() || cmd.Annotations[tabularAnnotation] == "true" {
+		return nil
+	}
+	return fmt.Errorf("--%s %s is only supported by infer-bump-batch, list-stale-rc, and list-tags", flagOutput, format)
+}
+
 func newVersionCommand() *cobra.Command {
 	return &cobra.Command{
 		Use:   "version",
@@ -152,384 +442,697 @@ func bindRootFlags(cmd *cobra.Command) *rootFlagSet {
 	fs := cmd.PersistentFlags()
 	return &rootFlagSet{
 		orgURL:      bindStringFlag(fs, "org-url", "org-url", "", envOrgURL, "", "Azure DevOps organization URL"),
-		project:     bindStringFlag(fs, "project", "project", "", envProject, "", "Azure DevOps project name"),
+		project:     bindStringFlag(fs, "project", "project", "", envProject, "", "Azure DevOps project name or ID"),
 		repo:        bindStringFlag(fs, "repo", "repo", "", envRepo, "", "Azure DevOps repository name"),
+		repoName:    bindStringFlag(fs, flagRepoName, flagRepoName, "", envRepoName, "", "Repository name shown in logs and summaries; API calls still use --repo (default: the --repo value)"),
 		token:       bindSecretFlag(fs, "token", "token", "", envToken, "", "Azure DevOps personal access token or System.AccessToken"),
 		logLevel:    bindStringFlag(fs, "log-level", "log-level", "", envLogLevel, logging.LevelTerse, "Log verbosity (terse or verbose)"),
+		logFields:   bindStringSliceFlag(fs, "log-field-map", "log-field-map", "", envLogFields, nil, "Rename structured log field keys (from=to, e.g. tag=git_tag)"),
 		labelPref:   bindStringFlag(fs, "label-prefix", "label-prefix", "", envLabelPref, "semver-", "Optional prefix for semver labels"),
 		labelMajor:  bindStringFlag(fs, "label-major", "label-major", "", envLabelMajor, "", "Override label name for major bumps"),
 		labelMinor:  bindStringFlag(fs, "label-minor", "label-minor", "", envLabelMinor, "", "Override label name for minor bumps"),
 		labelPatch:  bindStringFlag(fs, "label-patch", "label-patch", "", envLabelPatch, "", "Override label name for patch bumps"),
+		labelNone:   bindStringFlag(fs, "label-none", "label-none", "", envLabelNone, "", "Override label name for merges that should not release"),
 		branchMaj:   bindStringSliceFlag(fs, "branch-major-prefixes", "branch-major-prefix", "", envBranchMajor, defaults.MajorPrefixes, "Branch prefixes that imply a major bump"),
 		branchMin:   bindStringSliceFlag(fs, "branch-minor-prefixes", "branch-minor-prefix", "", envBranchMinor, defaults.MinorPrefixes, "Branch prefixes that imply a minor bump"),
 		branchPatch: bindStringSliceFlag(fs, "branch-patch-prefixes", "branch-patch-prefix", "", envBranchPatch, defaults.PatchPrefixes, "Branch prefixes that imply a patch bump"),
+		output:      bindStringFlag(fs, flagOutput, flagOutput, "", envOutput, string(output.FormatText), "Result format written to stdout (text or json)"),
+		preflight:   bindBoolFlag(fs, flagPreflight, flagPreflight, "", envPreflight, false, "Verify the token's write permissions before pr-label or create-tag makes changes"),
+		traceAPI:    bindBoolFlag(fs, flagTraceAPI, flagTraceAPI, "", envTraceAPI, false, "Log every Azure DevOps API call with its key arguments and outcome"),
+		quiet:       bindBoolFlag(fs, flagQuiet, flagQuiet, "", envQuiet, false, "Suppress the one-line summary printed to stderr at the end of a run"),
+		noNewline:   bindBoolFlag(fs, flagNoNewline, flagNoNewline, "", envNoNewline, false, "Print text results without a trailing newline, for exact capture in $(...)"),
+		repoConfig:  bindStringFlag(fs, flagConfigFromRepo, flagConfigFromRepo, "", envConfigFromRepo, "", "Path of a config file (e.g. .aav.yaml) in the target repository supplying label and branch defaults"),
+		protected:   bindStringSliceFlag(fs, flagProtectedTags, flagProtectedTags, "", envProtectedTags, nil, "Tags (globs such as v1, latest, release-*) that are never deleted or moved, even by floating updates and stale RC cleanup"),
+		resultFile:  bindStringFlag(fs, flagResultFile, flagResultFile, "", envResultFile, "", "Also write the stdout result to this file, replacing it atomically once the command succeeds"),
+		operator:    bindStringFlag(fs, flagOperator, flagOperator, "", envOperator, "", "Who or what started the run, recorded in logs and JSON results (default: the pipeline requester, GitHub actor, or $USER)"),
+		strictPref:  bindBoolFlag(fs, flagStrictPrefixes, flagStrictPrefixes, "", envStrictPrefixes, false, "Fail instead of warning when a branch prefix is unreachable because a higher bump level already matches it"),
+		refCache:    bindStringFlag(fs, flagRefCacheTTL, flagRefCacheTTL, "", envRefCacheTTL, "", "Serve repeated ref listings from memory for this long (Go duration, e.g. 30s); ref writes clear it. Off by default"),
+		ancestry:    bindBoolFlag(fs, flagAncestryCache, flagAncestryCache, "", envAncestryCache, true, "Remember commit ancestry checks for the rest of the run so repeated branch and floating-tag guards do not call ADO again"),
+		eventLog:    bindStringFlag(fs, flagEventLog, flagEventLog, "", envEventLog, "", "Write a JSON line for each significant step of the run (refs listed, plan computed, tags written) to this file. Off by default"),
 	}
 }
 
-func newPRLabelCommand(rootFlags *rootFlagSet) *cobra.Command {
-	var prIDFlag *intFlag
-	var branchFlag *stringFlag
+type prLabelFlagSet struct {
+	prID      *intFlag
+	branch    *stringFlag
+	fullRef   *boolFlag
+	unmatched *stringFlag
+	settle    *stringFlag
+	exitOn    *stringFlag
+}
 
+func newPRLabelCommand(rootFlags *rootFlagSet) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "pr-label",
 		Short: "Ensure the expected semver label exists on a pull request",
-		RunE: func(cmd *cobra.Command, _ []string) error {
-			ctx := cmd.Context()
-			runtime, cleanup, err := buildRuntime(ctx, rootFlags)
-			if err != nil {
-				return err
-			}
-			defer cleanup()
-
-			prID, err := prIDFlag.Value(runtime.resolver)
-			if err != nil {
-				return err
-			}
-			if prID <= 0 {
-				return fmt.Errorf("pr-id must be greater than zero")
-			}
-
-			branch := branchFlag.Value(runtime.resolver)
-			if strings.TrimSpace(branch) == "" {
-				return fmt.Errorf("source-branch is required")
-			}
-
-			service := prlabel.NewService(runtime.client, runtime.branches, runtime.labels)
-			result, err := service.Apply(ctx, prlabel.Config{PRID: prID, Branch: branch})
-			if err != nil {
-				return err
-			}
-
-			log := runtime.logger.With(
-				zap.Int("pr", prID),
-				zap.String("branch", branch),
-				zap.String("bump", result.Bump.String()),
-				zap.Bool("branchMatched", result.BranchMatched),
-				zap.String("matchedPrefix", result.MatchedPrefix),
-			)
-
-			switch result.Decision {
-			case labels.DecisionAddExpected:
-				log.Info("adding semver label", zap.String("label", result.ExpectedLabel))
-			case labels.DecisionConflict:
-				log.Warn("conflicting semver labels detected", zap.String("expected", result.ExpectedLabel), zap.Strings("existing", result.ExistingSemver))
-			default:
-				log.Info("expected semver label already present", zap.String("label", result.ExpectedLabel))
-			}
-
-			if result.LabelAdded {
-				log.Info("semver label added", zap.String("label", result.ExpectedLabel))
-			}
-
-			return nil
-		},
 	}
 
-	fs := cmd.Flags()
-	prIDFlag = bindIntFlag(fs, "pr-id", "pr-id", "", envPRID, 0, "Pull request ID to label")
-	branchFlag = bindStringFlag(fs, "source-branch", "source-branch", "", envSourceBranch, "", "Source branch name for the pull request")
+	labelFlags := bindPRLabelFlags(cmd)
 
-	return cmd
-}
+	cmd.RunE = func(cmd *cobra.Command, _ []string) error {
+		ctx := cmd.Context()
+		runtime, cleanup, err := buildRuntime(ctx, cmd, rootFlags)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
 
-func newInferCommand(rootFlags *rootFlagSet) *cobra.Command {
-	var commitFlag *stringFlag
-	var strictFlag *boolFlag
+		labelCfg, exitPolicy, err := labelFlags.resolve(runtime.resolver)
+		if err != nil {
+			return err
+		}
 
-	cmd := &cobra.Command{
-		Use:   "infer-bump",
-		Short: "Infer bump intent from the merge commit's pull request labels",
-		RunE: func(cmd *cobra.Command, _ []string) error {
-			ctx := cmd.Context()
-			runtime, cleanup, err := buildRuntime(ctx, rootFlags)
-			if err != nil {
-				return err
-			}
-			defer cleanup()
+		if err := runPreflight(ctx, runtime, ado.PermissionPullRequestContribute); err != nil {
+			return err
+		}
+		if err := applyRepoConfig(ctx, &runtime, rootFlags, ""); err != nil {
+			return err
+		}
 
-			commit := strings.TrimSpace(commitFlag.Value(runtime.resolver))
-			if commit == "" {
-				return fmt.Errorf(requiredFlagFormat, flagCommitSHA)
-			}
+		service := prlabel.NewService(runtime.client, runtime.branches, runtime.labels)
+		result, err := service.Apply(ctx, labelCfg)
+		if err != nil {
+			return err
+		}
 
-			strict, err := strictFlag.Value(runtime.resolver)
-			if err != nil {
+		logPRLabelResult(runtime.logger, labelCfg, result)
+		if err := annotateGitHub(cmd, output.PRLabelAnnotations(labelCfg.PRID, result)); err != nil {
+			return err
+		}
+
+		if runtime.format == output.FormatJSON {
+			payload := output.NewPRLabelResult(labelCfg.PRID, labelCfg.Branch, result)
+			payload.Operator = runtime.operator
+			if err := output.WriteJSON(cmd.OutOrStdout(), payload); err != nil {
 				return err
 			}
-
-			return runInferCommand(cmd, ctx, runtime, commit, strict)
-		},
+		}
+		rootFlags.exitCode = exitPolicy.ExitCode(result)
+		return writeSummary(cmd, runtime, output.PRLabelSummary(labelCfg.PRID, result))
 	}
 
-	fs := cmd.Flags()
-	commitFlag = bindStringFlag(fs, flagCommitSHA, flagCommitSHA, "", envCommit, "", "Merge commit SHA to inspect")
-	strictFlag = bindBoolFlag(fs, "strict", "strict", "", envStrict, false, "Fail when the merge commit cannot be mapped to a pull request")
-
 	return cmd
 }
 
-func runInferCommand(cmd *cobra.Command, ctx context.Context, runtime runtimeConfig, commit string, strict bool) error {
-	service := inferbump.NewService(runtime.client, runtime.labels)
-	result, err := service.Resolve(ctx, inferbump.Config{CommitSHA: commit, Strict: strict})
+func bindPRLabelFlags(cmd *cobra.Command) *prLabelFlagSet {
+	fs := cmd.Flags()
+	return &prLabelFlagSet{
+		prID:      bindIntFlag(fs, flagPRID, flagPRID, "", envPRID, 0, "Pull request ID to label"),
+		branch:    bindStringFlag(fs, "source-branch", "source-branch", "", envSourceBranch, "", "Source branch name for the pull request"),
+		fullRef:   bindBoolFlag(fs, "match-full-ref", "match-full-ref", "", envMatchFullRef, false, "Match branch prefixes against the full refs/heads/ ref instead of stripping it"),
+		unmatched: bindStringFlag(fs, "on-unmatched", "on-unmatched", "", envOnUnmatched, string(prlabel.UnmatchedLabelPatch), "Action when the branch matches no prefix (label-patch, skip, fail)"),
+		exitOn:    bindStringFlag(fs, flagExitOn, flagExitOn, "", envExitOn, string(prlabel.ExitAlwaysZero), fmt.Sprintf("Exit code policy on success: always-zero, or changed to exit %d when no label was added", prlabel.NoopExitCode)),
+		settle:    bindStringFlag(fs, flagLabelSettle, flagLabelSettle, "", envLabelSettle, "", "Re-list labels after this delay until they stop changing before deciding (Go duration, e.g. 5s; empty disables)"),
+	}
+}
+
+// resolve reads the pull request to label, how its branch is matched, and the exit code policy.
+func (f *prLabelFlagSet) resolve(resolver config.Resolver) (prlabel.Config, prlabel.ExitPolicy, error) {
+	prID, err := f.prID.Value(resolver)
 	if err != nil {
-		return err
+		return prlabel.Config{}, "", err
+	}
+	if prID <= 0 {
+		return prlabel.Config{}, "", fmt.Errorf("pr-id must be greater than zero")
+	}
+	cfg := prlabel.Config{PRID: prID, Branch: f.branch.Value(resolver)}
+	if strings.TrimSpace(cfg.Branch) == "" {
+		return prlabel.Config{}, "", fmt.Errorf("source-branch is required")
 	}
 
-	log := runtime.logger.With(zap.String("commit", result.CommitSHA))
-	if result.PRID > 0 {
-		log = log.With(zap.Int("pr", result.PRID))
+	if cfg.MatchFullRef, err = f.fullRef.Value(resolver); err != nil {
+		return prlabel.Config{}, "", err
+	}
+	if cfg.OnUnmatched, err = prlabel.ParseUnmatchedPolicy(f.unmatched.Value(resolver)); err != nil {
+		return prlabel.Config{}, "", err
+	}
+	if cfg.LabelSettle, err = parseLabelSettle(f.settle.Value(resolver)); err != nil {
+		return prlabel.Config{}, "", err
+	}
+	exitPolicy, err := prlabel.ParseExitPolicy(f.exitOn.Value(resolver))
+	if err != nil {
+		return prlabel.Config{}, "", err
 	}
+	return cfg, exitPolicy, nil
+}
 
-	if result.Defaulted {
-		log.Warn("default bump applied", zap.String("bump", result.Bump.String()), zap.String("reason", string(result.DefaultReason)))
-	} else {
-		log.Info("bump inferred", zap.String("bump", result.Bump.String()))
+// logPRLabelResult reports the label decision and whether a label was added.
+func logPRLabelResult(logger *zap.Logger, cfg prlabel.Config, result prlabel.Result) {
+	log := logger.With(
+		zap.Int("pr", cfg.PRID),
+		zap.String("branch", cfg.Branch),
+		zap.String("bump", result.Bump.String()),
+		zap.Bool("branchMatched", result.BranchMatched),
+		zap.String("matchedPrefix", result.MatchedPrefix),
+	)
+	if result.SettleChanges > 0 {
+		log.Info("pull request labels changed while settling", zap.Int("changes", result.SettleChanges))
 	}
 
-	if len(result.SemverLabels) > 0 {
-		log.Debug("semver labels considered", zap.Strings("labels", result.SemverLabels))
+	switch {
+	case result.SkipReason != "":
+		log.Info("leaving pull request unlabeled", zap.String("reason", result.SkipReason))
+	case result.Decision == labels.DecisionAddExpected:
+		log.Info("adding semver label", zap.String("label", result.ExpectedLabel))
+	case result.Decision == labels.DecisionConflict:
+		log.Warn("conflicting semver labels detected", zap.String("expected", result.ExpectedLabel), zap.Strings("existing", result.ExistingSemver))
+	default:
+		log.Info("expected semver label already present", zap.String("label", result.ExpectedLabel))
 	}
 
-	if _, err := fmt.Fprintln(cmd.OutOrStdout(), result.Bump.String()); err != nil {
-		return fmt.Errorf("writing bump result: %w", err)
+	if result.LabelAdded {
+		log.Info("semver label added", zap.String("label", result.ExpectedLabel))
 	}
-	return nil
 }
 
-func newTagCommand(rootFlags *rootFlagSet) *cobra.Command {
+// parseLabelSettle reads --label-settle; empty disables the re-listing.
+func parseLabelSettle(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	settle, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", flagLabelSettle, err)
+	}
+	if settle < 0 {
+		return 0, fmt.Errorf("%s must not be negative", flagLabelSettle)
+	}
+	return settle, nil
+}
+
+type inferFlagSet struct {
+	commit         *stringFlag
+	strict         *boolFlag
+	strictConflict *boolFlag
+	conflict       *stringFlag
+	retries        *intFlag
+	delay          *stringFlag
+	requireOn      *stringFlag
+	envOut         *stringFlag
+	envAppend      *boolFlag
+	shellOut       *boolFlag
+	annotate       *boolFlag
+	title          *boolFlag
+	commitMsg      *boolFlag
+	failDefault    *boolFlag
+	sources        *stringFlag
+	combine        *stringFlag
+	aggregate      *boolFlag
+}
+
+func newInferCommand(rootFlags *rootFlagSet) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "create-tag",
-		Short: "Plan the next release or RC tag",
+		Use:   "infer-bump",
+		Short: "Infer bump intent from the merge commit's pull request labels",
 	}
 
-	tagFlags := bindTagFlags(cmd)
+	inferFlags := bindInferFlags(cmd)
 
 	cmd.RunE = func(cmd *cobra.Command, _ []string) error {
 		ctx := cmd.Context()
-		runtime, cleanup, err := buildRuntime(ctx, rootFlags)
-		if err != nil {
+		if err := inferFlags.validate(validationInputs(rootFlags)); err != nil {
 			return err
 		}
-		defer cleanup()
 
-		createCfg, err := tagFlags.resolve(runtime.resolver)
+		runtime, cleanup, err := buildRuntime(ctx, cmd, rootFlags)
 		if err != nil {
 			return err
 		}
+		defer cleanup()
 
-		tagPrefix := strings.TrimSpace(tagFlags.tagPrefix.Value(runtime.resolver))
-		planner := tagplan.NewPlanner(tagPrefix)
-		service := tagging.NewService(runtime.client, planner)
-		result, err := service.PlanAndCreate(ctx, createCfg)
+		inferCfg, err := inferFlags.resolve(runtime.resolver)
 		if err != nil {
 			return err
 		}
-
-		log := runtime.logger.With(
-			zap.String("mode", string(result.Mode)),
-			zap.String("tag", result.TagName),
-			zap.String("releaseBase", result.ReleaseBase.String()),
-			zap.String("baseSource", string(result.BaseSource)),
-			zap.String("targetRelease", result.TargetRelease.String()),
-			zap.String("commit", createCfg.CommitSHA),
-			zap.String("tagger", createCfg.TaggerName),
-		)
-		if createCfg.Message != "" {
-			log = log.With(zap.String("message", createCfg.Message))
-		}
-		if result.Mode == tagplan.ModeRC {
-			log = log.With(zap.Int("rcNumber", result.RCNumber))
-		}
-		if tagPrefix != "" {
-			log = log.With(zap.String("tagPrefix", tagPrefix))
+		outputs, err := inferFlags.outputs(runtime.resolver)
+		if err != nil {
+			return err
 		}
-		log.Info("annotated tag created")
-
-		if result.Mode == tagplan.ModeRelease {
-			f := result.Floating
-			switch {
-			case f.Enabled:
-				floatingLog := runtime.logger.With(zap.String("floatingTag", f.TagName))
-				if f.DeletedExisting {
-					floatingLog = floatingLog.With(zap.Bool("replaced", true))
-				}
-				if f.AutoDetected && !createCfg.UseFloatingTags {
-					floatingLog = floatingLog.With(
-						zap.Bool("autoEnabled", true),
-						zap.Uint64("detectedMajor", f.AutoDetectedMajor),
-					)
-				}
-				floatingLog.Info("floating tag updated")
-			case createCfg.UseFloatingTags:
-				runtime.logger.Warn("floating tag requested but not applied", zap.String("reason", "floating tags only apply to release mode"))
-			case f.AutoDetected:
-				runtime.logger.Info("floating tag usage detected", zap.Uint64("floatingMajor", f.AutoDetectedMajor))
-			}
+		if err := checkShellOut(outputs.shellOut, runtime.format); err != nil {
+			return err
 		}
 
-		if _, err := fmt.Fprintln(cmd.OutOrStdout(), result.TagName); err != nil {
-			return fmt.Errorf("writing tag result: %w", err)
+		if err := applyRepoConfig(ctx, &runtime, rootFlags, inferCfg.CommitSHA); err != nil {
+			return err
 		}
-		return nil
+
+		return runInferCommand(cmd, ctx, runtime, inferCfg, outputs)
 	}
 
 	return cmd
 }
 
-func bindTagFlags(cmd *cobra.Command) *tagFlagSet {
+func bindInferFlags(cmd *cobra.Command) *inferFlagSet {
 	fs := cmd.Flags()
-	return &tagFlagSet{
-		mode:        bindStringFlag(fs, flagTagMode, flagTagMode, "", envTagMode, "", "Tag mode to run (release or rc)"),
-		bump:        bindStringFlag(fs, flagBump, flagBump, "", envBump, "", "Bump intent (major, minor, patch)"),
-		base:        bindStringFlag(fs, flagBaseVersion, flagBaseVersion, "", envBaseVersion, "", "Optional base version to use when no releases exist"),
-		commit:      bindStringFlag(fs, flagCommitSHA, flagCommitSHA, "", envCommit, "", "Commit SHA the tag should reference"),
-		message:     bindStringFlag(fs, flagTagMessage, flagTagMessage, "", envTagMessage, "", "Message stored in the annotated tag"),
-		taggerName:  bindStringFlag(fs, flagTaggerName, flagTaggerName, "", envTaggerName, defaultTaggerName, "Name recorded as the tagger"),
-		taggerEmail: bindStringFlag(fs, flagTaggerEmail, flagTaggerEmail, "", envTaggerEmail, defaultTaggerEmail, "Email recorded as the tagger"),
-		tagPrefix:   bindStringFlag(fs, "tag-prefix", "tag-prefix", "", envTagPrefix, "", "String prepended to computed tag names (e.g. 'v')"),
-		useFloating: bindBoolFlag(fs, flagUseFloating, flagUseFloating, "", envUseFloatingTags, false, "Create/maintain floating major refs (v<major>)"),
+	f := &inferFlagSet{
+		commit:         bindStringFlag(fs, flagCommitSHA, flagCommitSHA, "", envCommit, "", "Merge commit SHA to inspect"),
+		strict:         bindBoolFlag(fs, "strict", "strict", "", envStrict, false, "Fail when the merge commit cannot be mapped to a pull request"),
+		strictConflict: bindBoolFlag(fs, flagStrictConflict, flagStrictConflict, "", envStrictConflict, false, "With --strict, also fail when the pull request has conflicting semver labels, whatever --conflict-bump says"),
+		retries:        bindIntFlag(fs, flagLookupRetries, flagLookupRetries, "", envLookupRetry, 0, "Extra PR lookups when the merge commit is not yet indexed (defaults to 3 with --strict)"),
+		delay:          bindStringFlag(fs, flagLookupDelay, flagLookupDelay, "", envLookupDelay, defaultLookupDelay, "Delay between PR lookup retries (Go duration, e.g. 2s)"),
+		requireOn:      bindStringFlag(fs, flagRequireOn, flagRequireOn, "", envRequireOn, "", "Require the merge commit to be reachable from this branch"),
+		envOut:         bindStringFlag(fs, flagEnvOut, flagEnvOut, "", envEnvOut, "", "Write the inferred bump as a dotenv file (AAV_BUMP, AAV_PR_ID, ...)"),
+		envAppend:      bindBoolFlag(fs, flagEnvAppend, flagEnvAppend, "", envEnvAppend, false, "Append to the --env-out file instead of overwriting it"),
+		shellOut:       bindBoolFlag(fs, flagShellOut, flagShellOut, "", envShellOut, false, "Print shell-quoted AAV_BUMP=... assignments to stdout for eval"),
+		annotate:       bindBoolFlag(fs, flagPipelineTags, flagPipelineTags, "", envPipelineTags, false, "Tag the Azure Pipelines run with the inferred bump (bump:minor); ignored outside a pipeline"),
+		conflict:       bindStringFlag(fs, flagConflictBump, flagConflictBump, "", envConflictBump, string(inferbump.ConflictMax), "Bump applied when semver labels conflict (max, min, error)"),
+		sources:        bindStringFlag(fs, flagInferSources, flagInferSources, "", envInferSources, "", "Ordered, comma-separated inference sources (labels, title, commit-message); overrides --infer-from-title and --commit-message-fallback"),
+		combine:        bindStringFlag(fs, flagInferCombine, flagInferCombine, "", envInferCombine, string(inferbump.CombineFirst), "How --infer-sources combine (first match wins, or max impact)"),
+		failDefault:    bindBoolFlag(fs, flagFailOnDefault, flagFailOnDefault, "", envFailDefault, false, "Fail whenever the default bump is applied (no pull request, no semver labels, or commit off --require-on-branch)"),
+		title:          bindBoolFlag(fs, "infer-from-title", "infer-from-title", "", envInferTitle, false, "Classify the PR title (feat:, fix:, feat!:) when it has no semver labels"),
+		commitMsg:      bindBoolFlag(fs, flagCommitMsg, flagCommitMsg, "", envCommitMsg, false, "Classify the merge commit message (feat:, fix:, feat!:, BREAKING CHANGE:) when the PR labels yield no bump"),
+		aggregate:      bindBoolFlag(fs, flagAggregatePRs, flagAggregatePRs, "", envAggregatePRs, false, "When several pull requests share the merge commit (e.g. cherry-picks), combine their semver labels and take the highest bump"),
 	}
+	completeValues(cmd, flagConflictBump, conflictCompletions...)
+	f.title.base.deprecate(inferTitleMigration)
+	return f
 }
 
-func (f *tagFlagSet) resolve(resolver config.Resolver) (tagging.CreateConfig, error) {
-	modeValue := strings.TrimSpace(strings.ToLower(f.mode.Value(resolver)))
-	if modeValue == "" {
-		return tagging.CreateConfig{}, fmt.Errorf(requiredFlagFormat, flagTagMode)
+// resolve reads the commit to inspect and how its bump is inferred.
+func (f *inferFlagSet) resolve(resolver config.Resolver) (inferbump.Config, error) {
+	cfg := inferbump.Config{
+		CommitSHA:       commitSHA(resolver, f.commit, true),
+		RequireOnBranch: strings.TrimSpace(f.requireOn.Value(resolver)),
 	}
-	mode, err := parseTagMode(modeValue)
-	if err != nil {
-		return tagging.CreateConfig{}, err
+	if cfg.CommitSHA == "" {
+		return inferbump.Config{}, fmt.Errorf(requiredFlagFormat, flagCommitSHA)
+	}
+
+	var err error
+	if cfg.Strict, err = f.strict.Value(resolver); err != nil {
+		return inferbump.Config{}, err
+	}
+	if cfg.StrictConflict, err = f.strictConflict.Value(resolver); err != nil {
+		return inferbump.Config{}, err
+	}
+	if cfg.ConflictPolicy, err = inferbump.ParseConflictPolicy(f.conflict.Value(resolver)); err != nil {
+		return inferbump.Config{}, err
+	}
+	if err := f.resolveSources(resolver, &cfg); err != nil {
+		return inferbump.Config{}, err
+	}
+	if err := f.resolveLookup(resolver, &cfg); err != nil {
+		return inferbump.Config{}, err
+	}
+	return cfg, nil
+}
+
+// resolveSources reads where the bump is inferred from and how the sources combine.
+func (f *inferFlagSet) resolveSources(resolver config.Resolver, cfg *inferbump.Config) error {
+	var err error
+	if cfg.InferFromTitle, err = f.title.Value(resolver); err != nil {
+		return err
+	}
+	if cfg.CommitMessageFallback, err = f.commitMsg.Value(resolver); err != nil {
+		return err
+	}
+	if cfg.FailOnDefault, err = f.failDefault.Value(resolver); err != nil {
+		return err
+	}
+	if cfg.Sources, err = inferbump.ParseSources(f.sources.Value(resolver)); err != nil {
+		return err
+	}
+	if cfg.Combine, err = inferbump.ParseCombineStrategy(f.combine.Value(resolver)); err != nil {
+		return err
 	}
+	cfg.AggregatePRs, err = f.aggregate.Value(resolver)
+	return err
+}
 
-	bumpValue := strings.TrimSpace(f.bump.Value(resolver))
-	if bumpValue == "" {
-		return tagging.CreateConfig{}, fmt.Errorf(requiredFlagFormat, flagBump)
+// resolveLookup reads how often the pull request lookup is retried; --strict raises the default
+// retry count. cfg.Strict must already be set.
+func (f *inferFlagSet) resolveLookup(resolver config.Resolver, cfg *inferbump.Config) error {
+	retries, err := f.retries.Value(resolver)
+	if err != nil {
+		return err
+	}
+	if retries < 0 {
+		return fmt.Errorf("%s must not be negative", flagLookupRetries)
 	}
-	bumpIntent, err := bump.Parse(bumpValue)
+	if cfg.Strict && !f.retries.base.explicit() {
+		retries = defaultStrictLookupRetries
+	}
+	cfg.LookupRetries = retries
+
+	cfg.LookupDelay, err = time.ParseDuration(strings.TrimSpace(f.delay.Value(resolver)))
 	if err != nil {
-		return tagging.CreateConfig{}, err
+		return fmt.Errorf("invalid %s: %w", flagLookupDelay, err)
 	}
+	return nil
+}
+
+// outputs reads the optional dotenv file, shell assignments, and pipeline run tags.
+func (f *inferFlagSet) outputs(resolver config.Resolver) (inferOutputs, error) {
+	outputs := inferOutputs{envPath: strings.TrimSpace(f.envOut.Value(resolver))}
+	var err error
+	if outputs.envAppend, err = f.envAppend.Value(resolver); err != nil {
+		return inferOutputs{}, err
+	}
+	if outputs.shellOut, err = f.shellOut.Value(resolver); err != nil {
+		return inferOutputs{}, err
+	}
+	if outputs.annotate, err = f.annotate.Value(resolver); err != nil {
+		return inferOutputs{}, err
+	}
+	return outputs, nil
+}
 
-	baseVersion := strings.TrimSpace(f.base.Value(resolver))
+// inferTitleMigration is the replacement for the deprecated --infer-from-title, which is the
+// same as listing both sources.
+const inferTitleMigration = "use --" + flagInferSources + " labels,title (" + envInferSources + ") instead"
+
+// inferOutputs describes the optional --env-out dotenv file, --shell-out assignments, and
+// --pipeline-annotate run tags.
+type inferOutputs struct {
+	envPath   string
+	envAppend bool
+	shellOut  bool
+	annotate  bool
+}
 
-	commit := strings.TrimSpace(f.commit.Value(resolver))
-	if commit == "" {
-		return tagging.CreateConfig{}, fmt.Errorf(requiredFlagFormat, flagCommitSHA)
+// checkShellOut rejects --shell-out with JSON output, since both claim stdout.
+func checkShellOut(shellOut bool, format output.Format) error {
+	if shellOut && format == output.FormatJSON {
+		return fmt.Errorf("%s cannot be combined with --%s %s", flagShellOut, flagOutput, output.FormatJSON)
 	}
+	return nil
+}
 
-	taggerName := strings.TrimSpace(f.taggerName.Value(resolver))
-	if taggerName == "" {
-		return tagging.CreateConfig{}, fmt.Errorf(requiredFlagFormat, flagTaggerName)
+func runInferCommand(cmd *cobra.Command, ctx context.Context, runtime runtimeConfig, inferCfg inferbump.Config, outputs inferOutputs) error {
+	service := inferbump.NewService(runtime.client, runtime.labels)
+	result, err := service.Resolve(ctx, inferCfg)
+	if err != nil {
+		return err
 	}
 
-	taggerEmail := strings.TrimSpace(f.taggerEmail.Value(resolver))
-	if taggerEmail == "" {
-		return tagging.CreateConfig{}, fmt.Errorf(requiredFlagFormat, flagTaggerEmail)
+	log := runtime.logger.With(zap.String("commit", result.CommitSHA))
+	if result.PRID > 0 {
+		log = log.With(zap.Int("pr", result.PRID))
+	}
+	if result.LookupAttempts > 1 {
+		log = log.With(zap.Int("lookupAttempts", result.LookupAttempts))
 	}
 
-	message := strings.TrimSpace(f.message.Value(resolver))
+	if result.Defaulted {
+		log.Warn("default bump applied", zap.String("bump", result.Bump.String()), zap.String("reason", string(result.DefaultReason)))
+	} else if result.DefaultReason == inferbump.DefaultReasonTitle {
+		log.Info("bump inferred from pull request title", zap.String("bump", result.Bump.String()), zap.String("title", result.Title))
+	} else if result.DefaultReason == inferbump.DefaultReasonConventionalCommit {
+		log.Info("bump inferred from merge commit message", zap.String("bump", result.Bump.String()))
+	} else {
+		log.Info("bump inferred", zap.String("bump", result.Bump.String()), zap.String("source", string(result.Source)))
+	}
 
-	useFloating := false
-	if f.useFloating != nil {
-		value, err := f.useFloating.Value(resolver)
-		if err != nil {
-			return tagging.CreateConfig{}, err
+	if result.Conflict {
+		log.Warn("conflicting semver labels resolved by policy",
+			zap.String("policy", string(result.ConflictPolicy)),
+			zap.Strings("labels", result.SemverLabels),
+		)
+	} else if len(result.SemverLabels) > 0 {
+		log.Debug("semver labels considered", zap.Strings("labels", result.SemverLabels))
+	}
+
+	if outputs.envPath != "" {
+		if err := output.WriteEnvFile(outputs.envPath, output.InferBumpEnv(result), outputs.envAppend); err != nil {
+			return fmt.Errorf("writing env output: %w", err)
 		}
-		useFloating = value
+		log.Debug("env output written", zap.String("path", outputs.envPath), zap.Bool("append", outputs.envAppend))
 	}
 
-	return tagging.CreateConfig{
-		Config: tagging.Config{
-			Mode:            mode,
-			Bump:            bumpIntent,
-			BaseVersion:     baseVersion,
-			UseFloatingTags: useFloating,
-		},
-		CommitSHA:   commit,
-		Message:     message,
-		TaggerName:  taggerName,
-		TaggerEmail: taggerEmail,
-	}, nil
+	if err := writeInferResult(cmd, runtime, result, outputs); err != nil {
+		return err
+	}
+	if outputs.annotate {
+		if err := annotatePipeline(cmd, runtime, output.InferBumpBuildTags(result)); err != nil {
+			return err
+		}
+	}
+	return writeSummary(cmd, runtime, output.InferBumpSummary(result))
+}
+
+func writeInferResult(cmd *cobra.Command, runtime runtimeConfig, result inferbump.Result, outputs inferOutputs) error {
+	if outputs.shellOut {
+		return output.WriteShellAssignments(cmd.OutOrStdout(), output.InferBumpEnv(result))
+	}
+	if runtime.format == output.FormatJSON {
+		payload := output.NewInferBumpResult(result)
+		payload.Operator = runtime.operator
+		return output.WriteJSON(cmd.OutOrStdout(), payload)
+	}
+	if err := output.WriteValue(cmd.OutOrStdout(), result.Bump.String(), !runtime.noNewline); err != nil {
+		return fmt.Errorf("writing bump result: %w", err)
+	}
+	return nil
+}
+
+// annotatePipeline adds tags to the Azure Pipelines run. The logging commands go to stderr so
+// stdout stays parseable; outside a pipeline nothing is printed.
+func annotatePipeline(cmd *cobra.Command, runtime runtimeConfig, tags []string) error {
+	if !output.InAzurePipelines(os.Getenv) {
+		runtime.logger.Debug("not running in Azure Pipelines; run tags skipped", zap.Strings("tags", tags))
+		return nil
+	}
+	return output.WriteBuildTags(cmd.ErrOrStderr(), tags)
 }
 
-func buildRuntime(ctx context.Context, flags *rootFlagSet) (runtimeConfig, func(), error) {
+// annotateGitHub prints workflow annotations when running under GitHub Actions, which parses
+// workflow commands on stderr as well as stdout; elsewhere nothing is printed.
+func annotateGitHub(cmd *cobra.Command, annotations []output.Annotation) error {
+	if len(annotations) == 0 || !output.InGitHubActions(os.Getenv) {
+		return nil
+	}
+	return output.WriteAnnotations(cmd.ErrOrStderr(), annotations)
+}
+
+// writeSummary prints the end-of-run summary line to stderr unless --quiet is set.
+func writeSummary(cmd *cobra.Command, runtime runtimeConfig, line string) error {
+	if runtime.quiet {
+		return nil
+	}
+	if _, err := fmt.Fprintln(cmd.ErrOrStderr(), output.WithRepoName(line, runtime.repoName)); err != nil {
+		return fmt.Errorf("writing summary: %w", err)
+	}
+	return nil
+}
+
+func buildRuntime(ctx context.Context, cmd *cobra.Command, flags *rootFlagSet) (runtimeConfig, func(), error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
-	nopResolver := config.NewResolver(zap.NewNop())
-	logLevel := flags.logLevel.Value(nopResolver)
-
-	logger, err := logging.New(logLevel)
+	logger, fieldMap, operator, err := flags.logger(cmd)
 	if err != nil {
-		return runtimeConfig{}, nil, fmt.Errorf("configuring logger: %w", err)
+		return runtimeConfig{}, nil, err
 	}
 
 	resolver := config.NewResolver(logger)
 	_ = flags.logLevel.Value(resolver)
+	_ = flags.logFields.Value(resolver)
+	_ = flags.operator.Value(resolver)
 
-	orgURL := strings.TrimSpace(flags.orgURL.Value(resolver))
-	if orgURL == "" {
-		return runtimeConfig{}, nil, fmt.Errorf("org-url is required (set %s or --org-url)", envOrgURL)
+	clientCfg, repoName, err := flags.connection(resolver)
+	if err != nil {
+		return runtimeConfig{}, nil, err
 	}
+	// The display name only labels output; the client is always built from the repository,
+	// which may be a GUID.
+	logName := repoName
+	if logName == "" {
+		logName = clientCfg.Repository
+	}
+	logger = logging.WithRepo(logger, logName)
+	clientCfg.Logger = logger
 
-	project := strings.TrimSpace(flags.project.Value(resolver))
-	if project == "" {
-		return runtimeConfig{}, nil, fmt.Errorf("project is required (set %s or --project)", envProject)
+	runtime, err := flags.settings(resolver)
+	if err != nil {
+		return runtimeConfig{}, nil, err
 	}
 
-	repo := strings.TrimSpace(flags.repo.Value(resolver))
-	if repo == "" {
-		return runtimeConfig{}, nil, fmt.Errorf("repo is required (set %s or --repo)", envRepo)
+	traceEnabled, err := flags.traceAPI.Value(resolver)
+	if err != nil {
+		return runtimeConfig{}, nil, err
+	}
+	if traceEnabled {
+		// Traces are debug lines; emit them even when the main logger is terse.
+		tracer, err := logging.New(logging.LevelVerbose)
+		if err != nil {
+			return runtimeConfig{}, nil, fmt.Errorf("configuring trace logger: %w", err)
+		}
+		clientCfg.Trace = logging.WithRepo(runLogger(tracer.Named("ado"), cmd, fieldMap, operator), logName)
+	}
+	if err := flags.clientCaches(resolver, &clientCfg); err != nil {
+		return runtimeConfig{}, nil, err
 	}
 
-	token := strings.TrimSpace(flags.token.Value(resolver))
-	if token == "" {
-		return runtimeConfig{}, nil, fmt.Errorf("token is required (set %s or --token)", envToken)
-	}
-
-	labelResolver := labels.NewResolver(labels.Config{
-		Prefix:     flags.labelPref.Value(resolver),
-		MajorLabel: flags.labelMajor.Value(resolver),
-		MinorLabel: flags.labelMinor.Value(resolver),
-		PatchLabel: flags.labelPatch.Value(resolver),
-	})
-
-	branchResolver := branchmap.NewResolver(branchmap.Mapping{
-		MajorPrefixes: flags.branchMaj.Value(resolver),
-		MinorPrefixes: flags.branchMin.Value(resolver),
-		PatchPrefixes: flags.branchPatch.Value(resolver),
-	})
-
-	client, err := ado.NewClient(ctx, ado.Config{
-		OrganizationURL: orgURL,
-		Project:         project,
-		Repository:      repo,
-		Token:           token,
-	})
+	runtime.labels, runtime.branches = buildResolvers(flags, resolver, config.RepoFile{})
+	if err := checkBranchMapping(logger, runtime.branches, runtime.strictPrefixes); err != nil {
+		return runtimeConfig{}, nil, err
+	}
+
+	client, err := ado.NewClient(ctx, clientCfg)
 	if err != nil {
 		return runtimeConfig{}, nil, err
 	}
 
+	var eventLog *events.Log
+	if path := strings.TrimSpace(flags.eventLog.Value(resolver)); path != "" {
+		eventLog, err = events.Open(path)
+		if err != nil {
+			return runtimeConfig{}, nil, err
+		}
+	}
+
 	cleanup := func() {
+		if err := eventLog.Close(); err != nil {
+			logger.Warn("event log incomplete", zap.Error(err))
+		}
 		_ = logger.Sync()
+		if clientCfg.Trace != nil {
+			_ = clientCfg.Trace.Sync()
+		}
 	}
 
-	return runtimeConfig{
-		resolver: resolver,
-		logger:   logger,
-		client:   client,
-		branches: branchResolver,
-		labels:   labelResolver,
-	}, cleanup, nil
+	runtime.resolver = resolver
+	runtime.logger = logger
+	runtime.client = client
+	runtime.repoName = repoName
+	runtime.operator = operator
+	runtime.events = eventLog
+	return runtime, cleanup, nil
 }
 
-func parseTagMode(value string) (tagplan.Mode, error) {
-	switch strings.ToLower(value) {
-	case string(tagplan.ModeRelease):
-		return tagplan.ModeRelease, nil
-	case string(tagplan.ModeRC):
-		return tagplan.ModeRC, nil
-	default:
-		return "", fmt.Errorf("invalid tag mode %q", value)
+// logger builds the run's logger from --log-level, --log-field-map, and --operator, which
+// are read quietly because the resolver that records settings needs this logger first.
+func (f *rootFlagSet) logger(cmd *cobra.Command) (*zap.Logger, map[string]string, string, error) {
+	nopResolver := config.NewResolver(zap.NewNop())
+	fieldMap, err := logging.ParseFieldMap(f.logFields.Value(nopResolver))
+	if err != nil {
+		return nil, nil, "", err
+	}
+	logger, err := logging.New(f.logLevel.Value(nopResolver))
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("configuring logger: %w", err)
+	}
+	operator := strings.TrimSpace(f.operator.Value(nopResolver))
+	if operator == "" {
+		operator = logging.DetectOperator(os.Getenv)
+	}
+	return runLogger(logger, cmd, fieldMap, operator), fieldMap, operator, nil
+}
+
+// connection reads the organization, project, repository, and token the client connects with,
+// and the --repo-display-name shown in place of the repository.
+func (f *rootFlagSet) connection(resolver config.Resolver) (ado.Config, string, error) {
+	orgURL := strings.TrimSpace(f.orgURL.Value(resolver))
+	if orgURL == "" {
+		return ado.Config{}, "", fmt.Errorf("org-url is required (set %s or --org-url)", envOrgURL)
+	}
+	project := strings.TrimSpace(f.project.Value(resolver))
+	if project == "" {
+		return ado.Config{}, "", fmt.Errorf("project is required (set %s or --project)", envProject)
+	}
+	repo := strings.TrimSpace(f.repo.Value(resolver))
+	if repo == "" {
+		return ado.Config{}, "", fmt.Errorf("repo is required (set %s or --repo)", envRepo)
+	}
+	repoName := strings.TrimSpace(f.repoName.Value(resolver))
+	token := strings.TrimSpace(f.token.Value(resolver))
+	if token == "" {
+		return ado.Config{}, "", fmt.Errorf("token is required (set %s or --token)", envToken)
 	}
+	return ado.Config{OrganizationURL: orgURL, Project: project, Repository: repo, Token: token}, repoName, nil
+}
+
+// settings reads the root flags every command applies after connecting: the output format and
+// summary, preflight, repo config, branch prefix strictness, and protected tags.
+func (f *rootFlagSet) settings(resolver config.Resolver) (runtimeConfig, error) {
+	runtime := runtimeConfig{repoConfig: strings.TrimSpace(f.repoConfig.Value(resolver))}
+	var err error
+	if runtime.format, err = output.ParseFormat(f.output.Value(resolver)); err != nil {
+		return runtimeConfig{}, err
+	}
+	if runtime.preflight, err = f.preflight.Value(resolver); err != nil {
+		return runtimeConfig{}, err
+	}
+	if runtime.quiet, err = f.quiet.Value(resolver); err != nil {
+		return runtimeConfig{}, err
+	}
+	if runtime.noNewline, err = f.noNewline.Value(resolver); err != nil {
+		return runtimeConfig{}, err
+	}
+	if runtime.strictPrefixes, err = f.strictPref.Value(resolver); err != nil {
+		return runtimeConfig{}, err
+	}
+	if runtime.protectedTags, err = tagging.ParseProtectedTags(f.protected.Value(resolver)); err != nil {
+		return runtimeConfig{}, err
+	}
+	return runtime, nil
+}
+
+// clientCaches reads --ref-cache-ttl and --ancestry-cache into the client config.
+func (f *rootFlagSet) clientCaches(resolver config.Resolver, clientCfg *ado.Config) error {
+	var err error
+	if clientCfg.RefCacheTTL, err = parseRefCacheTTL(f.refCache.Value(resolver)); err != nil {
+		return err
+	}
+	clientCfg.AncestryCache, err = f.ancestry.Value(resolver)
+	return err
+}
+
+// parseRefCacheTTL reads --ref-cache-ttl; empty or zero leaves ref listings uncached.
+func parseRefCacheTTL(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %w", flagRefCacheTTL, err)
+	}
+	if ttl < 0 {
+		return 0, fmt.Errorf("%s must not be negative", flagRefCacheTTL)
+	}
+	return ttl, nil
+}
+
+// runLogger attaches the fields every log line of a run carries: the --log-fields renames, the
+// operator, and the subcommand name.
+func runLogger(logger *zap.Logger, cmd *cobra.Command, fieldMap map[string]string, operator string) *zap.Logger {
+	logger = logging.WithOperator(logging.WithFieldMap(logger, fieldMap), operator)
+	return logging.WithCommand(logger, commandName(cmd))
+}
+
+// commandName returns cmd's path below the root command, such as "create-tag", for the log
+// command field.
+func commandName(cmd *cobra.Command) string {
+	return strings.TrimSpace(strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()))
+}
+
+// checkBranchMapping reports prefixes that can never match because a higher bump level claims
+// them first, failing under --strict-branch-prefixes.
+func checkBranchMapping(logger *zap.Logger, branches branchmap.Resolver, strict bool) error {
+	for _, shadowed := range branches.Shadowed() {
+		if strict {
+			return fmt.Errorf("%w: %s", branchmap.ErrShadowedPrefix, shadowed)
+		}
+		logger.Warn("branch prefix is unreachable",
+			zap.String("prefix", shadowed.Prefix),
+			zap.String("level", shadowed.Level.String()),
+			zap.String("shadowedBy", shadowed.By),
+			zap.String("precedence", shadowed.ByLevel.String()),
+		)
+	}
+	return nil
+}
+
+// runPreflight verifies the token holds the listed permissions when --preflight is enabled.
+func runPreflight(ctx context.Context, runtime runtimeConfig, required ...ado.Permission) error {
+	if !runtime.preflight {
+		return nil
+	}
+	if err := preflight.NewService(runtime.client).Check(ctx, required...); err != nil {
+		return err
+	}
+	runtime.logger.Debug("preflight permission check passed", zap.Int("permissions", len(required)))
+	return nil
 }
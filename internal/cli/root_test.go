@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/logging"
+)
+
+func TestRunLoggerCarriesCommand(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{args: []string{"infer-bump"}, want: "infer-bump"},
+		{args: []string{"create-tag", "--tag-mode", "rc"}, want: "create-tag"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.want, func(t *testing.T) {
+			t.Parallel()
+
+			cmd, _, err := newRootCommand().Find(tc.args)
+			if err != nil {
+				t.Fatalf("find %v: %v", tc.args, err)
+			}
+			core, logs := observer.New(zap.DebugLevel)
+			runLogger(zap.New(core), cmd, nil, "ci-bot").Info("bump inferred")
+
+			entries := logs.All()
+			if len(entries) != 1 {
+				t.Fatalf("expected one entry, got %d", len(entries))
+			}
+			fields := entries[0].ContextMap()
+			if fields[logging.CommandField] != tc.want {
+				t.Fatalf("expected %s=%s, got %v", logging.CommandField, tc.want, fields)
+			}
+			if len(fields) != 2 {
+				t.Fatalf("expected the command and operator fields, got %v", fields)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/output"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/tagging"
+)
+
+func newStaleRCCommand(rootFlags *rootFlagSet) *cobra.Command {
+	var prefixFlag *stringFlag
+	var separatorFlag *stringFlag
+	var componentFlag *stringFlag
+	var deleteFlag *boolFlag
+	var dryRunFlag *boolFlag
+
+	cmd := &cobra.Command{
+		Use:         "list-stale-rc",
+		Short:       "List prerelease tags whose target release already exists",
+		Annotations: tabularOutput,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			runtime, cleanup, err := buildRuntime(ctx, cmd, rootFlags)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			deleteStale, err := deleteFlag.Value(runtime.resolver)
+			if err != nil {
+				return err
+			}
+			dryRun, err := dryRunFlag.Value(runtime.resolver)
+			if err != nil {
+				return err
+			}
+
+			// Deleting tags is gated behind Force push in Azure DevOps.
+			if deleteStale && !dryRun {
+				if err := runPreflight(ctx, runtime, ado.PermissionForcePush); err != nil {
+					return err
+				}
+			}
+
+			planner := tagplan.NewPlanner(strings.TrimSpace(prefixFlag.Value(runtime.resolver))).
+				WithPrefixSeparator(separatorFlag.Value(runtime.resolver)).
+				WithComponent(componentFlag.Value(runtime.resolver))
+			report, err := tagging.NewService(runtime.client, planner).WithProtectedTags(runtime.protectedTags).ListStaleRCs(ctx, tagging.StaleRCConfig{
+				DeleteStale: deleteStale,
+				DryRun:      dryRun,
+			})
+			if err != nil {
+				return err
+			}
+			result := output.NewStaleRCResult(report)
+
+			log := runtime.logger.With(
+				zap.Int("prereleases", len(result.Prereleases)),
+				zap.Int("stale", result.StaleCount),
+			)
+			switch {
+			case deleteStale && dryRun:
+				log.Info("dry run: stale prerelease tags not deleted", zap.Strings("wouldDelete", report.Deleted))
+			case deleteStale:
+				log.Info("stale prerelease tags deleted", zap.Strings("deleted", report.Deleted))
+			default:
+				log.Info("prerelease tags listed")
+			}
+
+			result.Operator = runtime.operator
+			if runtime.format == output.FormatJSON {
+				return output.WriteJSON(cmd.OutOrStdout(), result)
+			}
+			if runtime.format.Tabular() {
+				return output.WriteTable(cmd.OutOrStdout(), runtime.format, output.StaleRCTable(result))
+			}
+			return output.WriteStaleRCs(cmd.OutOrStdout(), result)
+		},
+	}
+
+	fs := cmd.Flags()
+	prefixFlag = bindStringFlag(fs, "tag-prefix", "tag-prefix", "", envTagPrefix, "", "Prefix of the tag names to inspect (e.g. 'v')")
+	separatorFlag = bindStringFlag(fs, flagPrefixSep, flagPrefixSep, "", envPrefixSep, "", "Separator between --tag-prefix and the version")
+	componentFlag = bindStringFlag(fs, flagComponent, flagComponent, "", envComponent, "", "Monorepo component whose tags live under '<component>/'")
+	deleteFlag = bindBoolFlag(fs, flagDeleteStale, flagDeleteStale, "", envDeleteStale, false, "Delete prerelease tags whose target release already exists")
+	dryRunFlag = bindBoolFlag(fs, flagDryRun, flagDryRun, "", envDryRun, false, "With --delete-stale, report the tags that would be deleted without removing them")
+
+	return cmd
+}
@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/config"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/output"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/tagging"
+)
+
+// validationInputs returns a quiet resolver and the requested output format so flag
+// combinations can be checked before buildRuntime connects to Azure DevOps. An invalid format
+// is left for buildRuntime to report.
+func validationInputs(flags *rootFlagSet) (config.Resolver, output.Format) {
+	resolver := config.NewResolver(zap.NewNop())
+	format, err := output.ParseFormat(flags.output.Value(resolver))
+	if err != nil {
+		return resolver, output.FormatText
+	}
+	return resolver, format
+}
+
+// validate checks create-tag's cross-flag invariants up front and reports every violation in
+// one error. Single-flag parsing errors are left to resolve and runOptions.
+func (f *tagFlagSet) validate(resolver config.Resolver, format output.Format) error {
+	var problems config.Problems
+
+	mode := strings.ToLower(strings.TrimSpace(f.mode.Value(resolver)))
+	bumpValue := strings.ToLower(strings.TrimSpace(f.bump.Value(resolver)))
+	hotfix := strings.TrimSpace(f.hotfixBase.Value(resolver)) != ""
+	fromTag := strings.TrimSpace(f.fromTag.Value(resolver)) != ""
+
+	problems.Conflict(hotfix, flagHotfixBase, mode == string(tagplan.ModeRC), flagTagMode+" "+string(tagplan.ModeRC))
+	problems.Addf(hotfix && bumpValue != "" && bumpValue != string(bump.BumpPatch), "--%s forces --%s patch, got %q", flagHotfixBase, flagBump, bumpValue)
+	versionSource := strings.TrimSpace(f.verSource.Value(resolver)) != ""
+	problems.Conflict(versionSource, flagVersionSource, hotfix, flagHotfixBase)
+	problems.Conflict(versionSource, flagVersionSource, f.base.base.explicit(), flagBaseVersion)
+	problems.Conflict(strings.TrimSpace(f.rcMinAge.Value(resolver)) != "", flagRCMinAge, mode == string(tagplan.ModeRC), flagTagMode+" "+string(tagplan.ModeRC))
+	problems.Conflict(f.maxScan.base.explicit(), flagMaxTagsScan, strings.TrimSpace(f.verRange.Value(resolver)) != "", flagVersionRange)
+	problems.Conflict(boolSet(f.requireBump, resolver), flagRequireBump, f.defBump.base.explicit(), flagDefaultBump)
+
+	problems.Requires(strings.TrimSpace(f.tagName.Value(resolver)) != "", flagTagName, fromTag, flagFromTag)
+	problems.Conflict(fromTag, flagFromTag, hotfix, flagHotfixBase)
+	problems.Conflict(fromTag, flagFromTag, f.prID.base.explicit(), flagPRID)
+	problems.Conflict(fromTag, flagFromTag, boolSet(f.useFloating, resolver), flagUseFloating)
+	problems.Conflict(fromTag, flagFromTag, boolSet(f.planOnly, resolver), flagPlanOnly)
+
+	skipCIMarker := strings.EqualFold(strings.TrimSpace(f.skipCI.Value(resolver)), string(tagging.FloatingSkipCIMarker))
+	floatLightweight := f.floatKind.base.explicit() && strings.EqualFold(strings.TrimSpace(f.floatKind.Value(resolver)), string(tagging.TagKindLightweight))
+	problems.Conflict(skipCIMarker, flagFloatingSkipCI+" marker", floatLightweight, flagFloatingKind+" lightweight")
+
+	problems.Conflict(boolSet(f.shellOut, resolver), flagShellOut, format == output.FormatJSON, flagOutput+" "+string(output.FormatJSON))
+
+	return problems.Err()
+}
+
+// validate checks infer-bump's cross-flag invariants, reporting every violation at once.
+func (f *inferFlagSet) validate(resolver config.Resolver, format output.Format) error {
+	var problems config.Problems
+	problems.Requires(boolSet(f.strictConflict, resolver), flagStrictConflict, boolSet(f.strict, resolver), "strict")
+	problems.Requires(boolSet(f.envAppend, resolver), flagEnvAppend, strings.TrimSpace(f.envOut.Value(resolver)) != "", flagEnvOut)
+	problems.Conflict(boolSet(f.shellOut, resolver), flagShellOut, format == output.FormatJSON, flagOutput+" "+string(output.FormatJSON))
+	return problems.Err()
+}
+
+// boolSet reports whether a boolean flag resolves to true; unparsable values count as unset and
+// are reported when the flag is read for use.
+func boolSet(flag *boolFlag, resolver config.Resolver) bool {
+	value, err := flag.Value(resolver)
+	return err == nil && value
+}
@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/config"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/output"
+)
+
+func newValidateBumpCommand(rootFlags *rootFlagSet) *cobra.Command {
+	var branchFlag *stringFlag
+	var bumpFlag *stringFlag
+	var toleranceFlag *intFlag
+
+	cmd := &cobra.Command{
+		Use:   "validate-bump",
+		Short: "Check that a bump matches the one the branch name implies",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			// Like doctor, the check needs only the branch mapping, so it runs without
+			// credentials and never calls Azure DevOps.
+			resolver := config.NewResolver(zap.NewNop())
+			format, err := output.ParseFormat(rootFlags.output.Value(resolver))
+			if err != nil {
+				return err
+			}
+
+			branch := strings.TrimPrefix(strings.TrimSpace(branchFlag.Value(resolver)), "refs/heads/")
+			if branch == "" {
+				return fmt.Errorf(requiredFlagFormat, flagBranch)
+			}
+			proposed, err := bump.Parse(strings.ToLower(strings.TrimSpace(bumpFlag.Value(resolver))))
+			if err != nil {
+				return fmt.Errorf("%s: %w", flagBump, err)
+			}
+			tolerance, err := toleranceFlag.Value(resolver)
+			if err != nil {
+				return err
+			}
+			if tolerance < 0 {
+				return fmt.Errorf("%s must not be negative", flagBumpTolerance)
+			}
+			noNewline, err := rootFlags.noNewline.Value(resolver)
+			if err != nil {
+				return err
+			}
+
+			_, branches := buildResolvers(rootFlags, resolver, config.RepoFile{})
+			check := branches.CheckBump(branch, proposed, tolerance)
+
+			if format == output.FormatJSON {
+				err = output.WriteJSON(cmd.OutOrStdout(), output.NewBumpCheckResult(check))
+			} else {
+				err = output.WriteValue(cmd.OutOrStdout(), check.Implied.String(), !noNewline)
+			}
+			if err != nil {
+				return err
+			}
+			return check.Err()
+		},
+	}
+
+	fs := cmd.Flags()
+	branchFlag = bindStringFlag(fs, flagBranch, flagBranch, "", envSourceBranch, "", "Branch whose implied bump is checked (e.g. feature/login)")
+	bumpFlag = bindStringFlag(fs, flagBump, flagBump, "", envBump, "", "Proposed bump (major, minor, patch, none)")
+	toleranceFlag = bindIntFlag(fs, flagBumpTolerance, flagBumpTolerance, "", envBumpTolerance, 0, "Levels the proposed bump may differ from the implied one (1 accepts minor on a patch branch)")
+	completeValues(cmd, flagBump, bumpCompletions...)
+
+	return cmd
+}
@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/output"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/tagging"
+)
+
+func newVerifyFloatingCommand(rootFlags *rootFlagSet) *cobra.Command {
+	var noSkipFlag *boolFlag
+	var prefixFlag *stringFlag
+	var separatorFlag *stringFlag
+	var componentFlag *stringFlag
+
+	cmd := &cobra.Command{
+		Use:   "verify-floating",
+		Short: "Check that floating major tags match the released majors without gaps",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			runtime, cleanup, err := buildRuntime(ctx, cmd, rootFlags)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			noSkip, err := noSkipFlag.Value(runtime.resolver)
+			if err != nil {
+				return err
+			}
+
+			planner := tagplan.NewPlanner(strings.TrimSpace(prefixFlag.Value(runtime.resolver))).
+				WithPrefixSeparator(separatorFlag.Value(runtime.resolver)).
+				WithComponent(componentFlag.Value(runtime.resolver))
+			majors, err := tagging.NewService(runtime.client, planner).VerifyFloatingMajors(ctx)
+			if err != nil {
+				return err
+			}
+
+			log := runtime.logger.With(
+				zap.Uint64s("floatingMajors", majors.Majors),
+				zap.Uint64("highestMajor", majors.HighestMajor),
+			)
+			switch {
+			case len(majors.Majors) == 0:
+				log.Info("no floating major tags found")
+			case majors.Contiguous():
+				log.Info("floating majors contiguous")
+			default:
+				log.Warn("floating majors do not match releases",
+					zap.Uint64s("missing", majors.Missing),
+					zap.Uint64s("extra", majors.Extra),
+				)
+			}
+
+			if runtime.format == output.FormatJSON {
+				payload := output.NewFloatingMajorsResult(majors)
+				payload.Operator = runtime.operator
+				err = output.WriteJSON(cmd.OutOrStdout(), payload)
+			} else {
+				err = output.WriteFloatingMajors(cmd.OutOrStdout(), majors)
+			}
+			if err != nil {
+				return err
+			}
+
+			if noSkip && !majors.Contiguous() {
+				return fmt.Errorf("floating majors are not contiguous: %s", formatFloatingViolations(majors))
+			}
+			return nil
+		},
+	}
+
+	fs := cmd.Flags()
+	noSkipFlag = bindBoolFlag(fs, "no-skip", "no-skip", "", envNoSkip, false, "Exit non-zero when floating majors are missing or have no release")
+	prefixFlag = bindStringFlag(fs, "tag-prefix", "tag-prefix", "", envTagPrefix, "", "Prefix of the tag names to inspect (e.g. 'v')")
+	separatorFlag = bindStringFlag(fs, flagPrefixSep, flagPrefixSep, "", envPrefixSep, "", "Separator between --tag-prefix and the version")
+	componentFlag = bindStringFlag(fs, flagComponent, flagComponent, "", envComponent, "", "Monorepo component whose tags live under '<component>/'")
+
+	return cmd
+}
+
+// formatFloatingViolations renders missing and extra majors as "missing v2; extra v4".
+func formatFloatingViolations(majors tagplan.FloatingMajors) string {
+	var parts []string
+	if len(majors.Missing) > 0 {
+		parts = append(parts, "missing "+formatMajors(majors.Missing))
+	}
+	if len(majors.Extra) > 0 {
+		parts = append(parts, "extra "+formatMajors(majors.Extra))
+	}
+	return strings.Join(parts, "; ")
+}
+
+func formatMajors(majors []uint64) string {
+	parts := make([]string, 0, len(majors))
+	for _, major := range majors {
+		parts = append(parts, fmt.Sprintf("v%d", major))
+	}
+	return strings.Join(parts, ", ")
+}
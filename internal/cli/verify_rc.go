@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/config"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/output"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/tagging"
+)
+
+func newVerifyRCCommand(rootFlags *rootFlagSet) *cobra.Command {
+	var targetFlag *stringFlag
+	var strictFlag *boolFlag
+	var prefixFlag *stringFlag
+	var separatorFlag *stringFlag
+	var componentFlag *stringFlag
+	var preLabelFlag *stringFlag
+	var channelFlag *stringFlag
+
+	cmd := &cobra.Command{
+		Use:   "verify-rc",
+		Short: "Check that a release's RC tags run from rc.1 without gaps",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+			runtime, cleanup, err := buildRuntime(ctx, cmd, rootFlags)
+			if err != nil {
+				return err
+			}
+			defer cleanup()
+
+			target := strings.TrimSpace(targetFlag.Value(runtime.resolver))
+			if target == "" {
+				return fmt.Errorf(requiredFlagFormat, flagRCTarget)
+			}
+			strict, err := strictFlag.Value(runtime.resolver)
+			if err != nil {
+				return err
+			}
+			label, err := verifyRCLabel(runtime.resolver, preLabelFlag, channelFlag)
+			if err != nil {
+				return err
+			}
+
+			planner := tagplan.NewPlanner(strings.TrimSpace(prefixFlag.Value(runtime.resolver))).
+				WithPrefixSeparator(separatorFlag.Value(runtime.resolver)).
+				WithComponent(componentFlag.Value(runtime.resolver)).
+				WithPrereleaseLabel(label)
+			sequence, err := tagging.NewService(runtime.client, planner).VerifyRCSequence(ctx, target)
+			if err != nil {
+				return err
+			}
+
+			log := runtime.logger.With(
+				zap.String("target", sequence.Target.String()),
+				zap.Ints("rcs", sequence.Numbers),
+			)
+			switch {
+			case len(sequence.Numbers) == 0:
+				log.Info("no rc tags found for target")
+			case sequence.Continuous():
+				log.Info("rc sequence continuous")
+			default:
+				log.Warn("rc sequence has gaps", zap.Ints("missing", sequence.Missing))
+			}
+
+			if runtime.format == output.FormatJSON {
+				payload := output.NewRCSequenceResult(sequence)
+				payload.Operator = runtime.operator
+				err = output.WriteJSON(cmd.OutOrStdout(), payload)
+			} else {
+				err = output.WriteRCSequence(cmd.OutOrStdout(), sequence)
+			}
+			if err != nil {
+				return err
+			}
+
+			if strict && !sequence.Continuous() {
+				return fmt.Errorf("rc sequence for %s is missing %s", sequence.Target, formatRCNumbers(sequence.Label, sequence.Missing))
+			}
+			return nil
+		},
+	}
+
+	fs := cmd.Flags()
+	targetFlag = bindStringFlag(fs, flagRCTarget, flagRCTarget, "", envRCTarget, "", "Release version whose RC tags are checked (e.g. 2.1.0)")
+	strictFlag = bindBoolFlag(fs, "strict", "strict", "", envRCStrict, false, "Exit non-zero when RC numbers are missing")
+	prefixFlag = bindStringFlag(fs, "tag-prefix", "tag-prefix", "", envTagPrefix, "", "Prefix of the tag names to inspect (e.g. 'v')")
+	separatorFlag = bindStringFlag(fs, flagPrefixSep, flagPrefixSep, "", envPrefixSep, "", "Separator between --tag-prefix and the version")
+	componentFlag = bindStringFlag(fs, flagComponent, flagComponent, "", envComponent, "", "Monorepo component whose tags live under '<component>/'")
+	preLabelFlag = bindStringFlag(fs, flagPreLabel, flagPreLabel, "", envPreLabel, tagplan.DefaultPrereleaseLabel, "Prerelease channel whose numbering is checked (e.g. alpha, beta)")
+	channelFlag = bindStringFlag(fs, flagPreChannel, flagPreChannel, "", envPreChannel, "", "Alias of --"+flagPreLabel+" that selects the channel per run")
+
+	return cmd
+}
+
+// verifyRCLabel picks the channel to check: --prerelease-channel when set, otherwise
+// --prerelease-label.
+func verifyRCLabel(resolver config.Resolver, labelFlag, channelFlag *stringFlag) (string, error) {
+	label, err := prereleaseChannel(resolver, labelFlag, channelFlag)
+	if err != nil {
+		return "", err
+	}
+	if label == "" {
+		label = labelFlag.Value(resolver)
+	}
+	return tagplan.ParsePrereleaseLabel(label)
+}
+
+// formatRCNumbers renders missing numbers on the label channel as "rc.1, rc.3".
+func formatRCNumbers(label string, numbers []int) string {
+	parts := make([]string, 0, len(numbers))
+	for _, number := range numbers {
+		parts = append(parts, fmt.Sprintf("%s.%d", label, number))
+	}
+	return strings.Join(parts, ", ")
+}
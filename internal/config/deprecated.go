@@ -0,0 +1,27 @@
+package config
+
+import (
+	"os"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// Deprecated warns when a setting kept only for compatibility is supplied through envKey or
+// the CLI, naming the replacement in migration. It reports whether the setting was supplied;
+// the value itself still resolves as usual.
+func (r Resolver) Deprecated(setting, envKey string, cliSet bool, migration string) bool {
+	envVal, envSet := os.LookupEnv(envKey)
+	envSet = envSet && strings.TrimSpace(envVal) != ""
+	if !envSet && !cliSet {
+		return false
+	}
+	if r.logger != nil {
+		r.logger.Warn(
+			"config: "+setting+" is deprecated",
+			zap.String("source", sourceOf(envSet, cliSet)),
+			zap.String("migration", migration),
+		)
+	}
+	return true
+}
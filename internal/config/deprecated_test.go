@@ -0,0 +1,63 @@
+package config
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestResolverDeprecated(t *testing.T) {
+	const envKey = "TEST_DEPRECATED_ENV"
+
+	tests := []struct {
+		name     string
+		env      string
+		cliSet   bool
+		cliVal   bool
+		source   string
+		supplied bool
+		expected bool
+	}{
+		{name: "unused", expected: false},
+		{name: "flag", cliSet: true, cliVal: true, source: SourceFlag, supplied: true, expected: true},
+		{name: "env", env: "true", source: SourceEnv, supplied: true, expected: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.env != "" {
+				t.Setenv(envKey, tc.env)
+			}
+			core, logs := observer.New(zap.WarnLevel)
+			resolver := NewResolver(zap.New(core))
+
+			supplied := resolver.Deprecated("old-setting", envKey, tc.cliSet, "use --new-setting instead")
+			if supplied != tc.supplied {
+				t.Fatalf("expected supplied=%v, got %v", tc.supplied, supplied)
+			}
+			value, err := resolver.Bool("old-setting", envKey, tc.cliVal, tc.cliSet, false)
+			if err != nil {
+				t.Fatalf("resolving bool: %v", err)
+			}
+			if value != tc.expected {
+				t.Fatalf("expected deprecated setting to still resolve to %v, got %v", tc.expected, value)
+			}
+
+			warnings := logs.FilterMessage("config: old-setting is deprecated").All()
+			if !tc.supplied {
+				if len(warnings) != 0 {
+					t.Fatalf("expected no warning, got %d", len(warnings))
+				}
+				return
+			}
+			if len(warnings) != 1 {
+				t.Fatalf("expected one deprecation warning, got %d", len(warnings))
+			}
+			fields := warnings[0].ContextMap()
+			if fields["source"] != tc.source || fields["migration"] != "use --new-setting instead" {
+				t.Fatalf("unexpected warning fields %v", fields)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package config
+
+import "strings"
+
+// SourcePipeline reports a value taken from an Azure Pipelines predefined variable.
+const SourcePipeline = "pipeline"
+
+// EnvBuildSourceVersion is the variable Azure Pipelines sets to the commit being built
+// (Build.SourceVersion).
+const EnvBuildSourceVersion = "BUILD_SOURCEVERSION"
+
+// CommitSHA resolves the commit setting. Unlike other settings, an explicit flag beats the
+// environment variable: a pipeline that exports AAV_COMMIT_SHA for every step can still tag a
+// different commit in one of them.
+func (r Resolver) CommitSHA(setting, envKey, cliVal string, cliSet bool, getenv func(string) string) string {
+	cliVal = strings.TrimSpace(cliVal)
+	envVal := strings.TrimSpace(getenv(envKey))
+	if cliSet && cliVal != "" {
+		if envVal != "" && envVal != cliVal {
+			r.logConflictDecision(setting, envVal, cliVal, "using cli value")
+		}
+		r.logDecision(setting, envKey, SourceFlag, cliVal)
+		return cliVal
+	}
+	if envVal != "" {
+		r.logDecision(setting, envKey, SourceEnv, envVal)
+		return envVal
+	}
+	return ""
+}
+
+// PipelineCommit resolves the commit setting like CommitSHA, in the order flag, environment
+// variable, then pipeline variable: when neither is set inside an Azure Pipelines job
+// (TF_BUILD=True), it falls back to Build.SourceVersion and reports true, so the commit being
+// built is used without passing it explicitly.
+func (r Resolver) PipelineCommit(setting, envKey, cliVal string, cliSet bool, getenv func(string) string) (string, bool) {
+	if value := r.CommitSHA(setting, envKey, cliVal, cliSet, getenv); value != "" {
+		return value, false
+	}
+	if !strings.EqualFold(strings.TrimSpace(getenv("TF_BUILD")), "true") {
+		return "", false
+	}
+	commit := strings.TrimSpace(getenv(EnvBuildSourceVersion))
+	if commit == "" {
+		return "", false
+	}
+	r.logDecision(setting, EnvBuildSourceVersion, SourcePipeline, commit)
+	return commit, true
+}
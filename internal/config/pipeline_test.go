@@ -0,0 +1,68 @@
+package config
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestPipelineCommit(t *testing.T) {
+	t.Parallel()
+
+	inPipeline := map[string]string{"TF_BUILD": "True", EnvBuildSourceVersion: "abc123"}
+	withEnv := map[string]string{"TF_BUILD": "True", EnvBuildSourceVersion: "abc123", "AAV_COMMIT_SHA": "env789"}
+	tests := []struct {
+		name         string
+		value        string
+		cliSet       bool
+		env          map[string]string
+		expect       string
+		source       string
+		fromPipeline bool
+	}{
+		{name: "explicit flag wins", value: "def456", cliSet: true, env: inPipeline, expect: "def456", source: SourceFlag},
+		{name: "flag beats env", value: "def456", cliSet: true, env: withEnv, expect: "def456", source: SourceFlag},
+		{name: "env beats pipeline", env: withEnv, expect: "env789", source: SourceEnv},
+		{name: "empty flag falls through to env", cliSet: true, env: withEnv, expect: "env789", source: SourceEnv},
+		{name: "pipeline source version used", env: inPipeline, expect: "abc123", source: SourcePipeline, fromPipeline: true},
+		{name: "outside a pipeline", env: map[string]string{EnvBuildSourceVersion: "abc123"}},
+		{name: "pipeline without source version", env: map[string]string{"TF_BUILD": "True"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := NewRecorder()
+			resolver := NewResolver(zap.NewNop()).WithRecorder(rec)
+			got, fromPipeline := resolver.PipelineCommit("commit-sha", "AAV_COMMIT_SHA", tc.value, tc.cliSet, func(key string) string { return tc.env[key] })
+			if got != tc.expect || fromPipeline != tc.fromPipeline {
+				t.Fatalf("expected %q (pipeline=%t), got %q (pipeline=%t)", tc.expect, tc.fromPipeline, got, fromPipeline)
+			}
+			res := rec.Resolutions()
+			if tc.source == "" {
+				if len(res) != 0 {
+					t.Fatalf("expected nothing recorded, got %+v", res)
+				}
+				return
+			}
+			if len(res) != 1 || res[0].Source != tc.source || res[0].Value != tc.expect {
+				t.Fatalf("expected %s recorded from %s, got %+v", tc.expect, tc.source, res)
+			}
+		})
+	}
+}
+
+func TestCommitSHARecordsConflict(t *testing.T) {
+	t.Parallel()
+
+	rec := NewRecorder()
+	resolver := NewResolver(zap.NewNop()).WithRecorder(rec)
+	got := resolver.CommitSHA("commit-sha", "AAV_COMMIT_SHA", "def456", true, func(string) string { return "env789" })
+	if got != "def456" {
+		t.Fatalf("expected the flag value, got %q", got)
+	}
+	if res := rec.Resolutions(); len(res) != 1 || !res[0].Conflict || res[0].Source != SourceFlag {
+		t.Fatalf("expected a flag resolution marked as a conflict, got %+v", res)
+	}
+}
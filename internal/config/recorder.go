@@ -0,0 +1,58 @@
+package config
+
+// Resolution describes how one setting was resolved. Secret values are already redacted.
+type Resolution struct {
+	Setting string
+	EnvKey  string
+	Value   string
+	// Source is SourceEnv, SourceFlag, or SourceDefault.
+	Source string
+	// Conflict reports that the environment and an explicit flag disagreed. The env value won,
+	// except for the commit SHA, where the flag does; see Resolver.CommitSHA.
+	Conflict bool
+}
+
+// Recorder collects the resolutions made by a Resolver, keeping the latest resolution of each
+// setting in the order settings were first resolved. A nil Recorder records nothing.
+type Recorder struct {
+	entries   []Resolution
+	index     map[string]int
+	conflicts map[string]bool
+}
+
+// NewRecorder returns an empty Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{index: map[string]int{}, conflicts: map[string]bool{}}
+}
+
+// Resolutions returns the recorded resolutions.
+func (rec *Recorder) Resolutions() []Resolution {
+	if rec == nil {
+		return nil
+	}
+	out := make([]Resolution, len(rec.entries))
+	for i, entry := range rec.entries {
+		entry.Conflict = rec.conflicts[entry.Setting]
+		out[i] = entry
+	}
+	return out
+}
+
+func (rec *Recorder) record(resolution Resolution) {
+	if rec == nil {
+		return
+	}
+	if i, ok := rec.index[resolution.Setting]; ok {
+		rec.entries[i] = resolution
+		return
+	}
+	rec.index[resolution.Setting] = len(rec.entries)
+	rec.entries = append(rec.entries, resolution)
+}
+
+func (rec *Recorder) conflict(setting string) {
+	if rec == nil {
+		return
+	}
+	rec.conflicts[setting] = true
+}
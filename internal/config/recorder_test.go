@@ -0,0 +1,54 @@
+package config
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestRecorderReportsSources(t *testing.T) {
+	t.Setenv("TEST_RECORD_ENV", "from-env")
+	t.Setenv("TEST_RECORD_SECRET", "s3cret")
+	t.Setenv("TEST_RECORD_CONFLICT", "true")
+
+	rec := NewRecorder()
+	resolver := NewResolver(zap.NewNop()).WithRecorder(rec)
+
+	_ = resolver.String("env-setting", "TEST_RECORD_ENV", "", false, "fallback")
+	_ = resolver.String("flag-setting", "TEST_RECORD_UNSET", "from-flag", true, "fallback")
+	_ = resolver.StringSlice("default-setting", "TEST_RECORD_UNSET", nil, false, []string{"a", "b"})
+	_ = resolver.Secret("token", "TEST_RECORD_SECRET", "", false, "")
+	if _, err := resolver.Bool("conflict-setting", "TEST_RECORD_CONFLICT", false, true, false); err != nil {
+		t.Fatalf("resolve bool: %v", err)
+	}
+	// A second resolution replaces the first without reordering.
+	_ = resolver.String("env-setting", "TEST_RECORD_ENV", "", false, "fallback")
+
+	want := []Resolution{
+		{Setting: "env-setting", EnvKey: "TEST_RECORD_ENV", Value: "from-env", Source: SourceEnv},
+		{Setting: "flag-setting", EnvKey: "TEST_RECORD_UNSET", Value: "from-flag", Source: SourceFlag},
+		{Setting: "default-setting", EnvKey: "TEST_RECORD_UNSET", Value: "a,b", Source: SourceDefault},
+		{Setting: "token", EnvKey: "TEST_RECORD_SECRET", Value: redacted, Source: SourceEnv},
+		{Setting: "conflict-setting", EnvKey: "TEST_RECORD_CONFLICT", Value: "true", Source: SourceEnv, Conflict: true},
+	}
+	got := rec.Resolutions()
+	if len(got) != len(want) {
+		t.Fatalf("expected %d resolutions, got %+v", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("resolution %d: want %+v, got %+v", i, want[i], got[i])
+		}
+	}
+}
+
+func TestNilRecorderIsIgnored(t *testing.T) {
+	resolver := NewResolver(zap.NewNop())
+	if got := resolver.String("setting", "TEST_RECORD_UNSET", "", false, "value"); got != "value" {
+		t.Fatalf("expected default value, got %q", got)
+	}
+	var rec *Recorder
+	if rec.Resolutions() != nil {
+		t.Fatal("expected no resolutions from a nil recorder")
+	}
+}
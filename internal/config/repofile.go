@@ -0,0 +1,50 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RepoFile is the versioning configuration a repository can keep in its own tree (e.g.
+// .aav.yaml). Unset fields leave the built-in defaults in place; explicit env and CLI values
+// still take precedence over anything set here.
+type RepoFile struct {
+	Labels   RepoLabels   `yaml:"labels"`
+	Branches RepoBranches `yaml:"branches"`
+}
+
+// RepoLabels overrides the semver label names. Prefix is a pointer so an empty prefix can be
+// configured explicitly.
+type RepoLabels struct {
+	Prefix *string `yaml:"prefix"`
+	Major  string  `yaml:"major"`
+	Minor  string  `yaml:"minor"`
+	Patch  string  `yaml:"patch"`
+	None   string  `yaml:"none"`
+}
+
+// RepoBranches overrides the branch prefixes mapped to each bump.
+type RepoBranches struct {
+	Major []string `yaml:"major"`
+	Minor []string `yaml:"minor"`
+	Patch []string `yaml:"patch"`
+}
+
+// ParseRepoFile decodes a repository config file. Unknown keys are rejected so typos do not
+// silently fall back to defaults; an empty file yields an empty RepoFile.
+func ParseRepoFile(data []byte) (RepoFile, error) {
+	var file RepoFile
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return RepoFile{}, fmt.Errorf("parsing repo config: %w", err)
+	}
+	file.Branches.Major = sanitizeStrings(file.Branches.Major)
+	file.Branches.Minor = sanitizeStrings(file.Branches.Minor)
+	file.Branches.Patch = sanitizeStrings(file.Branches.Patch)
+	return file, nil
+}
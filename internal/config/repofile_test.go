@@ -0,0 +1,65 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRepoFile(t *testing.T) {
+	t.Parallel()
+
+	file, err := ParseRepoFile([]byte(`
+labels:
+  prefix: ""
+  major: breaking
+  none: skip-release
+branches:
+  minor: [feature/, " feat/ ", ""]
+  patch:
+    - fix/
+`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if file.Labels.Prefix == nil || *file.Labels.Prefix != "" {
+		t.Fatalf("expected explicit empty prefix, got %v", file.Labels.Prefix)
+	}
+	if file.Labels.Major != "breaking" || file.Labels.Minor != "" || file.Labels.None != "skip-release" {
+		t.Fatalf("unexpected labels: %+v", file.Labels)
+	}
+	if file.Branches.Major != nil {
+		t.Fatalf("expected unset major prefixes, got %v", file.Branches.Major)
+	}
+	if !reflect.DeepEqual(file.Branches.Minor, []string{"feature/", "feat/"}) {
+		t.Fatalf("unexpected minor prefixes: %v", file.Branches.Minor)
+	}
+	if !reflect.DeepEqual(file.Branches.Patch, []string{"fix/"}) {
+		t.Fatalf("unexpected patch prefixes: %v", file.Branches.Patch)
+	}
+}
+
+func TestParseRepoFileEdgeCases(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{name: "empty file", input: ""},
+		{name: "comments only", input: "# nothing configured\n"},
+		{name: "json document", input: `{"labels": {"prefix": "v-"}}`},
+		{name: "unknown key", input: "label:\n  prefix: x\n", wantErr: true},
+		{name: "wrong type", input: "branches:\n  major: 3\n", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := ParseRepoFile([]byte(tc.input))
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error=%v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
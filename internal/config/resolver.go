@@ -9,9 +9,20 @@ import (
 	"go.uber.org/zap"
 )
 
+// Sources reported when logging or recording where a setting's value came from.
+const (
+	SourceEnv     = "env"
+	SourceFlag    = "flag"
+	SourceDefault = "default"
+)
+
+// redacted replaces secret values in logs.
+const redacted = "***"
+
 // Resolver provides helper functions for applying env > CLI > default precedence.
 type Resolver struct {
-	logger *zap.Logger
+	logger   *zap.Logger
+	recorder *Recorder
 }
 
 // NewResolver creates a Resolver with the provided logger.
@@ -19,7 +30,19 @@ func NewResolver(logger *zap.Logger) Resolver {
 	return Resolver{logger: logger}
 }
 
+// WithRecorder returns a copy of the resolver that also records every resolution in rec.
+func (r Resolver) WithRecorder(rec *Recorder) Resolver {
+	r.recorder = rec
+	return r
+}
+
 func (r Resolver) logConflict(setting, envVal, cliVal string) {
+	r.logConflictDecision(setting, envVal, cliVal, "using env value")
+}
+
+// logConflictDecision warns that env and cli disagree and which value was kept.
+func (r Resolver) logConflictDecision(setting, envVal, cliVal, decision string) {
+	r.recorder.conflict(setting)
 	if r.logger == nil {
 		return
 	}
@@ -27,49 +50,84 @@ func (r Resolver) logConflict(setting, envVal, cliVal string) {
 		"config: conflict for "+setting,
 		zap.String("env", envVal),
 		zap.String("cli", cliVal),
-		zap.String("decision", "using env value"),
+		zap.String("decision", decision),
+	)
+}
+
+// logDecision records the source chosen for a setting. It logs at debug level,
+// so only verbose runs see it.
+func (r Resolver) logDecision(setting, envKey, source, value string) {
+	r.recorder.record(Resolution{Setting: setting, EnvKey: envKey, Source: source, Value: value})
+	if r.logger == nil {
+		return
+	}
+	r.logger.Debug(
+		"config: resolved "+setting,
+		zap.String("source", source),
+		zap.String("value", value),
 	)
 }
 
-func (r Resolver) pick(setting string, envVal string, envSet bool, cliVal string, cliSet bool, defaultVal string, isSecret bool) string {
+func sourceOf(envSet, cliSet bool) string {
+	switch {
+	case envSet:
+		return SourceEnv
+	case cliSet:
+		return SourceFlag
+	default:
+		return SourceDefault
+	}
+}
+
+func (r Resolver) pick(setting, envKey string, envVal string, envSet bool, cliVal string, cliSet bool, defaultVal string, isSecret bool) string {
 	if envSet && cliSet && envVal != cliVal {
 		logEnv := envVal
 		logCli := cliVal
 		if isSecret {
-			logEnv = "***"
-			logCli = "***"
+			logEnv = redacted
+			logCli = redacted
 		}
 		r.logConflict(setting, logEnv, logCli)
 	}
-	if envSet {
-		return envVal
+
+	value := defaultVal
+	switch {
+	case envSet:
+		value = envVal
+	case cliSet:
+		value = cliVal
 	}
-	if cliSet {
-		return cliVal
+
+	logValue := value
+	if isSecret && value != "" {
+		logValue = redacted
 	}
-	return defaultVal
+	r.logDecision(setting, envKey, sourceOf(envSet, cliSet), logValue)
+	return value
 }
 
 // String resolves a string setting using the precedence rules.
 func (r Resolver) String(setting, envKey, cliVal string, cliSet bool, defaultVal string) string {
 	envVal, envSet := os.LookupEnv(envKey)
-	return r.pick(setting, strings.TrimSpace(envVal), envSet, cliVal, cliSet, defaultVal, false)
+	return r.pick(setting, envKey, strings.TrimSpace(envVal), envSet, cliVal, cliSet, defaultVal, false)
 }
 
 // Secret resolves a sensitive string setting using the precedence rules, redacting values in logs.
 func (r Resolver) Secret(setting, envKey, cliVal string, cliSet bool, defaultVal string) string {
 	envVal, envSet := os.LookupEnv(envKey)
-	return r.pick(setting, strings.TrimSpace(envVal), envSet, cliVal, cliSet, defaultVal, true)
+	return r.pick(setting, envKey, strings.TrimSpace(envVal), envSet, cliVal, cliSet, defaultVal, true)
 }
 
 // Bool resolves a boolean setting.
 func (r Resolver) Bool(setting, envKey string, cliVal bool, cliSet bool, defaultVal bool) (bool, error) {
 	envVal, envSet := os.LookupEnv(envKey)
 	if !envSet {
+		value := defaultVal
 		if cliSet {
-			return cliVal, nil
+			value = cliVal
 		}
-		return defaultVal, nil
+		r.logDecision(setting, envKey, sourceOf(false, cliSet), strconv.FormatBool(value))
+		return value, nil
 	}
 
 	parsed, err := strconv.ParseBool(strings.TrimSpace(envVal))
@@ -81,6 +139,7 @@ func (r Resolver) Bool(setting, envKey string, cliVal bool, cliSet bool, default
 		r.logConflict(setting, envVal, strconv.FormatBool(cliVal))
 	}
 
+	r.logDecision(setting, envKey, SourceEnv, strconv.FormatBool(parsed))
 	return parsed, nil
 }
 
@@ -88,10 +147,12 @@ func (r Resolver) Bool(setting, envKey string, cliVal bool, cliSet bool, default
 func (r Resolver) Int(setting, envKey string, cliVal int, cliSet bool, defaultVal int) (int, error) {
 	envVal, envSet := os.LookupEnv(envKey)
 	if !envSet {
+		value := defaultVal
 		if cliSet {
-			return cliVal, nil
+			value = cliVal
 		}
-		return defaultVal, nil
+		r.logDecision(setting, envKey, sourceOf(false, cliSet), strconv.Itoa(value))
+		return value, nil
 	}
 
 	parsed, err := strconv.Atoi(strings.TrimSpace(envVal))
@@ -103,25 +164,28 @@ func (r Resolver) Int(setting, envKey string, cliVal int, cliSet bool, defaultVa
 		r.logConflict(setting, envVal, strconv.Itoa(cliVal))
 	}
 
+	r.logDecision(setting, envKey, SourceEnv, strconv.Itoa(parsed))
 	return parsed, nil
 }
 
 // StringSlice resolves a slice of strings. Env values are comma-separated.
 func (r Resolver) StringSlice(setting, envKey string, cliVal []string, cliSet bool, defaultVal []string) []string {
 	envVal, envSet := os.LookupEnv(envKey)
-	if envSet {
-		parts := splitAndClean(envVal)
-		if cliSet && !equalSlices(parts, cliVal) {
+	var value []string
+	switch {
+	case envSet:
+		value = splitAndClean(envVal)
+		if cliSet && !equalSlices(value, cliVal) {
 			r.logConflict(setting, envVal, strings.Join(cliVal, ","))
 		}
-		return parts
-	}
-
-	if cliSet {
-		return sanitizeStrings(cliVal)
+	case cliSet:
+		value = sanitizeStrings(cliVal)
+	default:
+		value = sanitizeStrings(defaultVal)
 	}
 
-	return sanitizeStrings(defaultVal)
+	r.logDecision(setting, envKey, sourceOf(envSet, cliSet), strings.Join(value, ","))
+	return value
 }
 
 func splitAndClean(value string) []string {
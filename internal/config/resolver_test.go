@@ -84,3 +84,94 @@ func TestResolver_String_DoesNotRedactLogs(t *testing.T) {
 		t.Errorf("expected cli field to be 'cli-value', got %q", fields["cli"])
 	}
 }
+
+func TestResolver_LogsDecisionSourceAtDebug(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	resolver := NewResolver(zap.New(core))
+
+	t.Setenv("TEST_DECISION_ENV", "env-value")
+	resolver.String("from-env", "TEST_DECISION_ENV", "cli-value", true, "default")
+	resolver.String("from-flag", "TEST_DECISION_UNSET", "cli-value", true, "default")
+	resolver.String("from-default", "TEST_DECISION_UNSET", "", false, "default")
+
+	expected := []struct {
+		message string
+		source  string
+		value   string
+	}{
+		{message: "config: resolved from-env", source: "env", value: "env-value"},
+		{message: "config: resolved from-flag", source: "flag", value: "cli-value"},
+		{message: "config: resolved from-default", source: "default", value: "default"},
+	}
+
+	decisions := logs.FilterLevelExact(zap.DebugLevel).All()
+	if len(decisions) != len(expected) {
+		t.Fatalf("expected %d decision entries, got %d", len(expected), len(decisions))
+	}
+	for i, want := range expected {
+		fields := decisions[i].ContextMap()
+		if decisions[i].Message != want.message || fields["source"] != want.source || fields["value"] != want.value {
+			t.Errorf("entry %d: expected %+v, got %q %v", i, want, decisions[i].Message, fields)
+		}
+	}
+}
+
+func TestResolver_DecisionLogRedactsSecrets(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	resolver := NewResolver(zap.New(core))
+
+	resolver.Secret("test-secret", "TEST_DECISION_UNSET", "cli-secret", true, "")
+
+	decisions := logs.FilterLevelExact(zap.DebugLevel).All()
+	if len(decisions) != 1 {
+		t.Fatalf("expected 1 decision entry, got %d", len(decisions))
+	}
+	if got := decisions[0].ContextMap()["value"]; got != "***" {
+		t.Errorf("expected secret value to be redacted, got %q", got)
+	}
+}
+
+func TestResolver_DecisionLogsTypedSettings(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	resolver := NewResolver(zap.New(core))
+
+	t.Setenv("TEST_DECISION_BOOL", "true")
+	if _, err := resolver.Bool("a-bool", "TEST_DECISION_BOOL", false, false, false); err != nil {
+		t.Fatalf("bool: %v", err)
+	}
+	if _, err := resolver.Int("an-int", "TEST_DECISION_UNSET", 3, true, 0); err != nil {
+		t.Fatalf("int: %v", err)
+	}
+	resolver.StringSlice("a-slice", "TEST_DECISION_UNSET", nil, false, []string{"a/", "b/"})
+
+	expected := map[string][2]string{
+		"config: resolved a-bool":  {"env", "true"},
+		"config: resolved an-int":  {"flag", "3"},
+		"config: resolved a-slice": {"default", "a/,b/"},
+	}
+	for _, entry := range logs.All() {
+		want, ok := expected[entry.Message]
+		if !ok {
+			t.Fatalf("unexpected entry %q", entry.Message)
+		}
+		fields := entry.ContextMap()
+		if fields["source"] != want[0] || fields["value"] != want[1] {
+			t.Errorf("%s: expected source=%s value=%s, got %v", entry.Message, want[0], want[1], fields)
+		}
+		delete(expected, entry.Message)
+	}
+	if len(expected) != 0 {
+		t.Fatalf("missing decision entries: %v", expected)
+	}
+}
+
+func TestResolver_DecisionLogsHiddenAboveDebug(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	resolver := NewResolver(zap.New(core))
+
+	resolver.String("quiet", "TEST_DECISION_UNSET", "cli-value", true, "default")
+
+	if logs.Len() != 0 {
+		t.Fatalf("expected no entries at info level, got %d", logs.Len())
+	}
+}
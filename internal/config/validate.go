@@ -0,0 +1,46 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidSettings is returned by Problems.Err when any cross-setting check failed.
+var ErrInvalidSettings = errors.New("invalid settings")
+
+// Problems collects cross-setting validation failures so a command can report every bad
+// combination in one error instead of stopping at the first.
+type Problems struct {
+	list []string
+}
+
+// Addf records a problem when failed is true.
+func (p *Problems) Addf(failed bool, format string, args ...any) {
+	if failed {
+		p.list = append(p.list, fmt.Sprintf(format, args...))
+	}
+}
+
+// Conflict records that two settings cannot be used together when both are set.
+func (p *Problems) Conflict(aSet bool, a string, bSet bool, b string) {
+	p.Addf(aSet && bSet, "--%s cannot be combined with --%s", a, b)
+}
+
+// Requires records that setting needs dependency when setting is used without it.
+func (p *Problems) Requires(set bool, setting string, dependencySet bool, dependency string) {
+	p.Addf(set && !dependencySet, "--%s requires --%s", setting, dependency)
+}
+
+// Err returns nil when no problems were recorded, or one ErrInvalidSettings error listing them
+// all in the order they were found.
+func (p Problems) Err() error {
+	switch len(p.list) {
+	case 0:
+		return nil
+	case 1:
+		return fmt.Errorf("%w: %s", ErrInvalidSettings, p.list[0])
+	default:
+		return fmt.Errorf("%w (%d problems): %s", ErrInvalidSettings, len(p.list), strings.Join(p.list, "; "))
+	}
+}
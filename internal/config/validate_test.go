@@ -0,0 +1,65 @@
+package config
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestProblemsErr(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		check func(p *Problems)
+		want  string
+	}{
+		{
+			name: "valid combination",
+			check: func(p *Problems) {
+				p.Conflict(true, "hotfix-base", false, "tag-mode rc")
+				p.Requires(true, "env-append", true, "env-out")
+				p.Addf(false, "never recorded")
+			},
+		},
+		{
+			name: "single problem",
+			check: func(p *Problems) {
+				p.Requires(true, "env-append", false, "env-out")
+			},
+			want: "invalid settings: --env-append requires --env-out",
+		},
+		{
+			name: "all problems reported in order",
+			check: func(p *Problems) {
+				p.Conflict(true, "hotfix-base", true, "tag-mode rc")
+				p.Conflict(true, "plan-only", false, "tf-out")
+				p.Requires(true, "tag-name", false, "from-tag")
+				p.Addf(true, "--%s %s cannot be combined with --%s", "floating-skip-ci", "marker", "floating-tag-kind lightweight")
+			},
+			want: "invalid settings (3 problems): --hotfix-base cannot be combined with --tag-mode rc; " +
+				"--tag-name requires --from-tag; --floating-skip-ci marker cannot be combined with --floating-tag-kind lightweight",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var problems Problems
+			tc.check(&problems)
+			err := problems.Err()
+			if tc.want == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrInvalidSettings) {
+				t.Fatalf("expected ErrInvalidSettings, got %v", err)
+			}
+			if err.Error() != tc.want {
+				t.Fatalf("want %q\n got %q", tc.want, err.Error())
+			}
+		})
+	}
+}
@@ -21,16 +21,19 @@ var defaultMapping = Mapping{
 
 // Resolver maps branch names to bump intents, allowing future injection of custom mappings.
 type Resolver struct {
-	mapping Mapping
+	mapping  Mapping
+	shadowed []Shadowed
 }
 
 // NewResolver creates a Resolver using the provided mapping or the defaults when empty.
+// Prefixes hidden by a higher-precedence level are reported by Shadowed.
 func NewResolver(mapping Mapping) Resolver {
 	resolved := mapping
 	if len(resolved.MajorPrefixes) == 0 && len(resolved.MinorPrefixes) == 0 && len(resolved.PatchPrefixes) == 0 {
 		resolved = defaultMapping
 	}
-	return Resolver{mapping: sanitize(resolved)}
+	clean := sanitize(resolved)
+	return Resolver{mapping: clean, shadowed: findShadowed(clean)}
 }
 
 // DefaultMapping exposes the built-in mapping so callers can extend/modify it before injection.
@@ -0,0 +1,53 @@
+package branchmap
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+)
+
+// ErrBumpMismatch indicates a proposed bump is further from the branch's implied bump than
+// the allowed tolerance.
+var ErrBumpMismatch = errors.New("branchmap: bump does not match branch")
+
+// BumpCheck compares a proposed bump with the one a branch name implies.
+type BumpCheck struct {
+	Branch        string
+	MatchedPrefix string
+	// Matched is false when no prefix matched and the implied bump is the patch default.
+	Matched  bool
+	Implied  bump.Bump
+	Proposed bump.Bump
+	// Tolerance is the number of levels the proposed bump may differ by; Distance is how many
+	// it actually differs by.
+	Tolerance int
+	Distance  int
+}
+
+// Within reports whether the proposed bump is inside the tolerance.
+func (c BumpCheck) Within() bool {
+	return c.Distance <= c.Tolerance
+}
+
+// Err returns an ErrBumpMismatch error describing the check when it failed, and nil otherwise.
+func (c BumpCheck) Err() error {
+	if c.Within() {
+		return nil
+	}
+	return fmt.Errorf("%w: %q implies %s, got %s (tolerance %d)", ErrBumpMismatch, c.Branch, c.Implied, c.Proposed, c.Tolerance)
+}
+
+// CheckBump resolves branch and measures how far proposed is from its implied bump.
+func (r Resolver) CheckBump(branch string, proposed bump.Bump, tolerance int) BumpCheck {
+	implied, prefix, matched := r.Resolve(branch)
+	return BumpCheck{
+		Branch:        branch,
+		MatchedPrefix: prefix,
+		Matched:       matched,
+		Implied:       implied,
+		Proposed:      proposed,
+		Tolerance:     tolerance,
+		Distance:      bump.Distance(implied, proposed),
+	}
+}
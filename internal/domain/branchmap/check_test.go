@@ -0,0 +1,51 @@
+package branchmap
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+)
+
+func TestResolverCheckBump(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		branch    string
+		proposed  bump.Bump
+		tolerance int
+		distance  int
+		within    bool
+	}{
+		{name: "feature branch with minor", branch: "feature/login", proposed: bump.BumpMinor, within: true},
+		{name: "fix branch with patch", branch: "fix/typo", proposed: bump.BumpPatch, within: true},
+		{name: "patch branch with major", branch: "fix/typo", proposed: bump.BumpMajor, distance: 2},
+		{name: "feature branch with major", branch: "feature/login", proposed: bump.BumpMajor, distance: 1},
+		{name: "one level tolerated", branch: "feature/login", proposed: bump.BumpMajor, tolerance: 1, distance: 1, within: true},
+		{name: "two levels exceed tolerance", branch: "fix/typo", proposed: bump.BumpMajor, tolerance: 1, distance: 2},
+		{name: "unmatched branch implies patch", branch: "wip/spike", proposed: bump.BumpMinor, distance: 1},
+	}
+
+	resolver := NewResolver(Mapping{})
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			check := resolver.CheckBump(tc.branch, tc.proposed, tc.tolerance)
+			if check.Distance != tc.distance {
+				t.Fatalf("expected distance %d, got %d", tc.distance, check.Distance)
+			}
+			if check.Within() != tc.within {
+				t.Fatalf("expected within=%v, got %v", tc.within, check.Within())
+			}
+			err := check.Err()
+			if tc.within && err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if !tc.within && !errors.Is(err, ErrBumpMismatch) {
+				t.Fatalf("expected ErrBumpMismatch, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package branchmap
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+)
+
+// ErrShadowedPrefix indicates a configured branch prefix can never match because a
+// higher-precedence level already claims every branch it would.
+var ErrShadowedPrefix = errors.New("branchmap: branch prefix is unreachable")
+
+// Shadowed describes a prefix that is dead configuration: Resolve checks major, then minor,
+// then patch, so a prefix is unreachable when an earlier level lists it or a shorter prefix of
+// it (rel/ under major hides both rel/ and rel/v2/ under minor).
+type Shadowed struct {
+	Prefix string
+	Level  bump.Bump
+	// By is the higher-precedence prefix that matches first, and ByLevel its level.
+	By      string
+	ByLevel bump.Bump
+}
+
+// Shadowed lists the unreachable prefixes found when the resolver was built, in configuration
+// order.
+func (r Resolver) Shadowed() []Shadowed {
+	return append([]Shadowed(nil), r.shadowed...)
+}
+
+func findShadowed(m Mapping) []Shadowed {
+	levels := []struct {
+		bump     bump.Bump
+		prefixes []string
+	}{
+		{bump.BumpMajor, m.MajorPrefixes},
+		{bump.BumpMinor, m.MinorPrefixes},
+		{bump.BumpPatch, m.PatchPrefixes},
+	}
+
+	var found []Shadowed
+	for i, level := range levels {
+		for _, prefix := range level.prefixes {
+			for _, earlier := range levels[:i] {
+				if by, ok := matchPrefix(prefix, earlier.prefixes); ok {
+					found = append(found, Shadowed{Prefix: prefix, Level: level.bump, By: by, ByLevel: earlier.bump})
+					break
+				}
+			}
+		}
+	}
+	return found
+}
+
+// String renders the finding for logs and errors, e.g. "minor prefix rel/ is shadowed by
+// major prefix rel/".
+func (s Shadowed) String() string {
+	return fmt.Sprintf("%s prefix %s is shadowed by %s prefix %s", s.Level, s.Prefix, s.ByLevel, s.By)
+}
@@ -0,0 +1,83 @@
+package branchmap
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+)
+
+func TestResolverShadowed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		mapping  Mapping
+		expected []Shadowed
+	}{
+		{
+			name:    "defaults are clean",
+			mapping: Mapping{},
+		},
+		{
+			name: "same prefix under major and minor",
+			mapping: Mapping{
+				MajorPrefixes: []string{"breaking/", "rel/"},
+				MinorPrefixes: []string{"feature/", " rel/ "},
+			},
+			expected: []Shadowed{{Prefix: "rel/", Level: bump.BumpMinor, By: "rel/", ByLevel: bump.BumpMajor}},
+		},
+		{
+			name: "shorter prefix at a higher level hides a longer one",
+			mapping: Mapping{
+				MinorPrefixes: []string{"feat"},
+				PatchPrefixes: []string{"feature/fix/", "fix/"},
+			},
+			expected: []Shadowed{{Prefix: "feature/fix/", Level: bump.BumpPatch, By: "feat", ByLevel: bump.BumpMinor}},
+		},
+		{
+			name: "major wins over patch even when minor also lists it",
+			mapping: Mapping{
+				MajorPrefixes: []string{"x/"},
+				MinorPrefixes: []string{"x/"},
+				PatchPrefixes: []string{"x/"},
+			},
+			expected: []Shadowed{
+				{Prefix: "x/", Level: bump.BumpMinor, By: "x/", ByLevel: bump.BumpMajor},
+				{Prefix: "x/", Level: bump.BumpPatch, By: "x/", ByLevel: bump.BumpMajor},
+			},
+		},
+		{
+			name: "longer prefix at a higher level is reachable both ways",
+			mapping: Mapping{
+				MajorPrefixes: []string{"feature/breaking/"},
+				MinorPrefixes: []string{"feature/"},
+			},
+		},
+		{
+			name: "repeats within one level are not shadowing",
+			mapping: Mapping{
+				MinorPrefixes: []string{"feature/", "feature/"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := NewResolver(tc.mapping).Shadowed()
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Fatalf("shadowed:\n got %+v\nwant %+v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestShadowedString(t *testing.T) {
+	t.Parallel()
+
+	s := Shadowed{Prefix: "rel/", Level: bump.BumpMinor, By: "rel/", ByLevel: bump.BumpMajor}
+	if got, want := s.String(), "minor prefix rel/ is shadowed by major prefix rel/"; got != want {
+		t.Fatalf("want %q got %q", want, got)
+	}
+}
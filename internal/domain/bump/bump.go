@@ -9,9 +9,12 @@ const (
 	BumpMajor Bump = "major"
 	BumpMinor Bump = "minor"
 	BumpPatch Bump = "patch"
+	// BumpNone is an explicit "do not release": it ranks below patch, so any other bump wins
+	// over it, and planning it produces no new version.
+	BumpNone Bump = "none"
 )
 
-// Default returns the default bump intent (patch).
+// Default returns the default bump intent (patch). It is never none: none must be chosen explicitly.
 func Default() Bump {
 	return BumpPatch
 }
@@ -19,7 +22,7 @@ func Default() Bump {
 // Parse converts a string into a Bump value.
 func Parse(value string) (Bump, error) {
 	switch Bump(value) {
-	case BumpMajor, BumpMinor, BumpPatch:
+	case BumpMajor, BumpMinor, BumpPatch, BumpNone:
 		return Bump(value), nil
 	default:
 		return "", fmt.Errorf("invalid bump %q", value)
@@ -31,21 +34,51 @@ func (b Bump) HigherImpactThan(other Bump) bool {
 	return weight(b) > weight(other)
 }
 
-// Max returns the highest-impact bump in the slice. Defaults to patch when empty.
+// Max returns the highest-impact bump in the slice, so none only wins when every value is none.
+// Defaults to patch when the slice holds no known bump.
 func Max(values ...Bump) Bump {
-	max := Default()
+	max := Bump("")
 	for _, v := range values {
 		if v.HigherImpactThan(max) {
 			max = v
 		}
 	}
+	if max == "" {
+		return Default()
+	}
 	return max
 }
 
+// Min returns the lowest-impact known bump in the slice. Defaults to patch when empty.
+func Min(values ...Bump) Bump {
+	min := Bump("")
+	for _, v := range values {
+		if weight(v) == 0 {
+			continue
+		}
+		if min == "" || min.HigherImpactThan(v) {
+			min = v
+		}
+	}
+	if min == "" {
+		return Default()
+	}
+	return min
+}
+
+// Distance returns how many levels apart two bumps are: 0 when equal, 2 for major and patch.
+func Distance(a, b Bump) int {
+	d := weight(a) - weight(b)
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
 // String returns the textual representation. Defaults to "patch" for unknown values.
 func (b Bump) String() string {
 	switch b {
-	case BumpMajor, BumpMinor, BumpPatch:
+	case BumpMajor, BumpMinor, BumpPatch, BumpNone:
 		return string(b)
 	default:
 		return string(BumpPatch)
@@ -55,10 +88,12 @@ func (b Bump) String() string {
 func weight(b Bump) int {
 	switch b {
 	case BumpMajor:
-		return 3
+		return 4
 	case BumpMinor:
-		return 2
+		return 3
 	case BumpPatch:
+		return 2
+	case BumpNone:
 		return 1
 	default:
 		return 0
@@ -0,0 +1,66 @@
+package conventional
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+)
+
+// headerPattern matches "type(scope)!: description"; scope and "!" are optional.
+var headerPattern = regexp.MustCompile(`^([A-Za-z]+)(?:\(([^()]*)\))?(!)?:\s+(\S.*)$`)
+
+// Header is a parsed conventional-commit header line.
+type Header struct {
+	Type        string
+	Scope       string
+	Breaking    bool
+	Description string
+}
+
+// Parse reads the first line of message as a conventional-commit header. Types are lowercased.
+func Parse(message string) (Header, bool) {
+	first, _, _ := strings.Cut(strings.TrimSpace(message), "\n")
+	match := headerPattern.FindStringSubmatch(strings.TrimSpace(first))
+	if match == nil {
+		return Header{}, false
+	}
+	return Header{
+		Type:        strings.ToLower(match[1]),
+		Scope:       strings.TrimSpace(match[2]),
+		Breaking:    match[3] == "!",
+		Description: strings.TrimSpace(match[4]),
+	}, true
+}
+
+// Classify maps a PR title or commit message to the bump conventional commits imply: a "!"
+// header or BREAKING CHANGE footer is major, feat is minor, and fix or perf is patch. Other
+// types, and messages without a conventional header, report false.
+func Classify(message string) (bump.Bump, bool) {
+	header, ok := Parse(message)
+	if !ok {
+		return "", false
+	}
+	if header.Breaking || hasBreakingFooter(message) {
+		return bump.BumpMajor, true
+	}
+	switch header.Type {
+	case "feat":
+		return bump.BumpMinor, true
+	case "fix", "perf":
+		return bump.BumpPatch, true
+	default:
+		return "", false
+	}
+}
+
+func hasBreakingFooter(message string) bool {
+	lines := strings.Split(message, "\n")
+	for _, line := range lines[1:] {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "BREAKING CHANGE:") || strings.HasPrefix(trimmed, "BREAKING-CHANGE:") {
+			return true
+		}
+	}
+	return false
+}
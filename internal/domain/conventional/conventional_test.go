@@ -0,0 +1,56 @@
+package conventional
+
+import (
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+)
+
+func TestClassify(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		message string
+		want    bump.Bump
+		ok      bool
+	}{
+		{name: "feat", message: "feat: add release notes", want: bump.BumpMinor, ok: true},
+		{name: "feat with scope", message: "feat(cli): add --shell-out", want: bump.BumpMinor, ok: true},
+		{name: "fix", message: "fix: handle empty prefix", want: bump.BumpPatch, ok: true},
+		{name: "perf", message: "perf: cache refs", want: bump.BumpPatch, ok: true},
+		{name: "breaking bang", message: "feat!: drop v1 config", want: bump.BumpMajor, ok: true},
+		{name: "breaking bang with scope", message: "fix(api)!: rename field", want: bump.BumpMajor, ok: true},
+		{name: "breaking footer", message: "refactor: move config\n\nBREAKING CHANGE: env names changed", want: bump.BumpMajor, ok: true},
+		{name: "breaking hyphen footer", message: "fix: rename output\n\nBREAKING-CHANGE: prId is now prIds", want: bump.BumpMajor, ok: true},
+		{name: "uppercase type", message: "Feat: loud title", want: bump.BumpMinor, ok: true},
+		{name: "other type", message: "chore: bump deps"},
+		{name: "plain title", message: "Add release notes"},
+		{name: "missing description", message: "feat:"},
+		{name: "missing space", message: "feat:add thing"},
+		{name: "empty", message: ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, ok := Classify(tc.message)
+			if ok != tc.ok || got != tc.want {
+				t.Fatalf("Classify(%q) = %q, %v; want %q, %v", tc.message, got, ok, tc.want, tc.ok)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	header, ok := Parse("feat(tagging)!: move floating tags\n\nbody")
+	if !ok {
+		t.Fatal("expected header to parse")
+	}
+	want := Header{Type: "feat", Scope: "tagging", Breaking: true, Description: "move floating tags"}
+	if header != want {
+		t.Fatalf("Parse = %+v, want %+v", header, want)
+	}
+}
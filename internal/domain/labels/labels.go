@@ -18,6 +18,8 @@ type Config struct {
 	MajorLabel string
 	MinorLabel string
 	PatchLabel string
+	// NoneLabel marks a pull request that should not release at all, e.g. CI-only changes.
+	NoneLabel string
 }
 
 // NewResolver builds a Resolver using the provided config. Prefix defaults to "semver-".
@@ -31,6 +33,7 @@ func NewResolver(cfg Config) Resolver {
 		bump.BumpMajor: chooseLabel(cfg.MajorLabel, prefix+"major"),
 		bump.BumpMinor: chooseLabel(cfg.MinorLabel, prefix+"minor"),
 		bump.BumpPatch: chooseLabel(cfg.PatchLabel, prefix+"patch"),
+		bump.BumpNone:  chooseLabel(cfg.NoneLabel, prefix+"none"),
 	}
 
 	lower := make(map[string]bump.Bump, len(labels))
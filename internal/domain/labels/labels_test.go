@@ -21,6 +21,9 @@ func TestNewResolverDefaults(t *testing.T) {
 	if resolved[bump.BumpPatch] != "semver-patch" {
 		t.Fatalf("expected default patch label, got %s", resolved[bump.BumpPatch])
 	}
+	if resolved[bump.BumpNone] != "semver-none" {
+		t.Fatalf("expected default none label, got %s", resolved[bump.BumpNone])
+	}
 }
 
 func TestDecide(t *testing.T) {
@@ -82,6 +85,10 @@ func TestBumpForLabel(t *testing.T) {
 		t.Fatalf("expected major bump, got %v, ok=%v", b, ok)
 	}
 
+	if b, ok := r.BumpForLabel("rel-none"); !ok || b != bump.BumpNone {
+		t.Fatalf("expected none bump, got %v, ok=%v", b, ok)
+	}
+
 	if _, ok := r.BumpForLabel("unknown"); ok {
 		t.Fatalf("expected no bump for unknown label")
 	}
@@ -0,0 +1,82 @@
+package tagplan
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	semver "github.com/blang/semver/v4"
+)
+
+var (
+	// ErrAliasSourceNotFound indicates the alias source does not name an existing tag.
+	ErrAliasSourceNotFound = errors.New("tagplan: alias source tag not found")
+	// ErrAliasSourceNotRelease indicates the alias source exists but is not a stable release tag.
+	ErrAliasSourceNotRelease = errors.New("tagplan: alias source is not a release tag")
+	// ErrEmptyAliasName indicates no name was provided for the alias tag.
+	ErrEmptyAliasName = errors.New("tagplan: alias tag name is empty")
+	// ErrAliasIsVersion indicates the alias name would itself be read as a version tag.
+	ErrAliasIsVersion = errors.New("tagplan: alias tag name must not be a version")
+	// ErrAliasExists indicates a tag with the alias name already exists.
+	ErrAliasExists = errors.New("tagplan: alias tag already exists")
+)
+
+// AliasPlan describes a new tag created at the commit of an existing release, such as a
+// "stable" channel tag pointing at v1.2.4.
+type AliasPlan struct {
+	TagName string
+	Source  Tag
+	Version semver.Version
+	// Commit is the source tag's peeled commit, which the alias references.
+	Commit string
+}
+
+// PlanAlias resolves source (a release tag name or version) and validates name as a new,
+// non-version tag to create at the same commit. Alias names are used as given; the planner's
+// prefix and component only apply when matching the source.
+func (p Planner) PlanAlias(tags []Tag, source, name string) (AliasPlan, error) {
+	catalog := buildCatalog(tags, p.component, p.namePrefix())
+
+	entry, ok := catalog.findRelease(source)
+	if !ok {
+		trimmed := strings.TrimSpace(source)
+		if _, exists := findTag(tags, trimmed); exists {
+			return AliasPlan{}, fmt.Errorf("%w: %s", ErrAliasSourceNotRelease, trimmed)
+		}
+		return AliasPlan{}, fmt.Errorf("%w: %s", ErrAliasSourceNotFound, trimmed)
+	}
+
+	alias := strings.TrimPrefix(strings.TrimSpace(name), "refs/tags/")
+	if alias == "" {
+		return AliasPlan{}, ErrEmptyAliasName
+	}
+	_, bare := parseSemverTag(alias, "", p.namePrefix())
+	_, scoped := parseSemverTag(alias, p.component, p.namePrefix())
+	if bare || scoped {
+		return AliasPlan{}, fmt.Errorf("%w: %s", ErrAliasIsVersion, alias)
+	}
+	if _, exists := findTag(tags, alias); exists {
+		return AliasPlan{}, fmt.Errorf("%w: %s", ErrAliasExists, alias)
+	}
+
+	return AliasPlan{
+		TagName: alias,
+		Source:  entry.tag,
+		Version: entry.version,
+		Commit:  strings.TrimSpace(entry.tag.ObjectID),
+	}, nil
+}
+
+// findTag matches a tag by name, with or without refs/tags/.
+func findTag(tags []Tag, name string) (Tag, bool) {
+	trimmed := strings.TrimPrefix(strings.TrimSpace(name), "refs/tags/")
+	if trimmed == "" {
+		return Tag{}, false
+	}
+	for _, tag := range tags {
+		if strings.TrimPrefix(tag.Name, "refs/tags/") == trimmed {
+			return tag, true
+		}
+	}
+	return Tag{}, false
+}
@@ -0,0 +1,94 @@
+package tagplan
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPlanAliasResolvesSourceCommit(t *testing.T) {
+	t.Parallel()
+
+	tags := []Tag{
+		{Name: "refs/tags/v1.2.3", ObjectID: "c123"},
+		{Name: "refs/tags/v1.2.4", ObjectID: "c124", RefObjectID: "tag-object-124"},
+		{Name: "refs/tags/v1", ObjectID: "c124"},
+	}
+
+	tests := []struct {
+		name   string
+		source string
+	}{
+		{name: "tag name", source: "v1.2.4"},
+		{name: "full ref", source: "refs/tags/v1.2.4"},
+		{name: "version", source: "1.2.4"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			plan, err := NewPlanner("v").PlanAlias(tags, tc.source, "refs/tags/stable")
+			if err != nil {
+				t.Fatalf("plan alias: %v", err)
+			}
+			if plan.TagName != "stable" || plan.Commit != "c124" {
+				t.Fatalf("expected stable at c124, got %s at %s", plan.TagName, plan.Commit)
+			}
+			if plan.Source.Name != "refs/tags/v1.2.4" || plan.Version.String() != "1.2.4" {
+				t.Fatalf("unexpected source %+v (%s)", plan.Source, plan.Version)
+			}
+		})
+	}
+}
+
+func TestPlanAliasComponentSource(t *testing.T) {
+	t.Parallel()
+
+	tags := []Tag{
+		{Name: "refs/tags/api/v1.2.4", ObjectID: "api124"},
+		{Name: "refs/tags/web/v1.2.4", ObjectID: "web124"},
+	}
+
+	plan, err := NewPlanner("v").WithComponent("api").PlanAlias(tags, "1.2.4", "api/stable")
+	if err != nil {
+		t.Fatalf("plan alias: %v", err)
+	}
+	if plan.Commit != "api124" || plan.TagName != "api/stable" {
+		t.Fatalf("expected api/stable at api124, got %s at %s", plan.TagName, plan.Commit)
+	}
+}
+
+func TestPlanAliasErrors(t *testing.T) {
+	t.Parallel()
+
+	tags := []Tag{
+		{Name: "refs/tags/v1.2.4", ObjectID: "c124"},
+		{Name: "refs/tags/v1.3.0-rc.1", ObjectID: "c130"},
+		{Name: "refs/tags/stable", ObjectID: "c123"},
+	}
+
+	tests := []struct {
+		name    string
+		source  string
+		alias   string
+		wantErr error
+	}{
+		{name: "missing source", source: "v9.9.9", alias: "latest", wantErr: ErrAliasSourceNotFound},
+		{name: "prerelease source", source: "v1.3.0-rc.1", alias: "latest", wantErr: ErrAliasSourceNotRelease},
+		{name: "non-version source", source: "stable", alias: "latest", wantErr: ErrAliasSourceNotRelease},
+		{name: "empty alias", source: "v1.2.4", alias: " ", wantErr: ErrEmptyAliasName},
+		{name: "version alias", source: "v1.2.4", alias: "v2.0.0", wantErr: ErrAliasIsVersion},
+		{name: "existing alias", source: "v1.2.4", alias: "refs/tags/stable", wantErr: ErrAliasExists},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewPlanner("v").PlanAlias(tags, tc.source, tc.alias)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
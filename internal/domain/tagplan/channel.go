@@ -0,0 +1,127 @@
+package tagplan
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	semver "github.com/blang/semver/v4"
+)
+
+// DefaultPrereleaseLabel is the channel RC mode tags when no prerelease label is configured.
+const DefaultPrereleaseLabel = "rc"
+
+// DefaultChannelOrder ranks the common prerelease channels from least to most mature.
+var DefaultChannelOrder = []string{"alpha", "beta", "rc"}
+
+var (
+	// ErrInvalidPrereleaseLabel is returned when a prerelease label is not a single alphanumeric
+	// semver identifier.
+	ErrInvalidPrereleaseLabel = errors.New("tagplan: invalid prerelease label")
+	// ErrChannelOrder is returned when channel order is enforced and the requested channel ranks
+	// below one that already has a prerelease for the same target.
+	ErrChannelOrder = errors.New("tagplan: prerelease channel out of order")
+)
+
+// ParsePrereleaseLabel lowercases and validates a prerelease channel label; empty selects
+// DefaultPrereleaseLabel. Labels are one non-numeric identifier such as "beta", because the
+// planner appends the number as its own identifier (beta.1).
+func ParsePrereleaseLabel(value string) (string, error) {
+	label := strings.ToLower(strings.TrimSpace(value))
+	if label == "" {
+		return DefaultPrereleaseLabel, nil
+	}
+	if strings.Contains(label, ".") {
+		return "", fmt.Errorf("%w: %q must not contain '.'", ErrInvalidPrereleaseLabel, value)
+	}
+	identifier, err := semver.NewPRVersion(label)
+	if err != nil || identifier.IsNum {
+		return "", fmt.Errorf("%w: %q", ErrInvalidPrereleaseLabel, value)
+	}
+	return label, nil
+}
+
+// ParseChannelOrder validates channel labels listed from least to most mature; empty selects
+// DefaultChannelOrder.
+func ParseChannelOrder(values []string) ([]string, error) {
+	order := make([]string, 0, len(values))
+	seen := make(map[string]bool, len(values))
+	for _, value := range values {
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+		label, err := ParsePrereleaseLabel(value)
+		if err != nil {
+			return nil, err
+		}
+		if seen[label] {
+			return nil, fmt.Errorf("%w: %q is listed twice in the channel order", ErrInvalidPrereleaseLabel, label)
+		}
+		seen[label] = true
+		order = append(order, label)
+	}
+	if len(order) == 0 {
+		return append([]string(nil), DefaultChannelOrder...), nil
+	}
+	return order, nil
+}
+
+// WithPrereleaseLabel returns a copy of the planner whose RC mode tags the label channel
+// (v1.3.0-beta.1) instead of rc. Numbering only counts prereleases of that channel, so beta
+// starts at 1 even when alphas exist for the same target.
+func (p Planner) WithPrereleaseLabel(label string) Planner {
+	p.prereleaseLabel = strings.ToLower(strings.TrimSpace(label))
+	return p
+}
+
+// WithChannelOrder returns a copy of the planner that, when enforce is set, refuses to create a
+// prerelease on a channel ranked below one the same target already has, such as a beta after
+// v1.3.0-rc.1. order lists channels from least to most mature.
+func (p Planner) WithChannelOrder(order []string, enforce bool) Planner {
+	p.channelOrder = append([]string(nil), order...)
+	p.enforceChannels = enforce
+	return p
+}
+
+// channel returns the prerelease label RC mode plans.
+func (p Planner) channel() string {
+	if p.prereleaseLabel == "" {
+		return DefaultPrereleaseLabel
+	}
+	return p.prereleaseLabel
+}
+
+// checkChannelOrder enforces the channel order for target. Prereleases on channels outside the
+// order never block, but the requested channel must be ranked.
+func (p Planner) checkChannelOrder(target semver.Version, prereleases []releaseEntry) error {
+	if !p.enforceChannels {
+		return nil
+	}
+	order := p.channelOrder
+	if len(order) == 0 {
+		order = DefaultChannelOrder
+	}
+	rank := channelRank(order, p.channel())
+	if rank < 0 {
+		return fmt.Errorf("%w: %q is not in the channel order (%s)", ErrChannelOrder, p.channel(), strings.Join(order, " < "))
+	}
+	for _, entry := range prereleases {
+		if !sameBase(entry.version, target) || len(entry.version.Pre) == 0 {
+			continue
+		}
+		label := strings.ToLower(entry.version.Pre[0].VersionStr)
+		if channelRank(order, label) > rank {
+			return fmt.Errorf("%w: cannot create %s after %s for %s", ErrChannelOrder, p.channel(), shortTagName(entry.tag.Name), target)
+		}
+	}
+	return nil
+}
+
+func channelRank(order []string, label string) int {
+	for i, candidate := range order {
+		if candidate == label {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,84 @@
+package tagplan
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+)
+
+func TestPlanRCChannels(t *testing.T) {
+	t.Parallel()
+
+	release := Tag{Name: "refs/tags/v1.2.0", ObjectID: "a"}
+	alpha1 := Tag{Name: "refs/tags/v1.3.0-alpha.1"}
+	alpha2 := Tag{Name: "refs/tags/v1.3.0-alpha.2"}
+	beta1 := Tag{Name: "refs/tags/v1.3.0-beta.1"}
+	rc1 := Tag{Name: "refs/tags/v1.3.0-rc.1"}
+	otherTarget := Tag{Name: "refs/tags/v1.4.0-rc.4"}
+
+	tests := []struct {
+		name      string
+		tags      []Tag
+		label     string
+		enforce   bool
+		expectTag string
+		expectErr error
+	}{
+		{name: "alpha numbering", tags: []Tag{release, alpha1, alpha2}, label: "alpha", expectTag: "v1.3.0-alpha.3"},
+		{name: "alpha numbering ignores betas", tags: []Tag{release, alpha1, beta1}, label: "alpha", expectTag: "v1.3.0-alpha.2"},
+		{name: "beta starts fresh after alphas", tags: []Tag{release, alpha1, alpha2}, label: "beta", expectTag: "v1.3.0-beta.1"},
+		{name: "rc starts fresh after betas", tags: []Tag{release, alpha2, beta1}, label: "rc", expectTag: "v1.3.0-rc.1"},
+		{name: "default channel is rc", tags: []Tag{release, beta1, rc1}, expectTag: "v1.3.0-rc.2"},
+		{name: "other targets do not count", tags: []Tag{release, otherTarget}, label: "rc", expectTag: "v1.3.0-rc.1"},
+		{name: "enforced promotion", tags: []Tag{release, alpha2, beta1}, label: "beta", enforce: true, expectTag: "v1.3.0-beta.2"},
+		{name: "enforced order ignores other targets", tags: []Tag{release, otherTarget}, label: "alpha", enforce: true, expectTag: "v1.3.0-alpha.1"},
+		{name: "lower channel allowed when not enforced", tags: []Tag{release, rc1}, label: "beta", expectTag: "v1.3.0-beta.1"},
+		{name: "beta after rc rejected", tags: []Tag{release, beta1, rc1}, label: "beta", enforce: true, expectErr: ErrChannelOrder},
+		{name: "alpha after beta rejected", tags: []Tag{release, beta1}, label: "alpha", enforce: true, expectErr: ErrChannelOrder},
+		{name: "unranked channel rejected", tags: []Tag{release}, label: "nightly", enforce: true, expectErr: ErrChannelOrder},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			planner := NewPlanner("v").WithPrereleaseLabel(tc.label).WithChannelOrder(DefaultChannelOrder, tc.enforce)
+			result, err := planner.PlanRC(tc.tags, bump.BumpMinor, "")
+			if tc.expectErr != nil {
+				if !errors.Is(err, tc.expectErr) {
+					t.Fatalf("expected %v, got %v", tc.expectErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("plan rc: %v", err)
+			}
+			if result.TagName != tc.expectTag {
+				t.Fatalf("expected %s, got %s", tc.expectTag, result.TagName)
+			}
+		})
+	}
+}
+
+func TestParseChannelOrder(t *testing.T) {
+	t.Parallel()
+
+	order, err := ParseChannelOrder([]string{" Dev ", "alpha", "rc"})
+	if err != nil {
+		t.Fatalf("parse order: %v", err)
+	}
+	if len(order) != 3 || order[0] != "dev" || order[2] != "rc" {
+		t.Fatalf("unexpected order %v", order)
+	}
+
+	if order, err := ParseChannelOrder(nil); err != nil || len(order) != len(DefaultChannelOrder) {
+		t.Fatalf("expected default order, got %v (%v)", order, err)
+	}
+
+	for _, values := range [][]string{{"beta", "beta"}, {"rc.1"}, {"7"}} {
+		if _, err := ParseChannelOrder(values); !errors.Is(err, ErrInvalidPrereleaseLabel) {
+			t.Fatalf("expected invalid label for %v, got %v", values, err)
+		}
+	}
+}
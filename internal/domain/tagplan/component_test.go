@@ -0,0 +1,123 @@
+package tagplan
+
+import (
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+)
+
+func monorepoTags() []Tag {
+	return []Tag{
+		{Name: "refs/tags/api/v1.4.0", ObjectID: "api-140"},
+		{Name: "refs/tags/api/v1.4.1-rc.1", ObjectID: "api-141-rc1"},
+		{Name: "refs/tags/api/v1", ObjectID: "api-140"},
+		{Name: "refs/tags/web/v3.0.0", ObjectID: "web-300"},
+		{Name: "refs/tags/web/v3", ObjectID: "web-300"},
+		{Name: "refs/tags/v9.9.9", ObjectID: "root-999"},
+		{Name: "refs/tags/v9", ObjectID: "root-999"},
+	}
+}
+
+func TestPlanReleaseWithComponent(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		component     string
+		intent        bump.Bump
+		expectTag     string
+		expectBase    string
+		expectFloat   string
+		expectExisted string
+	}{
+		{name: "api minor", component: "api", intent: bump.BumpMinor, expectTag: "api/v1.5.0", expectBase: "api-140", expectFloat: "api/v1", expectExisted: "refs/tags/api/v1"},
+		{name: "web major", component: "web", intent: bump.BumpMajor, expectTag: "web/v4.0.0", expectBase: "web-300", expectFloat: "web/v4"},
+		{name: "surrounding slashes trimmed", component: "/api/", intent: bump.BumpPatch, expectTag: "api/v1.4.1", expectBase: "api-140", expectFloat: "api/v1", expectExisted: "refs/tags/api/v1"},
+		{name: "new component starts at zero", component: "worker", intent: bump.BumpMinor, expectTag: "worker/v0.1.0", expectFloat: "worker/v0"},
+		{name: "root ignores component tags", intent: bump.BumpPatch, expectTag: "v9.9.10", expectBase: "root-999", expectFloat: "v9", expectExisted: "refs/tags/v9"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := NewPlanner("v").WithComponent(tc.component).PlanRelease(monorepoTags(), tc.intent, "")
+			if err != nil {
+				t.Fatalf(errPlanRelease, err)
+			}
+			if result.TagName != tc.expectTag {
+				t.Fatalf("tag: want %s got %s", tc.expectTag, result.TagName)
+			}
+			if result.BaseTag.ObjectID != tc.expectBase {
+				t.Fatalf("base tag: want %q got %+v", tc.expectBase, result.BaseTag)
+			}
+			if result.Floating.TagName != tc.expectFloat {
+				t.Fatalf("floating: want %s got %s", tc.expectFloat, result.Floating.TagName)
+			}
+			if result.Floating.Existing.Name != tc.expectExisted {
+				t.Fatalf("existing floating: want %q got %+v", tc.expectExisted, result.Floating.Existing)
+			}
+		})
+	}
+}
+
+func TestPlanRCWithComponent(t *testing.T) {
+	t.Parallel()
+
+	result, err := NewPlanner("v").WithComponent("api").PlanRC(monorepoTags(), bump.BumpPatch, "")
+	if err != nil {
+		t.Fatalf("plan rc: %v", err)
+	}
+	if result.TagName != "api/v1.4.1-rc.2" {
+		t.Fatalf("expected api/v1.4.1-rc.2, got %s", result.TagName)
+	}
+}
+
+func TestPrereleasesWithComponent(t *testing.T) {
+	t.Parallel()
+
+	tags := append(monorepoTags(), Tag{Name: "refs/tags/web/v3.0.0-rc.1"})
+	statuses := NewPlanner("v").WithComponent("web").Prereleases(tags)
+	if len(statuses) != 1 || statuses[0].TagName != "web/v3.0.0-rc.1" || !statuses[0].ReleaseExists {
+		t.Fatalf("expected only the stale web prerelease, got %+v", statuses)
+	}
+}
+
+func TestPlanHotfixWithComponent(t *testing.T) {
+	t.Parallel()
+
+	tags := append(monorepoTags(), Tag{Name: "refs/tags/api/v1.5.0", ObjectID: "api-150"})
+	result, err := NewPlanner("v").WithComponent("api").PlanHotfix(tags, "api/v1.4.0")
+	if err != nil {
+		t.Fatalf("plan hotfix: %v", err)
+	}
+	if result.TagName != "api/v1.4.1" || result.Floating.TagName != "api/v1" || !result.Floating.Superseded {
+		t.Fatalf("unexpected hotfix plan: %+v", result)
+	}
+}
+
+func TestPlanMinorFloatingWithComponent(t *testing.T) {
+	t.Parallel()
+
+	tags := append(monorepoTags(),
+		Tag{Name: "refs/tags/api/v1.4", ObjectID: "api-140"},
+		Tag{Name: "refs/tags/web/v1.4", ObjectID: "web-140"},
+	)
+
+	api, err := NewPlanner("v").WithComponent("api").PlanRelease(tags, bump.BumpPatch, "")
+	if err != nil {
+		t.Fatalf(errPlanRelease, err)
+	}
+	minor := api.Floating.Minor
+	if minor == nil || minor.TagName != "api/v1.4" || minor.Existing.Name != "refs/tags/api/v1.4" || !minor.AutoDetected {
+		t.Fatalf("expected api/v1.4 detected for api, got %+v", minor)
+	}
+
+	root, err := NewPlanner("v").PlanRelease(tags, bump.BumpPatch, "")
+	if err != nil {
+		t.Fatalf(errPlanRelease, err)
+	}
+	if root.TagName != "v9.9.10" || root.Floating.Minor == nil || root.Floating.Minor.TagName != "v9.9" || root.Floating.Minor.Existing.Name != "" {
+		t.Fatalf("expected component minor floating tags ignored at the root, got %+v", root.Floating.Minor)
+	}
+}
@@ -0,0 +1,37 @@
+package tagplan
+
+import (
+	"errors"
+	"fmt"
+
+	semver "github.com/blang/semver/v4"
+)
+
+// ErrDowngrade is returned when a computed version is lower than an existing release.
+var ErrDowngrade = errors.New("tagplan: computed version is lower than an existing release")
+
+// WithAllowDowngrade returns a copy of the planner that accepts computed versions below the
+// highest existing release, for example when a version source deliberately rewinds a line.
+// Hotfixes are never checked.
+func (p Planner) WithAllowDowngrade(allow bool) Planner {
+	p.allowDowngrade = allow
+	return p
+}
+
+// checkDowngrade rejects version when it is lower than the highest of releases, the base
+// candidates left after --ignore-tags and --version-range.
+func (p Planner) checkDowngrade(releases []releaseEntry, version semver.Version) error {
+	if p.allowDowngrade || len(releases) == 0 {
+		return nil
+	}
+	highest := releases[0]
+	for _, candidate := range releases[1:] {
+		if candidate.version.GT(highest.version) {
+			highest = candidate
+		}
+	}
+	if version.LT(highest.version) {
+		return fmt.Errorf("%w: %s is below %s", ErrDowngrade, version.String(), shortTagName(highest.tag.Name))
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package tagplan
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+)
+
+func downgradeTags() []Tag {
+	return []Tag{
+		{Name: "refs/tags/v1.0.0", ObjectID: "c100"},
+		{Name: "refs/tags/v1.2.3", ObjectID: "c123"},
+	}
+}
+
+func TestPlanRejectsDowngrade(t *testing.T) {
+	t.Parallel()
+
+	planner := NewPlanner("v").WithCurrentVersion("1.0.0")
+	_, err := planner.PlanRelease(downgradeTags(), bump.BumpPatch, "")
+	if !errors.Is(err, ErrDowngrade) || !strings.Contains(err.Error(), "1.0.1 is below v1.2.3") {
+		t.Fatalf("expected a downgrade error naming v1.2.3, got %v", err)
+	}
+	if _, err := planner.PlanRC(downgradeTags(), bump.BumpMinor, ""); !errors.Is(err, ErrDowngrade) {
+		t.Fatalf("expected RC planning to reject the downgrade, got %v", err)
+	}
+}
+
+func TestPlanAllowsExplicitDowngrade(t *testing.T) {
+	t.Parallel()
+
+	result, err := NewPlanner("v").WithCurrentVersion("1.0.0").WithAllowDowngrade(true).PlanRelease(downgradeTags(), bump.BumpPatch, "")
+	if err != nil {
+		t.Fatalf(errPlanRelease, err)
+	}
+	if result.TagName != "v1.0.1" {
+		t.Fatalf("expected v1.0.1, got %s", result.TagName)
+	}
+}
+
+func TestPlanDowngradeIgnoresReleasesOutsideRange(t *testing.T) {
+	t.Parallel()
+
+	tags := append(downgradeTags(), Tag{Name: "refs/tags/v2.0.0", ObjectID: "c200"})
+	versionRange, err := ParseVersionRange("<2.0.0")
+	if err != nil {
+		t.Fatalf("parse range: %v", err)
+	}
+	result, err := NewPlanner("v").WithVersionRange(versionRange).PlanRelease(tags, bump.BumpPatch, "")
+	if err != nil || result.TagName != "v1.2.4" {
+		t.Fatalf("expected maintenance release v1.2.4, got %+v, %v", result, err)
+	}
+}
@@ -0,0 +1,68 @@
+package tagplan
+
+import (
+	"strings"
+
+	semver "github.com/blang/semver/v4"
+)
+
+// versionSet collects entries with distinct versions. The index keys each version to its
+// position in entries, so building a catalog stays linear in the number of tags.
+type versionSet struct {
+	entries []releaseEntry
+	index   map[string]int
+}
+
+// add appends entry unless a tag with the same version is already present, as with v1.2.3,
+// V1.2.3, and 1.2.3. Of two such tags the one written with the planner's own prefix is kept; the
+// other is returned so callers can report it.
+func (s *versionSet) add(entry releaseEntry, component, namePrefix string) (Tag, bool) {
+	key := versionKey(entry.version)
+	i, ok := s.index[key]
+	if !ok {
+		if s.index == nil {
+			s.index = map[string]int{}
+		}
+		s.index[key] = len(s.entries)
+		s.entries = append(s.entries, entry)
+		return Tag{}, false
+	}
+	existing := s.entries[i]
+	if !hasWritePrefix(existing.tag.Name, component, namePrefix) && hasWritePrefix(entry.tag.Name, component, namePrefix) {
+		s.entries[i] = entry
+		return existing.tag, true
+	}
+	return entry.tag, true
+}
+
+// versionKey identifies version the way semver.Version.EQ compares it, ignoring build metadata.
+func versionKey(version semver.Version) string {
+	version.Build = nil
+	return version.String()
+}
+
+// hasWritePrefix reports whether name starts with exactly the prefix formatTagName writes,
+// matching case; without a configured prefix that means a bare version.
+func hasWritePrefix(name, component, namePrefix string) bool {
+	short, _ := stripComponent(name, component)
+	if namePrefix == "" {
+		return short != "" && short[0] >= '0' && short[0] <= '9'
+	}
+	return strings.HasPrefix(short, namePrefix)
+}
+
+func shortTagName(name string) string {
+	return strings.TrimPrefix(strings.TrimSpace(name), "refs/tags/")
+}
+
+// duplicateNames returns the short names of tags dropped as duplicate versions.
+func (c catalog) duplicateNames() []string {
+	if len(c.duplicates) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(c.duplicates))
+	for _, tag := range c.duplicates {
+		names = append(names, shortTagName(tag.Name))
+	}
+	return names
+}
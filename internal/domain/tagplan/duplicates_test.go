@@ -0,0 +1,114 @@
+package tagplan
+
+import (
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+)
+
+func TestPlanReleaseDeduplicatesCaseVariantPrefixes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		prefix     string
+		tags       []Tag
+		expectBase string
+		expectDup  string
+	}{
+		{
+			name:       "lowercase listed first",
+			prefix:     "v",
+			tags:       []Tag{{Name: "refs/tags/v1.2.3", ObjectID: "lower"}, {Name: "refs/tags/V1.2.3", ObjectID: "upper"}, {Name: "refs/tags/v1.2.2"}},
+			expectBase: "lower",
+			expectDup:  "V1.2.3",
+		},
+		{
+			name:       "uppercase listed first",
+			prefix:     "v",
+			tags:       []Tag{{Name: "refs/tags/V1.2.3", ObjectID: "upper"}, {Name: "refs/tags/v1.2.3", ObjectID: "lower"}},
+			expectBase: "lower",
+			expectDup:  "V1.2.3",
+		},
+		{
+			name:       "uppercase write prefix keeps uppercase",
+			prefix:     "V",
+			tags:       []Tag{{Name: "refs/tags/v1.2.3", ObjectID: "lower"}, {Name: "refs/tags/V1.2.3", ObjectID: "upper"}},
+			expectBase: "upper",
+			expectDup:  "v1.2.3",
+		},
+		{
+			name:       "v prefix keeps prefixed over bare version",
+			prefix:     "v",
+			tags:       []Tag{{Name: "refs/tags/1.2.3", ObjectID: "bare"}, {Name: "refs/tags/v1.2.3", ObjectID: "lower"}},
+			expectBase: "lower",
+			expectDup:  "1.2.3",
+		},
+		{
+			name:       "build metadata names the same version",
+			prefix:     "v",
+			tags:       []Tag{{Name: "refs/tags/v1.2.3", ObjectID: "lower"}, {Name: "refs/tags/V1.2.3+build.7", ObjectID: "upper"}},
+			expectBase: "lower",
+			expectDup:  "V1.2.3+build.7",
+		},
+		{
+			name:       "no prefix keeps bare version",
+			tags:       []Tag{{Name: "refs/tags/v1.2.3", ObjectID: "lower"}, {Name: "refs/tags/1.2.3", ObjectID: "bare"}},
+			expectBase: "bare",
+			expectDup:  "v1.2.3",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := NewPlanner(tc.prefix).PlanRelease(tc.tags, bump.BumpPatch, "")
+			if err != nil {
+				t.Fatalf(errPlanRelease, err)
+			}
+			if result.Version.String() != "1.2.4" {
+				t.Fatalf("version: want 1.2.4 got %s", result.Version)
+			}
+			if result.BaseTag.ObjectID != tc.expectBase {
+				t.Fatalf("base tag: want %s got %+v", tc.expectBase, result.BaseTag)
+			}
+			if len(result.DuplicateTags) != 1 || result.DuplicateTags[0] != tc.expectDup {
+				t.Fatalf("duplicates: want [%s] got %v", tc.expectDup, result.DuplicateTags)
+			}
+		})
+	}
+}
+
+func TestPlanRCDeduplicatesCaseVariantPrereleases(t *testing.T) {
+	t.Parallel()
+
+	tags := []Tag{
+		{Name: "refs/tags/v1.2.3"},
+		{Name: "refs/tags/v1.3.0-rc.1"},
+		{Name: "refs/tags/V1.3.0-rc.1"},
+	}
+
+	result, err := NewPlanner("v").PlanRC(tags, bump.BumpMinor, "")
+	if err != nil {
+		t.Fatalf(errPlanRC, err)
+	}
+	if result.TagName != "v1.3.0-rc.2" {
+		t.Fatalf("tag name: want v1.3.0-rc.2 got %s", result.TagName)
+	}
+	if len(result.DuplicateTags) != 1 || result.DuplicateTags[0] != "V1.3.0-rc.1" {
+		t.Fatalf("duplicates: want [V1.3.0-rc.1] got %v", result.DuplicateTags)
+	}
+}
+
+func TestPlanReleaseWithoutDuplicates(t *testing.T) {
+	t.Parallel()
+
+	result, err := NewPlanner("v").PlanRelease([]Tag{{Name: "refs/tags/v1.0.0"}, {Name: "refs/tags/v1.1.0"}}, bump.BumpPatch, "")
+	if err != nil {
+		t.Fatalf(errPlanRelease, err)
+	}
+	if result.DuplicateTags != nil {
+		t.Fatalf("expected no duplicates, got %v", result.DuplicateTags)
+	}
+}
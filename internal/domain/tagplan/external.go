@@ -0,0 +1,33 @@
+package tagplan
+
+import (
+	"fmt"
+	"strings"
+
+	semver "github.com/blang/semver/v4"
+)
+
+// BaseSourceExternal indicates the base was supplied by an external version source instead of
+// the repository's release tags.
+const BaseSourceExternal BaseSource = "external-source"
+
+// WithCurrentVersion returns a copy of the planner that bumps from version instead of the
+// highest release tag. Existing tags still drive RC numbering and floating tags.
+func (p Planner) WithCurrentVersion(version string) Planner {
+	p.currentVersion = strings.TrimSpace(version)
+	return p
+}
+
+// chooseBase prefers the externally supplied current version over the release catalog.
+func (p Planner) chooseBase(releases []releaseEntry, baseOverride string) (semver.Version, BaseSource, error) {
+	if p.currentVersion == "" {
+		return chooseBaseRelease(releases, baseOverride)
+	}
+	version, err := parseVersionString(p.currentVersion)
+	if err != nil {
+		return semver.Version{}, "", fmt.Errorf("invalid current version: %w", err)
+	}
+	version.Pre = nil
+	version.Build = nil
+	return version, BaseSourceExternal, nil
+}
@@ -0,0 +1,60 @@
+package tagplan
+
+import (
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+)
+
+func TestPlanReleaseUsesCurrentVersion(t *testing.T) {
+	t.Parallel()
+
+	// v9.0.0 is above the computed version, so the downgrade guard is lifted.
+	planner := NewPlanner("v").WithCurrentVersion("v3.4.5").WithAllowDowngrade(true)
+	tags := []Tag{
+		{Name: "refs/tags/v1.2.3"},
+		{Name: "refs/tags/v9.0.0"},
+	}
+
+	result, err := planner.PlanRelease(tags, bump.BumpMinor, "0.1.0")
+	if err != nil {
+		t.Fatalf(errPlanRelease, err)
+	}
+
+	if result.TagName != "v3.5.0" {
+		t.Fatalf("tag name: want v3.5.0 got %s", result.TagName)
+	}
+	if result.BaseSource != BaseSourceExternal {
+		t.Fatalf("base source: want external got %s", result.BaseSource)
+	}
+	if result.BaseTag.Name != "" {
+		t.Fatalf("expected no base tag, got %s", result.BaseTag.Name)
+	}
+}
+
+func TestPlanRCUsesCurrentVersionWithExistingRCs(t *testing.T) {
+	t.Parallel()
+
+	planner := NewPlanner("v").WithCurrentVersion("3.4.5-rc.2+build.7")
+	tags := []Tag{
+		{Name: "refs/tags/v3.4.6-rc.1"},
+	}
+
+	result, err := planner.PlanRC(tags, bump.BumpPatch, "")
+	if err != nil {
+		t.Fatalf(errPlanRC, err)
+	}
+
+	if result.TagName != "v3.4.6-rc.2" {
+		t.Fatalf("tag name: want v3.4.6-rc.2 got %s", result.TagName)
+	}
+}
+
+func TestPlanReleaseRejectsInvalidCurrentVersion(t *testing.T) {
+	t.Parallel()
+
+	planner := NewPlanner("v").WithCurrentVersion("not-a-version")
+	if _, err := planner.PlanRelease(nil, bump.BumpPatch, ""); err == nil {
+		t.Fatalf("expected invalid current version error")
+	}
+}
@@ -0,0 +1,66 @@
+package tagplan
+
+import "sort"
+
+// FloatingMajors compares the floating major tags (v1, v2, ...) with the release majors.
+// Every released major from the lowest floating major up to the highest release major is
+// expected to carry a floating tag, and every floating tag is expected to have a release behind
+// it.
+type FloatingMajors struct {
+	// HighestMajor is the major of the highest release; Released is false when there is none.
+	HighestMajor uint64
+	Released     bool
+	// Floating maps each floating major found to its short tag name; Majors lists them ascending.
+	Floating map[uint64]string
+	Majors   []uint64
+	// Missing lists the released majors between the lowest floating major and HighestMajor
+	// without a floating tag.
+	Missing []uint64
+	// Extra lists floating majors with no release in their major, such as v2 when releases
+	// jump from v1.x to v3.x, or a major above HighestMajor.
+	Extra []uint64
+}
+
+// Contiguous reports that no floating majors are missing or extra. A repository without
+// floating tags is contiguous.
+func (f FloatingMajors) Contiguous() bool {
+	return len(f.Missing) == 0 && len(f.Extra) == 0
+}
+
+// FloatingMajors collects the floating major tags and stable releases in tags and reports the
+// floating majors that are missing or have no release behind them.
+func (p Planner) FloatingMajors(tags []Tag) FloatingMajors {
+	c := buildCatalog(tags, p.component, p.namePrefix())
+	result := FloatingMajors{Floating: make(map[uint64]string)}
+
+	released := make(map[uint64]bool)
+	for _, entry := range c.releases {
+		major := entry.version.Major
+		released[major] = true
+		if !result.Released || major > result.HighestMajor {
+			result.HighestMajor, result.Released = major, true
+		}
+	}
+	for _, entry := range c.floating {
+		if _, seen := result.Floating[entry.major]; seen {
+			continue
+		}
+		result.Floating[entry.major] = shortTagName(entry.tag.Name)
+		result.Majors = append(result.Majors, entry.major)
+	}
+	sort.Slice(result.Majors, func(i, j int) bool { return result.Majors[i] < result.Majors[j] })
+
+	for _, major := range result.Majors {
+		if !released[major] {
+			result.Extra = append(result.Extra, major)
+		}
+	}
+	if len(result.Majors) > 0 && result.Released {
+		for major := result.Majors[0]; major <= result.HighestMajor; major++ {
+			if _, ok := result.Floating[major]; !ok && released[major] {
+				result.Missing = append(result.Missing, major)
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,97 @@
+package tagplan
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFloatingMajors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		tags          []Tag
+		expectMajors  []uint64
+		expectMissing []uint64
+		expectExtra   []uint64
+	}{
+		{
+			name: "contiguous",
+			tags: []Tag{
+				{Name: "refs/tags/v1.4.0"},
+				{Name: "refs/tags/v2.0.1"},
+				{Name: "refs/tags/v1"},
+				{Name: "refs/tags/v2"},
+			},
+			expectMajors: []uint64{1, 2},
+		},
+		{
+			name: "missing released major",
+			tags: []Tag{
+				{Name: "refs/tags/v1.4.0"},
+				{Name: "refs/tags/v2.0.0"},
+				{Name: "refs/tags/v3.1.0"},
+				{Name: "refs/tags/v1"},
+				{Name: "refs/tags/v3"},
+			},
+			expectMajors:  []uint64{1, 3},
+			expectMissing: []uint64{2},
+		},
+		{
+			name: "orphaned floating in skipped major",
+			tags: []Tag{
+				{Name: "refs/tags/v1.4.0"},
+				{Name: "refs/tags/v3.0.0"},
+				{Name: "refs/tags/v1"},
+				{Name: "refs/tags/v2"},
+				{Name: "refs/tags/v3"},
+			},
+			expectMajors: []uint64{1, 2, 3},
+			expectExtra:  []uint64{2},
+		},
+		{
+			name: "floating above highest release",
+			tags: []Tag{
+				{Name: "refs/tags/v1.4.0"},
+				{Name: "refs/tags/v2.0.0-rc.1"},
+				{Name: "refs/tags/v1"},
+				{Name: "refs/tags/v2"},
+			},
+			expectMajors: []uint64{1, 2},
+			expectExtra:  []uint64{2},
+		},
+		{
+			name: "releases before floating adoption",
+			tags: []Tag{
+				{Name: "refs/tags/v1.4.0"},
+				{Name: "refs/tags/v2.0.0"},
+				{Name: "refs/tags/v2"},
+			},
+			expectMajors: []uint64{2},
+		},
+		{
+			name: "no floating tags",
+			tags: []Tag{{Name: "refs/tags/v1.0.0"}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			majors := NewPlanner("v").FloatingMajors(tc.tags)
+			if !reflect.DeepEqual(majors.Majors, tc.expectMajors) {
+				t.Fatalf("majors: want %v got %v", tc.expectMajors, majors.Majors)
+			}
+			if !reflect.DeepEqual(majors.Missing, tc.expectMissing) {
+				t.Fatalf("missing: want %v got %v", tc.expectMissing, majors.Missing)
+			}
+			if !reflect.DeepEqual(majors.Extra, tc.expectExtra) {
+				t.Fatalf("extra: want %v got %v", tc.expectExtra, majors.Extra)
+			}
+			if majors.Contiguous() != (len(tc.expectMissing) == 0 && len(tc.expectExtra) == 0) {
+				t.Fatalf("contiguous: got %t", majors.Contiguous())
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package tagplan
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	semver "github.com/blang/semver/v4"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+)
+
+// BaseSourceHotfix indicates the base was pinned to an existing release tag via a hotfix base.
+const BaseSourceHotfix BaseSource = "hotfix-base"
+
+var (
+	// ErrHotfixBaseNotFound indicates the requested hotfix base is not an existing release tag.
+	ErrHotfixBaseNotFound = errors.New("tagplan: hotfix base is not an existing release tag")
+	// ErrHotfixTargetExists indicates the next patch on the hotfix line has already been tagged.
+	ErrHotfixTargetExists = errors.New("tagplan: hotfix target release already exists")
+)
+
+// PlanHotfix determines the next patch release directly after the pinned hotfix base tag,
+// regardless of newer release lines. The floating plan targets the base's major and is marked
+// Superseded when a newer release already exists in that major.
+func (p Planner) PlanHotfix(tags []Tag, hotfixBase string) (Result, error) {
+	catalog := buildCatalog(tags, p.component, p.namePrefix())
+
+	base, ok := catalog.findRelease(hotfixBase)
+	if !ok {
+		return Result{}, fmt.Errorf("%w: %s", ErrHotfixBaseNotFound, strings.TrimSpace(hotfixBase))
+	}
+
+	next, err := bumpVersion(base.version, bump.BumpPatch)
+	if err != nil {
+		return Result{}, fmt.Errorf("computing hotfix bump: %w", err)
+	}
+	if err := p.limits.check(next); err != nil {
+		return Result{}, err
+	}
+	if err := p.versionRange.check(next); err != nil {
+		return Result{}, err
+	}
+	if _, exists := catalog.releaseForVersion(next); exists {
+		return Result{}, fmt.Errorf("%w: %s", ErrHotfixTargetExists, next.String())
+	}
+	highest, newHighest := catalog.highestRelease(next)
+
+	return Result{
+		Mode:           ModeRelease,
+		TagName:        p.formatTagName(next),
+		Version:        next,
+		ReleaseBase:    base.version,
+		BaseSource:     BaseSourceHotfix,
+		BaseTag:        base.tag,
+		TargetRelease:  next,
+		Floating:       p.lineFloating(catalog, next),
+		DuplicateTags:  catalog.duplicateNames(),
+		HighestRelease: highest,
+		NewHighest:     newHighest,
+	}, nil
+}
+
+// lineFloating plans the floating tags for a release on an older line, such as a hotfix: they
+// target the release's own major and minor and are Superseded when those already have a newer
+// release.
+func (p Planner) lineFloating(c catalog, next semver.Version) FloatingPlan {
+	floating := FloatingPlan{TagName: floatingTagName(p.component, next.Major)}
+	if existing, ok := c.floatingTagForMajor(next.Major); ok {
+		floating.Existing = existing
+	}
+	floating.AutoDetectedMajor = next.Major
+	floating.AutoDetected = c.hasValidFloatingForMajor(next.Major, p.floatingTrack)
+	floating.Superseded = c.hasReleaseAbove(next)
+	floating.Minor = planMinorFloating(c, p.component, next, p.floatingTrack)
+	floating.Minor.AutoDetectedMajor = next.Major
+	floating.Minor.AutoDetected = c.hasValidFloatingForMinor(next, p.component, p.floatingTrack)
+	floating.Minor.Superseded = c.hasReleaseAboveInMinor(next)
+	return floating
+}
+
+// findRelease matches a release by tag name (with or without refs/tags/) or by version.
+func (c catalog) findRelease(name string) (releaseEntry, bool) {
+	trimmed := strings.TrimPrefix(strings.TrimSpace(name), "refs/tags/")
+	if trimmed == "" {
+		return releaseEntry{}, false
+	}
+	for _, entry := range c.releases {
+		if strings.TrimPrefix(entry.tag.Name, "refs/tags/") == trimmed {
+			return entry, true
+		}
+	}
+	version, err := parseVersionString(trimmed)
+	if err != nil {
+		return releaseEntry{}, false
+	}
+	return c.releaseForVersion(version)
+}
+
+func (c catalog) releaseForVersion(version semver.Version) (releaseEntry, bool) {
+	for _, entry := range c.releases {
+		if entry.version.EQ(version) {
+			return entry, true
+		}
+	}
+	return releaseEntry{}, false
+}
+
+func (c catalog) hasReleaseAbove(version semver.Version) bool {
+	for _, entry := range c.releases {
+		if entry.version.Major == version.Major && entry.version.GT(version) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package tagplan
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPlanHotfixPinsBaseLine(t *testing.T) {
+	t.Parallel()
+
+	planner := NewPlanner("v")
+	tags := []Tag{
+		{Name: "refs/tags/v1.2.3", ObjectID: "c123"},
+		{Name: "refs/tags/v1.3.0", ObjectID: "c130"},
+		{Name: "refs/tags/v2.0.0", ObjectID: "c200"},
+		{Name: "refs/tags/v1", ObjectID: "c130", RefObjectID: "v1-object"},
+	}
+
+	result, err := planner.PlanHotfix(tags, "v1.2.3")
+	if err != nil {
+		t.Fatalf("plan hotfix: %v", err)
+	}
+
+	if result.TagName != "v1.2.4" || result.Mode != ModeRelease {
+		t.Fatalf("unexpected hotfix tag %s (%s)", result.TagName, result.Mode)
+	}
+	if result.BaseSource != BaseSourceHotfix || result.BaseTag.Name != "refs/tags/v1.2.3" {
+		t.Fatalf("expected hotfix base recorded, got %s %+v", result.BaseSource, result.BaseTag)
+	}
+	if result.Floating.TagName != "v1" || result.Floating.Existing.Name != "refs/tags/v1" {
+		t.Fatalf("expected floating plan for v1, got %+v", result.Floating)
+	}
+	if !result.Floating.Superseded {
+		t.Fatalf("expected floating tag to be superseded by v1.3.0")
+	}
+}
+
+func TestPlanHotfixLatestOnLineMovesFloating(t *testing.T) {
+	t.Parallel()
+
+	planner := NewPlanner("v")
+	tags := []Tag{
+		{Name: "refs/tags/v1.2.3", ObjectID: "c123"},
+		{Name: "refs/tags/v2.0.0", ObjectID: "c200"},
+		{Name: "refs/tags/v1", ObjectID: "c123"},
+	}
+
+	result, err := planner.PlanHotfix(tags, "1.2.3")
+	if err != nil {
+		t.Fatalf("plan hotfix: %v", err)
+	}
+	if result.Floating.Superseded || !result.Floating.AutoDetected || result.Floating.AutoDetectedMajor != 1 {
+		t.Fatalf("expected v1 floating to be eligible, got %+v", result.Floating)
+	}
+}
+
+func TestPlanHotfixErrors(t *testing.T) {
+	t.Parallel()
+
+	planner := NewPlanner("v")
+	tags := []Tag{
+		{Name: "refs/tags/v1.2.3"},
+		{Name: "refs/tags/v1.2.4"},
+		{Name: "refs/tags/v1.3.0-rc.1"},
+	}
+
+	if _, err := planner.PlanHotfix(tags, "v1.1.0"); !errors.Is(err, ErrHotfixBaseNotFound) {
+		t.Fatalf("expected ErrHotfixBaseNotFound, got %v", err)
+	}
+	if _, err := planner.PlanHotfix(tags, "v1.3.0-rc.1"); !errors.Is(err, ErrHotfixBaseNotFound) {
+		t.Fatalf("expected prerelease base to be rejected, got %v", err)
+	}
+	if _, err := planner.PlanHotfix(tags, "v1.2.3"); !errors.Is(err, ErrHotfixTargetExists) {
+		t.Fatalf("expected ErrHotfixTargetExists, got %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+package tagplan
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// ErrInvalidIgnoreTag is returned when an ignore pattern is not a valid glob.
+var ErrInvalidIgnoreTag = errors.New("tagplan: invalid ignore tag pattern")
+
+// ParseIgnoreTags trims and validates ignore patterns. Patterns are globs over short tag names
+// such as "*-yanked" or "v1.3.0"; a refs/tags/ prefix is accepted and dropped.
+func ParseIgnoreTags(values []string) ([]string, error) {
+	patterns := make([]string, 0, len(values))
+	for _, value := range values {
+		pattern := shortTagName(value)
+		if pattern == "" {
+			continue
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidIgnoreTag, pattern)
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, nil
+}
+
+// WithIgnoreTags returns a copy of the planner that never bases a release or RC on a retracted
+// release. A release is retracted when its own tag matches one of patterns or when a matching
+// marker tag extends its name with "-", so "*-yanked" and a v1.3.0-yanked tag retract v1.3.0
+// while its tag stays in place.
+func (p Planner) WithIgnoreTags(patterns []string) Planner {
+	p.ignoreTags = append([]string(nil), patterns...)
+	return p
+}
+
+// baseCandidates returns the releases eligible as a base: inside the version range and not
+// retracted. The second value names the retracted release tags.
+func (p Planner) baseCandidates(c catalog) ([]releaseEntry, []string) {
+	if len(p.ignoreTags) == 0 {
+		return p.versionRange.filter(c.releases), nil
+	}
+	var markers []string
+	for _, group := range [][]releaseEntry{c.releases, c.prereleases} {
+		for _, entry := range group {
+			if p.ignored(entry.tag.Name) {
+				markers = append(markers, shortTagName(entry.tag.Name))
+			}
+		}
+	}
+	for _, tag := range c.unparsed {
+		if p.ignored(tag.Name) {
+			markers = append(markers, shortTagName(tag.Name))
+		}
+	}
+
+	releases := make([]releaseEntry, 0, len(c.releases))
+	var retracted []string
+	for _, entry := range c.releases {
+		if name := shortTagName(entry.tag.Name); retractedBy(name, markers) {
+			retracted = append(retracted, name)
+			continue
+		}
+		releases = append(releases, entry)
+	}
+	return p.versionRange.filter(releases), retracted
+}
+
+// retractedBy reports whether name is one of markers or is extended by one ("v1.3.0" by
+// "v1.3.0-yanked").
+func retractedBy(name string, markers []string) bool {
+	for _, marker := range markers {
+		if marker == name || strings.HasPrefix(marker, name+"-") {
+			return true
+		}
+	}
+	return false
+}
+
+// ignored reports whether name matches an ignore pattern. With a component, the pattern may
+// match either the full name ("api/v1.3.0") or the name relative to the component.
+func (p Planner) ignored(name string) bool {
+	short := shortTagName(name)
+	relative, _ := stripComponent(name, p.component)
+	for _, pattern := range p.ignoreTags {
+		if ok, _ := path.Match(pattern, short); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, relative); ok && p.component != "" {
+			return true
+		}
+	}
+	return false
+}
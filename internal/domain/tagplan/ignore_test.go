@@ -0,0 +1,83 @@
+package tagplan
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+)
+
+func TestPlanIgnoresRetractedReleases(t *testing.T) {
+	t.Parallel()
+
+	tags := []Tag{
+		{Name: "refs/tags/v1.2.0"},
+		{Name: "refs/tags/v1.3.0"},
+		{Name: "refs/tags/v1.3.0-yanked"},
+		{Name: "refs/tags/v1.3.0-rc.1"},
+	}
+
+	tests := []struct {
+		name          string
+		patterns      []string
+		mode          Mode
+		expectTag     string
+		expectBase    string
+		expectIgnored []string
+	}{
+		{name: "no patterns uses the highest release", mode: ModeRelease, expectTag: "v1.3.1", expectBase: "1.3.0"},
+		{name: "yanked release skipped for release", patterns: []string{"v1.3.0"}, mode: ModeRelease, expectTag: "v1.2.1", expectBase: "1.2.0", expectIgnored: []string{"v1.3.0"}},
+		{name: "yanked release skipped for rc", patterns: []string{"refs/tags/v1.3.*"}, mode: ModeRC, expectTag: "v1.2.1-rc.1", expectBase: "1.2.0", expectIgnored: []string{"v1.3.0"}},
+		{name: "marker tag retracts the release it extends", patterns: []string{"*-yanked"}, mode: ModeRelease, expectTag: "v1.2.1", expectBase: "1.2.0", expectIgnored: []string{"v1.3.0"}},
+		{name: "unmatched pattern changes nothing", patterns: []string{"*-withdrawn"}, mode: ModeRelease, expectTag: "v1.3.1", expectBase: "1.3.0"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			patterns, err := ParseIgnoreTags(tc.patterns)
+			if err != nil {
+				t.Fatalf("parse ignore tags: %v", err)
+			}
+			planner := NewPlanner("v").WithIgnoreTags(patterns)
+			var result Result
+			if tc.mode == ModeRC {
+				result, err = planner.PlanRC(tags, bump.BumpPatch, "")
+			} else {
+				result, err = planner.PlanRelease(tags, bump.BumpPatch, "")
+			}
+			if err != nil {
+				t.Fatalf("plan: %v", err)
+			}
+			if result.TagName != tc.expectTag || result.ReleaseBase.String() != tc.expectBase {
+				t.Fatalf("expected %s from %s, got %s from %s", tc.expectTag, tc.expectBase, result.TagName, result.ReleaseBase)
+			}
+			if !reflect.DeepEqual(result.IgnoredTags, tc.expectIgnored) {
+				t.Fatalf("expected ignored %v, got %v", tc.expectIgnored, result.IgnoredTags)
+			}
+		})
+	}
+}
+
+func TestIgnoreTagsMatchComponentRelativeNames(t *testing.T) {
+	t.Parallel()
+
+	tags := []Tag{{Name: "refs/tags/api/v1.2.0"}, {Name: "refs/tags/api/v1.3.0"}}
+	result, err := NewPlanner("v").WithComponent("api").WithIgnoreTags([]string{"v1.3.0"}).PlanRelease(tags, bump.BumpPatch, "")
+	if err != nil {
+		t.Fatalf("plan: %v", err)
+	}
+	if result.TagName != "api/v1.2.1" {
+		t.Fatalf("expected api/v1.2.1, got %s", result.TagName)
+	}
+}
+
+func TestParseIgnoreTagsRejectsInvalidGlob(t *testing.T) {
+	t.Parallel()
+
+	if _, err := ParseIgnoreTags([]string{"v1.["}); !errors.Is(err, ErrInvalidIgnoreTag) {
+		t.Fatalf("expected ErrInvalidIgnoreTag, got %v", err)
+	}
+}
@@ -0,0 +1,115 @@
+package tagplan
+
+import (
+	"errors"
+	"sort"
+
+	semver "github.com/blang/semver/v4"
+)
+
+// ErrInventoryFilterConflict is returned when an inventory is asked for only releases and only
+// prereleases at once.
+var ErrInventoryFilterConflict = errors.New("tagplan: releases-only and prereleases-only are mutually exclusive")
+
+// InventoryFilter narrows an Inventory. The zero value lists every tag.
+type InventoryFilter struct {
+	ReleasesOnly    bool
+	PrereleasesOnly bool
+	// Major keeps only versions and floating tags of this major when HasMajor is set.
+	Major    uint64
+	HasMajor bool
+}
+
+// active reports that any filter is set; unparsed tags carry no version to filter on, so they
+// are only listed by an unfiltered inventory.
+func (f InventoryFilter) active() bool {
+	return f.ReleasesOnly || f.PrereleasesOnly || f.HasMajor
+}
+
+// InventoryTag is one tag of an Inventory. Version is the zero value for unparsed tags.
+type InventoryTag struct {
+	Tag     Tag
+	TagName string
+	Version semver.Version
+}
+
+// Inventory sorts a repository's tags the way the planner reads them. Unlike planning, every
+// tag is kept: duplicates of a version and ignored tags are listed with the others, and names
+// that are neither versions nor floating tags land in Unparsed instead of being dropped.
+type Inventory struct {
+	// Releases and Prereleases are in ascending semver order.
+	Releases    []InventoryTag
+	Prereleases []InventoryTag
+	// Floating holds the major (v1) and minor (v1.2) floating tags, ordered by version.
+	Floating []InventoryTag
+	// Unparsed holds the remaining tags ordered by name.
+	Unparsed []InventoryTag
+}
+
+// Inventory classifies tags with the planner's prefix, separator, and component and applies
+// filter. With a component, tags outside "<component>/" are left out rather than reported as
+// unparsed.
+func (p Planner) Inventory(tags []Tag, filter InventoryFilter) (Inventory, error) {
+	if filter.ReleasesOnly && filter.PrereleasesOnly {
+		return Inventory{}, ErrInventoryFilterConflict
+	}
+
+	var inventory Inventory
+	for _, tag := range tags {
+		if _, ok := stripComponent(tag.Name, p.component); !ok {
+			continue
+		}
+		entry := InventoryTag{Tag: tag, TagName: shortTagName(tag.Name)}
+		if version, ok := parseSemverTag(tag.Name, p.component, p.namePrefix()); ok {
+			entry.Version = version
+			if filter.HasMajor && version.Major != filter.Major {
+				continue
+			}
+			if len(version.Pre) == 0 && !filter.PrereleasesOnly {
+				inventory.Releases = append(inventory.Releases, entry)
+			} else if len(version.Pre) > 0 && !filter.ReleasesOnly {
+				inventory.Prereleases = append(inventory.Prereleases, entry)
+			}
+			continue
+		}
+		if version, ok := p.floatingVersion(tag.Name); ok {
+			entry.Version = version
+			if !filter.ReleasesOnly && !filter.PrereleasesOnly && (!filter.HasMajor || version.Major == filter.Major) {
+				inventory.Floating = append(inventory.Floating, entry)
+			}
+			continue
+		}
+		if !filter.active() {
+			inventory.Unparsed = append(inventory.Unparsed, entry)
+		}
+	}
+
+	sortByVersion(inventory.Releases)
+	sortByVersion(inventory.Prereleases)
+	sortByVersion(inventory.Floating)
+	sort.SliceStable(inventory.Unparsed, func(i, j int) bool {
+		return inventory.Unparsed[i].TagName < inventory.Unparsed[j].TagName
+	})
+	return inventory, nil
+}
+
+// floatingVersion parses a major or minor floating tag name, returning its major.minor.0.
+func (p Planner) floatingVersion(name string) (semver.Version, bool) {
+	if major, ok := parseFloatingTag(name, p.component); ok {
+		return semver.Version{Major: major}, true
+	}
+	if major, minor, ok := parseMinorFloatingTag(name, p.component); ok {
+		return semver.Version{Major: major, Minor: minor}, true
+	}
+	return semver.Version{}, false
+}
+
+// sortByVersion orders tags by version, breaking ties (such as v1 and v1.0) by name.
+func sortByVersion(tags []InventoryTag) {
+	sort.SliceStable(tags, func(i, j int) bool {
+		if cmp := tags[i].Version.Compare(tags[j].Version); cmp != 0 {
+			return cmp < 0
+		}
+		return tags[i].TagName < tags[j].TagName
+	})
+}
@@ -0,0 +1,127 @@
+package tagplan
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func inventoryNames(tags []InventoryTag) []string {
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		names = append(names, tag.TagName)
+	}
+	return names
+}
+
+func TestInventorySortsAndSeparatesTags(t *testing.T) {
+	t.Parallel()
+
+	tags := []Tag{
+		{Name: "refs/tags/v1.10.0"},
+		{Name: "refs/tags/v1.2.0-rc.10"},
+		{Name: "refs/tags/v1.2.0"},
+		{Name: "refs/tags/V1.2.0"},
+		{Name: "refs/tags/v1.2.0-rc.2"},
+		{Name: "refs/tags/v2.0.0"},
+		{Name: "refs/tags/v1.9.0"},
+		{Name: "refs/tags/v2"},
+		{Name: "refs/tags/v1.2"},
+		{Name: "refs/tags/v1"},
+		{Name: "refs/tags/nightly"},
+		{Name: "refs/tags/latest"},
+	}
+
+	inventory, err := NewPlanner("v").Inventory(tags, InventoryFilter{})
+	if err != nil {
+		t.Fatalf("inventory: %v", err)
+	}
+	checks := []struct {
+		section string
+		got     []InventoryTag
+		want    []string
+	}{
+		// Both spellings of 1.2.0 are listed; planning would drop one as a duplicate.
+		{"releases", inventory.Releases, []string{"V1.2.0", "v1.2.0", "v1.9.0", "v1.10.0", "v2.0.0"}},
+		{"prereleases", inventory.Prereleases, []string{"v1.2.0-rc.2", "v1.2.0-rc.10"}},
+		{"floating", inventory.Floating, []string{"v1", "v1.2", "v2"}},
+		{"unparsed", inventory.Unparsed, []string{"latest", "nightly"}},
+	}
+	for _, check := range checks {
+		if got := inventoryNames(check.got); !slices.Equal(got, check.want) {
+			t.Fatalf("%s: expected %v, got %v", check.section, check.want, got)
+		}
+	}
+}
+
+func TestInventoryFilters(t *testing.T) {
+	t.Parallel()
+
+	tags := []Tag{
+		{Name: "refs/tags/v1.2.0"},
+		{Name: "refs/tags/v1.3.0-rc.1"},
+		{Name: "refs/tags/v2.0.0"},
+		{Name: "refs/tags/v2.1.0-rc.1"},
+		{Name: "refs/tags/v1"},
+		{Name: "refs/tags/v2"},
+		{Name: "refs/tags/latest"},
+	}
+
+	tests := []struct {
+		name   string
+		filter InventoryFilter
+		want   []string
+	}{
+		{name: "releases only", filter: InventoryFilter{ReleasesOnly: true}, want: []string{"v1.2.0", "v2.0.0"}},
+		{name: "prereleases only", filter: InventoryFilter{PrereleasesOnly: true}, want: []string{"v1.3.0-rc.1", "v2.1.0-rc.1"}},
+		{name: "major", filter: InventoryFilter{Major: 2, HasMajor: true}, want: []string{"v2.0.0", "v2.1.0-rc.1", "v2"}},
+		{name: "major releases", filter: InventoryFilter{ReleasesOnly: true, Major: 1, HasMajor: true}, want: []string{"v1.2.0"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			inventory, err := NewPlanner("v").Inventory(tags, tc.filter)
+			if err != nil {
+				t.Fatalf("inventory: %v", err)
+			}
+			var got []string
+			for _, section := range [][]InventoryTag{inventory.Releases, inventory.Prereleases, inventory.Floating, inventory.Unparsed} {
+				got = append(got, inventoryNames(section)...)
+			}
+			if !slices.Equal(got, tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+
+	if _, err := NewPlanner("v").Inventory(tags, InventoryFilter{ReleasesOnly: true, PrereleasesOnly: true}); !errors.Is(err, ErrInventoryFilterConflict) {
+		t.Fatalf("expected ErrInventoryFilterConflict, got %v", err)
+	}
+}
+
+func TestInventoryWithComponentSkipsOtherTags(t *testing.T) {
+	t.Parallel()
+
+	tags := []Tag{
+		{Name: "refs/tags/api/v1.2.0"},
+		{Name: "refs/tags/api/v1"},
+		{Name: "refs/tags/api/notes"},
+		{Name: "refs/tags/web/v3.0.0"},
+		{Name: "refs/tags/v9.9.9"},
+	}
+
+	inventory, err := NewPlanner("v").WithComponent("api").Inventory(tags, InventoryFilter{})
+	if err != nil {
+		t.Fatalf("inventory: %v", err)
+	}
+	if got := inventoryNames(inventory.Releases); !slices.Equal(got, []string{"api/v1.2.0"}) {
+		t.Fatalf("expected only api releases, got %v", got)
+	}
+	if got := inventoryNames(inventory.Floating); !slices.Equal(got, []string{"api/v1"}) {
+		t.Fatalf("expected api/v1 floating, got %v", got)
+	}
+	if got := inventoryNames(inventory.Unparsed); !slices.Equal(got, []string{"api/notes"}) {
+		t.Fatalf("expected api/notes unparsed, got %v", got)
+	}
+}
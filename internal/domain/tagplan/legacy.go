@@ -0,0 +1,71 @@
+package tagplan
+
+import (
+	"regexp"
+	"strings"
+)
+
+// LegacyScheme names a pre-semver tag naming convention the planner recognizes but cannot bump.
+type LegacyScheme string
+
+const (
+	// LegacySchemeDate matches date-based tags such as 2024.01.15, 2024-01-15, or 20240115.1.
+	LegacySchemeDate LegacyScheme = "date"
+	// LegacySchemeMajorMinor matches two-part versions such as v1.4.
+	LegacySchemeMajorMinor LegacyScheme = "major-minor"
+)
+
+var legacyPatterns = []struct {
+	scheme  LegacyScheme
+	pattern *regexp.Regexp
+}{
+	{scheme: LegacySchemeDate, pattern: regexp.MustCompile(`^(19|20)\d{2}([.\-_]?)(0[1-9]|1[0-2])[.\-_]?(0[1-9]|[12]\d|3[01])([.\-_]\d+)?$`)},
+	{scheme: LegacySchemeMajorMinor, pattern: regexp.MustCompile(`^\d+\.\d+$`)},
+}
+
+// LegacyTag is a tag that did not parse as a version but matches a known legacy scheme.
+type LegacyTag struct {
+	Name   string
+	Scheme LegacyScheme
+}
+
+// legacyTagsFor reports legacy tags only when they explain a 0.0.0 fallback.
+func (p Planner) legacyTagsFor(c catalog, source BaseSource) []LegacyTag {
+	if source != BaseSourceZero {
+		return nil
+	}
+	return c.legacyTags(p.component, p.namePrefix())
+}
+
+// legacyTags classifies the unparsed tags against the known legacy schemes.
+func (c catalog) legacyTags(component, namePrefix string) []LegacyTag {
+	var found []LegacyTag
+	for _, tag := range c.unparsed {
+		name, ok := stripComponent(tag.Name, component)
+		if !ok {
+			continue
+		}
+		if scheme, ok := legacySchemeOf(name, namePrefix); ok {
+			found = append(found, LegacyTag{Name: shortTagName(tag.Name), Scheme: scheme})
+		}
+	}
+	return found
+}
+
+func legacySchemeOf(name, namePrefix string) (LegacyScheme, bool) {
+	candidates := []string{name}
+	if namePrefix != "" && strings.HasPrefix(name, namePrefix) {
+		candidates = append(candidates, strings.TrimPrefix(name, namePrefix))
+	}
+	if len(name) > 1 && (name[0] == 'v' || name[0] == 'V') {
+		candidates = append(candidates, name[1:])
+	}
+	for _, candidate := range candidates {
+		for _, legacy := range legacyPatterns {
+			if legacy.pattern.MatchString(candidate) {
+				return legacy.scheme, true
+			}
+		}
+	}
+	return "", false
+}
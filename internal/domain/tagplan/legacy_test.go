@@ -0,0 +1,91 @@
+package tagplan
+
+import (
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+)
+
+func TestPlanReleaseReportsLegacyTags(t *testing.T) {
+	t.Parallel()
+
+	planner := NewPlanner("v")
+	tags := []Tag{
+		{Name: "refs/tags/2023.09.30"},
+		{Name: "refs/tags/2024-01-15.2"},
+		{Name: "refs/tags/20240301"},
+		{Name: "refs/tags/v3.1"},
+		{Name: "refs/tags/nightly"},
+		{Name: "refs/tags/v1.3.0-rc.1"},
+		{Name: "refs/tags/v1"},
+	}
+
+	result, err := planner.PlanRelease(tags, bump.BumpPatch, "")
+	if err != nil {
+		t.Fatalf(errPlanRelease, err)
+	}
+
+	if result.BaseSource != BaseSourceZero {
+		t.Fatalf("base source: want zero got %s", result.BaseSource)
+	}
+	expect := []LegacyTag{
+		{Name: "2023.09.30", Scheme: LegacySchemeDate},
+		{Name: "2024-01-15.2", Scheme: LegacySchemeDate},
+		{Name: "20240301", Scheme: LegacySchemeDate},
+		{Name: "v3.1", Scheme: LegacySchemeMajorMinor},
+	}
+	if len(result.LegacyTags) != len(expect) {
+		t.Fatalf("expected legacy tags %v, got %v", expect, result.LegacyTags)
+	}
+	for i, tag := range expect {
+		if result.LegacyTags[i] != tag {
+			t.Fatalf("expected legacy tags %v, got %v", expect, result.LegacyTags)
+		}
+	}
+}
+
+func TestPlanLegacyTagsOnlyOnZeroFallback(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		tags     []Tag
+		override string
+	}{
+		{name: "release tag present", tags: []Tag{{Name: "refs/tags/2024.01.15"}, {Name: "refs/tags/v0.4.0"}}},
+		{name: "base version configured", tags: []Tag{{Name: "refs/tags/2024.01.15"}}, override: "1.0.0"},
+		{name: "no legacy tags", tags: []Tag{{Name: "refs/tags/nightly"}, {Name: "refs/tags/2024.13.40"}}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := NewPlanner("v").PlanRC(tc.tags, bump.BumpMinor, tc.override)
+			if err != nil {
+				t.Fatalf(errPlanRC, err)
+			}
+			if len(result.LegacyTags) != 0 {
+				t.Fatalf("expected no legacy tags, got %v", result.LegacyTags)
+			}
+		})
+	}
+}
+
+func TestLegacyTagsRespectComponent(t *testing.T) {
+	t.Parallel()
+
+	planner := NewPlanner("v").WithComponent("api")
+	tags := []Tag{
+		{Name: "refs/tags/api/2024.01.15"},
+		{Name: "refs/tags/web/2024.01.15"},
+	}
+
+	result, err := planner.PlanRelease(tags, bump.BumpPatch, "")
+	if err != nil {
+		t.Fatalf(errPlanRelease, err)
+	}
+	if len(result.LegacyTags) != 1 || result.LegacyTags[0].Name != "api/2024.01.15" {
+		t.Fatalf("expected only the component's legacy tag, got %v", result.LegacyTags)
+	}
+}
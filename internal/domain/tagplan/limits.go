@@ -0,0 +1,44 @@
+package tagplan
+
+import (
+	"errors"
+	"fmt"
+
+	semver "github.com/blang/semver/v4"
+)
+
+// ErrVersionLimit is returned when a computed version component exceeds its configured maximum.
+var ErrVersionLimit = errors.New("tagplan: computed version exceeds limit")
+
+// Limits caps the components of computed versions to catch mistyped inputs such as a base
+// version of 100.0.0. Zero values disable the corresponding check.
+type Limits struct {
+	MaxMajor uint64
+	MaxMinor uint64
+	MaxPatch uint64
+}
+
+// WithLimits returns a copy of the planner that rejects computed versions beyond limits.
+func (p Planner) WithLimits(limits Limits) Planner {
+	p.limits = limits
+	return p
+}
+
+// check reports the first component of version that exceeds its maximum.
+func (l Limits) check(version semver.Version) error {
+	components := []struct {
+		name  string
+		value uint64
+		max   uint64
+	}{
+		{name: "major", value: version.Major, max: l.MaxMajor},
+		{name: "minor", value: version.Minor, max: l.MaxMinor},
+		{name: "patch", value: version.Patch, max: l.MaxPatch},
+	}
+	for _, c := range components {
+		if c.max > 0 && c.value > c.max {
+			return fmt.Errorf("%w: %s %d is above the maximum %d (computed %s)", ErrVersionLimit, c.name, c.value, c.max, version.String())
+		}
+	}
+	return nil
+}
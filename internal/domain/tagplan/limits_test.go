@@ -0,0 +1,68 @@
+package tagplan
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+)
+
+func TestPlanReleaseVersionLimits(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		limits     Limits
+		base       string
+		intent     bump.Bump
+		expectTag  string
+		expectText string
+	}{
+		{name: "no limits", base: "100.0.0", intent: bump.BumpPatch, expectTag: "v100.0.1"},
+		{name: "within limits", limits: Limits{MaxMajor: 5, MaxMinor: 50, MaxPatch: 500}, base: "1.2.3", intent: bump.BumpMinor, expectTag: "v1.3.0"},
+		{name: "at the limit", limits: Limits{MaxMajor: 2}, base: "1.9.9", intent: bump.BumpMajor, expectTag: "v2.0.0"},
+		{name: "major beyond limit", limits: Limits{MaxMajor: 5}, base: "100.0.0", intent: bump.BumpPatch, expectText: "major 100 is above the maximum 5 (computed 100.0.1)"},
+		{name: "minor beyond limit", limits: Limits{MaxMinor: 10}, base: "1.10.0", intent: bump.BumpMinor, expectText: "minor 11 is above the maximum 10 (computed 1.11.0)"},
+		{name: "patch beyond limit", limits: Limits{MaxPatch: 99}, base: "1.0.99", intent: bump.BumpPatch, expectText: "patch 100 is above the maximum 99 (computed 1.0.100)"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := NewPlanner("v").WithLimits(tc.limits).PlanRelease(nil, tc.intent, tc.base)
+			if tc.expectText != "" {
+				if !errors.Is(err, ErrVersionLimit) {
+					t.Fatalf("expected ErrVersionLimit, got %v", err)
+				}
+				if !strings.Contains(err.Error(), tc.expectText) {
+					t.Fatalf("expected error to mention %q, got %q", tc.expectText, err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf(errPlanRelease, err)
+			}
+			if result.TagName != tc.expectTag {
+				t.Fatalf("tag name: want %s got %s", tc.expectTag, result.TagName)
+			}
+		})
+	}
+}
+
+func TestPlanRCVersionLimits(t *testing.T) {
+	t.Parallel()
+
+	planner := NewPlanner("v").WithLimits(Limits{MaxMajor: 3})
+	if _, err := planner.PlanRC(nil, bump.BumpMajor, "3.1.0"); !errors.Is(err, ErrVersionLimit) {
+		t.Fatalf("expected ErrVersionLimit for RC target, got %v", err)
+	}
+	result, err := planner.PlanRC(nil, bump.BumpMinor, "3.1.0")
+	if err != nil {
+		t.Fatalf(errPlanRC, err)
+	}
+	if result.TagName != "v3.2.0-rc.1" {
+		t.Fatalf("tag name: want v3.2.0-rc.1 got %s", result.TagName)
+	}
+}
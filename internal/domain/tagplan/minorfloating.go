@@ -0,0 +1,89 @@
+package tagplan
+
+import (
+	"fmt"
+	"strings"
+
+	semver "github.com/blang/semver/v4"
+)
+
+// minorFloatingTagName returns the v<major>.<minor> floating tag name for version.
+func minorFloatingTagName(component string, version semver.Version) string {
+	return fmt.Sprintf("%sv%d.%d", componentPrefix(component), version.Major, version.Minor)
+}
+
+// parseMinorFloatingTag parses a v<major>.<minor> floating tag name. Such names never parse as
+// releases, which always have three version parts, so v1.2 is not mistaken for v1.2.0.
+func parseMinorFloatingTag(name, component string) (uint64, uint64, bool) {
+	trimmed, ok := stripComponent(name, component)
+	if !ok || len(trimmed) <= 1 || (trimmed[0] != 'v' && trimmed[0] != 'V') {
+		return 0, 0, false
+	}
+	majorDigits, minorDigits, found := strings.Cut(trimmed[1:], ".")
+	if !found {
+		return 0, 0, false
+	}
+	major, ok := parseDigits(majorDigits)
+	if !ok {
+		return 0, 0, false
+	}
+	minor, ok := parseDigits(minorDigits)
+	if !ok {
+		return 0, 0, false
+	}
+	return major, minor, true
+}
+
+// minorFloatingTag returns the existing floating tag for major.minor. Minor floating tags stay
+// among the unparsed tags so that two-part legacy names are still reported as such.
+func (c catalog) minorFloatingTag(major, minor uint64, component string) (Tag, bool) {
+	for _, tag := range c.unparsed {
+		if tagMajor, tagMinor, ok := parseMinorFloatingTag(tag.Name, component); ok && tagMajor == major && tagMinor == minor {
+			return tag, true
+		}
+	}
+	return Tag{}, false
+}
+
+// hasValidFloatingForMinor reports whether the floating ref for version's major.minor points
+// at a tag it tracks in that minor line.
+func (c catalog) hasValidFloatingForMinor(version semver.Version, component string, track FloatingTrack) bool {
+	tag, ok := c.minorFloatingTag(version.Major, version.Minor, component)
+	if !ok || tag.ObjectID == "" {
+		return false
+	}
+	for _, release := range c.tracked(track) {
+		if release.version.Major != version.Major || release.version.Minor != version.Minor {
+			continue
+		}
+		if release.tag.ObjectID != "" && release.tag.ObjectID == tag.ObjectID {
+			return true
+		}
+	}
+	return false
+}
+
+// hasReleaseAboveInMinor reports whether a newer release exists in version's minor line.
+func (c catalog) hasReleaseAboveInMinor(version semver.Version) bool {
+	for _, entry := range c.releases {
+		if entry.version.Major == version.Major && entry.version.Minor == version.Minor && entry.version.GT(version) {
+			return true
+		}
+	}
+	return false
+}
+
+// planMinorFloating plans the v<major>.<minor> floating tag for target. It is auto-detected
+// when the minor floating tag of the highest tracked release already points at a release in
+// that line, mirroring the major tag.
+func planMinorFloating(c catalog, component string, target semver.Version, track FloatingTrack) *FloatingPlan {
+	plan := &FloatingPlan{TagName: minorFloatingTagName(component, target)}
+	if existing, ok := c.minorFloatingTag(target.Major, target.Minor, component); ok {
+		plan.Existing = existing
+	}
+	if highest, ok := highestEntry(c.tracked(track)); ok {
+		plan.AutoDetectedMajor = highest.version.Major
+		plan.AutoDetected = c.hasValidFloatingForMinor(highest.version, component, track)
+	}
+	return plan
+}
@@ -0,0 +1,123 @@
+package tagplan
+
+import (
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+)
+
+func TestParseMinorFloatingTag(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		component   string
+		expectMajor uint64
+		expectMinor uint64
+		expectOK    bool
+	}{
+		{name: "refs/tags/v1.2", expectMajor: 1, expectMinor: 2, expectOK: true},
+		{name: "V10.0", expectMajor: 10, expectMinor: 0, expectOK: true},
+		{name: "refs/tags/api/v1.2", component: "api", expectMajor: 1, expectMinor: 2, expectOK: true},
+		{name: "refs/tags/v1.2.0"},
+		{name: "refs/tags/v1"},
+		{name: "refs/tags/v1."},
+		{name: "refs/tags/1.2"},
+		{name: "refs/tags/v1.2-rc"},
+		{name: "refs/tags/v1.2", component: "api"},
+	}
+	for _, tc := range tests {
+		major, minor, ok := parseMinorFloatingTag(tc.name, tc.component)
+		if ok != tc.expectOK || major != tc.expectMajor || minor != tc.expectMinor {
+			t.Errorf("%s (component %q): expected %d.%d ok=%t, got %d.%d ok=%t",
+				tc.name, tc.component, tc.expectMajor, tc.expectMinor, tc.expectOK, major, minor, ok)
+		}
+	}
+}
+
+func TestPlanReleaseMinorFloating(t *testing.T) {
+	t.Parallel()
+
+	tags := []Tag{
+		{Name: "refs/tags/v1.2.3", ObjectID: "release-commit"},
+		{Name: "refs/tags/v1.2", ObjectID: "release-commit"},
+		{Name: "refs/tags/v1", ObjectID: "release-commit"},
+	}
+
+	patch, err := NewPlanner("v").PlanRelease(tags, bump.BumpPatch, "")
+	if err != nil {
+		t.Fatalf("plan: %v", err)
+	}
+	minor := patch.Floating.Minor
+	if minor == nil || minor.TagName != "v1.2" || minor.Existing.Name != "refs/tags/v1.2" || !minor.AutoDetected {
+		t.Fatalf("expected v1.2 detected and replaced, got %+v", minor)
+	}
+
+	next, err := NewPlanner("v").PlanRelease(tags, bump.BumpMinor, "")
+	if err != nil {
+		t.Fatalf("plan: %v", err)
+	}
+	if minor := next.Floating.Minor; minor.TagName != "v1.3" || minor.Existing.Name != "" || !minor.AutoDetected {
+		t.Fatalf("expected a new v1.3 with usage detected from v1.2, got %+v", minor)
+	}
+
+	stale := []Tag{
+		{Name: "refs/tags/v1.2.3", ObjectID: "release-commit"},
+		{Name: "refs/tags/v1.2", ObjectID: "older-commit"},
+	}
+	result, err := NewPlanner("v").PlanRelease(stale, bump.BumpPatch, "")
+	if err != nil {
+		t.Fatalf("plan: %v", err)
+	}
+	if result.Floating.Minor.AutoDetected {
+		t.Fatal("expected no detection when v1.2 points at no release")
+	}
+}
+
+func TestMinorFloatingTagIsNotARelease(t *testing.T) {
+	t.Parallel()
+
+	tags := []Tag{
+		{Name: "refs/tags/v1.1.0", ObjectID: "old-release"},
+		{Name: "refs/tags/v1.2", ObjectID: "old-release"},
+	}
+	result, err := NewPlanner("v").PlanRelease(tags, bump.BumpMinor, "")
+	if err != nil {
+		t.Fatalf("plan: %v", err)
+	}
+	if result.TagName != "v1.2.0" || result.ReleaseBase.String() != "1.1.0" {
+		t.Fatalf("expected v1.2.0 from 1.1.0, got %s from %s", result.TagName, result.ReleaseBase)
+	}
+	if result.Floating.Minor.Existing.Name != "refs/tags/v1.2" {
+		t.Fatalf("expected v1.2 planned as the floating tag to move, got %+v", result.Floating.Minor)
+	}
+}
+
+func TestPlanHotfixMinorFloatingSuperseded(t *testing.T) {
+	t.Parallel()
+
+	tags := []Tag{
+		{Name: "refs/tags/v1.2.3", ObjectID: "a"},
+		{Name: "refs/tags/v1.2.5", ObjectID: "b"},
+		{Name: "refs/tags/v1.3.0", ObjectID: "c"},
+	}
+
+	behind, err := NewPlanner("v").PlanHotfix(tags, "v1.2.3")
+	if err != nil {
+		t.Fatalf("hotfix: %v", err)
+	}
+	if minor := behind.Floating.Minor; minor.TagName != "v1.2" || !minor.Superseded {
+		t.Fatalf("expected v1.2 left on v1.2.5, got %+v", minor)
+	}
+
+	latest, err := NewPlanner("v").PlanHotfix(tags, "v1.2.5")
+	if err != nil {
+		t.Fatalf("hotfix: %v", err)
+	}
+	if minor := latest.Floating.Minor; minor.TagName != "v1.2" || minor.Superseded {
+		t.Fatalf("expected v1.2 to follow v1.2.6, got %+v", minor)
+	}
+	if !latest.Floating.Superseded {
+		t.Fatal("expected v1 superseded by v1.3.0")
+	}
+}
@@ -0,0 +1,35 @@
+package tagplan
+
+import (
+	semver "github.com/blang/semver/v4"
+)
+
+// NoBumpReason is the SkipReason of a plan for bump.BumpNone.
+const NoBumpReason = "bump is none, so no new version is tagged"
+
+// planNoBump returns the skipped plan for bump.BumpNone: nothing is written, and the result
+// names the current release, which stays the latest version. Without a release tag the name is
+// derived from the configured or zero base.
+func (p Planner) planNoBump(mode Mode, c catalog, releases []releaseEntry, ignored []string, base semver.Version, source BaseSource) Result {
+	current := baseTag(releases, source)
+	highest, _ := c.highestRelease(base)
+	name := shortTagName(current.Name)
+	if name == "" {
+		name = p.formatTagName(base)
+	}
+	return Result{
+		Mode:           mode,
+		TagName:        name,
+		Version:        base,
+		ReleaseBase:    base,
+		BaseSource:     source,
+		BaseTag:        current,
+		TargetRelease:  base,
+		DuplicateTags:  c.duplicateNames(),
+		LegacyTags:     p.legacyTagsFor(c, source),
+		IgnoredTags:    ignored,
+		Skipped:        true,
+		SkipReason:     NoBumpReason,
+		HighestRelease: highest,
+	}
+}
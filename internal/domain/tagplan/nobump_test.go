@@ -0,0 +1,58 @@
+package tagplan
+
+import (
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+)
+
+func TestPlanNoBumpSkips(t *testing.T) {
+	t.Parallel()
+
+	tags := []Tag{
+		{Name: "refs/tags/v1.2.0", ObjectID: "a"},
+		{Name: "refs/tags/v1.3.0-rc.1", ObjectID: "b"},
+	}
+	planner := NewPlanner("v")
+
+	tests := []struct {
+		name string
+		plan func() (Result, error)
+		mode Mode
+	}{
+		{name: "release", plan: func() (Result, error) { return planner.PlanRelease(tags, bump.BumpNone, "") }, mode: ModeRelease},
+		{name: "rc", plan: func() (Result, error) { return planner.PlanRC(tags, bump.BumpNone, "") }, mode: ModeRC},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := tc.plan()
+			if err != nil {
+				t.Fatalf("plan: %v", err)
+			}
+			if !result.Skipped || result.SkipReason != NoBumpReason {
+				t.Fatalf("expected no-bump skip, got skipped=%v reason=%q", result.Skipped, result.SkipReason)
+			}
+			if result.Mode != tc.mode || result.TagName != "v1.2.0" || result.Version.String() != "1.2.0" {
+				t.Fatalf("expected %s plan naming v1.2.0, got %s %s (%s)", tc.mode, result.Mode, result.TagName, result.Version)
+			}
+			if result.RCNumber != 0 || result.Floating.TagName != "" {
+				t.Fatalf("expected no RC or floating plan, got rc %d floating %q", result.RCNumber, result.Floating.TagName)
+			}
+		})
+	}
+}
+
+func TestPlanNoBumpWithoutReleases(t *testing.T) {
+	t.Parallel()
+
+	result, err := NewPlanner("v").PlanRelease(nil, bump.BumpNone, "0.4.0")
+	if err != nil {
+		t.Fatalf("plan: %v", err)
+	}
+	if !result.Skipped || result.TagName != "v0.4.0" || result.BaseSource != BaseSourceConfigured {
+		t.Fatalf("expected skip naming configured base v0.4.0, got %+v", result)
+	}
+}
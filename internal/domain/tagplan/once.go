@@ -0,0 +1,30 @@
+package tagplan
+
+import (
+	"strings"
+
+	semver "github.com/blang/semver/v4"
+)
+
+// ReleaseAtCommit returns the highest release tag, within the planner's prefix and component,
+// that points at commit. Prerelease tags on the commit are ignored, so an RC can still be
+// finalized there.
+func (p Planner) ReleaseAtCommit(tags []Tag, commit string) (Tag, semver.Version, bool) {
+	commit = strings.TrimSpace(commit)
+	if commit == "" {
+		return Tag{}, semver.Version{}, false
+	}
+	c := buildCatalog(tags, p.component, p.namePrefix())
+
+	var best releaseEntry
+	found := false
+	for _, entry := range c.releases {
+		if !strings.EqualFold(strings.TrimSpace(entry.tag.ObjectID), commit) {
+			continue
+		}
+		if !found || entry.version.GT(best.version) {
+			best, found = entry, true
+		}
+	}
+	return best.tag, best.version, found
+}
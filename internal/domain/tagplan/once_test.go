@@ -0,0 +1,37 @@
+package tagplan
+
+import "testing"
+
+func TestReleaseAtCommit(t *testing.T) {
+	t.Parallel()
+
+	tags := []Tag{
+		{Name: "refs/tags/v1.2.3", ObjectID: "abc123"},
+		{Name: "refs/tags/v1.3.0", ObjectID: "ABC123"},
+		{Name: "refs/tags/v1.4.0-rc.1", ObjectID: "def456"},
+		{Name: "refs/tags/api/v9.0.0", ObjectID: "abc123"},
+	}
+
+	tests := []struct {
+		name      string
+		planner   Planner
+		commit    string
+		expectTag string
+	}{
+		{name: "highest release on the commit", planner: NewPlanner("v"), commit: "abc123", expectTag: "refs/tags/v1.3.0"},
+		{name: "prerelease only", planner: NewPlanner("v"), commit: "def456"},
+		{name: "unknown commit", planner: NewPlanner("v"), commit: "0000000"},
+		{name: "component scope", planner: NewPlanner("v").WithComponent("api"), commit: "abc123", expectTag: "refs/tags/api/v9.0.0"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tag, _, ok := tc.planner.ReleaseAtCommit(tags, tc.commit)
+			if ok != (tc.expectTag != "") || tag.Name != tc.expectTag {
+				t.Fatalf("expected %q, got %q (found=%t)", tc.expectTag, tag.Name, ok)
+			}
+		})
+	}
+}
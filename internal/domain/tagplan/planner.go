@@ -48,12 +48,51 @@ type FloatingPlan struct {
 	AutoDetectedMajor uint64
 	Enabled           bool
 	DeletedExisting   bool
-	Created           bool
+	// Moved reports that the existing ref was updated in place instead of deleted and recreated.
+	Moved bool
+	// Superseded reports that a newer release exists in the floating tag's major, so moving it
+	// would regress the line (hotfix plans only).
+	Superseded bool
+	// Regressed reports that the existing floating ref pointed at a newer commit than the
+	// release and was moved back anyway under a force option.
+	Regressed bool
+	Created   bool
+	// AlreadyExists reports that the existing floating ref already pointed at the target
+	// commit, so it was left in place.
+	AlreadyExists bool
+	// PreviousObjectID and PreviousCommit record the existing floating ref's object (the tag
+	// object when annotated) and peeled commit before it was replaced; TargetCommit is the
+	// commit it points at afterwards. Filled by the tagging service for auditing.
+	PreviousObjectID string
+	PreviousCommit   string
+	TargetCommit     string
+	// Minor is the v<major>.<minor> floating tag planned next to the major one, with the same
+	// detection and execution details; the tagging service's floating level decides whether it
+	// is maintained. It is nil on the minor plan itself.
+	Minor *FloatingPlan
 }
 
 // Planner computes release and RC tagging plans from a set of tags.
 type Planner struct {
-	tagPrefix string
+	tagPrefix       string
+	prefixSeparator string
+	floatingTrack   FloatingTrack
+	limits          Limits
+	versionRange    VersionRange
+	component       string
+	currentVersion  string
+	// preferPrerelease plans releases from a higher prerelease line; see WithPreferPrereleaseLine.
+	preferPrerelease bool
+	// ignoreTags lists globs of release tags never used as a base; see WithIgnoreTags.
+	ignoreTags []string
+	// prereleaseLabel is the channel RC mode tags; see WithPrereleaseLabel and WithChannelOrder.
+	prereleaseLabel string
+	channelOrder    []string
+	enforceChannels bool
+	// rcScope selects the versions whose RCs share a counter; see WithRCScope.
+	rcScope RCScope
+	// allowDowngrade skips the check against the highest release; see WithAllowDowngrade.
+	allowDowngrade bool
 }
 
 // NewPlanner creates a Planner instance with the provided prefix (trimmed) applied to tag names.
@@ -61,6 +100,21 @@ func NewPlanner(prefix string) Planner {
 	return Planner{tagPrefix: strings.TrimSpace(prefix)}
 }
 
+// WithPrefixSeparator returns a copy of the planner that places separator between the tag
+// prefix and the version, e.g. "release" and "-" produce "release-1.2.3".
+func (p Planner) WithPrefixSeparator(separator string) Planner {
+	p.prefixSeparator = strings.TrimSpace(separator)
+	return p
+}
+
+// WithComponent returns a copy of the planner scoped to one component of a monorepo: only tags
+// under "<component>/" are considered, and release and floating tags are written there too
+// (e.g. "api/v1.2.3" and "api/v1").
+func (p Planner) WithComponent(component string) Planner {
+	p.component = strings.Trim(strings.TrimSpace(component), "/")
+	return p
+}
+
 // Result captures the outcome of planning a tag creation operation.
 type Result struct {
 	Mode          Mode
@@ -68,72 +122,178 @@ type Result struct {
 	Version       semver.Version
 	ReleaseBase   semver.Version
 	BaseSource    BaseSource
+	BaseTag       Tag
 	TargetRelease semver.Version
 	RCNumber      int
 	Floating      FloatingPlan
+	// DuplicateTags lists tags ignored because another tag names the same version with a
+	// different prefix, such as V1.2.3 next to v1.2.3.
+	DuplicateTags []string
+	// BranchCollisions lists planned tag names that also exist as branches; filled by the
+	// tagging service's ref check.
+	BranchCollisions []string
+	// CommitUnverified explains why the target commit could not be confirmed to exist when the
+	// tagging service's missing-commit policy let the run continue anyway.
+	CommitUnverified string
+	// Skipped reports that no refs were written, either because the target commit does not
+	// exist and the missing-commit policy is skip, or for the reason in SkipReason.
+	Skipped bool
+	// SkipReason explains a skip decided by the tagging service other than a missing commit,
+	// such as a pull request that only changes skipped paths.
+	SkipReason string
+	// AlreadyReleased marks a skip because the target commit already carries the release tag
+	// named by TagName.
+	AlreadyReleased bool
+	// AlreadyExists reports that TagName already pointed at the target commit, such as after a
+	// retried run, so the tagging service did not create it again.
+	AlreadyExists bool
+	// RCCollisions counts RC numbers found already taken at creation time and replanned past
+	// by the tagging service's collision retry.
+	RCCollisions int
+	// VerifyAttempts counts the ref listings the tagging service needed to see the created tag
+	// when verification is enabled.
+	VerifyAttempts int
+	// LegacyTags lists tags matching a known pre-semver scheme when the planner fell back to
+	// 0.0.0, explaining why existing tags were not used as the base.
+	LegacyTags []LegacyTag
+	// HigherPrerelease names the highest prerelease tag on a major above the release base whose
+	// target is unreleased, such as v2.0.0-rc.3 next to v1.9.0; PrereleaseLineUsed reports that
+	// the release finalizes its target instead of bumping the base.
+	HigherPrerelease   string
+	PrereleaseLineUsed bool
+	// IgnoredTags names retracted release tags excluded from base selection; see WithIgnoreTags.
+	IgnoredTags []string
+	// HighestRelease is the highest stable release tag before the plan, whatever base it used,
+	// such as v2.3.1 when --version-range plans v1.6.0. NewHighest reports that the planned
+	// release supersedes it; it is never set for RCs.
+	HighestRelease Tag
+	NewHighest     bool
 }
 
-// PlanRelease determines the next release tag using the provided bump intent.
+// PlanRelease determines the next release tag using the provided bump intent. A none intent
+// returns a skipped plan naming the current release; see NoBumpReason.
 func (p Planner) PlanRelease(tags []Tag, intent bump.Bump, baseOverride string) (Result, error) {
-	catalog := buildCatalog(tags)
+	catalog := buildCatalog(tags, p.component, p.namePrefix())
 
-	base, source, err := chooseBaseRelease(catalog.releases, baseOverride)
+	releases, ignored := p.baseCandidates(catalog)
+	base, source, err := p.chooseBase(releases, baseOverride)
 	if err != nil {
 		return Result{}, err
 	}
+	if intent == bump.BumpNone {
+		return p.planNoBump(ModeRelease, catalog, releases, ignored, base, source), nil
+	}
 
 	next, err := bumpVersion(base, intent)
 	if err != nil {
 		return Result{}, fmt.Errorf("computing release bump: %w", err)
 	}
+	var higher Tag
+	lineUsed := false
+	if entry, target, ok := p.higherPrereleaseLine(catalog, base); ok {
+		higher = entry.tag
+		if p.preferPrerelease {
+			next, lineUsed = target, true
+		}
+	}
+	if err := p.limits.check(next); err != nil {
+		return Result{}, err
+	}
+	if err := p.versionRange.check(next); err != nil {
+		return Result{}, err
+	}
+	if err := p.checkDowngrade(releases, next); err != nil {
+		return Result{}, err
+	}
+	highest, newHighest := catalog.highestRelease(next)
 
 	return Result{
-		Mode:          ModeRelease,
-		TagName:       p.formatTagName(next),
-		Version:       next,
-		ReleaseBase:   base,
-		BaseSource:    source,
-		TargetRelease: next,
-		Floating:      planFloating(catalog, next),
+		Mode:               ModeRelease,
+		TagName:            p.formatTagName(next),
+		Version:            next,
+		ReleaseBase:        base,
+		BaseSource:         source,
+		BaseTag:            baseTag(releases, source),
+		TargetRelease:      next,
+		Floating:           planFloating(catalog, p.component, next, p.floatingTrack),
+		DuplicateTags:      catalog.duplicateNames(),
+		LegacyTags:         p.legacyTagsFor(catalog, source),
+		HigherPrerelease:   shortTagName(higher.Name),
+		PrereleaseLineUsed: lineUsed,
+		IgnoredTags:        ignored,
+		HighestRelease:     highest,
+		NewHighest:         newHighest,
 	}, nil
 }
 
-// PlanRC determines the next RC tag for the upcoming release implied by the bump intent.
+// PlanRC determines the next RC tag for the upcoming release implied by the bump intent. Like
+// PlanRelease, a none intent returns a skipped plan.
 func (p Planner) PlanRC(tags []Tag, intent bump.Bump, baseOverride string) (Result, error) {
-	catalog := buildCatalog(tags)
+	catalog := buildCatalog(tags, p.component, p.namePrefix())
 
-	base, source, err := chooseBaseRelease(catalog.releases, baseOverride)
+	releases, ignored := p.baseCandidates(catalog)
+	base, source, err := p.chooseBase(releases, baseOverride)
 	if err != nil {
 		return Result{}, err
 	}
+	if intent == bump.BumpNone {
+		return p.planNoBump(ModeRC, catalog, releases, ignored, base, source), nil
+	}
 
 	target, err := bumpVersion(base, intent)
 	if err != nil {
 		return Result{}, fmt.Errorf("computing release bump: %w", err)
 	}
+	if err := p.limits.check(target); err != nil {
+		return Result{}, err
+	}
+	if err := p.versionRange.check(target); err != nil {
+		return Result{}, err
+	}
+	if err := p.checkDowngrade(releases, target); err != nil {
+		return Result{}, err
+	}
+
+	if err := p.checkChannelOrder(target, catalog.prereleases); err != nil {
+		return Result{}, err
+	}
 
-	rcNumber := nextRCNumber(target, catalog.prereleases)
+	rcNumber := nextRCNumber(target, p.channel(), p.rcScope, catalog.prereleases)
 
-	rcVersion, err := attachRC(target, rcNumber)
+	rcVersion, err := attachRC(target, p.channel(), rcNumber)
 	if err != nil {
 		return Result{}, err
 	}
-
-	return Result{
-		Mode:          ModeRC,
-		TagName:       p.formatTagName(rcVersion),
-		Version:       rcVersion,
-		ReleaseBase:   base,
-		BaseSource:    source,
-		TargetRelease: target,
-		RCNumber:      rcNumber,
-	}, nil
+	highest, _ := catalog.highestRelease(target)
+
+	result := Result{
+		Mode:           ModeRC,
+		TagName:        p.formatTagName(rcVersion),
+		Version:        rcVersion,
+		ReleaseBase:    base,
+		BaseSource:     source,
+		BaseTag:        baseTag(releases, source),
+		TargetRelease:  target,
+		RCNumber:       rcNumber,
+		DuplicateTags:  catalog.duplicateNames(),
+		LegacyTags:     p.legacyTagsFor(catalog, source),
+		IgnoredTags:    ignored,
+		HighestRelease: highest,
+	}
+	if p.floatingTrack == FloatingTrackAny {
+		result.Floating = planFloating(catalog, p.component, rcVersion, p.floatingTrack)
+	}
+	return result, nil
 }
 
 type catalog struct {
 	releases    []releaseEntry
-	prereleases []semver.Version
+	prereleases []releaseEntry
 	floating    []floatingEntry
+	// duplicates holds tags dropped because another tag carries the same version.
+	duplicates []Tag
+	// unparsed holds tags that are neither versions nor floating refs.
+	unparsed []Tag
 }
 
 type releaseEntry struct {
@@ -146,28 +306,48 @@ type floatingEntry struct {
 	tag   Tag
 }
 
-func buildCatalog(tags []Tag) catalog {
+func buildCatalog(tags []Tag, component, namePrefix string) catalog {
 	var c catalog
+	var releases, prereleases versionSet
 	for _, tag := range tags {
-		version, ok := parseSemverTag(tag.Name)
+		version, ok := parseSemverTag(tag.Name, component, namePrefix)
 		if !ok {
-			if major, isFloating := parseFloatingTag(tag.Name); isFloating {
+			if major, isFloating := parseFloatingTag(tag.Name, component); isFloating {
 				c.floating = append(c.floating, floatingEntry{major: major, tag: tag})
+			} else {
+				c.unparsed = append(c.unparsed, tag)
 			}
 			continue
 		}
+		entry := releaseEntry{version: version, tag: tag}
+		var dropped Tag
+		var duplicate bool
 		if len(version.Pre) == 0 {
-			c.releases = append(c.releases, releaseEntry{version: version, tag: tag})
-			continue
+			dropped, duplicate = releases.add(entry, component, namePrefix)
+		} else {
+			dropped, duplicate = prereleases.add(entry, component, namePrefix)
+		}
+		if duplicate {
+			c.duplicates = append(c.duplicates, dropped)
 		}
-		c.prereleases = append(c.prereleases, version)
 	}
+	c.releases, c.prereleases = releases.entries, prereleases.entries
 	return c
 }
 
-func parseSemverTag(name string) (semver.Version, bool) {
-	normalized := strings.TrimSpace(name)
-	normalized = strings.TrimPrefix(normalized, "refs/tags/")
+// parseSemverTag parses a tag name as a version, stripping namePrefix (the planner's prefix and
+// separator) or a leading "v" when present. With a component, only names under "<component>/"
+// parse.
+func parseSemverTag(name, component, namePrefix string) (semver.Version, bool) {
+	normalized, ok := stripComponent(name, component)
+	if !ok {
+		return semver.Version{}, false
+	}
+	if namePrefix != "" && strings.HasPrefix(normalized, namePrefix) {
+		if version, err := semver.Parse(strings.TrimPrefix(normalized, namePrefix)); err == nil {
+			return version, true
+		}
+	}
 	if normalized == "" {
 		return semver.Version{}, false
 	}
@@ -234,6 +414,8 @@ func bumpVersion(base semver.Version, intent bump.Bump) (semver.Version, error)
 		err = next.IncrementMajor()
 	case bump.BumpMinor:
 		err = next.IncrementMinor()
+	case bump.BumpNone:
+		// Planners skip before bumping; none keeps the base as it is.
 	default:
 		err = next.IncrementPatch()
 	}
@@ -246,24 +428,51 @@ func bumpVersion(base semver.Version, intent bump.Bump) (semver.Version, error)
 }
 
 func (p Planner) formatTagName(version semver.Version) string {
+	return componentPrefix(p.component) + p.namePrefix() + version.String()
+}
+
+func componentPrefix(component string) string {
+	if component == "" {
+		return ""
+	}
+	return component + "/"
+}
+
+// stripComponent returns the short tag name with the component namespace removed, reporting
+// false when the tag belongs to a different namespace. Without a component every tag matches.
+func stripComponent(name, component string) (string, bool) {
+	short := shortTagName(name)
+	if component == "" {
+		return short, true
+	}
+	return strings.CutPrefix(short, componentPrefix(component))
+}
+
+// namePrefix is the text placed before the version in tag names; the separator only applies
+// when a prefix is configured.
+func (p Planner) namePrefix() string {
 	prefix := strings.TrimSpace(p.tagPrefix)
-	return prefix + version.String()
+	if prefix == "" {
+		return ""
+	}
+	return prefix + p.prefixSeparator
 }
 
-func planFloating(c catalog, target semver.Version) FloatingPlan {
-	plan := FloatingPlan{TagName: floatingTagName(target.Major)}
+func planFloating(c catalog, component string, target semver.Version, track FloatingTrack) FloatingPlan {
+	plan := FloatingPlan{TagName: floatingTagName(component, target.Major)}
 	if existing, ok := c.floatingTagForMajor(target.Major); ok {
 		plan.Existing = existing
 	}
-	if highest, ok := c.highestRelease(); ok {
+	if highest, ok := highestEntry(c.tracked(track)); ok {
 		plan.AutoDetectedMajor = highest.version.Major
-		plan.AutoDetected = c.hasValidFloatingForMajor(highest.version.Major)
+		plan.AutoDetected = c.hasValidFloatingForMajor(highest.version.Major, track)
 	}
+	plan.Minor = planMinorFloating(c, component, target, track)
 	return plan
 }
 
-func floatingTagName(major uint64) string {
-	return fmt.Sprintf("v%d", major)
+func floatingTagName(component string, major uint64) string {
+	return fmt.Sprintf("%sv%d", componentPrefix(component), major)
 }
 
 func (c catalog) floatingTagForMajor(major uint64) (Tag, bool) {
@@ -275,12 +484,12 @@ func (c catalog) floatingTagForMajor(major uint64) (Tag, bool) {
 	return Tag{}, false
 }
 
-func (c catalog) highestRelease() (releaseEntry, bool) {
-	if len(c.releases) == 0 {
+func highestEntry(entries []releaseEntry) (releaseEntry, bool) {
+	if len(entries) == 0 {
 		return releaseEntry{}, false
 	}
-	highest := c.releases[0]
-	for _, candidate := range c.releases[1:] {
+	highest := entries[0]
+	for _, candidate := range entries[1:] {
 		if candidate.version.GT(highest.version) {
 			highest = candidate
 		}
@@ -288,7 +497,26 @@ func (c catalog) highestRelease() (releaseEntry, bool) {
 	return highest, true
 }
 
-func (c catalog) hasValidFloatingForMajor(major uint64) bool {
+// highestRelease returns the highest stable release tag in c, whatever base the plan used, and
+// whether a release of next would supersede it.
+func (c catalog) highestRelease(next semver.Version) (Tag, bool) {
+	highest, ok := highestEntry(c.releases)
+	return highest.tag, !ok || next.GT(highest.version)
+}
+
+// baseTag returns the release tag backing the base version when it came from existing tags.
+func baseTag(releases []releaseEntry, source BaseSource) Tag {
+	if source != BaseSourceExisting {
+		return Tag{}
+	}
+	highest, _ := highestEntry(releases)
+	return highest.tag
+}
+
+// hasValidFloatingForMajor reports whether the floating ref for major points at a tag it
+// tracks: a stable release, or under FloatingTrackAny any tag in that major.
+func (c catalog) hasValidFloatingForMajor(major uint64, track FloatingTrack) bool {
+	candidates := c.tracked(track)
 	for _, entry := range c.floating {
 		if entry.major != major {
 			continue
@@ -296,7 +524,7 @@ func (c catalog) hasValidFloatingForMajor(major uint64) bool {
 		if entry.tag.ObjectID == "" {
 			continue
 		}
-		for _, release := range c.releases {
+		for _, release := range candidates {
 			if release.version.Major != major {
 				continue
 			}
@@ -308,13 +536,16 @@ func (c catalog) hasValidFloatingForMajor(major uint64) bool {
 	return false
 }
 
-func nextRCNumber(target semver.Version, prereleases []semver.Version) int {
+// nextRCNumber returns the next number on the label channel for target, counting the
+// prereleases of every version in scope; other channels' prereleases are not counted.
+func nextRCNumber(target semver.Version, label string, scope RCScope, prereleases []releaseEntry) int {
 	max := 0
-	for _, version := range prereleases {
-		if !sameBase(version, target) {
+	for _, entry := range prereleases {
+		version := entry.version
+		if !scope.contains(version, target) {
 			continue
 		}
-		number, ok := rcNumber(version)
+		number, ok := prereleaseNumber(version, label)
 		if !ok {
 			continue
 		}
@@ -329,7 +560,8 @@ func sameBase(left, right semver.Version) bool {
 	return left.Major == right.Major && left.Minor == right.Minor && left.Patch == right.Patch
 }
 
-func rcNumber(version semver.Version) (int, bool) {
+// prereleaseNumber reads N from a <label>.N prerelease.
+func prereleaseNumber(version semver.Version, label string) (int, bool) {
 	if len(version.Pre) != 2 {
 		return 0, false
 	}
@@ -340,7 +572,7 @@ func rcNumber(version semver.Version) (int, bool) {
 	if first.IsNum {
 		return 0, false
 	}
-	if !strings.EqualFold(first.VersionStr, "rc") {
+	if !strings.EqualFold(first.VersionStr, label) {
 		return 0, false
 	}
 	if !second.IsNum {
@@ -356,14 +588,14 @@ func rcNumber(version semver.Version) (int, bool) {
 	return int(second.VersionNum), true
 }
 
-func attachRC(target semver.Version, rc int) (semver.Version, error) {
+func attachRC(target semver.Version, label string, rc int) (semver.Version, error) {
 	if rc <= 0 {
 		return semver.Version{}, fmt.Errorf("invalid rc number %d", rc)
 	}
 
 	base := target
 
-	rcLabel, err := semver.NewPRVersion("rc")
+	rcLabel, err := semver.NewPRVersion(label)
 	if err != nil {
 		return semver.Version{}, fmt.Errorf("building rc label: %w", err)
 	}
@@ -379,16 +611,19 @@ func attachRC(target semver.Version, rc int) (semver.Version, error) {
 	return base, nil
 }
 
-func parseFloatingTag(name string) (uint64, bool) {
-	trimmed := strings.TrimSpace(name)
-	trimmed = strings.TrimPrefix(trimmed, "refs/tags/")
-	if len(trimmed) <= 1 {
+func parseFloatingTag(name, component string) (uint64, bool) {
+	trimmed, ok := stripComponent(name, component)
+	if !ok || len(trimmed) <= 1 {
 		return 0, false
 	}
 	if trimmed[0] != 'v' && trimmed[0] != 'V' {
 		return 0, false
 	}
-	digits := trimmed[1:]
+	return parseDigits(trimmed[1:])
+}
+
+// parseDigits parses a non-empty run of decimal digits.
+func parseDigits(digits string) (uint64, bool) {
 	if digits == "" {
 		return 0, false
 	}
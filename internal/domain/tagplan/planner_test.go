@@ -229,3 +229,80 @@ func TestPlanReleaseWithoutPrefix(t *testing.T) {
 		t.Fatalf("tag name: want 1.1.0 got %s", result.TagName)
 	}
 }
+
+func TestPlanRecordsBaseTag(t *testing.T) {
+	t.Parallel()
+
+	planner := NewPlanner("v")
+	tags := []Tag{
+		{Name: "refs/tags/v1.2.3", ObjectID: "abc"},
+		{Name: "refs/tags/v1.1.0", ObjectID: "old"},
+	}
+
+	result, err := planner.PlanRC(tags, bump.BumpPatch, "")
+	if err != nil {
+		t.Fatalf(errPlanRC, err)
+	}
+	if result.BaseTag.Name != "refs/tags/v1.2.3" || result.BaseTag.ObjectID != "abc" {
+		t.Fatalf("expected base tag v1.2.3@abc got %+v", result.BaseTag)
+	}
+
+	result, err = planner.PlanRelease(nil, bump.BumpPatch, "1.0.0")
+	if err != nil {
+		t.Fatalf(errPlanRelease, err)
+	}
+	if result.BaseTag.Name != "" {
+		t.Fatalf("did not expect base tag for configured base, got %+v", result.BaseTag)
+	}
+}
+
+func TestPlanReleasePrefixSeparatorRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	planner := NewPlanner("release").WithPrefixSeparator("-")
+	tags := []Tag{
+		{Name: "refs/tags/release-1.2.3", ObjectID: "abc"},
+		{Name: "refs/tags/release-1.3.0-rc.1", ObjectID: "def"},
+		{Name: "refs/tags/other-9.9.9", ObjectID: "ghi"},
+	}
+
+	release, err := planner.PlanRelease(tags, bump.BumpPatch, "")
+	if err != nil {
+		t.Fatalf(errPlanRelease, err)
+	}
+	if release.TagName != "release-1.2.4" {
+		t.Fatalf("tag name: want release-1.2.4 got %s", release.TagName)
+	}
+	if release.BaseSource != BaseSourceExisting || release.BaseTag.Name != "refs/tags/release-1.2.3" {
+		t.Fatalf("expected release-1.2.3 as the base, got %s %+v", release.BaseSource, release.BaseTag)
+	}
+
+	rc, err := planner.PlanRC(tags, bump.BumpMinor, "")
+	if err != nil {
+		t.Fatalf(errPlanRC, err)
+	}
+	if rc.TagName != "release-1.3.0-rc.2" {
+		t.Fatalf("tag name: want release-1.3.0-rc.2 got %s", rc.TagName)
+	}
+
+	// Feed the planned tag back in and confirm it becomes the next base.
+	next, err := planner.PlanRelease(append(tags, Tag{Name: "refs/tags/" + release.TagName, ObjectID: "jkl"}), bump.BumpPatch, "")
+	if err != nil {
+		t.Fatalf(errPlanRelease, err)
+	}
+	if next.TagName != "release-1.2.5" {
+		t.Fatalf("tag name: want release-1.2.5 got %s", next.TagName)
+	}
+}
+
+func TestPlanReleaseSeparatorIgnoredWithoutPrefix(t *testing.T) {
+	t.Parallel()
+
+	result, err := NewPlanner("").WithPrefixSeparator("-").PlanRelease([]Tag{{Name: "refs/tags/1.0.0"}}, bump.BumpMinor, "")
+	if err != nil {
+		t.Fatalf(errPlanRelease, err)
+	}
+	if result.TagName != "1.1.0" {
+		t.Fatalf("tag name: want 1.1.0 got %s", result.TagName)
+	}
+}
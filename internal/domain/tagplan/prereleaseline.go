@@ -0,0 +1,38 @@
+package tagplan
+
+import (
+	semver "github.com/blang/semver/v4"
+)
+
+// WithPreferPrereleaseLine returns a copy of the planner that, when a prerelease exists on a
+// higher major than the release base and its target is not yet released, plans the release as
+// that target (finalizing v2.0.0-rc.3 as v2.0.0) instead of bumping the base.
+func (p Planner) WithPreferPrereleaseLine(prefer bool) Planner {
+	p.preferPrerelease = prefer
+	return p
+}
+
+// higherPrereleaseLine returns the highest prerelease whose major is above base's and whose
+// target release does not exist yet.
+func (p Planner) higherPrereleaseLine(c catalog, base semver.Version) (releaseEntry, semver.Version, bool) {
+	var best releaseEntry
+	found := false
+	for _, entry := range c.prereleases {
+		if entry.version.Major <= base.Major || !p.versionRange.contains(entry.version) {
+			continue
+		}
+		if found && !entry.version.GT(best.version) {
+			continue
+		}
+		target := semver.Version{Major: entry.version.Major, Minor: entry.version.Minor, Patch: entry.version.Patch}
+		if _, released := c.releaseForVersion(target); released {
+			continue
+		}
+		best, found = entry, true
+	}
+	if !found {
+		return releaseEntry{}, semver.Version{}, false
+	}
+	target := semver.Version{Major: best.version.Major, Minor: best.version.Minor, Patch: best.version.Patch}
+	return best, target, true
+}
@@ -0,0 +1,58 @@
+package tagplan
+
+import (
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+)
+
+func TestPlanReleaseHigherPrereleaseLine(t *testing.T) {
+	t.Parallel()
+
+	mismatched := []Tag{
+		{Name: "refs/tags/v1.9.0"},
+		{Name: "refs/tags/v2.0.0-rc.2"},
+		{Name: "refs/tags/v2.0.0-rc.3"},
+	}
+
+	tests := []struct {
+		name         string
+		tags         []Tag
+		prefer       bool
+		expectTag    string
+		expectHigher string
+		expectUsed   bool
+	}{
+		{name: "default warns and bumps the stable line", tags: mismatched, expectTag: "v1.9.1", expectHigher: "v2.0.0-rc.3"},
+		{name: "prefer finalizes the prerelease line", tags: mismatched, prefer: true, expectTag: "v2.0.0", expectHigher: "v2.0.0-rc.3", expectUsed: true},
+		{
+			name:      "released prerelease line is ignored",
+			tags:      append([]Tag{{Name: "refs/tags/v2.0.0"}}, mismatched...),
+			prefer:    true,
+			expectTag: "v2.0.1",
+		},
+		{
+			name:      "same major prerelease is ignored",
+			tags:      []Tag{{Name: "refs/tags/v1.9.0"}, {Name: "refs/tags/v1.10.0-rc.1"}},
+			prefer:    true,
+			expectTag: "v1.9.1",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := NewPlanner("v").WithPreferPrereleaseLine(tc.prefer).PlanRelease(tc.tags, bump.BumpPatch, "")
+			if err != nil {
+				t.Fatalf(errPlanRelease, err)
+			}
+			if result.TagName != tc.expectTag {
+				t.Fatalf("tag name: want %s got %s", tc.expectTag, result.TagName)
+			}
+			if result.HigherPrerelease != tc.expectHigher || result.PrereleaseLineUsed != tc.expectUsed {
+				t.Fatalf("prerelease line: want %q used=%t got %q used=%t", tc.expectHigher, tc.expectUsed, result.HigherPrerelease, result.PrereleaseLineUsed)
+			}
+		})
+	}
+}
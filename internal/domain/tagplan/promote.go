@@ -0,0 +1,129 @@
+package tagplan
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	semver "github.com/blang/semver/v4"
+)
+
+// BaseSourcePromoted indicates the release promotes an existing release candidate; BaseTag is
+// that RC.
+const BaseSourcePromoted BaseSource = "promoted-rc"
+
+var (
+	// ErrNoRCToPromote indicates no unreleased RC matches the promotion target.
+	ErrNoRCToPromote = errors.New("tagplan: no release candidate to promote")
+	// ErrPromoteAmbiguous indicates several RC lines are open and no target was given.
+	ErrPromoteAmbiguous = errors.New("tagplan: several release candidate lines are open")
+	// ErrPromoteTargetExists indicates the target version has already been released.
+	ErrPromoteTargetExists = errors.New("tagplan: promoted release already exists")
+)
+
+// PlanPromotion plans the release of the highest RC for target, a version or tag name such as
+// 2.1.0 or v2.1.0. An empty target selects the only RC line without a release. The release is
+// not bumped: it is the RC's core version, and BaseTag.ObjectID is the commit to tag. Like a
+// hotfix, the floating plan follows the promoted release and is Superseded when its major or
+// minor already has a newer release.
+func (p Planner) PlanPromotion(tags []Tag, target string) (Result, error) {
+	catalog := buildCatalog(tags, p.component, p.namePrefix())
+
+	open := p.openRCLines(catalog)
+	var rc releaseEntry
+	if trimmed := strings.TrimSpace(target); trimmed != "" {
+		version, err := p.parseTarget(trimmed)
+		if err != nil {
+			return Result{}, err
+		}
+		if _, exists := catalog.releaseForVersion(version); exists {
+			return Result{}, fmt.Errorf("%w: %s", ErrPromoteTargetExists, version.String())
+		}
+		entry, ok := open[version.String()]
+		if !ok {
+			return Result{}, fmt.Errorf("%w: %s", ErrNoRCToPromote, version.String())
+		}
+		rc = entry
+	} else {
+		switch len(open) {
+		case 0:
+			return Result{}, ErrNoRCToPromote
+		case 1:
+			for _, entry := range open {
+				rc = entry
+			}
+		default:
+			lines := make([]string, 0, len(open))
+			for line := range open {
+				lines = append(lines, line)
+			}
+			sort.Strings(lines)
+			return Result{}, fmt.Errorf("%w: %s", ErrPromoteAmbiguous, strings.Join(lines, ", "))
+		}
+	}
+
+	next := rc.version
+	next.Pre = nil
+	next.Build = nil
+	if err := p.limits.check(next); err != nil {
+		return Result{}, err
+	}
+	if err := p.versionRange.check(next); err != nil {
+		return Result{}, err
+	}
+	number, _ := prereleaseNumber(rc.version, p.channel())
+	highest, newHighest := catalog.highestRelease(next)
+
+	return Result{
+		Mode:           ModeRelease,
+		TagName:        p.formatTagName(next),
+		Version:        next,
+		ReleaseBase:    rc.version,
+		BaseSource:     BaseSourcePromoted,
+		BaseTag:        rc.tag,
+		TargetRelease:  next,
+		RCNumber:       number,
+		Floating:       p.lineFloating(catalog, next),
+		DuplicateTags:  catalog.duplicateNames(),
+		HighestRelease: highest,
+		NewHighest:     newHighest,
+	}, nil
+}
+
+// openRCLines returns the highest RC on the planner's channel for each core version that has
+// no release yet, keyed by that version.
+func (p Planner) openRCLines(c catalog) map[string]releaseEntry {
+	open := map[string]releaseEntry{}
+	for _, entry := range c.prereleases {
+		if _, ok := prereleaseNumber(entry.version, p.channel()); !ok {
+			continue
+		}
+		core := entry.version
+		core.Pre = nil
+		core.Build = nil
+		if _, released := c.releaseForVersion(core); released {
+			continue
+		}
+		if current, ok := open[core.String()]; !ok || entry.version.GT(current.version) {
+			open[core.String()] = entry
+		}
+	}
+	return open
+}
+
+// parseTarget reads a promotion target given as a tag name or a version; any prerelease is
+// dropped so v2.1.0-rc.3 selects the 2.1.0 line.
+func (p Planner) parseTarget(target string) (semver.Version, error) {
+	version, ok := parseSemverTag(target, p.component, p.namePrefix())
+	if !ok {
+		parsed, err := parseVersionString(target)
+		if err != nil {
+			return semver.Version{}, fmt.Errorf("invalid target version: %w", err)
+		}
+		version = parsed
+	}
+	version.Pre = nil
+	version.Build = nil
+	return version, nil
+}
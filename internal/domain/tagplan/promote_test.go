@@ -0,0 +1,91 @@
+package tagplan
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func promotionTags() []Tag {
+	return []Tag{
+		{Name: "refs/tags/v2.0.0", ObjectID: "c200"},
+		{Name: "refs/tags/v2", ObjectID: "c200"},
+		{Name: "refs/tags/v2.1.0-rc.1", ObjectID: "c210-rc1"},
+		{Name: "refs/tags/v2.1.0-rc.3", ObjectID: "c210-rc3"},
+		{Name: "refs/tags/v2.1.0-rc.2", ObjectID: "c210-rc2"},
+		{Name: "refs/tags/v2.0.0-rc.1", ObjectID: "c200-rc1"},
+	}
+}
+
+func TestPlanPromotionUsesHighestRC(t *testing.T) {
+	t.Parallel()
+
+	result, err := NewPlanner("v").PlanPromotion(promotionTags(), "")
+	if err != nil {
+		t.Fatalf("plan promotion: %v", err)
+	}
+	if result.TagName != "v2.1.0" || result.BaseSource != BaseSourcePromoted || result.RCNumber != 3 {
+		t.Fatalf("unexpected promotion: %+v", result)
+	}
+	if result.BaseTag.Name != "refs/tags/v2.1.0-rc.3" || result.BaseTag.ObjectID != "c210-rc3" {
+		t.Fatalf("expected rc.3 and its commit, got %+v", result.BaseTag)
+	}
+	if result.Floating.TagName != "v2" || result.Floating.Existing.Name != "refs/tags/v2" || result.Floating.Superseded {
+		t.Fatalf("expected v2 to follow the promoted release, got %+v", result.Floating)
+	}
+}
+
+func TestPlanPromotionTargets(t *testing.T) {
+	t.Parallel()
+
+	tags := append(promotionTags(),
+		Tag{Name: "refs/tags/v3.0.0-rc.1", ObjectID: "c300-rc1"},
+		Tag{Name: "refs/tags/v3.0.0-beta.4", ObjectID: "c300-beta4"},
+	)
+	planner := NewPlanner("v")
+
+	if _, err := planner.PlanPromotion(tags, ""); !errors.Is(err, ErrPromoteAmbiguous) || !strings.Contains(err.Error(), "2.1.0, 3.0.0") {
+		t.Fatalf("expected both open lines reported, got %v", err)
+	}
+
+	tests := []struct {
+		target    string
+		expectTag string
+		expectRC  string
+		expectErr error
+	}{
+		{target: "2.1.0", expectTag: "v2.1.0", expectRC: "refs/tags/v2.1.0-rc.3"},
+		{target: "v3.0.0", expectTag: "v3.0.0", expectRC: "refs/tags/v3.0.0-rc.1"},
+		{target: "v3.0.0-rc.1", expectTag: "v3.0.0", expectRC: "refs/tags/v3.0.0-rc.1"},
+		{target: "2.0.0", expectErr: ErrPromoteTargetExists},
+		{target: "2.2.0", expectErr: ErrNoRCToPromote},
+	}
+	for _, tc := range tests {
+		t.Run(tc.target, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := planner.PlanPromotion(tags, tc.target)
+			if tc.expectErr != nil {
+				if !errors.Is(err, tc.expectErr) {
+					t.Fatalf("expected %v, got %v", tc.expectErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("plan promotion: %v", err)
+			}
+			if result.TagName != tc.expectTag || result.BaseTag.Name != tc.expectRC {
+				t.Fatalf("expected %s from %s, got %s from %s", tc.expectTag, tc.expectRC, result.TagName, result.BaseTag.Name)
+			}
+		})
+	}
+}
+
+func TestPlanPromotionWithoutRC(t *testing.T) {
+	t.Parallel()
+
+	tags := []Tag{{Name: "refs/tags/v1.0.0", ObjectID: "c100"}, {Name: "refs/tags/v1.0.0-rc.1", ObjectID: "c100"}}
+	if _, err := NewPlanner("v").PlanPromotion(tags, ""); !errors.Is(err, ErrNoRCToPromote) {
+		t.Fatalf("expected no RC to promote, got %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+package tagplan
+
+import (
+	"fmt"
+	"strings"
+
+	semver "github.com/blang/semver/v4"
+)
+
+// RCScope selects which prereleases share an RC counter when the next RC number is chosen.
+type RCScope string
+
+const (
+	// RCScopeExact numbers RCs per target version, so 1.3.1 starts again at rc.1.
+	RCScopeExact RCScope = "exact"
+	// RCScopeMinor continues the count across patches of a minor line (1.3.0-rc.2, then
+	// 1.3.1-rc.3).
+	RCScopeMinor RCScope = "minor"
+	// RCScopeMajor continues the count across every version of a major line.
+	RCScopeMajor RCScope = "major"
+)
+
+// ParseRCScope converts a string into an RCScope. Empty values map to RCScopeExact.
+func ParseRCScope(value string) (RCScope, error) {
+	switch RCScope(strings.ToLower(strings.TrimSpace(value))) {
+	case "", RCScopeExact:
+		return RCScopeExact, nil
+	case RCScopeMinor:
+		return RCScopeMinor, nil
+	case RCScopeMajor:
+		return RCScopeMajor, nil
+	default:
+		return "", fmt.Errorf("invalid rc scope %q", value)
+	}
+}
+
+// WithRCScope returns a copy of the planner that numbers RC tags across the given scope.
+func (p Planner) WithRCScope(scope RCScope) Planner {
+	p.rcScope = scope
+	return p
+}
+
+// contains reports whether version shares target's RC counter under the scope.
+func (s RCScope) contains(version, target semver.Version) bool {
+	switch s {
+	case RCScopeMajor:
+		return version.Major == target.Major
+	case RCScopeMinor:
+		return version.Major == target.Major && version.Minor == target.Minor
+	default:
+		return sameBase(version, target)
+	}
+}
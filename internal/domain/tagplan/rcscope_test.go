@@ -0,0 +1,58 @@
+package tagplan
+
+import (
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+)
+
+func TestPlanRCScope(t *testing.T) {
+	t.Parallel()
+
+	// The next patch RC targets 1.3.1; earlier RCs exist for 1.3.0 and for the 1.2 line.
+	tags := []Tag{
+		{Name: "refs/tags/v1.2.0", ObjectID: "a"},
+		{Name: "refs/tags/v1.2.1-rc.5", ObjectID: "b"},
+		{Name: "refs/tags/v1.3.0-rc.1", ObjectID: "c"},
+		{Name: "refs/tags/v1.3.0-rc.2", ObjectID: "d"},
+		{Name: "refs/tags/v1.3.0", ObjectID: "d"},
+	}
+
+	tests := []struct {
+		scope    RCScope
+		expected string
+		rc       int
+	}{
+		{scope: RCScopeExact, expected: "v1.3.1-rc.1", rc: 1},
+		{scope: RCScopeMinor, expected: "v1.3.1-rc.3", rc: 3},
+		{scope: RCScopeMajor, expected: "v1.3.1-rc.6", rc: 6},
+	}
+
+	for _, tc := range tests {
+		t.Run(string(tc.scope), func(t *testing.T) {
+			t.Parallel()
+
+			result, err := NewPlanner("v").WithRCScope(tc.scope).PlanRC(tags, bump.BumpPatch, "")
+			if err != nil {
+				t.Fatalf("plan rc: %v", err)
+			}
+			if result.TagName != tc.expected || result.RCNumber != tc.rc {
+				t.Fatalf("expected %s (rc %d), got %s (rc %d)", tc.expected, tc.rc, result.TagName, result.RCNumber)
+			}
+		})
+	}
+}
+
+func TestParseRCScope(t *testing.T) {
+	t.Parallel()
+
+	for value, expected := range map[string]RCScope{"": RCScopeExact, "exact": RCScopeExact, "Minor": RCScopeMinor, "major": RCScopeMajor} {
+		scope, err := ParseRCScope(value)
+		if err != nil || scope != expected {
+			t.Fatalf("ParseRCScope(%q) = %q, %v; want %q", value, scope, err, expected)
+		}
+	}
+	if _, err := ParseRCScope("patch"); err == nil {
+		t.Fatalf("expected an error for an unknown scope")
+	}
+}
@@ -0,0 +1,66 @@
+package tagplan
+
+import (
+	"fmt"
+	"sort"
+
+	semver "github.com/blang/semver/v4"
+)
+
+// RCSequence describes the RC tags of one target release and the numbers missing between
+// <label>.1 and the highest RC.
+type RCSequence struct {
+	Target semver.Version
+	// Label is the prerelease channel checked, such as "rc" or "beta".
+	Label string
+	// RCs maps each RC number found to its short tag name.
+	RCs map[int]string
+	// Numbers lists the RC numbers found in ascending order.
+	Numbers []int
+	// Missing lists the numbers between 1 and the highest RC that have no tag.
+	Missing []int
+}
+
+// Continuous reports that the RCs run from rc.1 with no gaps. A target without RCs is
+// continuous.
+func (s RCSequence) Continuous() bool {
+	return len(s.Missing) == 0
+}
+
+// RCSequence collects the <label>.N tags of target, which must be a stable version, on the
+// planner's prerelease channel and reports the numbers missing from 1 up to the highest RC.
+// Prereleases on other channels are ignored.
+func (p Planner) RCSequence(tags []Tag, target string) (RCSequence, error) {
+	version, err := parseVersionString(target)
+	if err != nil {
+		return RCSequence{}, fmt.Errorf("invalid target: %w", err)
+	}
+	if len(version.Pre) > 0 || len(version.Build) > 0 {
+		return RCSequence{}, fmt.Errorf("invalid target %q: must be a release version such as 2.1.0", target)
+	}
+
+	c := buildCatalog(tags, p.component, p.namePrefix())
+	sequence := RCSequence{Target: version, Label: p.channel(), RCs: make(map[int]string)}
+	for _, entry := range c.prereleases {
+		if !sameBase(entry.version, version) {
+			continue
+		}
+		number, ok := prereleaseNumber(entry.version, sequence.Label)
+		if !ok {
+			continue
+		}
+		sequence.RCs[number] = shortTagName(entry.tag.Name)
+		sequence.Numbers = append(sequence.Numbers, number)
+	}
+	sort.Ints(sequence.Numbers)
+
+	if len(sequence.Numbers) > 0 {
+		highest := sequence.Numbers[len(sequence.Numbers)-1]
+		for number := 1; number < highest; number++ {
+			if _, ok := sequence.RCs[number]; !ok {
+				sequence.Missing = append(sequence.Missing, number)
+			}
+		}
+	}
+	return sequence, nil
+}
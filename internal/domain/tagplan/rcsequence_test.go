@@ -0,0 +1,91 @@
+package tagplan
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRCSequence(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		label         string
+		tags          []Tag
+		target        string
+		expectNumbers []int
+		expectMissing []int
+	}{
+		{
+			name: "continuous",
+			tags: []Tag{
+				{Name: "refs/tags/v2.1.0-rc.2"},
+				{Name: "refs/tags/v2.1.0-rc.1"},
+				{Name: "refs/tags/v2.1.0-rc.3"},
+				{Name: "refs/tags/v2.0.0-rc.5"},
+			},
+			target:        "2.1.0",
+			expectNumbers: []int{1, 2, 3},
+		},
+		{
+			name: "gaps",
+			tags: []Tag{
+				{Name: "refs/tags/v2.1.0-rc.2"},
+				{Name: "refs/tags/v2.1.0-rc.5"},
+				{Name: "refs/tags/v2.1.0"},
+			},
+			target:        "v2.1.0",
+			expectNumbers: []int{2, 5},
+			expectMissing: []int{1, 3, 4},
+		},
+		{
+			name:   "no rcs",
+			tags:   []Tag{{Name: "refs/tags/v2.1.0"}},
+			target: "2.1.0",
+		},
+		{
+			name:          "non rc prereleases ignored",
+			tags:          []Tag{{Name: "refs/tags/v2.1.0-beta.1"}, {Name: "refs/tags/v2.1.0-rc.1"}},
+			target:        "2.1.0",
+			expectNumbers: []int{1},
+		},
+		{
+			name:          "configured channel",
+			label:         "beta",
+			tags:          []Tag{{Name: "refs/tags/v2.1.0-beta.3"}, {Name: "refs/tags/v2.1.0-beta.1"}, {Name: "refs/tags/v2.1.0-rc.2"}},
+			target:        "2.1.0",
+			expectNumbers: []int{1, 3},
+			expectMissing: []int{2},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			sequence, err := NewPlanner("v").WithPrereleaseLabel(tc.label).RCSequence(tc.tags, tc.target)
+			if err != nil {
+				t.Fatalf("rc sequence: %v", err)
+			}
+			if !reflect.DeepEqual(sequence.Numbers, tc.expectNumbers) {
+				t.Fatalf("numbers: want %v got %v", tc.expectNumbers, sequence.Numbers)
+			}
+			if !reflect.DeepEqual(sequence.Missing, tc.expectMissing) {
+				t.Fatalf("missing: want %v got %v", tc.expectMissing, sequence.Missing)
+			}
+			if sequence.Continuous() != (len(tc.expectMissing) == 0) {
+				t.Fatalf("continuous: got %t with missing %v", sequence.Continuous(), sequence.Missing)
+			}
+		})
+	}
+}
+
+func TestRCSequenceRejectsInvalidTargets(t *testing.T) {
+	t.Parallel()
+
+	for _, target := range []string{"", "2.1", "2.1.0-rc.1"} {
+		if _, err := NewPlanner("v").RCSequence(nil, target); err == nil {
+			t.Fatalf("target %q: expected error", target)
+		}
+	}
+}
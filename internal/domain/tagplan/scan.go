@@ -0,0 +1,69 @@
+package tagplan
+
+import (
+	"container/heap"
+
+	semver "github.com/blang/semver/v4"
+)
+
+// TopTags returns the limit highest-versioned tags plus every tag that is not a version
+// (floating refs and unrecognized names), preserving input order. Versions below the cut cannot
+// hold the highest release or the RCs of the next one, so only lookups of specific old versions,
+// such as hotfix bases or version ranges, are affected. A limit of zero or less returns tags
+// unchanged.
+func (p Planner) TopTags(tags []Tag, limit int) []Tag {
+	if limit <= 0 || len(tags) <= limit {
+		return tags
+	}
+
+	component, namePrefix := p.component, p.namePrefix()
+	keep := make([]bool, len(tags))
+	top := make(versionHeap, 0, limit+1)
+	for i, tag := range tags {
+		version, ok := parseSemverTag(tag.Name, component, namePrefix)
+		if !ok {
+			keep[i] = true
+			continue
+		}
+		if len(top) < limit {
+			heap.Push(&top, indexedVersion{index: i, version: version})
+			continue
+		}
+		if version.GT(top[0].version) {
+			top[0] = indexedVersion{index: i, version: version}
+			heap.Fix(&top, 0)
+		}
+	}
+	for _, entry := range top {
+		keep[entry.index] = true
+	}
+
+	selected := make([]Tag, 0, len(top))
+	for i, tag := range tags {
+		if keep[i] {
+			selected = append(selected, tag)
+		}
+	}
+	return selected
+}
+
+type indexedVersion struct {
+	index   int
+	version semver.Version
+}
+
+// versionHeap is a min-heap on version, so its root is the lowest version still retained.
+type versionHeap []indexedVersion
+
+func (h versionHeap) Len() int           { return len(h) }
+func (h versionHeap) Less(i, j int) bool { return h[i].version.LT(h[j].version) }
+func (h versionHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *versionHeap) Push(x any) { *h = append(*h, x.(indexedVersion)) }
+
+func (h *versionHeap) Pop() any {
+	old := *h
+	last := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return last
+}
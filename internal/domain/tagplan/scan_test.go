@@ -0,0 +1,115 @@
+package tagplan
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+)
+
+// syntheticTags returns releases v0.0.0 through v<majors-1>.9.9 in scrambled order, an RC for
+// the next minor of the highest line, one floating tag per major, and an unrelated tag.
+func syntheticTags(majors int) []Tag {
+	var tags []Tag
+	for minor := 9; minor >= 0; minor-- {
+		for major := 0; major < majors; major++ {
+			for patch := 0; patch < 10; patch++ {
+				tags = append(tags, Tag{Name: fmt.Sprintf("refs/tags/v%d.%d.%d", major, minor, patch)})
+			}
+		}
+	}
+	for major := 0; major < majors; major++ {
+		tags = append(tags, Tag{Name: fmt.Sprintf("refs/tags/v%d", major)})
+	}
+	return append(tags,
+		Tag{Name: fmt.Sprintf("refs/tags/v%d.10.0-rc.1", majors-1)},
+		Tag{Name: "refs/tags/nightly"},
+	)
+}
+
+func TestTopTagsKeepsHighestVersionsAndNonVersions(t *testing.T) {
+	t.Parallel()
+
+	tags := []Tag{
+		{Name: "refs/tags/v1.0.0"},
+		{Name: "refs/tags/v1"},
+		{Name: "refs/tags/v2.1.0"},
+		{Name: "refs/tags/nightly"},
+		{Name: "refs/tags/v2.0.0"},
+		{Name: "refs/tags/v2.2.0-rc.1"},
+	}
+
+	got := NewPlanner("v").TopTags(tags, 2)
+
+	want := []string{"refs/tags/v1", "refs/tags/v2.1.0", "refs/tags/nightly", "refs/tags/v2.2.0-rc.1"}
+	if len(got) != len(want) {
+		t.Fatalf("tags: want %v got %+v", want, got)
+	}
+	for i, name := range want {
+		if got[i].Name != name {
+			t.Fatalf("tag %d: want %s got %s", i, name, got[i].Name)
+		}
+	}
+}
+
+func TestTopTagsWithoutLimit(t *testing.T) {
+	t.Parallel()
+
+	tags := []Tag{{Name: "refs/tags/v1.0.0"}, {Name: "refs/tags/v1.1.0"}}
+	for _, limit := range []int{0, -1, 2, 5} {
+		if got := NewPlanner("v").TopTags(tags, limit); len(got) != len(tags) {
+			t.Fatalf("limit %d: want %d tags got %d", limit, len(tags), len(got))
+		}
+	}
+}
+
+func TestTopTagsPreservesNextVersion(t *testing.T) {
+	t.Parallel()
+
+	tags := syntheticTags(50)
+	planner := NewPlanner("v")
+	capped := planner.TopTags(tags, 25)
+
+	full, err := planner.PlanRelease(tags, bump.BumpMinor, "")
+	if err != nil {
+		t.Fatalf(errPlanRelease, err)
+	}
+	scanned, err := planner.PlanRelease(capped, bump.BumpMinor, "")
+	if err != nil {
+		t.Fatalf(errPlanRelease, err)
+	}
+	if full.TagName != scanned.TagName || full.BaseTag.Name != scanned.BaseTag.Name {
+		t.Fatalf("release: want %s from %s got %s from %s", full.TagName, full.BaseTag.Name, scanned.TagName, scanned.BaseTag.Name)
+	}
+	if full.Floating.TagName != scanned.Floating.TagName || full.Floating.Existing.Name != scanned.Floating.Existing.Name {
+		t.Fatalf("floating: want %+v got %+v", full.Floating, scanned.Floating)
+	}
+
+	rc, err := planner.PlanRC(capped, bump.BumpMinor, "")
+	if err != nil {
+		t.Fatalf(errPlanRC, err)
+	}
+	if rc.TagName != "v49.10.0-rc.2" {
+		t.Fatalf("rc: want v49.10.0-rc.2 got %s", rc.TagName)
+	}
+}
+
+func BenchmarkTopTags(b *testing.B) {
+	tags := syntheticTags(100)
+	planner := NewPlanner("v")
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		planner.PlanRelease(planner.TopTags(tags, 100), bump.BumpPatch, "")
+	}
+}
+
+func BenchmarkPlanReleaseAllTags(b *testing.B) {
+	tags := syntheticTags(100)
+	planner := NewPlanner("v")
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		planner.PlanRelease(tags, bump.BumpPatch, "")
+	}
+}
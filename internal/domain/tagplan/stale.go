@@ -0,0 +1,45 @@
+package tagplan
+
+import (
+	"sort"
+
+	semver "github.com/blang/semver/v4"
+)
+
+// PrereleaseStatus describes one prerelease tag and whether the release it targets exists.
+type PrereleaseStatus struct {
+	Tag     Tag
+	TagName string
+	Version semver.Version
+	// Target is the stable release the prerelease leads up to (1.2.0 for 1.2.0-rc.3).
+	Target semver.Version
+	// ReleaseExists reports that Target is already tagged, which makes the prerelease stale.
+	ReleaseExists bool
+}
+
+// Prereleases lists every prerelease tag recognized by the planner, ordered by target and then
+// version, and marks those whose target release already exists.
+func (p Planner) Prereleases(tags []Tag) []PrereleaseStatus {
+	c := buildCatalog(tags, p.component, p.namePrefix())
+
+	statuses := make([]PrereleaseStatus, 0, len(c.prereleases))
+	for _, entry := range c.prereleases {
+		target := semver.Version{Major: entry.version.Major, Minor: entry.version.Minor, Patch: entry.version.Patch}
+		_, released := c.releaseForVersion(target)
+		statuses = append(statuses, PrereleaseStatus{
+			Tag:           entry.tag,
+			TagName:       shortTagName(entry.tag.Name),
+			Version:       entry.version,
+			Target:        target,
+			ReleaseExists: released,
+		})
+	}
+
+	sort.SliceStable(statuses, func(i, j int) bool {
+		if !statuses[i].Target.EQ(statuses[j].Target) {
+			return statuses[i].Target.LT(statuses[j].Target)
+		}
+		return statuses[i].Version.LT(statuses[j].Version)
+	})
+	return statuses
+}
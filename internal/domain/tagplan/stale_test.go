@@ -0,0 +1,54 @@
+package tagplan
+
+import "testing"
+
+func TestPrereleasesMarksReleasedTargets(t *testing.T) {
+	t.Parallel()
+
+	tags := []Tag{
+		{Name: "refs/tags/v1.3.0-rc.2"},
+		{Name: "refs/tags/v1.2.0-rc.1"},
+		{Name: "refs/tags/v1.2.0"},
+		{Name: "refs/tags/v1.3.0-rc.10"},
+		{Name: "refs/tags/v1.2.0-rc.2"},
+		{Name: "refs/tags/v2.0.0-beta.1"},
+		{Name: "refs/tags/v1"},
+		{Name: "refs/tags/notes"},
+	}
+
+	got := NewPlanner("v").Prereleases(tags)
+
+	want := []struct {
+		tag    string
+		target string
+		stale  bool
+	}{
+		{tag: "v1.2.0-rc.1", target: "1.2.0", stale: true},
+		{tag: "v1.2.0-rc.2", target: "1.2.0", stale: true},
+		{tag: "v1.3.0-rc.2", target: "1.3.0"},
+		{tag: "v1.3.0-rc.10", target: "1.3.0"},
+		{tag: "v2.0.0-beta.1", target: "2.0.0"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d prereleases, got %+v", len(want), got)
+	}
+	for i, w := range want {
+		if got[i].TagName != w.tag || got[i].Target.String() != w.target || got[i].ReleaseExists != w.stale {
+			t.Fatalf("entry %d: want %+v got tag=%s target=%s stale=%v", i, w, got[i].TagName, got[i].Target, got[i].ReleaseExists)
+		}
+	}
+}
+
+func TestPrereleasesHonorsPrefixSeparator(t *testing.T) {
+	t.Parallel()
+
+	tags := []Tag{
+		{Name: "refs/tags/release-1.0.0-rc.1"},
+		{Name: "refs/tags/release-1.0.0"},
+	}
+
+	got := NewPlanner("release").WithPrefixSeparator("-").Prereleases(tags)
+	if len(got) != 1 || !got[0].ReleaseExists || got[0].TagName != "release-1.0.0-rc.1" {
+		t.Fatalf("expected one stale prefixed prerelease, got %+v", got)
+	}
+}
@@ -0,0 +1,52 @@
+package tagplan
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FloatingTrack selects which tags a floating v<major> ref follows.
+type FloatingTrack string
+
+const (
+	// FloatingTrackStable follows stable releases only.
+	FloatingTrackStable FloatingTrack = "stable"
+	// FloatingTrackAny follows the latest tag in the major, including release candidates.
+	FloatingTrackAny FloatingTrack = "any"
+)
+
+// ParseFloatingTrack converts a string into a FloatingTrack. Empty values map to
+// FloatingTrackStable.
+func ParseFloatingTrack(value string) (FloatingTrack, error) {
+	switch FloatingTrack(strings.ToLower(strings.TrimSpace(value))) {
+	case "", FloatingTrackStable:
+		return FloatingTrackStable, nil
+	case FloatingTrackAny:
+		return FloatingTrackAny, nil
+	default:
+		return "", fmt.Errorf("invalid floating track %q", value)
+	}
+}
+
+// WithFloatingTrack returns a copy of the planner that detects and targets floating tags
+// using the provided track.
+func (p Planner) WithFloatingTrack(track FloatingTrack) Planner {
+	p.floatingTrack = track
+	return p
+}
+
+// FloatingEligible reports whether the floating tag may follow this plan: always for
+// releases, and for RC tags when the planner tracks prereleases.
+func (r Result) FloatingEligible() bool {
+	return r.Mode == ModeRelease || r.Floating.TagName != ""
+}
+
+// tracked returns the tags a floating ref may point at under the given track.
+func (c catalog) tracked(track FloatingTrack) []releaseEntry {
+	if track != FloatingTrackAny {
+		return c.releases
+	}
+	entries := make([]releaseEntry, 0, len(c.releases)+len(c.prereleases))
+	entries = append(entries, c.releases...)
+	return append(entries, c.prereleases...)
+}
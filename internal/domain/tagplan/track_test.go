@@ -0,0 +1,115 @@
+package tagplan
+
+import (
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+)
+
+func TestPlanReleaseFloatingTrackPrereleaseHighest(t *testing.T) {
+	t.Parallel()
+
+	// v2 points at the release candidate, which is the highest tag overall.
+	tags := []Tag{
+		{Name: "refs/tags/v1.4.0", ObjectID: "stable"},
+		{Name: "refs/tags/v2.0.0-rc.1", ObjectID: "rc"},
+		{Name: "refs/tags/v2", ObjectID: "rc"},
+	}
+
+	tests := []struct {
+		name         string
+		track        FloatingTrack
+		autoDetected bool
+		detected     uint64
+	}{
+		{name: "stable ignores the prerelease", track: FloatingTrackStable, autoDetected: false, detected: 1},
+		{name: "any follows the prerelease", track: FloatingTrackAny, autoDetected: true, detected: 2},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := NewPlanner("v").WithFloatingTrack(tc.track).PlanRelease(tags, bump.BumpMajor, "")
+			if err != nil {
+				t.Fatalf(errPlanRelease, err)
+			}
+			if result.TagName != "v2.0.0" {
+				t.Fatalf("tag name: want v2.0.0 got %s", result.TagName)
+			}
+			if result.Floating.AutoDetected != tc.autoDetected || result.Floating.AutoDetectedMajor != tc.detected {
+				t.Fatalf("expected autoDetected=%t major=%d, got %+v", tc.autoDetected, tc.detected, result.Floating)
+			}
+			if result.Floating.TagName != "v2" || result.Floating.Existing.Name != "refs/tags/v2" {
+				t.Fatalf("expected existing v2 floating tag, got %+v", result.Floating)
+			}
+		})
+	}
+}
+
+func TestPlanRCFloatingTrack(t *testing.T) {
+	t.Parallel()
+
+	tags := []Tag{
+		{Name: "refs/tags/v1.2.3", ObjectID: "stable"},
+		{Name: "refs/tags/v1.3.0-rc.1", ObjectID: "rc1"},
+		{Name: "refs/tags/v1", ObjectID: "rc1"},
+	}
+
+	stable, err := NewPlanner("v").PlanRC(tags, bump.BumpMinor, "")
+	if err != nil {
+		t.Fatalf(errPlanRC, err)
+	}
+	if stable.FloatingEligible() || stable.Floating.TagName != "" {
+		t.Fatalf("expected no floating plan for RC under the stable track, got %+v", stable.Floating)
+	}
+
+	anyTrack, err := NewPlanner("v").WithFloatingTrack(FloatingTrackAny).PlanRC(tags, bump.BumpMinor, "")
+	if err != nil {
+		t.Fatalf(errPlanRC, err)
+	}
+	if anyTrack.TagName != "v1.3.0-rc.2" {
+		t.Fatalf("tag name: want v1.3.0-rc.2 got %s", anyTrack.TagName)
+	}
+	if !anyTrack.FloatingEligible() || anyTrack.Floating.TagName != "v1" {
+		t.Fatalf("expected v1 floating plan for RC under the any track, got %+v", anyTrack.Floating)
+	}
+	if !anyTrack.Floating.AutoDetected || anyTrack.Floating.AutoDetectedMajor != 1 {
+		t.Fatalf("expected floating detection from the prerelease, got %+v", anyTrack.Floating)
+	}
+}
+
+func TestParseFloatingTrack(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input     string
+		expected  FloatingTrack
+		expectErr bool
+	}{
+		{input: "", expected: FloatingTrackStable},
+		{input: "stable", expected: FloatingTrackStable},
+		{input: " ANY ", expected: FloatingTrackAny},
+		{input: "latest", expectErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseFloatingTrack(tc.input)
+			if tc.expectErr {
+				if err == nil {
+					t.Fatalf("expected error for %q", tc.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			if got != tc.expected {
+				t.Fatalf("expected %s, got %s", tc.expected, got)
+			}
+		})
+	}
+}
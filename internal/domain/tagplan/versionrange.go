@@ -0,0 +1,69 @@
+package tagplan
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	semver "github.com/blang/semver/v4"
+)
+
+// ErrVersionRange is returned when a computed version falls outside the configured range.
+var ErrVersionRange = errors.New("tagplan: computed version outside range")
+
+// VersionRange constrains planning to one release line, e.g. ">=1.0.0 <2.0.0". The zero value
+// accepts every version.
+type VersionRange struct {
+	expr  string
+	match semver.Range
+}
+
+// ParseVersionRange parses a semver range expression; empty input disables the constraint.
+func ParseVersionRange(expr string) (VersionRange, error) {
+	trimmed := strings.TrimSpace(expr)
+	if trimmed == "" {
+		return VersionRange{}, nil
+	}
+	match, err := semver.ParseRange(trimmed)
+	if err != nil {
+		return VersionRange{}, fmt.Errorf("invalid version range %q: %w", trimmed, err)
+	}
+	return VersionRange{expr: trimmed, match: match}, nil
+}
+
+// String returns the range expression as configured.
+func (r VersionRange) String() string {
+	return r.expr
+}
+
+// WithVersionRange returns a copy of the planner that only bases releases on tags inside r and
+// rejects computed versions outside it.
+func (p Planner) WithVersionRange(r VersionRange) Planner {
+	p.versionRange = r
+	return p
+}
+
+func (r VersionRange) contains(version semver.Version) bool {
+	return r.match == nil || r.match(version)
+}
+
+// filter returns the entries whose versions fall inside the range.
+func (r VersionRange) filter(entries []releaseEntry) []releaseEntry {
+	if r.match == nil {
+		return entries
+	}
+	var kept []releaseEntry
+	for _, entry := range entries {
+		if r.match(entry.version) {
+			kept = append(kept, entry)
+		}
+	}
+	return kept
+}
+
+func (r VersionRange) check(version semver.Version) error {
+	if r.contains(version) {
+		return nil
+	}
+	return fmt.Errorf("%w: %s does not satisfy %q", ErrVersionRange, version.String(), r.expr)
+}
@@ -0,0 +1,104 @@
+package tagplan
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+)
+
+func TestPlanReleaseVersionRange(t *testing.T) {
+	t.Parallel()
+
+	tags := []Tag{
+		{Name: "refs/tags/v1.4.0", ObjectID: "one"},
+		{Name: "refs/tags/v1.5.2", ObjectID: "two"},
+		{Name: "refs/tags/v2.0.0", ObjectID: "three"},
+		{Name: "refs/tags/v2.3.1", ObjectID: "four"},
+		{Name: "refs/tags/v3.0.0", ObjectID: "five"},
+	}
+
+	tests := []struct {
+		name      string
+		expr      string
+		intent    bump.Bump
+		expectTag string
+		expectObj string
+		// expectNew reports whether the release supersedes v3.0.0, the highest overall.
+		expectNew bool
+		expectErr bool
+	}{
+		{name: "no range uses highest overall", intent: bump.BumpPatch, expectTag: "v3.0.1", expectObj: "five", expectNew: true},
+		{name: "range selects first major", expr: ">=1.0.0 <2.0.0", intent: bump.BumpMinor, expectTag: "v1.6.0", expectObj: "two"},
+		{name: "range selects middle major", expr: ">=2.0.0 <3.0.0", intent: bump.BumpPatch, expectTag: "v2.3.2", expectObj: "four"},
+		{name: "major bump leaves range", expr: ">=1.0.0 <2.0.0", intent: bump.BumpMajor, expectErr: true},
+		{name: "range without releases falls back to zero", expr: ">=4.0.0 <5.0.0", intent: bump.BumpPatch, expectErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			versionRange, err := ParseVersionRange(tc.expr)
+			if err != nil {
+				t.Fatalf("parse range: %v", err)
+			}
+			result, err := NewPlanner("v").WithVersionRange(versionRange).PlanRelease(tags, tc.intent, "")
+			if tc.expectErr {
+				if !errors.Is(err, ErrVersionRange) {
+					t.Fatalf("expected ErrVersionRange, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf(errPlanRelease, err)
+			}
+			if result.TagName != tc.expectTag {
+				t.Fatalf("tag name: want %s got %s", tc.expectTag, result.TagName)
+			}
+			if result.BaseTag.ObjectID != tc.expectObj {
+				t.Fatalf("base tag: want %s got %+v", tc.expectObj, result.BaseTag)
+			}
+			if result.HighestRelease.ObjectID != "five" || result.NewHighest != tc.expectNew {
+				t.Fatalf("highest release: want five new=%v, got %+v new=%v", tc.expectNew, result.HighestRelease, result.NewHighest)
+			}
+		})
+	}
+}
+
+func TestPlanRCVersionRange(t *testing.T) {
+	t.Parallel()
+
+	tags := []Tag{
+		{Name: "refs/tags/v1.5.2"},
+		{Name: "refs/tags/v1.6.0-rc.1"},
+		{Name: "refs/tags/v2.3.1"},
+	}
+	versionRange, err := ParseVersionRange(">=1.0.0 <2.0.0")
+	if err != nil {
+		t.Fatalf("parse range: %v", err)
+	}
+	planner := NewPlanner("v").WithVersionRange(versionRange)
+
+	result, err := planner.PlanRC(tags, bump.BumpMinor, "")
+	if err != nil {
+		t.Fatalf(errPlanRC, err)
+	}
+	if result.TagName != "v1.6.0-rc.2" {
+		t.Fatalf("tag name: want v1.6.0-rc.2 got %s", result.TagName)
+	}
+	if _, err := planner.PlanRC(tags, bump.BumpMajor, ""); !errors.Is(err, ErrVersionRange) {
+		t.Fatalf("expected ErrVersionRange for RC target 2.0.0, got %v", err)
+	}
+}
+
+func TestParseVersionRange(t *testing.T) {
+	t.Parallel()
+
+	if r, err := ParseVersionRange("  "); err != nil || r.String() != "" {
+		t.Fatalf("expected empty range to disable the constraint, got %q, %v", r.String(), err)
+	}
+	if _, err := ParseVersionRange("not-a-range"); err == nil {
+		t.Fatal("expected error for malformed range")
+	}
+}
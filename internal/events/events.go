@@ -0,0 +1,118 @@
+// Package events records the significant steps of a run as JSON lines for support and replay.
+// Unlike the human logs, the stream is meant to be parsed: event names and data keys are
+// stable, and new information is only ever added. Callers record names, object IDs, and
+// counts; credentials are never passed to a Log.
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Event names written by the tagging service.
+const (
+	RefsListed      = "refs_listed"
+	PlanComputed    = "plan_computed"
+	TagCreated      = "tag_created"
+	FloatingUpdated = "floating_updated"
+)
+
+// Event is one line of the stream. Seq starts at 1 and orders events within a run.
+type Event struct {
+	Seq   int            `json:"seq"`
+	Time  time.Time      `json:"time"`
+	Event string         `json:"event"`
+	Data  map[string]any `json:"data,omitempty"`
+}
+
+// Log writes events to w, one JSON object per line. A nil *Log discards events, so callers
+// record unconditionally. It is safe for concurrent use.
+type Log struct {
+	mu     sync.Mutex
+	w      io.Writer
+	closer io.Closer
+	seq    int
+	now    func() time.Time
+	err    error
+}
+
+// New returns a Log writing to w.
+func New(w io.Writer) *Log {
+	return &Log{w: w, now: time.Now}
+}
+
+// Open creates or truncates the file at path and returns a Log writing to it; Close releases
+// the file.
+func Open(path string) (*Log, error) {
+	target := strings.TrimSpace(path)
+	if target == "" {
+		return nil, fmt.Errorf("events: file path is empty")
+	}
+	file, err := os.Create(target)
+	if err != nil {
+		return nil, fmt.Errorf("events: opening %s: %w", target, err)
+	}
+	log := New(file)
+	log.closer = file
+	return log, nil
+}
+
+// Record writes one event. The first write error is kept for Close and later records are
+// dropped, so a full disk never fails the run itself.
+func (l *Log) Record(event string, data map[string]any) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.err != nil {
+		return
+	}
+	l.seq++
+	line, err := json.Marshal(Event{Seq: l.seq, Time: l.now().UTC(), Event: event, Data: data})
+	if err != nil {
+		l.err = fmt.Errorf("events: encoding %s: %w", event, err)
+		return
+	}
+	if _, err := l.w.Write(append(line, '\n')); err != nil {
+		l.err = fmt.Errorf("events: writing %s: %w", event, err)
+	}
+}
+
+// Close releases the file opened by Open and reports the first write error, if any.
+func (l *Log) Close() error {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.closer != nil {
+		if err := l.closer.Close(); err != nil && l.err == nil {
+			l.err = fmt.Errorf("events: closing: %w", err)
+		}
+		l.closer = nil
+	}
+	return l.err
+}
+
+// Decode reads an event stream written by a Log, for replaying a run in tests or support.
+func Decode(r io.Reader) ([]Event, error) {
+	var decoded []Event
+	decoder := json.NewDecoder(r)
+	for {
+		var event Event
+		err := decoder.Decode(&event)
+		if err == io.EOF {
+			return decoded, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("events: decoding: %w", err)
+		}
+		decoded = append(decoded, event)
+	}
+}
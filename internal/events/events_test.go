@@ -0,0 +1,75 @@
+package events
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLogWritesSequencedLines(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	log := New(&buf)
+	log.Record(RefsListed, map[string]any{"count": 2})
+	log.Record(PlanComputed, nil)
+
+	decoded, err := Decode(&buf)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(decoded) != 2 || decoded[0].Seq != 1 || decoded[0].Event != RefsListed || decoded[1].Seq != 2 || decoded[1].Event != PlanComputed {
+		t.Fatalf("unexpected events: %+v", decoded)
+	}
+	if count, ok := decoded[0].Data["count"].(float64); !ok || count != 2 {
+		t.Fatalf("expected count 2, got %+v", decoded[0].Data)
+	}
+}
+
+func TestNilLogDiscards(t *testing.T) {
+	t.Parallel()
+
+	var log *Log
+	log.Record(TagCreated, map[string]any{"tag": "v1.0.0"})
+	if err := log.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestLogReportsFirstWriteError(t *testing.T) {
+	t.Parallel()
+
+	log := New(failingWriter{})
+	log.Record(TagCreated, nil)
+	log.Record(FloatingUpdated, nil)
+	if err := log.Close(); err == nil || !bytes.Contains([]byte(err.Error()), []byte(TagCreated)) {
+		t.Fatalf("expected the first write error, got %v", err)
+	}
+}
+
+func TestOpenWritesFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	log, err := Open(path)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	log.Record(TagCreated, map[string]any{"tag": "v1.0.0"})
+	if err := log.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if !bytes.Contains(data, []byte(`"event":"tag_created"`)) {
+		t.Fatalf("expected tag_created in %s", data)
+	}
+}
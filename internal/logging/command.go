@@ -0,0 +1,16 @@
+package logging
+
+import "go.uber.org/zap"
+
+// CommandField is the log field naming the subcommand that emitted an entry.
+const CommandField = "command"
+
+// WithCommand attaches the subcommand name (e.g. "create-tag") to every entry logged through
+// logger, so interleaved output from several invocations can be told apart. An empty name
+// leaves the logger unchanged.
+func WithCommand(logger *zap.Logger, name string) *zap.Logger {
+	if name == "" {
+		return logger
+	}
+	return logger.With(zap.String(CommandField, name))
+}
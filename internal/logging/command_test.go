@@ -0,0 +1,43 @@
+package logging
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestWithCommandAddsField(t *testing.T) {
+	t.Parallel()
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	WithCommand(zap.New(core), "create-tag").Info("tag created")
+	WithCommand(zap.New(core), "").Info("no command")
+
+	entries := logs.All()
+	if len(entries) != 2 {
+		t.Fatalf("expected two entries, got %d", len(entries))
+	}
+	if got := entries[0].ContextMap()[CommandField]; got != "create-tag" {
+		t.Fatalf("expected the command name, got %v", entries[0].ContextMap())
+	}
+	if _, ok := entries[1].ContextMap()[CommandField]; ok {
+		t.Fatalf("expected no command field, got %v", entries[1].ContextMap())
+	}
+}
+
+func TestWithCommandHonoursFieldMap(t *testing.T) {
+	t.Parallel()
+
+	fieldMap, err := ParseFieldMap([]string{"command=aav_command"})
+	if err != nil {
+		t.Fatalf("parse field map: %v", err)
+	}
+	core, logs := observer.New(zapcore.InfoLevel)
+	WithCommand(WithFieldMap(zap.New(core), fieldMap), "infer-bump").Info("bump inferred")
+
+	if got := logs.All()[0].ContextMap()["aav_command"]; got != "infer-bump" {
+		t.Fatalf("expected the renamed command field, got %v", logs.All()[0].ContextMap())
+	}
+}
@@ -0,0 +1,73 @@
+package logging
+
+import (
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// ParseFieldMap converts "from=to" entries into a field key remapping. Empty entries are
+// ignored; entries without a key on both sides, or mapping the same key twice, are rejected.
+func ParseFieldMap(entries []string) (map[string]string, error) {
+	fieldMap := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		from, to, ok := strings.Cut(entry, "=")
+		from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+		if !ok || from == "" || to == "" {
+			return nil, fmt.Errorf("invalid log field mapping %q (want from=to)", entry)
+		}
+		if _, dup := fieldMap[from]; dup {
+			return nil, fmt.Errorf("log field %q is mapped more than once", from)
+		}
+		fieldMap[from] = to
+	}
+	return fieldMap, nil
+}
+
+// WithFieldMap returns a logger that renames structured field keys found in fieldMap. Keys
+// not in the map, and the time/level/msg keys set by the encoder, are left untouched.
+func WithFieldMap(logger *zap.Logger, fieldMap map[string]string) *zap.Logger {
+	if len(fieldMap) == 0 {
+		return logger
+	}
+	return logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return fieldMapCore{Core: core, fieldMap: fieldMap}
+	}))
+}
+
+type fieldMapCore struct {
+	zapcore.Core
+	fieldMap map[string]string
+}
+
+func (c fieldMapCore) With(fields []zapcore.Field) zapcore.Core {
+	return fieldMapCore{Core: c.Core.With(c.rename(fields)), fieldMap: c.fieldMap}
+}
+
+func (c fieldMapCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c fieldMapCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(entry, c.rename(fields))
+}
+
+func (c fieldMapCore) rename(fields []zapcore.Field) []zapcore.Field {
+	renamed := make([]zapcore.Field, len(fields))
+	for i, field := range fields {
+		if key, ok := c.fieldMap[field.Key]; ok {
+			field.Key = key
+		}
+		renamed[i] = field
+	}
+	return renamed
+}
@@ -0,0 +1,76 @@
+package logging
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestParseFieldMap(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		entries   []string
+		expect    map[string]string
+		expectErr bool
+	}{
+		{name: "empty", expect: map[string]string{}},
+		{name: "pairs", entries: []string{"tag=git_tag", " commit = git_commit ", ""}, expect: map[string]string{"tag": "git_tag", "commit": "git_commit"}},
+		{name: "missing separator", entries: []string{"tag"}, expectErr: true},
+		{name: "missing target", entries: []string{"tag="}, expectErr: true},
+		{name: "duplicate source", entries: []string{"tag=a", "tag=b"}, expectErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseFieldMap(tc.entries)
+			if tc.expectErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			if len(got) != len(tc.expect) {
+				t.Fatalf("expected %v, got %v", tc.expect, got)
+			}
+			for from, to := range tc.expect {
+				if got[from] != to {
+					t.Fatalf("expected %v, got %v", tc.expect, got)
+				}
+			}
+		})
+	}
+}
+
+func TestWithFieldMapRenamesKeys(t *testing.T) {
+	t.Parallel()
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	logger := WithFieldMap(zap.New(core), map[string]string{"tag": "git_tag", "commit": "git_commit"})
+
+	logger.With(zap.String("tag", "v1.2.3")).Info("tag created", zap.String("commit", "abc"), zap.Int("rc", 2))
+	logger.Debug("filtered by level", zap.String("tag", "v1"))
+
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("expected one entry, got %d", len(entries))
+	}
+	fields := entries[0].ContextMap()
+	if fields["git_tag"] != "v1.2.3" || fields["git_commit"] != "abc" {
+		t.Fatalf("expected remapped keys, got %v", fields)
+	}
+	if _, ok := fields["tag"]; ok {
+		t.Fatalf("expected original key to be renamed, got %v", fields)
+	}
+	if fields["rc"] != int64(2) {
+		t.Fatalf("expected unmapped key to be kept, got %v", fields)
+	}
+}
@@ -0,0 +1,34 @@
+package logging
+
+import (
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// OperatorField is the log field naming who or what started the run.
+const OperatorField = "operator"
+
+// operatorEnv lists the variables DetectOperator consults, most specific first: the Azure
+// Pipelines and GitHub Actions requesters, then the local user.
+var operatorEnv = []string{"BUILD_REQUESTEDFOR", "GITHUB_ACTOR", "USER", "USERNAME"}
+
+// DetectOperator returns the first non-empty identity from the CI requester and user variables,
+// or "" when none is set.
+func DetectOperator(getenv func(string) string) string {
+	for _, key := range operatorEnv {
+		if value := strings.TrimSpace(getenv(key)); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// WithOperator attaches the operator field to every entry logged through logger. An empty
+// operator leaves the logger unchanged.
+func WithOperator(logger *zap.Logger, operator string) *zap.Logger {
+	if operator == "" {
+		return logger
+	}
+	return logger.With(zap.String(OperatorField, operator))
+}
@@ -0,0 +1,55 @@
+package logging
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestDetectOperator(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		env    map[string]string
+		expect string
+	}{
+		{name: "azure pipelines requester", env: map[string]string{"BUILD_REQUESTEDFOR": "Jane Doe", "GITHUB_ACTOR": "octocat", "USER": "agent"}, expect: "Jane Doe"},
+		{name: "github actor", env: map[string]string{"GITHUB_ACTOR": "octocat", "USER": "runner"}, expect: "octocat"},
+		{name: "local user", env: map[string]string{"USER": " alice "}, expect: "alice"},
+		{name: "windows user", env: map[string]string{"USERNAME": "bob"}, expect: "bob"},
+		{name: "unknown"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := DetectOperator(func(key string) string { return tc.env[key] }); got != tc.expect {
+				t.Fatalf("expected %q, got %q", tc.expect, got)
+			}
+		})
+	}
+}
+
+func TestWithOperatorAddsField(t *testing.T) {
+	t.Parallel()
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	logger := WithOperator(WithFieldMap(zap.New(core), map[string]string{OperatorField: "actor"}), "octocat")
+	logger.Info("tag created")
+	WithOperator(zap.New(core), "").Info("no operator")
+
+	entries := logs.All()
+	if len(entries) != 2 {
+		t.Fatalf("expected two entries, got %d", len(entries))
+	}
+	if got := entries[0].ContextMap()["actor"]; got != "octocat" {
+		t.Fatalf("expected the renamed operator field, got %v", entries[0].ContextMap())
+	}
+	if _, ok := entries[1].ContextMap()[OperatorField]; ok {
+		t.Fatalf("expected no operator field, got %v", entries[1].ContextMap())
+	}
+}
@@ -0,0 +1,16 @@
+package logging
+
+import "go.uber.org/zap"
+
+// RepoField is the log field naming the repository a run works on.
+const RepoField = "repo"
+
+// WithRepo attaches the repository's display name to every entry logged through logger, so
+// logs gathered from many repositories can be told apart. An empty name leaves the logger
+// unchanged.
+func WithRepo(logger *zap.Logger, name string) *zap.Logger {
+	if name == "" {
+		return logger
+	}
+	return logger.With(zap.String(RepoField, name))
+}
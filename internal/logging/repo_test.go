@@ -0,0 +1,28 @@
+package logging
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestWithRepoAddsField(t *testing.T) {
+	t.Parallel()
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	WithRepo(zap.New(core), "payments-api").Info("tag created")
+	WithRepo(zap.New(core), "").Info("no repo")
+
+	entries := logs.All()
+	if len(entries) != 2 {
+		t.Fatalf("expected two entries, got %d", len(entries))
+	}
+	if got := entries[0].ContextMap()[RepoField]; got != "payments-api" {
+		t.Fatalf("expected the repo display name, got %v", entries[0].ContextMap())
+	}
+	if _, ok := entries[1].ContextMap()[RepoField]; ok {
+		t.Fatalf("expected no repo field, got %v", entries[1].ContextMap())
+	}
+}
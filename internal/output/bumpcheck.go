@@ -0,0 +1,31 @@
+package output
+
+import "github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/branchmap"
+
+// BumpCheckResult is the JSON document printed by validate-bump.
+type BumpCheckResult struct {
+	Outcome       Outcome `json:"outcome"`
+	Branch        string  `json:"branch"`
+	MatchedPrefix string  `json:"matchedPrefix,omitempty"`
+	BranchMatched bool    `json:"branchMatched"`
+	ImpliedBump   string  `json:"impliedBump"`
+	Bump          string  `json:"bump"`
+	Tolerance     int     `json:"tolerance"`
+	Distance      int     `json:"distance"`
+	Valid         bool    `json:"valid"`
+}
+
+// NewBumpCheckResult converts a branch bump check into its JSON representation.
+func NewBumpCheckResult(check branchmap.BumpCheck) BumpCheckResult {
+	return BumpCheckResult{
+		Outcome:       bumpCheckOutcome(check),
+		Branch:        check.Branch,
+		MatchedPrefix: check.MatchedPrefix,
+		BranchMatched: check.Matched,
+		ImpliedBump:   check.Implied.String(),
+		Bump:          check.Proposed.String(),
+		Tolerance:     check.Tolerance,
+		Distance:      check.Distance,
+		Valid:         check.Within(),
+	}
+}
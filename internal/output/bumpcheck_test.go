@@ -0,0 +1,30 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/branchmap"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+)
+
+func TestNewBumpCheckResult(t *testing.T) {
+	t.Parallel()
+
+	check := branchmap.NewResolver(branchmap.Mapping{}).CheckBump("fix/typo", bump.BumpMajor, 1)
+	result := NewBumpCheckResult(check)
+
+	expected := BumpCheckResult{
+		Outcome:       OutcomeBumpOutOfRange,
+		Branch:        "fix/typo",
+		MatchedPrefix: "fix/",
+		BranchMatched: true,
+		ImpliedBump:   "patch",
+		Bump:          "major",
+		Tolerance:     1,
+		Distance:      2,
+		Valid:         false,
+	}
+	if result != expected {
+		t.Fatalf("expected %+v, got %+v", expected, result)
+	}
+}
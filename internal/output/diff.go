@@ -0,0 +1,47 @@
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/tagging"
+)
+
+// WriteDiff renders a before/after ref diff: unchanged refs are indented, refs that
+// disappear or move are prefixed with "-", and their replacements with "+".
+func WriteDiff(w io.Writer, diff tagging.Diff) error {
+	lines := []string{"--- before", "+++ after"}
+	for _, before := range diff.Before {
+		prefix := "-"
+		if containsSnapshot(diff.After, before) {
+			prefix = " "
+		}
+		lines = append(lines, formatSnapshot(prefix, before))
+	}
+	for _, after := range diff.After {
+		if containsSnapshot(diff.Before, after) {
+			continue
+		}
+		lines = append(lines, formatSnapshot("+", after))
+	}
+
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return fmt.Errorf("writing diff: %w", err)
+		}
+	}
+	return nil
+}
+
+func formatSnapshot(prefix string, snapshot tagging.RefSnapshot) string {
+	return fmt.Sprintf("%s %-16s %s -> %s", prefix, snapshot.Role, snapshot.Name, snapshot.Commit)
+}
+
+func containsSnapshot(values []tagging.RefSnapshot, target tagging.RefSnapshot) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/tagging"
+)
+
+func TestWriteDiff(t *testing.T) {
+	t.Parallel()
+
+	diff := tagging.Diff{
+		Before: []tagging.RefSnapshot{
+			{Role: tagging.RefRoleHighestRelease, Name: "v1.2.3", Commit: "aaa"},
+			{Role: tagging.RefRoleFloating, Name: "v1", Commit: "aaa"},
+		},
+		After: []tagging.RefSnapshot{
+			{Role: tagging.RefRoleHighestRelease, Name: "v1.2.3", Commit: "aaa"},
+			{Role: tagging.RefRoleFloating, Name: "v1", Commit: "bbb"},
+			{Role: tagging.RefRoleNewTag, Name: "v1.2.4-rc.1", Commit: "bbb"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteDiff(&buf, diff); err != nil {
+		t.Fatalf("write diff: %v", err)
+	}
+
+	want := []string{
+		"--- before",
+		"+++ after",
+		"  highest-release  v1.2.3 -> aaa",
+		"- floating         v1 -> aaa",
+		"+ floating         v1 -> bbb",
+		"+ new-tag          v1.2.4-rc.1 -> bbb",
+	}
+	got := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	if len(got) != len(want) {
+		t.Fatalf("want %d lines got %d:\n%s", len(want), len(got), buf.String())
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("line %d: want %q got %q", i, want[i], got[i])
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/config"
+)
+
+// ConfigSetting is one row of the doctor configuration report.
+type ConfigSetting struct {
+	Setting  string `json:"setting"`
+	Value    string `json:"value"`
+	Source   string `json:"source"`
+	EnvKey   string `json:"env,omitempty"`
+	Conflict bool   `json:"conflict,omitempty"`
+}
+
+// ConfigReport is the JSON document printed by doctor.
+type ConfigReport struct {
+	Outcome  Outcome         `json:"outcome"`
+	Settings []ConfigSetting `json:"settings"`
+}
+
+// NewConfigReport converts recorded resolutions into the doctor report.
+func NewConfigReport(resolutions []config.Resolution) ConfigReport {
+	report := ConfigReport{Outcome: OutcomeConfigOK, Settings: make([]ConfigSetting, 0, len(resolutions))}
+	for _, r := range resolutions {
+		if r.Conflict {
+			report.Outcome = OutcomeConfigConflict
+		}
+		report.Settings = append(report.Settings, ConfigSetting{
+			Setting:  r.Setting,
+			Value:    r.Value,
+			Source:   r.Source,
+			EnvKey:   r.EnvKey,
+			Conflict: r.Conflict,
+		})
+	}
+	return report
+}
+
+// WriteConfigReport prints an aligned SETTING/VALUE/SOURCE table. Env sources name the variable,
+// and a setting whose flag was overridden by the environment is marked as a conflict.
+func WriteConfigReport(w io.Writer, report ConfigReport) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(tw, "SETTING\tVALUE\tSOURCE"); err != nil {
+		return fmt.Errorf("writing config report: %w", err)
+	}
+	for _, s := range report.Settings {
+		value := s.Value
+		if value == "" {
+			value = "-"
+		}
+		source := s.Source
+		if (s.Source == config.SourceEnv || s.Source == config.SourcePipeline) && s.EnvKey != "" {
+			source = fmt.Sprintf("%s (%s)", s.Source, s.EnvKey)
+		}
+		if s.Conflict {
+			source += ", overrides flag"
+		}
+		if _, err := fmt.Fprintf(tw, "%s\t%s\t%s\n", s.Setting, value, source); err != nil {
+			return fmt.Errorf("writing config report: %w", err)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("writing config report: %w", err)
+	}
+	return nil
+}
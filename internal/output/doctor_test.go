@@ -0,0 +1,33 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/config"
+)
+
+func TestWriteConfigReport(t *testing.T) {
+	t.Parallel()
+
+	report := NewConfigReport([]config.Resolution{
+		{Setting: "org-url", EnvKey: "AAV_ORG_URL", Value: "https://dev.azure.com/acme", Source: config.SourceEnv},
+		{Setting: "token", EnvKey: "AAV_TOKEN", Value: "***", Source: config.SourceEnv, Conflict: true},
+		{Setting: "output", EnvKey: "AAV_OUTPUT", Value: "json", Source: config.SourceFlag},
+		{Setting: "label-major", EnvKey: "AAV_LABEL_MAJOR", Source: config.SourceDefault},
+	})
+
+	var buf bytes.Buffer
+	if err := WriteConfigReport(&buf, report); err != nil {
+		t.Fatalf("write report: %v", err)
+	}
+	want := "" +
+		"SETTING      VALUE                       SOURCE\n" +
+		"org-url      https://dev.azure.com/acme  env (AAV_ORG_URL)\n" +
+		"token        ***                         env (AAV_TOKEN), overrides flag\n" +
+		"output       json                        flag\n" +
+		"label-major  -                           default\n"
+	if buf.String() != want {
+		t.Fatalf("unexpected table:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
@@ -0,0 +1,75 @@
+package output
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/inferbump"
+)
+
+// plainEnvValue matches values that can be written to a dotenv file without quoting.
+var plainEnvValue = regexp.MustCompile(`^[A-Za-z0-9_./,:@+-]*$`)
+
+// EnvVar is a single KEY=value line in a dotenv file.
+type EnvVar struct {
+	Key   string
+	Value string
+}
+
+// InferBumpEnv lists the variables infer-bump writes to --env-out. The key set is always
+// written so sourcing the file clears values from a previous run.
+func InferBumpEnv(result inferbump.Result) []EnvVar {
+	prID := ""
+	if result.PRID > 0 {
+		prID = strconv.Itoa(result.PRID)
+	}
+	return []EnvVar{
+		{Key: "AAV_BUMP", Value: result.Bump.String()},
+		{Key: "AAV_PR_ID", Value: prID},
+		{Key: "AAV_BUMP_DEFAULTED", Value: strconv.FormatBool(result.Defaulted)},
+		{Key: "AAV_BUMP_DEFAULT_REASON", Value: string(result.DefaultReason)},
+		{Key: "AAV_SEMVER_LABELS", Value: strings.Join(result.SemverLabels, ",")},
+	}
+}
+
+// WriteEnvFile writes vars to path in dotenv format, appending to an existing file when
+// appendMode is set and replacing it otherwise.
+func WriteEnvFile(path string, vars []EnvVar, appendMode bool) error {
+	target := strings.TrimSpace(path)
+	if target == "" {
+		return fmt.Errorf("output: file path is empty")
+	}
+
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if appendMode {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+	file, err := os.OpenFile(target, flags, filePermissions)
+	if err != nil {
+		return fmt.Errorf("opening %s: %w", target, err)
+	}
+
+	var b strings.Builder
+	for _, v := range vars {
+		fmt.Fprintf(&b, "%s=%s\n", v.Key, formatEnvValue(v.Value))
+	}
+	if _, err := file.WriteString(b.String()); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("writing %s: %w", target, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("closing %s: %w", target, err)
+	}
+	return nil
+}
+
+func formatEnvValue(value string) string {
+	if plainEnvValue.MatchString(value) {
+		return value
+	}
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "$", `\$`, "`", "\\`", "\n", `\n`)
+	return `"` + replacer.Replace(value) + `"`
+}
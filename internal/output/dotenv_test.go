@@ -0,0 +1,53 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/inferbump"
+)
+
+func TestWriteEnvFileOverwritesAndAppends(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "bump.env")
+	if err := os.WriteFile(path, []byte("STALE=1\n"), 0o600); err != nil {
+		t.Fatalf("seed file: %v", err)
+	}
+
+	result := inferbump.Result{Bump: bump.BumpMinor, PRID: 42, SemverLabels: []string{"semver-minor"}}
+	if err := WriteEnvFile(path, InferBumpEnv(result), false); err != nil {
+		t.Fatalf("write env file: %v", err)
+	}
+
+	want := "AAV_BUMP=minor\nAAV_PR_ID=42\nAAV_BUMP_DEFAULTED=false\nAAV_BUMP_DEFAULT_REASON=\nAAV_SEMVER_LABELS=semver-minor\n"
+	if got := readFile(t, path); got != want {
+		t.Fatalf("unexpected env file:\n%s", got)
+	}
+
+	if err := WriteEnvFile(path, []EnvVar{{Key: "EXTRA", Value: "has space"}}, true); err != nil {
+		t.Fatalf("append env file: %v", err)
+	}
+	if got := readFile(t, path); got != want+"EXTRA=\"has space\"\n" {
+		t.Fatalf("unexpected appended env file:\n%s", got)
+	}
+}
+
+func TestFormatEnvValueQuotesShellCharacters(t *testing.T) {
+	t.Parallel()
+
+	if got := formatEnvValue(`a "b" $c`); got != `"a \"b\" \$c"` {
+		t.Fatalf("unexpected quoting %s", got)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read file: %v", err)
+	}
+	return string(data)
+}
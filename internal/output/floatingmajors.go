@@ -0,0 +1,75 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+// FloatingMajorsResult is the JSON document printed by verify-floating. HighestMajor is nil
+// when the repository has no release.
+type FloatingMajorsResult struct {
+	Outcome      Outcome  `json:"outcome"`
+	HighestMajor *uint64  `json:"highestMajor"`
+	Tags         []string `json:"tags"`
+	Missing      []uint64 `json:"missing"`
+	Extra        []uint64 `json:"extra"`
+	Contiguous   bool     `json:"contiguous"`
+	Operator     string   `json:"operator,omitempty"`
+}
+
+// NewFloatingMajorsResult converts a floating majors report into its JSON representation,
+// listing tags in major order.
+func NewFloatingMajorsResult(majors tagplan.FloatingMajors) FloatingMajorsResult {
+	result := FloatingMajorsResult{
+		Tags:       make([]string, 0, len(majors.Majors)),
+		Missing:    append([]uint64{}, majors.Missing...),
+		Extra:      append([]uint64{}, majors.Extra...),
+		Contiguous: majors.Contiguous(),
+	}
+	if majors.Released {
+		highest := majors.HighestMajor
+		result.HighestMajor = &highest
+	}
+	for _, major := range majors.Majors {
+		result.Tags = append(result.Tags, majors.Floating[major])
+	}
+	result.Outcome = OutcomeFloatingContiguous
+	if !result.Contiguous {
+		result.Outcome = OutcomeFloatingViolations
+	}
+	return result
+}
+
+// WriteFloatingMajors prints one tab-separated line per major that has a floating tag or is
+// missing one: the major, its tag or "missing", and "ok", "missing", or "extra".
+func WriteFloatingMajors(w io.Writer, majors tagplan.FloatingMajors) error {
+	status := make(map[uint64]string)
+	for _, major := range majors.Majors {
+		status[major] = "ok"
+	}
+	for _, major := range majors.Extra {
+		status[major] = "extra"
+	}
+	for _, major := range majors.Missing {
+		status[major] = "missing"
+	}
+	ordered := make([]uint64, 0, len(status))
+	for major := range status {
+		ordered = append(ordered, major)
+	}
+	slices.Sort(ordered)
+
+	for _, major := range ordered {
+		tag, ok := majors.Floating[major]
+		if !ok {
+			tag = "missing"
+		}
+		if _, err := fmt.Fprintf(w, "%d\t%s\t%s\n", major, tag, status[major]); err != nil {
+			return fmt.Errorf("writing floating majors: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package output
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+func TestWriteFloatingMajors(t *testing.T) {
+	t.Parallel()
+
+	majors := tagplan.FloatingMajors{
+		HighestMajor: 4,
+		Released:     true,
+		Floating:     map[uint64]string{1: "v1", 2: "v2", 4: "v4"},
+		Majors:       []uint64{1, 2, 4},
+		Missing:      []uint64{3},
+		Extra:        []uint64{2},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteFloatingMajors(&buf, majors); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	expected := "1\tv1\tok\n2\tv2\textra\n3\tmissing\tmissing\n4\tv4\tok\n"
+	if buf.String() != expected {
+		t.Fatalf("expected %q, got %q", expected, buf.String())
+	}
+
+	result := NewFloatingMajorsResult(majors)
+	if result.Contiguous || result.HighestMajor == nil || *result.HighestMajor != 4 {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	if !reflect.DeepEqual(result.Tags, []string{"v1", "v2", "v4"}) || !reflect.DeepEqual(result.Missing, []uint64{3}) || !reflect.DeepEqual(result.Extra, []uint64{2}) {
+		t.Fatalf("unexpected tags, missing, or extra: %+v", result)
+	}
+	if NewFloatingMajorsResult(tagplan.FloatingMajors{}).HighestMajor != nil {
+		t.Fatal("expected no highest major without releases")
+	}
+}
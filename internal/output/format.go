@@ -0,0 +1,53 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Format selects how command results are written to stdout.
+type Format string
+
+const (
+	// FormatText prints the bare result value (tag name, bump) for shell capture.
+	FormatText Format = "text"
+	// FormatJSON prints a single JSON object describing the full result.
+	FormatJSON Format = "json"
+	// FormatCSV prints one comma-separated row per item with a header; batch commands only.
+	FormatCSV Format = "csv"
+	// FormatTSV is FormatCSV with tab separators.
+	FormatTSV Format = "tsv"
+)
+
+// ParseFormat converts a flag value into a Format. Empty values default to text.
+func ParseFormat(value string) (Format, error) {
+	switch Format(strings.ToLower(strings.TrimSpace(value))) {
+	case "", FormatText:
+		return FormatText, nil
+	case FormatJSON:
+		return FormatJSON, nil
+	case FormatCSV:
+		return FormatCSV, nil
+	case FormatTSV:
+		return FormatTSV, nil
+	default:
+		return "", fmt.Errorf("invalid output format %q", value)
+	}
+}
+
+// Tabular reports whether the format prints rows through WriteTable.
+func (f Format) Tabular() bool {
+	return f == FormatCSV || f == FormatTSV
+}
+
+// WriteJSON encodes value as a single indented JSON document followed by a newline.
+func WriteJSON(w io.Writer, value any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		return fmt.Errorf("encoding json result: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestParseFormat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input   string
+		want    Format
+		wantErr bool
+	}{
+		{input: "", want: FormatText},
+		{input: "text", want: FormatText},
+		{input: " JSON ", want: FormatJSON},
+		{input: "csv", want: FormatCSV},
+		{input: "TSV", want: FormatTSV},
+		{input: "yaml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseFormat(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error for %q", tt.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("want %s got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, map[string]string{"bump": "minor"}); err != nil {
+		t.Fatalf("write json: %v", err)
+	}
+	if got := buf.String(); got != "{\n  \"bump\": \"minor\"\n}\n" {
+		t.Fatalf("unexpected json %q", got)
+	}
+}
@@ -0,0 +1,76 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/labels"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/prlabel"
+)
+
+// AnnotationLevel is the severity of a GitHub Actions workflow annotation.
+type AnnotationLevel string
+
+const (
+	AnnotationWarning AnnotationLevel = "warning"
+	AnnotationError   AnnotationLevel = "error"
+)
+
+// Annotation is a ::warning:: or ::error:: workflow command.
+type Annotation struct {
+	Level   AnnotationLevel
+	Title   string
+	Message string
+}
+
+// InGitHubActions reports whether the process runs in a GitHub Actions job, which sets
+// GITHUB_ACTIONS=true for every step.
+func InGitHubActions(getenv func(string) string) bool {
+	return strings.EqualFold(strings.TrimSpace(getenv("GITHUB_ACTIONS")), "true")
+}
+
+// PRLabelAnnotations lists the workflow annotations for a pr-label result: a warning naming the
+// conflicting semver labels when the decision is a conflict, and nothing otherwise.
+func PRLabelAnnotations(prID int, result prlabel.Result) []Annotation {
+	if result.Decision != labels.DecisionConflict {
+		return nil
+	}
+	return []Annotation{{
+		Level: AnnotationWarning,
+		Title: "Conflicting semver labels",
+		Message: fmt.Sprintf("Pull request %d has conflicting semver labels %s (expected %s).",
+			prID, strings.Join(result.ExistingSemver, ", "), result.ExpectedLabel),
+	}}
+}
+
+// WriteAnnotations prints one workflow command per annotation.
+func WriteAnnotations(w io.Writer, annotations []Annotation) error {
+	var b strings.Builder
+	for _, a := range annotations {
+		b.WriteString("::")
+		b.WriteString(string(a.Level))
+		if a.Title != "" {
+			b.WriteString(" title=")
+			b.WriteString(escapeWorkflowProperty(a.Title))
+		}
+		b.WriteString("::")
+		b.WriteString(escapeWorkflowData(a.Message))
+		b.WriteString("\n")
+	}
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("writing annotations: %w", err)
+	}
+	return nil
+}
+
+// escapeWorkflowData applies the runner's escaping for command messages so a value cannot end
+// the line or start a second command.
+func escapeWorkflowData(value string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(value)
+}
+
+// escapeWorkflowProperty additionally escapes the separators used between command properties.
+func escapeWorkflowProperty(value string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(value)
+}
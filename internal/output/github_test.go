@@ -0,0 +1,78 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/labels"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/prlabel"
+)
+
+func TestInGitHubActions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		value    string
+		expected bool
+	}{
+		{name: "runner value", value: "true", expected: true},
+		{name: "unset", value: "", expected: false},
+		{name: "other", value: "1", expected: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			getenv := func(key string) string {
+				if key == "GITHUB_ACTIONS" {
+					return tc.value
+				}
+				return ""
+			}
+			if got := InGitHubActions(getenv); got != tc.expected {
+				t.Fatalf("expected %t, got %t", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestWritePRLabelConflictAnnotation(t *testing.T) {
+	t.Parallel()
+
+	result := prlabel.Result{
+		Decision:       labels.DecisionConflict,
+		ExpectedLabel:  "semver-minor",
+		ExistingSemver: []string{"semver-major", "semver-patch"},
+	}
+	var buf bytes.Buffer
+	if err := WriteAnnotations(&buf, PRLabelAnnotations(42, result)); err != nil {
+		t.Fatalf("write annotations: %v", err)
+	}
+	want := "::warning title=Conflicting semver labels::Pull request 42 has conflicting semver labels semver-major, semver-patch (expected semver-minor).\n"
+	if buf.String() != want {
+		t.Fatalf("unexpected annotation:\n%q\nwant\n%q", buf.String(), want)
+	}
+}
+
+func TestPRLabelAnnotationsIgnoreNonConflicts(t *testing.T) {
+	t.Parallel()
+
+	if got := PRLabelAnnotations(42, prlabel.Result{Decision: labels.DecisionAddExpected}); len(got) != 0 {
+		t.Fatalf("expected no annotations, got %+v", got)
+	}
+}
+
+func TestWriteAnnotationsEscapesCommandData(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	err := WriteAnnotations(&buf, []Annotation{{Level: AnnotationError, Title: "a:b,c", Message: "50%\n::error::x"}})
+	if err != nil {
+		t.Fatalf("write annotations: %v", err)
+	}
+	want := "::error title=a%3Ab%2Cc::50%25%0A::error::x\n"
+	if buf.String() != want {
+		t.Fatalf("unexpected escaping %q", buf.String())
+	}
+}
@@ -0,0 +1,109 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/inferbump"
+)
+
+// InferBatchEntry is one commit in the infer-bump-batch JSON document. Error is set, and the
+// bump fields are empty, when the commit could not be resolved; Outcome is then BUMP_ERROR.
+type InferBatchEntry struct {
+	Outcome       Outcome `json:"outcome"`
+	Commit        string  `json:"commit"`
+	PRID          int     `json:"prId,omitempty"`
+	Bump          string  `json:"bump,omitempty"`
+	Defaulted     bool    `json:"defaulted"`
+	DefaultReason string  `json:"defaultReason,omitempty"`
+	Source        string  `json:"source,omitempty"`
+	Error         string  `json:"error,omitempty"`
+}
+
+// InferBatchResult is the JSON document printed by infer-bump-batch; Bump is omitted when no
+// commit resolved.
+type InferBatchResult struct {
+	Outcome  Outcome           `json:"outcome"`
+	Bump     string            `json:"bump,omitempty"`
+	Resolved int               `json:"resolved"`
+	Failed   int               `json:"failed"`
+	Commits  []InferBatchEntry `json:"commits"`
+	Operator string            `json:"operator,omitempty"`
+}
+
+// NewInferBatchResult converts a batch resolution into its JSON representation.
+func NewInferBatchResult(batch inferbump.BatchResult) InferBatchResult {
+	result := InferBatchResult{
+		Outcome:  batchOutcome(batch),
+		Bump:     string(batch.Bump),
+		Resolved: batch.Resolved,
+		Failed:   batch.Failed,
+		Commits:  make([]InferBatchEntry, 0, len(batch.Entries)),
+	}
+	for _, entry := range batch.Entries {
+		item := InferBatchEntry{Commit: entry.CommitSHA}
+		if entry.Err != nil {
+			item.Outcome = OutcomeBumpError
+			item.Error = entry.Err.Error()
+		} else {
+			item.Outcome = bumpOutcome(entry.Result)
+			item.PRID = entry.Result.PRID
+			item.Bump = entry.Result.Bump.String()
+			item.Defaulted = entry.Result.Defaulted
+			item.DefaultReason = string(entry.Result.DefaultReason)
+			item.Source = string(entry.Result.Source)
+		}
+		result.Commits = append(result.Commits, item)
+	}
+	return result
+}
+
+// InferBatchTable flattens the batch into one row per commit; the aggregate bump is left to
+// the text and JSON formats.
+func InferBatchTable(result InferBatchResult) Table {
+	table := Table{Header: []string{"commit", "pr_id", "bump", "defaulted", "default_reason", "source", "error"}}
+	for _, entry := range result.Commits {
+		pr := ""
+		if entry.PRID > 0 {
+			pr = strconv.Itoa(entry.PRID)
+		}
+		table.Rows = append(table.Rows, []string{
+			entry.Commit, pr, entry.Bump, strconv.FormatBool(entry.Defaulted), entry.DefaultReason, entry.Source, entry.Error,
+		})
+	}
+	return table
+}
+
+// WriteInferBatch prints one tab-separated line per commit (commit, PR or "-", bump or
+// "error", and the default reason or error when present), then a "total" line with the
+// aggregate bump.
+func WriteInferBatch(w io.Writer, result InferBatchResult) error {
+	for _, entry := range result.Commits {
+		pr := "-"
+		if entry.PRID > 0 {
+			pr = strconv.Itoa(entry.PRID)
+		}
+		line := fmt.Sprintf("%s\t%s\t", entry.Commit, pr)
+		switch {
+		case entry.Error != "":
+			line += "error\t" + entry.Error
+		case entry.DefaultReason != "":
+			line += entry.Bump + "\t" + entry.DefaultReason
+		default:
+			line += entry.Bump
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return fmt.Errorf("writing batch report: %w", err)
+		}
+	}
+
+	total := result.Bump
+	if total == "" {
+		total = "none"
+	}
+	if _, err := fmt.Fprintf(w, "total\t%s\t%d resolved, %d failed\n", total, result.Resolved, result.Failed); err != nil {
+		return fmt.Errorf("writing batch report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package output
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/inferbump"
+)
+
+func TestWriteInferBatch(t *testing.T) {
+	t.Parallel()
+
+	batch := inferbump.BatchResult{
+		Entries: []inferbump.BatchEntry{
+			{CommitSHA: "c1", Result: inferbump.Result{PRID: 11, Bump: bump.BumpMinor, Source: inferbump.SourceLabels}},
+			{CommitSHA: "c2", Result: inferbump.Result{Bump: bump.BumpPatch, Defaulted: true, DefaultReason: inferbump.DefaultReasonNoPullRequest}},
+			{CommitSHA: "c3", Err: errors.New("listing pull request labels: boom")},
+		},
+		Bump:     bump.BumpMinor,
+		Resolved: 2,
+		Failed:   1,
+	}
+
+	result := NewInferBatchResult(batch)
+	if result.Commits[0].Source != "labels" || result.Commits[2].Bump != "" || result.Commits[2].Error == "" {
+		t.Fatalf("unexpected JSON entries %+v", result.Commits)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteInferBatch(&buf, result); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	expected := "c1\t11\tminor\n" +
+		"c2\t-\tpatch\tno-pull-request\n" +
+		"c3\t-\terror\tlisting pull request labels: boom\n" +
+		"total\tminor\t2 resolved, 1 failed\n"
+	if buf.String() != expected {
+		t.Fatalf("want %q\n got %q", expected, buf.String())
+	}
+}
+
+func TestWriteInferBatchNothingResolved(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := WriteInferBatch(&buf, NewInferBatchResult(inferbump.BatchResult{})); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if buf.String() != "total\tnone\t0 resolved, 0 failed\n" {
+		t.Fatalf("unexpected output %q", buf.String())
+	}
+}
+
+func TestInferBatchTable(t *testing.T) {
+	t.Parallel()
+
+	result := NewInferBatchResult(inferbump.BatchResult{
+		Entries: []inferbump.BatchEntry{
+			{CommitSHA: "c1", Result: inferbump.Result{PRID: 11, Bump: bump.BumpMinor, Source: inferbump.SourceLabels}},
+			{CommitSHA: "c2", Err: errors.New("listing pull request labels: boom, twice")},
+		},
+	})
+
+	tests := []struct {
+		format   Format
+		expected string
+	}{
+		{format: FormatCSV, expected: "commit,pr_id,bump,defaulted,default_reason,source,error\n" +
+			"c1,11,minor,false,,labels,\n" +
+			"c2,,,false,,,\"listing pull request labels: boom, twice\"\n"},
+		{format: FormatTSV, expected: "commit\tpr_id\tbump\tdefaulted\tdefault_reason\tsource\terror\n" +
+			"c1\t11\tminor\tfalse\t\tlabels\t\n" +
+			"c2\t\t\tfalse\t\t\tlisting pull request labels: boom, twice\n"},
+	}
+	for _, tc := range tests {
+		t.Run(string(tc.format), func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			if err := WriteTable(&buf, tc.format, InferBatchTable(result)); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+			if buf.String() != tc.expected {
+				t.Fatalf("want %q\n got %q", tc.expected, buf.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package output
+
+import (
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/branchmap"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/labels"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/inferbump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/prlabel"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/prpreview"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/tagging"
+)
+
+// Outcome is the machine-readable code in the "outcome" field of every JSON document, so
+// integrations can branch on a result without parsing messages. Codes are part of the public
+// output: existing values keep their meaning across versions and new outcomes get new codes.
+type Outcome string
+
+const (
+	// OutcomeLabelAdded means pr-label added the expected semver label.
+	OutcomeLabelAdded Outcome = "LABEL_ADDED"
+	// OutcomeLabelNoop means the expected label was already present.
+	OutcomeLabelNoop Outcome = "LABEL_NOOP"
+	// OutcomeLabelConflict means other semver labels were present and left as they are.
+	OutcomeLabelConflict Outcome = "LABEL_CONFLICT"
+	// OutcomeLabelSkipped means the branch matched no prefix and the unmatched policy skipped it.
+	OutcomeLabelSkipped Outcome = "LABEL_SKIPPED"
+
+	// OutcomeBumpInferred means the bump came from the pull request's labels, title, or commit.
+	OutcomeBumpInferred Outcome = "BUMP_INFERRED"
+	// OutcomeBumpDefaulted means nothing decided the bump and the default applied.
+	OutcomeBumpDefaulted Outcome = "BUMP_DEFAULTED"
+	// OutcomeBumpConflict means several semver labels were present and the conflict policy
+	// picked the bump.
+	OutcomeBumpConflict Outcome = "BUMP_CONFLICT"
+	// OutcomeBumpError marks an infer-bump-batch commit that could not be resolved.
+	OutcomeBumpError Outcome = "BUMP_ERROR"
+
+	// OutcomeBatchResolved means every infer-bump-batch commit resolved.
+	OutcomeBatchResolved Outcome = "BATCH_RESOLVED"
+	// OutcomeBatchPartial means some infer-bump-batch commits failed to resolve.
+	OutcomeBatchPartial Outcome = "BATCH_PARTIAL"
+	// OutcomeBatchUnresolved means no infer-bump-batch commit resolved.
+	OutcomeBatchUnresolved Outcome = "BATCH_UNRESOLVED"
+
+	// OutcomeTagCreated means create-tag or apply-plan wrote the release or RC tag.
+	OutcomeTagCreated Outcome = "TAG_CREATED"
+	// OutcomeTagPlanned means the tag was planned under --dry-run or --plan-only and not written.
+	OutcomeTagPlanned Outcome = "TAG_PLANNED"
+	// OutcomeTagExists means the planned tag already pointed at the commit and was not recreated.
+	OutcomeTagExists Outcome = "TAG_EXISTS"
+	// OutcomeTagAlreadyReleased means --once found a release tag on the commit.
+	OutcomeTagAlreadyReleased Outcome = "TAG_ALREADY_RELEASED"
+	// OutcomeTagNoBump means the bump was none, so no version was tagged.
+	OutcomeTagNoBump Outcome = "TAG_NO_BUMP"
+	// OutcomeTagSkipped means tagging was skipped for another reason, such as a missing commit
+	// or a pull request that only changes skipped paths; skipReason explains it when known.
+	OutcomeTagSkipped Outcome = "TAG_SKIPPED"
+
+	// OutcomeAliasCreated means create-tag --from-tag wrote the alias tag.
+	OutcomeAliasCreated Outcome = "ALIAS_CREATED"
+	// OutcomeAliasPlanned means the alias was planned under --dry-run and not written.
+	OutcomeAliasPlanned Outcome = "ALIAS_PLANNED"
+
+	// OutcomeTagDeleted means delete-tag removed the tag.
+	OutcomeTagDeleted Outcome = "TAG_DELETED"
+	// OutcomeTagDeletePlanned means the tag was found under --dry-run and not deleted.
+	OutcomeTagDeletePlanned Outcome = "TAG_DELETE_PLANNED"
+
+	// OutcomePreviewLabelPresent means the expected label is already on the pull request.
+	OutcomePreviewLabelPresent Outcome = "PREVIEW_LABEL_PRESENT"
+	// OutcomePreviewLabelMissing means pr-label would add the expected label.
+	OutcomePreviewLabelMissing Outcome = "PREVIEW_LABEL_MISSING"
+	// OutcomePreviewLabelConflict means pr-label would find conflicting semver labels.
+	OutcomePreviewLabelConflict Outcome = "PREVIEW_LABEL_CONFLICT"
+
+	// OutcomeCommentPosted means pr-preview posted its comment.
+	OutcomeCommentPosted Outcome = "COMMENT_POSTED"
+	// OutcomeCommentFailed means posting the pr-preview comment failed; postError explains it.
+	OutcomeCommentFailed Outcome = "COMMENT_FAILED"
+	// OutcomeCommentRendered means the pr-preview comment was rendered without posting.
+	OutcomeCommentRendered Outcome = "COMMENT_RENDERED"
+
+	// OutcomeBumpValid means validate-bump found the bump within tolerance of the branch.
+	OutcomeBumpValid Outcome = "BUMP_VALID"
+	// OutcomeBumpOutOfRange means the bump is further from the branch's bump than allowed.
+	OutcomeBumpOutOfRange Outcome = "BUMP_OUT_OF_RANGE"
+
+	// OutcomeStaleNone means list-stale-rc found no prerelease whose release exists.
+	OutcomeStaleNone Outcome = "STALE_RC_NONE"
+	// OutcomeStaleFound means stale prereleases were found and left in place (including a dry
+	// run of --delete-stale).
+	OutcomeStaleFound Outcome = "STALE_RC_FOUND"
+	// OutcomeStaleDeleted means stale prereleases were deleted.
+	OutcomeStaleDeleted Outcome = "STALE_RC_DELETED"
+
+	// OutcomeRCContinuous means verify-rc found no gaps.
+	OutcomeRCContinuous Outcome = "RC_SEQUENCE_CONTINUOUS"
+	// OutcomeRCGaps means verify-rc found missing RC numbers.
+	OutcomeRCGaps Outcome = "RC_SEQUENCE_GAPS"
+
+	// OutcomeFloatingContiguous means verify-floating found no missing or extra majors.
+	OutcomeFloatingContiguous Outcome = "FLOATING_CONTIGUOUS"
+	// OutcomeFloatingViolations means verify-floating found missing or extra majors.
+	OutcomeFloatingViolations Outcome = "FLOATING_VIOLATIONS"
+
+	// OutcomeTagsListed means list-tags printed the repository's tags.
+	OutcomeTagsListed Outcome = "TAGS_LISTED"
+
+	// OutcomeConfigOK means doctor found no setting given conflicting values.
+	OutcomeConfigOK Outcome = "CONFIG_OK"
+	// OutcomeConfigConflict means at least one doctor setting has conflicting values.
+	OutcomeConfigConflict Outcome = "CONFIG_CONFLICT"
+)
+
+func labelOutcome(result prlabel.Result) Outcome {
+	switch {
+	case result.SkipReason != "":
+		return OutcomeLabelSkipped
+	case result.LabelAdded:
+		return OutcomeLabelAdded
+	case result.Decision == labels.DecisionConflict:
+		return OutcomeLabelConflict
+	default:
+		return OutcomeLabelNoop
+	}
+}
+
+func bumpOutcome(result inferbump.Result) Outcome {
+	switch {
+	case result.Defaulted:
+		return OutcomeBumpDefaulted
+	case result.Conflict:
+		return OutcomeBumpConflict
+	default:
+		return OutcomeBumpInferred
+	}
+}
+
+func batchOutcome(batch inferbump.BatchResult) Outcome {
+	switch {
+	case batch.Resolved == 0:
+		return OutcomeBatchUnresolved
+	case batch.Failed > 0:
+		return OutcomeBatchPartial
+	default:
+		return OutcomeBatchResolved
+	}
+}
+
+func tagOutcome(plan tagplan.Result, dryRun bool) Outcome {
+	switch {
+	case plan.AlreadyReleased:
+		return OutcomeTagAlreadyReleased
+	case plan.Skipped && plan.SkipReason == tagplan.NoBumpReason:
+		return OutcomeTagNoBump
+	case plan.Skipped:
+		return OutcomeTagSkipped
+	case plan.AlreadyExists:
+		return OutcomeTagExists
+	case dryRun:
+		return OutcomeTagPlanned
+	default:
+		return OutcomeTagCreated
+	}
+}
+
+func previewOutcome(status prpreview.LabelStatus) Outcome {
+	switch status {
+	case prpreview.LabelPresent:
+		return OutcomePreviewLabelPresent
+	case prpreview.LabelConflict:
+		return OutcomePreviewLabelConflict
+	default:
+		return OutcomePreviewLabelMissing
+	}
+}
+
+func commentOutcome(preview prpreview.Result) Outcome {
+	switch {
+	case preview.PostError != "":
+		return OutcomeCommentFailed
+	case preview.Posted:
+		return OutcomeCommentPosted
+	default:
+		return OutcomeCommentRendered
+	}
+}
+
+func bumpCheckOutcome(check branchmap.BumpCheck) Outcome {
+	if check.Within() {
+		return OutcomeBumpValid
+	}
+	return OutcomeBumpOutOfRange
+}
+
+func staleOutcome(report tagging.StaleRCReport, staleCount int) Outcome {
+	switch {
+	case staleCount == 0:
+		return OutcomeStaleNone
+	case len(report.Deleted) > 0 && !report.DryRun:
+		return OutcomeStaleDeleted
+	default:
+		return OutcomeStaleFound
+	}
+}
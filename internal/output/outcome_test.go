@@ -0,0 +1,185 @@
+package output
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/config"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/branchmap"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/labels"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/inferbump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/prlabel"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/prpreview"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/tagging"
+)
+
+func TestPRLabelOutcome(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		result prlabel.Result
+		expect Outcome
+	}{
+		{name: "added", result: prlabel.Result{Decision: labels.DecisionAddExpected, LabelAdded: true}, expect: OutcomeLabelAdded},
+		{name: "already present", result: prlabel.Result{Decision: labels.DecisionNoop}, expect: OutcomeLabelNoop},
+		{name: "conflict", result: prlabel.Result{Decision: labels.DecisionConflict}, expect: OutcomeLabelConflict},
+		{name: "unmatched branch skipped", result: prlabel.Result{SkipReason: "no prefix"}, expect: OutcomeLabelSkipped},
+	}
+	for _, tc := range tests {
+		if got := NewPRLabelResult(1, "feature/x", tc.result).Outcome; got != tc.expect {
+			t.Errorf("%s: expected %s, got %s", tc.name, tc.expect, got)
+		}
+	}
+}
+
+func TestInferBumpOutcome(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		result inferbump.Result
+		expect Outcome
+	}{
+		{name: "labels", result: inferbump.Result{Bump: bump.BumpMinor, Source: inferbump.SourceLabels}, expect: OutcomeBumpInferred},
+		{name: "defaulted", result: inferbump.Result{Bump: bump.BumpPatch, Defaulted: true}, expect: OutcomeBumpDefaulted},
+		{name: "conflicting labels", result: inferbump.Result{Bump: bump.BumpMajor, Conflict: true}, expect: OutcomeBumpConflict},
+	}
+	for _, tc := range tests {
+		if got := NewInferBumpResult(tc.result).Outcome; got != tc.expect {
+			t.Errorf("%s: expected %s, got %s", tc.name, tc.expect, got)
+		}
+	}
+}
+
+func TestInferBatchOutcome(t *testing.T) {
+	t.Parallel()
+
+	resolved := inferbump.BatchEntry{CommitSHA: "a", Result: inferbump.Result{Bump: bump.BumpMinor}}
+	failed := inferbump.BatchEntry{CommitSHA: "b", Err: errors.New("boom")}
+
+	tests := []struct {
+		name   string
+		batch  inferbump.BatchResult
+		expect Outcome
+	}{
+		{name: "all resolved", batch: inferbump.BatchResult{Entries: []inferbump.BatchEntry{resolved}, Resolved: 1}, expect: OutcomeBatchResolved},
+		{name: "partial", batch: inferbump.BatchResult{Entries: []inferbump.BatchEntry{resolved, failed}, Resolved: 1, Failed: 1}, expect: OutcomeBatchPartial},
+		{name: "none resolved", batch: inferbump.BatchResult{Entries: []inferbump.BatchEntry{failed}, Failed: 1}, expect: OutcomeBatchUnresolved},
+	}
+	for _, tc := range tests {
+		if got := NewInferBatchResult(tc.batch).Outcome; got != tc.expect {
+			t.Errorf("%s: expected %s, got %s", tc.name, tc.expect, got)
+		}
+	}
+
+	result := NewInferBatchResult(inferbump.BatchResult{Entries: []inferbump.BatchEntry{resolved, failed}})
+	if result.Commits[0].Outcome != OutcomeBumpInferred || result.Commits[1].Outcome != OutcomeBumpError {
+		t.Fatalf("unexpected entry outcomes: %+v", result.Commits)
+	}
+}
+
+func TestCreateTagOutcome(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		plan   tagplan.Result
+		dryRun bool
+		expect Outcome
+	}{
+		{name: "created", plan: tagplan.Result{TagName: "v1.2.4"}, expect: OutcomeTagCreated},
+		{name: "dry run", plan: tagplan.Result{TagName: "v1.2.4"}, dryRun: true, expect: OutcomeTagPlanned},
+		{name: "already exists", plan: tagplan.Result{TagName: "v1.2.4", AlreadyExists: true}, expect: OutcomeTagExists},
+		{name: "already released", plan: tagplan.Result{TagName: "v1.2.3", Skipped: true, AlreadyReleased: true}, expect: OutcomeTagAlreadyReleased},
+		{name: "none bump", plan: tagplan.Result{Skipped: true, SkipReason: tagplan.NoBumpReason}, expect: OutcomeTagNoBump},
+		{name: "missing commit skipped", plan: tagplan.Result{Skipped: true}, expect: OutcomeTagSkipped},
+	}
+	for _, tc := range tests {
+		if got := NewCreateTagResult(tc.plan, "deadbeef", tc.dryRun).Outcome; got != tc.expect {
+			t.Errorf("%s: expected %s, got %s", tc.name, tc.expect, got)
+		}
+	}
+
+	if got := NewAliasTagResult(tagplan.AliasPlan{TagName: "stable"}, false).Outcome; got != OutcomeAliasCreated {
+		t.Errorf("alias: expected %s, got %s", OutcomeAliasCreated, got)
+	}
+	if got := NewAliasTagResult(tagplan.AliasPlan{TagName: "stable"}, true).Outcome; got != OutcomeAliasPlanned {
+		t.Errorf("alias dry run: expected %s, got %s", OutcomeAliasPlanned, got)
+	}
+
+	deleted := tagging.ExistingTag{Name: "refs/tags/v1.2.4"}
+	if got := NewDeleteTagResult(deleted, false).Outcome; got != OutcomeTagDeleted {
+		t.Errorf("delete: expected %s, got %s", OutcomeTagDeleted, got)
+	}
+	if got := NewDeleteTagResult(deleted, true).Outcome; got != OutcomeTagDeletePlanned {
+		t.Errorf("delete dry run: expected %s, got %s", OutcomeTagDeletePlanned, got)
+	}
+}
+
+func TestPreviewOutcomes(t *testing.T) {
+	t.Parallel()
+
+	checks := map[prpreview.LabelStatus]Outcome{
+		prpreview.LabelPresent:  OutcomePreviewLabelPresent,
+		prpreview.LabelMissing:  OutcomePreviewLabelMissing,
+		prpreview.LabelConflict: OutcomePreviewLabelConflict,
+	}
+	for status, expect := range checks {
+		if got := NewPreviewResult(prpreview.Check{LabelStatus: status}).Outcome; got != expect {
+			t.Errorf("label status %s: expected %s, got %s", status, expect, got)
+		}
+	}
+
+	comments := []struct {
+		name    string
+		preview prpreview.Result
+		expect  Outcome
+	}{
+		{name: "rendered", preview: prpreview.Result{Comment: "c"}, expect: OutcomeCommentRendered},
+		{name: "posted", preview: prpreview.Result{Comment: "c", Posted: true}, expect: OutcomeCommentPosted},
+		{name: "post failed", preview: prpreview.Result{Comment: "c", PostError: "denied"}, expect: OutcomeCommentFailed},
+	}
+	for _, tc := range comments {
+		if got := NewPRPreviewResult(tc.preview).Outcome; got != tc.expect {
+			t.Errorf("%s: expected %s, got %s", tc.name, tc.expect, got)
+		}
+	}
+}
+
+func TestCheckOutcomes(t *testing.T) {
+	t.Parallel()
+
+	resolver := branchmap.NewResolver(branchmap.Mapping{})
+	if got := NewBumpCheckResult(resolver.CheckBump("fix/typo", bump.BumpPatch, 0)).Outcome; got != OutcomeBumpValid {
+		t.Errorf("validate-bump: expected %s, got %s", OutcomeBumpValid, got)
+	}
+
+	stale := []struct {
+		name   string
+		report tagging.StaleRCReport
+		expect Outcome
+	}{
+		{name: "none", report: tagging.StaleRCReport{Prereleases: []tagplan.PrereleaseStatus{{TagName: "v1.3.0-rc.1"}}}, expect: OutcomeStaleNone},
+		{name: "found", report: tagging.StaleRCReport{Prereleases: []tagplan.PrereleaseStatus{{TagName: "v1.2.0-rc.1", ReleaseExists: true}}}, expect: OutcomeStaleFound},
+		{name: "dry run delete", report: tagging.StaleRCReport{Prereleases: []tagplan.PrereleaseStatus{{TagName: "v1.2.0-rc.1", ReleaseExists: true}}, Deleted: []string{"v1.2.0-rc.1"}, DryRun: true}, expect: OutcomeStaleFound},
+		{name: "deleted", report: tagging.StaleRCReport{Prereleases: []tagplan.PrereleaseStatus{{TagName: "v1.2.0-rc.1", ReleaseExists: true}}, Deleted: []string{"v1.2.0-rc.1"}}, expect: OutcomeStaleDeleted},
+	}
+	for _, tc := range stale {
+		if got := NewStaleRCResult(tc.report).Outcome; got != tc.expect {
+			t.Errorf("stale %s: expected %s, got %s", tc.name, tc.expect, got)
+		}
+	}
+
+	if got := NewRCSequenceResult(tagplan.RCSequence{Missing: []int{1}}).Outcome; got != OutcomeRCGaps {
+		t.Errorf("verify-rc: expected %s, got %s", OutcomeRCGaps, got)
+	}
+	if got := NewFloatingMajorsResult(tagplan.FloatingMajors{}).Outcome; got != OutcomeFloatingContiguous {
+		t.Errorf("verify-floating: expected %s, got %s", OutcomeFloatingContiguous, got)
+	}
+	if got := NewConfigReport([]config.Resolution{{Setting: "org", Conflict: true}}).Outcome; got != OutcomeConfigConflict {
+		t.Errorf("doctor: expected %s, got %s", OutcomeConfigConflict, got)
+	}
+}
@@ -0,0 +1,52 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/inferbump"
+)
+
+// InAzurePipelines reports whether the process runs in an Azure Pipelines job, which sets
+// TF_BUILD=True for every step.
+func InAzurePipelines(getenv func(string) string) bool {
+	return strings.EqualFold(strings.TrimSpace(getenv("TF_BUILD")), "true")
+}
+
+// InferBumpBuildTags lists the run tags infer-bump adds with --pipeline-annotate.
+func InferBumpBuildTags(result inferbump.Result) []string {
+	return []string{"bump:" + result.Bump.String()}
+}
+
+// CreateTagBuildTags lists the run tags create-tag adds with --pipeline-annotate: the created
+// tag and the floating tags that were maintained.
+func CreateTagBuildTags(result tagplan.Result) []string {
+	tags := []string{result.TagName}
+	if result.Floating.Enabled {
+		tags = append(tags, result.Floating.TagName)
+	}
+	if minor := result.Floating.Minor; minor != nil && minor.Enabled {
+		tags = append(tags, minor.TagName)
+	}
+	return tags
+}
+
+// WriteBuildTags prints one ##vso[build.addbuildtag] logging command per tag.
+func WriteBuildTags(w io.Writer, tags []string) error {
+	var b strings.Builder
+	for _, tag := range tags {
+		fmt.Fprintf(&b, "##vso[build.addbuildtag]%s\n", escapeLoggingData(tag))
+	}
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("writing build tags: %w", err)
+	}
+	return nil
+}
+
+// escapeLoggingData applies the agent's escaping for logging command data so a value cannot
+// end the line or smuggle in a second command.
+func escapeLoggingData(value string) string {
+	return strings.NewReplacer("%", "%AZP25", "\r", "%0D", "\n", "%0A").Replace(value)
+}
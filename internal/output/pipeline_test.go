@@ -0,0 +1,88 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	semver "github.com/blang/semver/v4"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/inferbump"
+)
+
+func TestInAzurePipelines(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		value    string
+		expected bool
+	}{
+		{name: "agent value", value: "True", expected: true},
+		{name: "lowercase", value: "true", expected: true},
+		{name: "unset", value: "", expected: false},
+		{name: "other", value: "1", expected: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			getenv := func(key string) string {
+				if key == "TF_BUILD" {
+					return tc.value
+				}
+				return ""
+			}
+			if got := InAzurePipelines(getenv); got != tc.expected {
+				t.Fatalf("expected %t, got %t", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestWriteBuildTags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		tags     []string
+		expected string
+	}{
+		{
+			name:     "infer-bump",
+			tags:     InferBumpBuildTags(inferbump.Result{Bump: bump.BumpMinor}),
+			expected: "##vso[build.addbuildtag]bump:minor\n",
+		},
+		{
+			name:     "create-tag",
+			tags:     CreateTagBuildTags(tagplan.Result{TagName: "v1.3.0", Version: semver.MustParse("1.3.0")}),
+			expected: "##vso[build.addbuildtag]v1.3.0\n",
+		},
+		{
+			name: "create-tag with floating tag",
+			tags: CreateTagBuildTags(tagplan.Result{
+				TagName:  "v1.3.0",
+				Floating: tagplan.FloatingPlan{TagName: "v1", Enabled: true},
+			}),
+			expected: "##vso[build.addbuildtag]v1.3.0\n##vso[build.addbuildtag]v1\n",
+		},
+		{
+			name:     "escapes line breaks",
+			tags:     []string{"v1\n##vso[task.complete result=Failed]100%"},
+			expected: "##vso[build.addbuildtag]v1%0A##vso[task.complete result=Failed]100%AZP25\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			if err := WriteBuildTags(&buf, tc.tags); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+			if buf.String() != tc.expected {
+				t.Fatalf("expected %q, got %q", tc.expected, buf.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/prpreview"
+)
+
+// PreviewResult is the JSON document printed by preview.
+type PreviewResult struct {
+	Outcome       Outcome  `json:"outcome"`
+	PRID          int      `json:"prId"`
+	Branch        string   `json:"branch"`
+	BranchBump    string   `json:"branchBump"`
+	BranchMatched bool     `json:"branchMatched"`
+	MatchedPrefix string   `json:"matchedPrefix,omitempty"`
+	ExpectedLabel string   `json:"expectedLabel"`
+	LabelStatus   string   `json:"labelStatus"`
+	SemverLabels  []string `json:"semverLabels,omitempty"`
+	Bump          string   `json:"bump"`
+	Source        string   `json:"source"`
+	TagName       string   `json:"tagName,omitempty"`
+	Version       string   `json:"version,omitempty"`
+	ReleaseBase   string   `json:"releaseBase,omitempty"`
+	Operator      string   `json:"operator,omitempty"`
+}
+
+// NewPreviewResult converts a preview check into its JSON representation. The version fields
+// are only set when the check planned a release.
+func NewPreviewResult(check prpreview.Check) PreviewResult {
+	result := PreviewResult{
+		Outcome:       previewOutcome(check.LabelStatus),
+		PRID:          check.PRID,
+		Branch:        check.Branch,
+		BranchBump:    check.BranchBump.String(),
+		BranchMatched: check.BranchMatched,
+		MatchedPrefix: check.MatchedPrefix,
+		ExpectedLabel: check.ExpectedLabel,
+		LabelStatus:   string(check.LabelStatus),
+		Bump:          check.Bump.String(),
+		Source:        string(check.Source),
+	}
+	if len(check.SemverLabels) > 0 {
+		result.SemverLabels = append([]string(nil), check.SemverLabels...)
+	}
+	if check.Plan != nil {
+		result.TagName = check.Plan.TagName
+		result.Version = check.Plan.Version.String()
+		result.ReleaseBase = check.Plan.ReleaseBase.String()
+	}
+	return result
+}
+
+// WritePreview prints one tab-separated key and value per line, ending with the planned tag
+// when a version was computed.
+func WritePreview(w io.Writer, result PreviewResult) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "branchBump\t%s\n", result.BranchBump)
+	fmt.Fprintf(&b, "expectedLabel\t%s\n", result.ExpectedLabel)
+	fmt.Fprintf(&b, "labelStatus\t%s\n", result.LabelStatus)
+	if len(result.SemverLabels) > 0 {
+		fmt.Fprintf(&b, "semverLabels\t%s\n", strings.Join(result.SemverLabels, ","))
+	}
+	fmt.Fprintf(&b, "bump\t%s\n", result.Bump)
+	if result.TagName != "" {
+		fmt.Fprintf(&b, "tag\t%s\n", result.TagName)
+	}
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("writing preview: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	semver "github.com/blang/semver/v4"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/prpreview"
+)
+
+func TestPreviewResult(t *testing.T) {
+	t.Parallel()
+
+	check := prpreview.Check{
+		PRID:          7,
+		Branch:        "fix/typo",
+		BranchBump:    bump.BumpPatch,
+		BranchMatched: true,
+		MatchedPrefix: "fix/",
+		ExpectedLabel: "semver-patch",
+		LabelStatus:   prpreview.LabelConflict,
+		SemverLabels:  []string{"semver-minor"},
+		Bump:          bump.BumpMinor,
+		Source:        prpreview.BumpSourceLabels,
+	}
+
+	result := NewPreviewResult(check)
+	if result.LabelStatus != "conflict" || result.Bump != "minor" || result.BranchBump != "patch" {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	if result.TagName != "" || result.Version != "" {
+		t.Fatalf("expected no version fields without a plan, got %+v", result)
+	}
+
+	var buf bytes.Buffer
+	if err := WritePreview(&buf, result); err != nil {
+		t.Fatalf("write preview: %v", err)
+	}
+	want := "branchBump\tpatch\nexpectedLabel\tsemver-patch\nlabelStatus\tconflict\nsemverLabels\tsemver-minor\nbump\tminor\n"
+	if buf.String() != want {
+		t.Fatalf("unexpected text output:\n%q", buf.String())
+	}
+
+	check.Plan = &tagplan.Result{TagName: "v1.3.0", Version: semver.MustParse("1.3.0"), ReleaseBase: semver.MustParse("1.2.5")}
+	result = NewPreviewResult(check)
+	if result.TagName != "v1.3.0" || result.Version != "1.3.0" || result.ReleaseBase != "1.2.5" {
+		t.Fatalf("expected version fields from the plan, got %+v", result)
+	}
+}
@@ -0,0 +1,56 @@
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/prpreview"
+)
+
+// PRPreviewResult is the JSON document printed by pr-preview.
+type PRPreviewResult struct {
+	Outcome       Outcome  `json:"outcome"`
+	PRID          int      `json:"prId"`
+	Bump          string   `json:"bump"`
+	Source        string   `json:"source"`
+	MatchedPrefix string   `json:"matchedPrefix,omitempty"`
+	SemverLabels  []string `json:"semverLabels,omitempty"`
+	TagName       string   `json:"tagName"`
+	Version       string   `json:"version"`
+	ReleaseBase   string   `json:"releaseBase"`
+	BaseSource    string   `json:"baseSource"`
+	Comment       string   `json:"comment"`
+	Posted        bool     `json:"posted"`
+	PostError     string   `json:"postError,omitempty"`
+	Operator      string   `json:"operator,omitempty"`
+}
+
+// NewPRPreviewResult converts a preview into its JSON representation.
+func NewPRPreviewResult(preview prpreview.Result) PRPreviewResult {
+	result := PRPreviewResult{
+		Outcome:       commentOutcome(preview),
+		PRID:          preview.PRID,
+		Bump:          preview.Bump.String(),
+		Source:        string(preview.Source),
+		MatchedPrefix: preview.MatchedPrefix,
+		TagName:       preview.Plan.TagName,
+		Version:       preview.Plan.Version.String(),
+		ReleaseBase:   preview.Plan.ReleaseBase.String(),
+		BaseSource:    string(preview.Plan.BaseSource),
+		Comment:       preview.Comment,
+		Posted:        preview.Posted,
+		PostError:     preview.PostError,
+	}
+	if len(preview.SemverLabels) > 0 {
+		result.SemverLabels = append([]string(nil), preview.SemverLabels...)
+	}
+	return result
+}
+
+// WritePRPreview prints the preview comment, the same text posted to the pull request.
+func WritePRPreview(w io.Writer, result PRPreviewResult) error {
+	if _, err := fmt.Fprintln(w, result.Comment); err != nil {
+		return fmt.Errorf("writing pr preview: %w", err)
+	}
+	return nil
+}
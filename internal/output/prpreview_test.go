@@ -0,0 +1,44 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	semver "github.com/blang/semver/v4"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/prpreview"
+)
+
+func TestPRPreviewResult(t *testing.T) {
+	t.Parallel()
+
+	preview := prpreview.Result{
+		PRID:         7,
+		Bump:         bump.BumpMinor,
+		Source:       prpreview.BumpSourceLabels,
+		SemverLabels: []string{"semver-minor"},
+		Plan: tagplan.Result{
+			TagName:     "v1.3.0",
+			Version:     semver.MustParse("1.3.0"),
+			ReleaseBase: semver.MustParse("1.2.5"),
+			BaseSource:  tagplan.BaseSourceExisting,
+		},
+		Comment:   "Merging this pull request will produce v1.3.0.",
+		PostError: "forbidden",
+	}
+
+	result := NewPRPreviewResult(preview)
+	if result.TagName != "v1.3.0" || result.Source != "labels" || result.ReleaseBase != "1.2.5" || result.Posted || result.PostError != "forbidden" {
+		t.Fatalf("unexpected result %+v", result)
+	}
+
+	var buf bytes.Buffer
+	if err := WritePRPreview(&buf, result); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if buf.String() != preview.Comment+"\n" {
+		t.Fatalf("unexpected text output %q", buf.String())
+	}
+}
@@ -0,0 +1,56 @@
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+// RCSequenceResult is the JSON document printed by verify-rc.
+type RCSequenceResult struct {
+	Outcome    Outcome  `json:"outcome"`
+	Target     string   `json:"target"`
+	Tags       []string `json:"tags"`
+	Missing    []int    `json:"missing"`
+	Continuous bool     `json:"continuous"`
+	Operator   string   `json:"operator,omitempty"`
+}
+
+// NewRCSequenceResult converts an RC sequence into its JSON representation, listing tags in
+// RC number order.
+func NewRCSequenceResult(sequence tagplan.RCSequence) RCSequenceResult {
+	result := RCSequenceResult{
+		Target:     sequence.Target.String(),
+		Tags:       make([]string, 0, len(sequence.Numbers)),
+		Missing:    append([]int{}, sequence.Missing...),
+		Continuous: sequence.Continuous(),
+	}
+	for _, number := range sequence.Numbers {
+		result.Tags = append(result.Tags, sequence.RCs[number])
+	}
+	result.Outcome = OutcomeRCContinuous
+	if !result.Continuous {
+		result.Outcome = OutcomeRCGaps
+	}
+	return result
+}
+
+// WriteRCSequence prints one tab-separated line per RC number from 1 to the highest: the
+// number and its tag, or "missing" for a gap.
+func WriteRCSequence(w io.Writer, sequence tagplan.RCSequence) error {
+	if len(sequence.Numbers) == 0 {
+		return nil
+	}
+	highest := sequence.Numbers[len(sequence.Numbers)-1]
+	for number := 1; number <= highest; number++ {
+		tag, ok := sequence.RCs[number]
+		if !ok {
+			tag = "missing"
+		}
+		if _, err := fmt.Fprintf(w, "%d\t%s\n", number, tag); err != nil {
+			return fmt.Errorf("writing rc sequence: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package output
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	semver "github.com/blang/semver/v4"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+func TestWriteRCSequence(t *testing.T) {
+	t.Parallel()
+
+	sequence := tagplan.RCSequence{
+		Target:  semver.MustParse("2.1.0"),
+		RCs:     map[int]string{2: "v2.1.0-rc.2", 4: "v2.1.0-rc.4"},
+		Numbers: []int{2, 4},
+		Missing: []int{1, 3},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteRCSequence(&buf, sequence); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	expected := "1\tmissing\n2\tv2.1.0-rc.2\n3\tmissing\n4\tv2.1.0-rc.4\n"
+	if buf.String() != expected {
+		t.Fatalf("expected %q, got %q", expected, buf.String())
+	}
+
+	result := NewRCSequenceResult(sequence)
+	if result.Continuous || result.Target != "2.1.0" {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	if !reflect.DeepEqual(result.Tags, []string{"v2.1.0-rc.2", "v2.1.0-rc.4"}) || !reflect.DeepEqual(result.Missing, []int{1, 3}) {
+		t.Fatalf("unexpected tags or missing: %+v", result)
+	}
+}
@@ -0,0 +1,42 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/releasenotes"
+)
+
+// shortSHALength is the commit abbreviation used in rendered release notes.
+const shortSHALength = 7
+
+// WriteReleaseNotes renders grouped commits as an editable Markdown release note headed by the tag name.
+func WriteReleaseNotes(w io.Writer, tagName string, notes releasenotes.Result) error {
+	lines := []string{"## " + tagName}
+	for _, group := range notes.Groups {
+		heading := "Other"
+		if group.Bump != "" {
+			heading = strings.ToUpper(group.Bump.String()[:1]) + group.Bump.String()[1:]
+		}
+		lines = append(lines, "", "### "+heading)
+		for _, entry := range group.Commits {
+			lines = append(lines, formatReleaseEntry(entry))
+		}
+	}
+
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return fmt.Errorf("writing release notes: %w", err)
+		}
+	}
+	return nil
+}
+
+func formatReleaseEntry(entry releasenotes.Entry) string {
+	commit := shortSHA(entry.Commit)
+	if entry.PRID > 0 {
+		return fmt.Sprintf("- %s (%s, PR %d)", entry.Subject, commit, entry.PRID)
+	}
+	return fmt.Sprintf("- %s (%s)", entry.Subject, commit)
+}
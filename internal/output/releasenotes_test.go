@@ -0,0 +1,30 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/releasenotes"
+)
+
+func TestWriteReleaseNotes(t *testing.T) {
+	t.Parallel()
+
+	notes := releasenotes.Result{
+		Groups: []releasenotes.Group{
+			{Bump: bump.BumpMinor, Commits: []releasenotes.Entry{{Commit: "0123456789abcdef", Subject: "Add api", PRID: 12}}},
+			{Commits: []releasenotes.Entry{{Commit: "abc", Subject: "direct push"}}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteReleaseNotes(&buf, "v1.3.0", notes); err != nil {
+		t.Fatalf("write release notes: %v", err)
+	}
+
+	want := "## v1.3.0\n\n### Minor\n- Add api (0123456, PR 12)\n\n### Other\n- direct push (abc)\n"
+	if buf.String() != want {
+		t.Fatalf("unexpected release notes:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
@@ -0,0 +1,73 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ResultFile mirrors a command's stdout so the same result can be saved to --result-file once
+// the command succeeds.
+type ResultFile struct {
+	path string
+	buf  bytes.Buffer
+}
+
+// NewResultFile returns a ResultFile that will be written to path.
+func NewResultFile(path string) (*ResultFile, error) {
+	target := strings.TrimSpace(path)
+	if target == "" {
+		return nil, fmt.Errorf("output: file path is empty")
+	}
+	return &ResultFile{path: target}, nil
+}
+
+// Tee returns a writer that writes to w and records the same bytes for Commit.
+func (f *ResultFile) Tee(w io.Writer) io.Writer {
+	return io.MultiWriter(w, &f.buf)
+}
+
+// Commit writes everything recorded so far to the result file.
+func (f *ResultFile) Commit() error {
+	return WriteFileAtomic(f.path, f.buf.Bytes())
+}
+
+// WriteFileAtomic writes data to a temporary file next to path and renames it into place, so
+// concurrent readers see either the previous file or the complete new one.
+func WriteFileAtomic(path string, data []byte) error {
+	target := strings.TrimSpace(path)
+	if target == "" {
+		return fmt.Errorf("output: file path is empty")
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(target), "."+filepath.Base(target)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("creating temp file for %s: %w", target, err)
+	}
+	tmpName := tmp.Name()
+	fail := func(step string, err error) error {
+		_ = tmp.Close()
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("%s %s: %w", step, target, err)
+	}
+	if err := tmp.Chmod(filePermissions); err != nil {
+		return fail("setting permissions on", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		return fail("writing", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		return fail("syncing", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("closing %s: %w", target, err)
+	}
+	if err := os.Rename(tmpName, target); err != nil {
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("renaming into %s: %w", target, err)
+	}
+	return nil
+}
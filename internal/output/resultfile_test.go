@@ -0,0 +1,92 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/inferbump"
+)
+
+func TestResultFileMatchesStdout(t *testing.T) {
+	t.Parallel()
+
+	result := inferbump.Result{Bump: bump.BumpMinor, PRID: 42, SemverLabels: []string{"semver-minor"}}
+	tests := []struct {
+		name  string
+		write func(*bytes.Buffer, *ResultFile) error
+	}{
+		{
+			name: "text",
+			write: func(stdout *bytes.Buffer, file *ResultFile) error {
+				_, err := fmt.Fprintln(file.Tee(stdout), result.Bump.String())
+				return err
+			},
+		},
+		{
+			name: "json",
+			write: func(stdout *bytes.Buffer, file *ResultFile) error {
+				return WriteJSON(file.Tee(stdout), NewInferBumpResult(result))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "result.out")
+			file, err := NewResultFile(path)
+			if err != nil {
+				t.Fatalf("new result file: %v", err)
+			}
+			var stdout bytes.Buffer
+			if err := tt.write(&stdout, file); err != nil {
+				t.Fatalf("write result: %v", err)
+			}
+			if err := file.Commit(); err != nil {
+				t.Fatalf("commit result file: %v", err)
+			}
+			if stdout.Len() == 0 {
+				t.Fatal("expected stdout output")
+			}
+			if got := readFile(t, path); got != stdout.String() {
+				t.Fatalf("result file does not match stdout:\nfile:   %q\nstdout: %q", got, stdout.String())
+			}
+		})
+	}
+}
+
+func TestWriteFileAtomicReplacesWithoutLeftovers(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "result.json")
+	if err := os.WriteFile(path, []byte("stale\n"), 0o600); err != nil {
+		t.Fatalf("seed file: %v", err)
+	}
+	if err := WriteFileAtomic(path, []byte("fresh\n")); err != nil {
+		t.Fatalf("write atomic: %v", err)
+	}
+	if got := readFile(t, path); got != "fresh\n" {
+		t.Fatalf("unexpected contents %q", got)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("read dir: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected only the result file, found %d entries", len(entries))
+	}
+}
+
+func TestNewResultFileRejectsEmptyPath(t *testing.T) {
+	t.Parallel()
+
+	if _, err := NewResultFile("  "); err == nil {
+		t.Fatal("expected error for empty path")
+	}
+}
@@ -0,0 +1,272 @@
+package output
+
+import (
+	"strings"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/labels"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/inferbump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/prlabel"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/releasenotes"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/tagging"
+)
+
+// CreateTagResult is the JSON document printed by create-tag.
+type CreateTagResult struct {
+	Outcome          Outcome              `json:"outcome"`
+	Mode             string               `json:"mode"`
+	TagName          string               `json:"tagName"`
+	Version          string               `json:"version"`
+	Commit           string               `json:"commit"`
+	ReleaseBase      string               `json:"releaseBase"`
+	BaseSource       string               `json:"baseSource"`
+	TargetRelease    string               `json:"targetRelease"`
+	RCNumber         int                  `json:"rcNumber,omitempty"`
+	RCCollisions     int                  `json:"rcCollisions,omitempty"`
+	DryRun           bool                 `json:"dryRun"`
+	Skipped          bool                 `json:"skipped,omitempty"`
+	AlreadyReleased  bool                 `json:"alreadyReleased,omitempty"`
+	AlreadyExists    bool                 `json:"alreadyExists,omitempty"`
+	SkipReason       string               `json:"skipReason,omitempty"`
+	CommitUnverified string               `json:"commitUnverified,omitempty"`
+	Floating         FloatingResult       `json:"floating"`
+	BranchCollisions []string             `json:"branchCollisions,omitempty"`
+	DuplicateTags    []string             `json:"duplicateTags,omitempty"`
+	LegacyTags       []string             `json:"legacyTags,omitempty"`
+	HigherPrerelease string               `json:"higherPrerelease,omitempty"`
+	PrereleaseLine   bool                 `json:"prereleaseLine,omitempty"`
+	IgnoredTags      []string             `json:"ignoredTags,omitempty"`
+	Diff             *tagging.Diff        `json:"diff,omitempty"`
+	Commits          *releasenotes.Result `json:"commits,omitempty"`
+	Operator         string               `json:"operator,omitempty"`
+}
+
+// FloatingResult describes the floating tag portion of a create-tag result.
+type FloatingResult struct {
+	TagName         string `json:"tagName,omitempty"`
+	Enabled         bool   `json:"enabled"`
+	AutoDetected    bool   `json:"autoDetected"`
+	DeletedExisting bool   `json:"deletedExisting"`
+	Moved           bool   `json:"moved"`
+	Created         bool   `json:"created"`
+	AlreadyExists   bool   `json:"alreadyExists,omitempty"`
+	// Action is "moved" for an atomic ref update, "recreated" for delete and recreate, and
+	// "created" for a new floating tag; empty when nothing was written.
+	Action           string `json:"action,omitempty"`
+	PreviousObjectID string `json:"previousObjectId,omitempty"`
+	PreviousCommit   string `json:"previousCommit,omitempty"`
+	TargetCommit     string `json:"targetCommit,omitempty"`
+	// Minor describes the v<major>.<minor> floating tag when it was maintained.
+	Minor *FloatingResult `json:"minor,omitempty"`
+}
+
+// NewCreateTagResult converts a tag plan into its JSON representation.
+func NewCreateTagResult(plan tagplan.Result, commit string, dryRun bool) CreateTagResult {
+	result := CreateTagResult{
+		Outcome:          tagOutcome(plan, dryRun),
+		Mode:             string(plan.Mode),
+		TagName:          plan.TagName,
+		Version:          plan.Version.String(),
+		Commit:           commit,
+		ReleaseBase:      plan.ReleaseBase.String(),
+		BaseSource:       string(plan.BaseSource),
+		TargetRelease:    plan.TargetRelease.String(),
+		DryRun:           dryRun,
+		Skipped:          plan.Skipped,
+		AlreadyReleased:  plan.AlreadyReleased,
+		AlreadyExists:    plan.AlreadyExists,
+		SkipReason:       plan.SkipReason,
+		CommitUnverified: plan.CommitUnverified,
+		HigherPrerelease: plan.HigherPrerelease,
+		PrereleaseLine:   plan.PrereleaseLineUsed,
+		IgnoredTags:      plan.IgnoredTags,
+	}
+	if len(plan.BranchCollisions) > 0 {
+		result.BranchCollisions = append([]string(nil), plan.BranchCollisions...)
+	}
+	if len(plan.DuplicateTags) > 0 {
+		result.DuplicateTags = append([]string(nil), plan.DuplicateTags...)
+	}
+	for _, tag := range plan.LegacyTags {
+		result.LegacyTags = append(result.LegacyTags, tag.Name)
+	}
+	if plan.Mode == tagplan.ModeRC {
+		result.RCNumber = plan.RCNumber
+		result.RCCollisions = plan.RCCollisions
+	}
+	if plan.FloatingEligible() && !plan.Skipped {
+		result.Floating = newFloatingResult(plan.Floating)
+		if minor := plan.Floating.Minor; minor != nil && minor.Enabled {
+			minorResult := newFloatingResult(*minor)
+			result.Floating.Minor = &minorResult
+		}
+	}
+	return result
+}
+
+func newFloatingResult(f tagplan.FloatingPlan) FloatingResult {
+	return FloatingResult{
+		TagName:          f.TagName,
+		Enabled:          f.Enabled,
+		AutoDetected:     f.AutoDetected,
+		DeletedExisting:  f.DeletedExisting,
+		Moved:            f.Moved,
+		Created:          f.Created,
+		AlreadyExists:    f.AlreadyExists,
+		Action:           floatingAction(f),
+		PreviousObjectID: f.PreviousObjectID,
+		PreviousCommit:   f.PreviousCommit,
+		TargetCommit:     f.TargetCommit,
+	}
+}
+
+func floatingAction(f tagplan.FloatingPlan) string {
+	switch {
+	case f.Moved:
+		return "moved"
+	case f.DeletedExisting && f.Created:
+		return "recreated"
+	case f.Created:
+		return "created"
+	default:
+		return ""
+	}
+}
+
+// AliasTagResult is the JSON document printed by create-tag --from-tag.
+type AliasTagResult struct {
+	Outcome   Outcome `json:"outcome"`
+	TagName   string  `json:"tagName"`
+	SourceTag string  `json:"sourceTag"`
+	Version   string  `json:"version"`
+	Commit    string  `json:"commit"`
+	DryRun    bool    `json:"dryRun"`
+	Operator  string  `json:"operator,omitempty"`
+}
+
+// NewAliasTagResult converts an alias plan into its JSON representation.
+func NewAliasTagResult(plan tagplan.AliasPlan, dryRun bool) AliasTagResult {
+	outcome := OutcomeAliasCreated
+	if dryRun {
+		outcome = OutcomeAliasPlanned
+	}
+	return AliasTagResult{
+		Outcome:   outcome,
+		TagName:   plan.TagName,
+		SourceTag: strings.TrimPrefix(plan.Source.Name, "refs/tags/"),
+		Version:   plan.Version.String(),
+		Commit:    plan.Commit,
+		DryRun:    dryRun,
+	}
+}
+
+// DeleteTagResult is the JSON document printed by delete-tag.
+type DeleteTagResult struct {
+	Outcome  Outcome `json:"outcome"`
+	TagName  string  `json:"tagName"`
+	ObjectID string  `json:"objectId"`
+	Commit   string  `json:"commit"`
+	DryRun   bool    `json:"dryRun"`
+	Operator string  `json:"operator,omitempty"`
+}
+
+// NewDeleteTagResult converts a deleted tag into its JSON representation.
+func NewDeleteTagResult(tag tagging.ExistingTag, dryRun bool) DeleteTagResult {
+	outcome := OutcomeTagDeleted
+	if dryRun {
+		outcome = OutcomeTagDeletePlanned
+	}
+	return DeleteTagResult{
+		Outcome:  outcome,
+		TagName:  tag.ShortName(),
+		ObjectID: tag.ObjectID,
+		Commit:   tag.Commit,
+		DryRun:   dryRun,
+	}
+}
+
+// InferBumpResult is the JSON document printed by infer-bump.
+type InferBumpResult struct {
+	Outcome        Outcome  `json:"outcome"`
+	Bump           string   `json:"bump"`
+	Commit         string   `json:"commit"`
+	PRID           int      `json:"prId,omitempty"`
+	SemverLabels   []string `json:"semverLabels"`
+	Defaulted      bool     `json:"defaulted"`
+	DefaultReason  string   `json:"defaultReason,omitempty"`
+	Conflict       bool     `json:"conflict"`
+	ConflictPolicy string   `json:"conflictPolicy,omitempty"`
+	Title          string   `json:"title,omitempty"`
+	Source         string   `json:"source,omitempty"`
+	PRIDs          []int    `json:"prIds,omitempty"`
+	Operator       string   `json:"operator,omitempty"`
+}
+
+// NewInferBumpResult converts an inference result into its JSON representation.
+func NewInferBumpResult(result inferbump.Result) InferBumpResult {
+	return InferBumpResult{
+		Outcome:        bumpOutcome(result),
+		Bump:           result.Bump.String(),
+		Commit:         result.CommitSHA,
+		PRID:           result.PRID,
+		SemverLabels:   nonNilStrings(result.SemverLabels),
+		Defaulted:      result.Defaulted,
+		DefaultReason:  string(result.DefaultReason),
+		Conflict:       result.Conflict,
+		ConflictPolicy: string(result.ConflictPolicy),
+		Title:          result.Title,
+		Source:         string(result.Source),
+		PRIDs:          result.PRIDs,
+	}
+}
+
+// PRLabelResult is the JSON document printed by pr-label.
+type PRLabelResult struct {
+	Outcome        Outcome  `json:"outcome"`
+	PRID           int      `json:"prId"`
+	Branch         string   `json:"branch"`
+	Bump           string   `json:"bump"`
+	BranchMatched  bool     `json:"branchMatched"`
+	MatchedPrefix  string   `json:"matchedPrefix,omitempty"`
+	Decision       string   `json:"decision"`
+	ExpectedLabel  string   `json:"expectedLabel"`
+	ExistingSemver []string `json:"existingSemver"`
+	LabelAdded     bool     `json:"labelAdded"`
+	SkipReason     string   `json:"skipReason,omitempty"`
+	Operator       string   `json:"operator,omitempty"`
+}
+
+// NewPRLabelResult converts a labeling result into its JSON representation.
+func NewPRLabelResult(prID int, branch string, result prlabel.Result) PRLabelResult {
+	return PRLabelResult{
+		Outcome:        labelOutcome(result),
+		PRID:           prID,
+		Branch:         branch,
+		Bump:           result.Bump.String(),
+		BranchMatched:  result.BranchMatched,
+		MatchedPrefix:  result.MatchedPrefix,
+		Decision:       decisionName(result.Decision),
+		ExpectedLabel:  result.ExpectedLabel,
+		ExistingSemver: nonNilStrings(result.ExistingSemver),
+		LabelAdded:     result.LabelAdded,
+		SkipReason:     result.SkipReason,
+	}
+}
+
+func decisionName(decision labels.Decision) string {
+	switch decision {
+	case labels.DecisionAddExpected:
+		return "add-expected"
+	case labels.DecisionConflict:
+		return "conflict"
+	default:
+		return "noop"
+	}
+}
+
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
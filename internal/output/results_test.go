@@ -0,0 +1,98 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	semver "github.com/blang/semver/v4"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+func TestCreateTagResultRecordsFloatingMove(t *testing.T) {
+	t.Parallel()
+
+	base := tagplan.FloatingPlan{
+		TagName:          "v1",
+		Enabled:          true,
+		Existing:         tagplan.Tag{Name: "refs/tags/v1"},
+		PreviousObjectID: "old-tag-object",
+		PreviousCommit:   "oldcommit",
+		TargetCommit:     "newcommit",
+		Created:          true,
+	}
+
+	tests := []struct {
+		name     string
+		moved    bool
+		deleted  bool
+		expected string
+	}{
+		{name: "atomic move", moved: true, expected: "moved"},
+		{name: "delete and recreate", deleted: true, expected: "recreated"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			floating := base
+			floating.Moved = tc.moved
+			floating.DeletedExisting = tc.deleted
+			plan := tagplan.Result{
+				Mode:     tagplan.ModeRelease,
+				TagName:  "v1.2.4",
+				Version:  semver.MustParse("1.2.4"),
+				Floating: floating,
+			}
+
+			var buf bytes.Buffer
+			if err := WriteJSON(&buf, NewCreateTagResult(plan, "newcommit", false)); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+			var decoded struct {
+				Floating map[string]any `json:"floating"`
+			}
+			if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			want := map[string]string{
+				"action":           tc.expected,
+				"previousObjectId": "old-tag-object",
+				"previousCommit":   "oldcommit",
+				"targetCommit":     "newcommit",
+			}
+			for key, value := range want {
+				if decoded.Floating[key] != value {
+					t.Fatalf("%s: want %q got %v", key, value, decoded.Floating[key])
+				}
+			}
+		})
+	}
+}
+
+func TestResultsCarryOperator(t *testing.T) {
+	t.Parallel()
+
+	tagResult := NewCreateTagResult(tagplan.Result{Mode: tagplan.ModeRelease, TagName: "v1.2.4"}, "abc", false)
+	tagResult.Operator = "octocat"
+	labelResult := PRLabelResult{PRID: 7}
+
+	for name, value := range map[string]any{"with operator": tagResult, "without operator": labelResult} {
+		var buf bytes.Buffer
+		if err := WriteJSON(&buf, value); err != nil {
+			t.Fatalf("%s: write: %v", name, err)
+		}
+		var decoded map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+			t.Fatalf("%s: decode: %v", name, err)
+		}
+		operator, ok := decoded["operator"]
+		if name == "with operator" && operator != "octocat" {
+			t.Fatalf("%s: expected operator octocat, got %v", name, decoded)
+		}
+		if name == "without operator" && ok {
+			t.Fatalf("%s: expected operator omitted, got %v", name, operator)
+		}
+	}
+}
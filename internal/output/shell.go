@@ -0,0 +1,46 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+// CreateTagEnv lists the variables create-tag prints with --shell-out. AAV_FLOATING_TAG is
+// empty when no floating tag was maintained.
+func CreateTagEnv(result tagplan.Result) []EnvVar {
+	floating := ""
+	if result.Floating.Enabled {
+		floating = result.Floating.TagName
+	}
+	return []EnvVar{
+		{Key: "AAV_TAG_NAME", Value: result.TagName},
+		{Key: "AAV_VERSION", Value: result.Version.String()},
+		{Key: "AAV_MAJOR", Value: strconv.FormatUint(result.Version.Major, 10)},
+		{Key: "AAV_MINOR", Value: strconv.FormatUint(result.Version.Minor, 10)},
+		{Key: "AAV_PATCH", Value: strconv.FormatUint(result.Version.Patch, 10)},
+		{Key: "AAV_IS_PRERELEASE", Value: strconv.FormatBool(len(result.Version.Pre) > 0)},
+		{Key: "AAV_FLOATING_TAG", Value: floating},
+	}
+}
+
+// WriteShellAssignments prints vars as single-quoted KEY='value' lines for eval "$(aav ...)".
+func WriteShellAssignments(w io.Writer, vars []EnvVar) error {
+	var b strings.Builder
+	for _, v := range vars {
+		fmt.Fprintf(&b, "%s=%s\n", v.Key, shellQuote(v.Value))
+	}
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("writing shell assignments: %w", err)
+	}
+	return nil
+}
+
+// shellQuote wraps value in single quotes, closing and escaping any embedded quote, so the
+// shell performs no expansion on it.
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
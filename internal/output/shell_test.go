@@ -0,0 +1,93 @@
+package output
+
+import (
+	"bytes"
+	"os/exec"
+	"strings"
+	"testing"
+
+	semver "github.com/blang/semver/v4"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+func TestShellQuote(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{name: "plain", value: "v1.2.4", expected: `'v1.2.4'`},
+		{name: "empty", value: "", expected: `''`},
+		{name: "spaces and expansions", value: "a b $HOME `id`", expected: "'a b $HOME `id`'"},
+		{name: "single quote", value: "it's", expected: `'it'\''s'`},
+		{name: "newline", value: "line1\nline2", expected: "'line1\nline2'"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := shellQuote(tc.value); got != tc.expected {
+				t.Fatalf("expected %s, got %s", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestWriteShellAssignmentsEvaluatesToOriginalValues(t *testing.T) {
+	t.Parallel()
+
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("sh not available")
+	}
+
+	values := []string{"it's", `back\slash "q"`, "$(echo nope) ${X}", "tab\there", "semi;colon&amp"}
+	vars := make([]EnvVar, 0, len(values))
+	for i, value := range values {
+		vars = append(vars, EnvVar{Key: "AAV_TEST_" + string(rune('A'+i)), Value: value})
+	}
+
+	var script bytes.Buffer
+	if err := WriteShellAssignments(&script, vars); err != nil {
+		t.Fatalf("write assignments: %v", err)
+	}
+	for _, v := range vars {
+		script.WriteString(`printf '%s\0' "$` + v.Key + `"` + "\n")
+	}
+
+	out, err := exec.Command(sh, "-c", script.String()).Output()
+	if err != nil {
+		t.Fatalf("eval assignments: %v", err)
+	}
+	got := strings.Split(strings.TrimSuffix(string(out), "\x00"), "\x00")
+	if len(got) != len(values) {
+		t.Fatalf("expected %d values, got %q", len(values), got)
+	}
+	for i, value := range values {
+		if got[i] != value {
+			t.Fatalf("value %d: expected %q, got %q", i, value, got[i])
+		}
+	}
+}
+
+func TestCreateTagEnv(t *testing.T) {
+	t.Parallel()
+
+	result := tagplan.Result{
+		TagName:  "v2.0.0-rc.1",
+		Version:  semver.MustParse("2.0.0-rc.1"),
+		Floating: tagplan.FloatingPlan{TagName: "v2"},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteShellAssignments(&buf, CreateTagEnv(result)); err != nil {
+		t.Fatalf("write assignments: %v", err)
+	}
+
+	want := "AAV_TAG_NAME='v2.0.0-rc.1'\nAAV_VERSION='2.0.0-rc.1'\nAAV_MAJOR='2'\nAAV_MINOR='0'\nAAV_PATCH='0'\nAAV_IS_PRERELEASE='true'\nAAV_FLOATING_TAG=''\n"
+	if buf.String() != want {
+		t.Fatalf("unexpected assignments:\n%s", buf.String())
+	}
+}
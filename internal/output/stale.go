@@ -0,0 +1,99 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/tagging"
+)
+
+// StaleRCEntry is one prerelease tag in the list-stale-rc JSON document.
+type StaleRCEntry struct {
+	Tag           string `json:"tag"`
+	Target        string `json:"target"`
+	ReleaseExists bool   `json:"releaseExists"`
+	Deleted       bool   `json:"deleted"`
+	// Age is how old the tag was when listed, or "unknown" for lightweight tags, which carry no
+	// creation date; Created is omitted for them.
+	Age     string     `json:"age"`
+	Created *time.Time `json:"created,omitempty"`
+}
+
+// ageUnknown is printed for prereleases without a creation date.
+const ageUnknown = "unknown"
+
+// StaleRCResult is the JSON document printed by list-stale-rc.
+type StaleRCResult struct {
+	Outcome     Outcome        `json:"outcome"`
+	Prereleases []StaleRCEntry `json:"prereleases"`
+	StaleCount  int            `json:"staleCount"`
+	DryRun      bool           `json:"dryRun"`
+	Operator    string         `json:"operator,omitempty"`
+}
+
+// NewStaleRCResult converts a stale RC report into its JSON representation. Under a dry run,
+// Deleted marks the tags that would have been removed.
+func NewStaleRCResult(report tagging.StaleRCReport) StaleRCResult {
+	deleted := make(map[string]bool, len(report.Deleted))
+	for _, name := range report.Deleted {
+		deleted[name] = true
+	}
+
+	result := StaleRCResult{Prereleases: make([]StaleRCEntry, 0, len(report.Prereleases)), DryRun: report.DryRun}
+	for _, status := range report.Prereleases {
+		if status.ReleaseExists {
+			result.StaleCount++
+		}
+		entry := StaleRCEntry{
+			Tag:           status.TagName,
+			Target:        status.Target.String(),
+			ReleaseExists: status.ReleaseExists,
+			Deleted:       deleted[status.TagName],
+			Age:           ageUnknown,
+		}
+		if age, ok := report.Age(status.TagName); ok {
+			created := report.Created[status.TagName].UTC()
+			entry.Age, entry.Created = age.Truncate(time.Second).String(), &created
+		}
+		result.Prereleases = append(result.Prereleases, entry)
+	}
+	result.Outcome = staleOutcome(report, result.StaleCount)
+	return result
+}
+
+// StaleRCTable flattens the report into one row per prerelease. Under a dry run, deleted marks
+// the tags that would have been removed.
+func StaleRCTable(result StaleRCResult) Table {
+	table := Table{Header: []string{"tag", "target", "release_exists", "age", "deleted", "dry_run"}}
+	for _, entry := range result.Prereleases {
+		table.Rows = append(table.Rows, []string{
+			entry.Tag, entry.Target, strconv.FormatBool(entry.ReleaseExists), entry.Age, strconv.FormatBool(entry.Deleted), strconv.FormatBool(result.DryRun),
+		})
+	}
+	return table
+}
+
+// WriteStaleRCs prints one tab-separated line per prerelease: tag, target release, "stale" or
+// "active", and age ("unknown" without a date), followed by "deleted" (or "would-delete" on a
+// dry run) when removed.
+func WriteStaleRCs(w io.Writer, result StaleRCResult) error {
+	for _, entry := range result.Prereleases {
+		state := "active"
+		if entry.ReleaseExists {
+			state = "stale"
+		}
+		line := fmt.Sprintf("%s\t%s\t%s\t%s", entry.Tag, entry.Target, state, entry.Age)
+		switch {
+		case entry.Deleted && result.DryRun:
+			line += "\twould-delete"
+		case entry.Deleted:
+			line += "\tdeleted"
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return fmt.Errorf("writing stale rc list: %w", err)
+		}
+	}
+	return nil
+}
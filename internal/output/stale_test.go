@@ -0,0 +1,99 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	semver "github.com/blang/semver/v4"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/tagging"
+)
+
+func TestWriteStaleRCs(t *testing.T) {
+	t.Parallel()
+
+	listedAt := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	report := tagging.StaleRCReport{
+		Prereleases: []tagplan.PrereleaseStatus{
+			{TagName: "v1.2.0-rc.1", Target: semver.MustParse("1.2.0"), ReleaseExists: true},
+			{TagName: "v1.3.0-rc.1", Target: semver.MustParse("1.3.0")},
+		},
+		Deleted:  []string{"v1.2.0-rc.1"},
+		Created:  map[string]time.Time{"v1.2.0-rc.1": listedAt.Add(-72 * time.Hour)},
+		ListedAt: listedAt,
+	}
+
+	tests := []struct {
+		name     string
+		dryRun   bool
+		expected string
+	}{
+		{name: "deleted", expected: "v1.2.0-rc.1\t1.2.0\tstale\t72h0m0s\tdeleted\nv1.3.0-rc.1\t1.3.0\tactive\tunknown\n"},
+		{name: "dry run", dryRun: true, expected: "v1.2.0-rc.1\t1.2.0\tstale\t72h0m0s\twould-delete\nv1.3.0-rc.1\t1.3.0\tactive\tunknown\n"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			input := report
+			input.DryRun = tc.dryRun
+			result := NewStaleRCResult(input)
+			if result.StaleCount != 1 {
+				t.Fatalf("expected one stale prerelease, got %d", result.StaleCount)
+			}
+
+			var buf bytes.Buffer
+			if err := WriteStaleRCs(&buf, result); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+			if buf.String() != tc.expected {
+				t.Fatalf("unexpected output:\n%q\nwant\n%q", buf.String(), tc.expected)
+			}
+		})
+	}
+}
+
+func TestStaleRCTable(t *testing.T) {
+	t.Parallel()
+
+	result := NewStaleRCResult(tagging.StaleRCReport{
+		Prereleases: []tagplan.PrereleaseStatus{
+			{TagName: "v1.2.0-rc.1", Target: semver.MustParse("1.2.0"), ReleaseExists: true},
+		},
+		Deleted: []string{"v1.2.0-rc.1"},
+		DryRun:  true,
+	})
+
+	var buf bytes.Buffer
+	if err := WriteTable(&buf, FormatCSV, StaleRCTable(result)); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	expected := "tag,target,release_exists,age,deleted,dry_run\nv1.2.0-rc.1,1.2.0,true,unknown,true,true\n"
+	if buf.String() != expected {
+		t.Fatalf("unexpected output:\n%q\nwant\n%q", buf.String(), expected)
+	}
+}
+
+func TestStaleRCResultAge(t *testing.T) {
+	t.Parallel()
+
+	listedAt := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+	result := NewStaleRCResult(tagging.StaleRCReport{
+		Prereleases: []tagplan.PrereleaseStatus{
+			{TagName: "v1.2.0-rc.1", Target: semver.MustParse("1.2.0")},
+			{TagName: "v1.2.0-rc.2", Target: semver.MustParse("1.2.0")},
+		},
+		Created:  map[string]time.Time{"v1.2.0-rc.1": listedAt.Add(-90 * time.Minute)},
+		ListedAt: listedAt,
+	})
+
+	annotated, lightweight := result.Prereleases[0], result.Prereleases[1]
+	if annotated.Age != "1h30m0s" || annotated.Created == nil || !annotated.Created.Equal(listedAt.Add(-90*time.Minute)) {
+		t.Fatalf("unexpected annotated entry: %+v", annotated)
+	}
+	if lightweight.Age != "unknown" || lightweight.Created != nil {
+		t.Fatalf("expected an unknown age for the lightweight entry, got %+v", lightweight)
+	}
+}
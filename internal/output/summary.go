@@ -0,0 +1,180 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/labels"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/inferbump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/prlabel"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/tagging"
+)
+
+// CreateTagSummary renders a one-line account of a create-tag run, e.g.
+// "Created release tag v1.2.4 at deadbee (minor bump from v1.2.3); updated floating tag v1."
+func CreateTagSummary(result tagplan.Result, intent bump.Bump, commit string, dryRun bool) string {
+	kind := "release tag"
+	switch {
+	case result.Mode == tagplan.ModeRC:
+		kind = "RC tag"
+	case result.BaseSource == tagplan.BaseSourceHotfix:
+		kind = "hotfix release tag"
+		intent = bump.BumpPatch
+	}
+	if result.Skipped && result.SkipReason != "" {
+		return fmt.Sprintf("Skipped %s %s: %s.", kind, result.TagName, result.SkipReason)
+	}
+	if result.Skipped {
+		return fmt.Sprintf("Skipped %s %s: commit %s not found.", kind, result.TagName, shortSHA(commit))
+	}
+	verb := "Created"
+	if dryRun {
+		verb = "Would create"
+	}
+
+	line := fmt.Sprintf("%s %s %s at %s (%s bump from %s)", verb, kind, result.TagName, shortSHA(commit), intent, baseDescription(result))
+	if result.PrereleaseLineUsed {
+		line = fmt.Sprintf("%s %s %s at %s (finalizes %s)", verb, kind, result.TagName, shortSHA(commit), result.HigherPrerelease)
+	}
+
+	f := result.Floating
+	if result.FloatingEligible() && f.Enabled && !f.Superseded {
+		action := "created"
+		if f.DeletedExisting || f.Moved || f.Existing.Name != "" {
+			action = "updated"
+		}
+		if dryRun {
+			action = "would " + strings.TrimSuffix(action, "d")
+		}
+		line += fmt.Sprintf("; %s floating tag %s", action, f.TagName)
+	}
+	return line + "."
+}
+
+// WithRepoName prefixes a summary line with the repository's display name, e.g.
+// "payments-api: Created release tag v1.2.4 ...". An empty name returns the line unchanged.
+func WithRepoName(line, name string) string {
+	if name == "" {
+		return line
+	}
+	return name + ": " + line
+}
+
+// AliasTagSummary renders a one-line account of a create-tag --from-tag run, e.g.
+// "Created alias tag stable for v1.2.4 at deadbee."
+func AliasTagSummary(plan tagplan.AliasPlan, dryRun bool) string {
+	verb := "Created"
+	if dryRun {
+		verb = "Would create"
+	}
+	source := strings.TrimPrefix(plan.Source.Name, "refs/tags/")
+	return fmt.Sprintf("%s alias tag %s for %s at %s.", verb, plan.TagName, source, shortSHA(plan.Commit))
+}
+
+// DeleteTagSummary renders a one-line account of a delete-tag run, e.g.
+// "Deleted tag v1.2.4 at deadbee."
+func DeleteTagSummary(tag tagging.ExistingTag, dryRun bool) string {
+	verb := "Deleted"
+	if dryRun {
+		verb = "Would delete"
+	}
+	return fmt.Sprintf("%s tag %s at %s.", verb, tag.ShortName(), shortSHA(tag.Commit))
+}
+
+// LegacyTagNotice explains a 0.0.0 fallback caused by tags in a legacy scheme, e.g.
+// "Found 3 date tags (e.g. 2024.03.01) that are not semver; planned from 0.0.0. Set
+// --base-version to continue from them." It returns "" when there are no legacy tags.
+func LegacyTagNotice(tags []tagplan.LegacyTag) string {
+	if len(tags) == 0 {
+		return ""
+	}
+	var schemes []string
+	seen := make(map[tagplan.LegacyScheme]bool)
+	for _, tag := range tags {
+		if !seen[tag.Scheme] {
+			seen[tag.Scheme] = true
+			schemes = append(schemes, string(tag.Scheme))
+		}
+	}
+	noun := "tags"
+	if len(tags) == 1 {
+		noun = "tag"
+	}
+	return fmt.Sprintf("Found %d %s %s (e.g. %s) that are not semver; planned from 0.0.0. Set --base-version to continue from them.",
+		len(tags), strings.Join(schemes, "/"), noun, tags[len(tags)-1].Name)
+}
+
+func baseDescription(result tagplan.Result) string {
+	switch result.BaseSource {
+	case tagplan.BaseSourceExisting, tagplan.BaseSourceHotfix:
+		if name := strings.TrimPrefix(strings.TrimSpace(result.BaseTag.Name), "refs/tags/"); name != "" {
+			return name
+		}
+	case tagplan.BaseSourceConfigured:
+		return "configured base " + result.ReleaseBase.String()
+	case tagplan.BaseSourceExternal:
+		return "external version " + result.ReleaseBase.String()
+	}
+	return result.ReleaseBase.String()
+}
+
+// PRLabelSummary renders a one-line account of a pr-label run.
+func PRLabelSummary(prID int, result prlabel.Result) string {
+	if result.SkipReason != "" {
+		return fmt.Sprintf("Left PR %d unlabeled: %s.", prID, result.SkipReason)
+	}
+
+	source := fmt.Sprintf("%s bump from branch prefix %s", result.Bump, result.MatchedPrefix)
+	if !result.BranchMatched {
+		source = fmt.Sprintf("%s default; branch matched no prefix", result.Bump)
+	}
+
+	switch result.Decision {
+	case labels.DecisionAddExpected:
+		return fmt.Sprintf("Added label %s to PR %d (%s).", result.ExpectedLabel, prID, source)
+	case labels.DecisionConflict:
+		return fmt.Sprintf("PR %d has conflicting semver labels %s; expected %s (%s).", prID, strings.Join(result.ExistingSemver, ", "), result.ExpectedLabel, source)
+	default:
+		return fmt.Sprintf("PR %d already has label %s (%s).", prID, result.ExpectedLabel, source)
+	}
+}
+
+// InferBumpSummary renders a one-line account of an infer-bump run.
+func InferBumpSummary(result inferbump.Result) string {
+	var line string
+	switch {
+	case result.Defaulted:
+		line = fmt.Sprintf("Defaulted to %s bump: %s", result.Bump, defaultReasonDescription(result))
+	case result.DefaultReason == inferbump.DefaultReasonTitle:
+		line = fmt.Sprintf("Inferred %s bump from the title of PR %d", result.Bump, result.PRID)
+	default:
+		line = fmt.Sprintf("Inferred %s bump from PR %d labels (%s)", result.Bump, result.PRID, strings.Join(result.SemverLabels, ", "))
+	}
+	if result.Conflict {
+		line += fmt.Sprintf("; conflicting labels resolved by %s", result.ConflictPolicy)
+	}
+	return line + "."
+}
+
+func defaultReasonDescription(result inferbump.Result) string {
+	switch result.DefaultReason {
+	case inferbump.DefaultReasonNoPullRequest:
+		return fmt.Sprintf("no pull request found for commit %s", shortSHA(result.CommitSHA))
+	case inferbump.DefaultReasonNoSemverLabels:
+		return fmt.Sprintf("PR %d has no semver labels", result.PRID)
+	case inferbump.DefaultReasonNotOnBranch:
+		return fmt.Sprintf("commit %s is not on the required branch", shortSHA(result.CommitSHA))
+	default:
+		return string(result.DefaultReason)
+	}
+}
+
+func shortSHA(commit string) string {
+	commit = strings.TrimSpace(commit)
+	if len(commit) > shortSHALength {
+		return commit[:shortSHALength]
+	}
+	return commit
+}
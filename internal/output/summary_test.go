@@ -0,0 +1,216 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	semver "github.com/blang/semver/v4"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/labels"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/inferbump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/prlabel"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/tagging"
+)
+
+func TestCreateTagSummary(t *testing.T) {
+	t.Parallel()
+
+	release := tagplan.Result{
+		Mode:        tagplan.ModeRelease,
+		TagName:     "v1.3.0",
+		ReleaseBase: semver.MustParse("1.2.3"),
+		BaseSource:  tagplan.BaseSourceExisting,
+		BaseTag:     tagplan.Tag{Name: "refs/tags/v1.2.3"},
+		Floating:    tagplan.FloatingPlan{TagName: "v1", Enabled: true, Created: true, Moved: true, Existing: tagplan.Tag{Name: "refs/tags/v1"}},
+	}
+	rc := tagplan.Result{
+		Mode:        tagplan.ModeRC,
+		TagName:     "v2.0.0-rc.1",
+		ReleaseBase: semver.MustParse("0.0.0"),
+		BaseSource:  tagplan.BaseSourceZero,
+	}
+
+	tests := []struct {
+		name     string
+		result   tagplan.Result
+		intent   bump.Bump
+		dryRun   bool
+		expected string
+	}{
+		{
+			name:     "release with floating move",
+			result:   release,
+			intent:   bump.BumpMinor,
+			expected: "Created release tag v1.3.0 at deadbee (minor bump from v1.2.3); updated floating tag v1.",
+		},
+		{
+			name:     "dry run",
+			result:   release,
+			intent:   bump.BumpMinor,
+			dryRun:   true,
+			expected: "Would create release tag v1.3.0 at deadbee (minor bump from v1.2.3); would update floating tag v1.",
+		},
+		{
+			name:     "rc from zero",
+			result:   rc,
+			intent:   bump.BumpMajor,
+			expected: "Created RC tag v2.0.0-rc.1 at deadbee (major bump from 0.0.0).",
+		},
+		{
+			name:     "skipped missing commit",
+			result:   tagplan.Result{Mode: tagplan.ModeRelease, TagName: "v1.3.0", Floating: release.Floating, Skipped: true},
+			intent:   bump.BumpMinor,
+			expected: "Skipped release tag v1.3.0: commit deadbee not found.",
+		},
+		{
+			name:     "finalizes prerelease line",
+			result:   tagplan.Result{Mode: tagplan.ModeRelease, TagName: "v2.0.0", BaseSource: tagplan.BaseSourceExisting, HigherPrerelease: "v2.0.0-rc.3", PrereleaseLineUsed: true},
+			intent:   bump.BumpPatch,
+			expected: "Created release tag v2.0.0 at deadbee (finalizes v2.0.0-rc.3).",
+		},
+		{
+			name:     "skipped with reason",
+			result:   tagplan.Result{Mode: tagplan.ModeRelease, TagName: "v1.3.0", Skipped: true, SkipReason: "pull request 42 only changes paths matching docs/**"},
+			intent:   bump.BumpMinor,
+			expected: "Skipped release tag v1.3.0: pull request 42 only changes paths matching docs/**.",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := CreateTagSummary(tc.result, tc.intent, "deadbeefcafe", tc.dryRun)
+			if got != tc.expected {
+				t.Fatalf("summary:\n got %q\nwant %q", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestAliasTagSummary(t *testing.T) {
+	t.Parallel()
+
+	plan := tagplan.AliasPlan{
+		TagName: "stable",
+		Source:  tagplan.Tag{Name: "refs/tags/v1.2.4"},
+		Commit:  "deadbeefcafe",
+	}
+	if got, want := AliasTagSummary(plan, false), "Created alias tag stable for v1.2.4 at deadbee."; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+	if got := AliasTagSummary(plan, true); !strings.HasPrefix(got, "Would create alias tag stable") {
+		t.Fatalf("unexpected dry-run summary %q", got)
+	}
+}
+
+func TestDeleteTagSummary(t *testing.T) {
+	t.Parallel()
+
+	tag := tagging.ExistingTag{Name: "refs/tags/v1.2.4", ObjectID: "tag-object", Commit: "deadbeefcafe"}
+	if got, want := DeleteTagSummary(tag, false), "Deleted tag v1.2.4 at deadbee."; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+	if got := DeleteTagSummary(tag, true); !strings.HasPrefix(got, "Would delete tag v1.2.4") {
+		t.Fatalf("unexpected dry-run summary %q", got)
+	}
+}
+
+func TestLegacyTagNotice(t *testing.T) {
+	t.Parallel()
+
+	if got := LegacyTagNotice(nil); got != "" {
+		t.Fatalf("expected no notice, got %q", got)
+	}
+
+	tags := []tagplan.LegacyTag{
+		{Name: "2024.01.15", Scheme: tagplan.LegacySchemeDate},
+		{Name: "v3.1", Scheme: tagplan.LegacySchemeMajorMinor},
+		{Name: "2024.03.01", Scheme: tagplan.LegacySchemeDate},
+	}
+	want := "Found 3 date/major-minor tags (e.g. 2024.03.01) that are not semver; planned from 0.0.0. Set --base-version to continue from them."
+	if got := LegacyTagNotice(tags); got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+	if got := LegacyTagNotice(tags[:1]); !strings.HasPrefix(got, "Found 1 date tag (e.g. 2024.01.15)") {
+		t.Fatalf("unexpected single-tag notice %q", got)
+	}
+}
+
+func TestPRLabelSummary(t *testing.T) {
+	t.Parallel()
+
+	added := PRLabelSummary(42, prlabel.Result{
+		Bump:          bump.BumpMinor,
+		BranchMatched: true,
+		MatchedPrefix: "feature/",
+		Decision:      labels.DecisionAddExpected,
+		ExpectedLabel: "semver-minor",
+		LabelAdded:    true,
+	})
+	for _, fact := range []string{"semver-minor", "PR 42", "minor", "feature/"} {
+		if !strings.Contains(added, fact) {
+			t.Fatalf("expected %q in summary %q", fact, added)
+		}
+	}
+
+	skipped := PRLabelSummary(7, prlabel.Result{SkipReason: `branch "docs/x" matches no configured prefix`})
+	if !strings.Contains(skipped, "unlabeled") || !strings.Contains(skipped, "docs/x") {
+		t.Fatalf("unexpected skip summary %q", skipped)
+	}
+}
+
+func TestInferBumpSummary(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		result inferbump.Result
+		facts  []string
+	}{
+		{
+			name:   "labels",
+			result: inferbump.Result{Bump: bump.BumpMajor, PRID: 12, SemverLabels: []string{"semver-major"}},
+			facts:  []string{"major", "PR 12", "semver-major"},
+		},
+		{
+			name:   "title",
+			result: inferbump.Result{Bump: bump.BumpMinor, PRID: 12, DefaultReason: inferbump.DefaultReasonTitle},
+			facts:  []string{"minor", "title", "PR 12"},
+		},
+		{
+			name:   "default without pull request",
+			result: inferbump.Result{Bump: bump.BumpPatch, CommitSHA: "abcdef123456", Defaulted: true, DefaultReason: inferbump.DefaultReasonNoPullRequest},
+			facts:  []string{"Defaulted to patch", "no pull request", "abcdef1"},
+		},
+		{
+			name:   "conflict",
+			result: inferbump.Result{Bump: bump.BumpMajor, PRID: 3, SemverLabels: []string{"semver-major", "semver-minor"}, Conflict: true, ConflictPolicy: inferbump.ConflictMax},
+			facts:  []string{"semver-major, semver-minor", "resolved by max"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := InferBumpSummary(tc.result)
+			for _, fact := range tc.facts {
+				if !strings.Contains(got, fact) {
+					t.Fatalf("expected %q in summary %q", fact, got)
+				}
+			}
+		})
+	}
+}
+
+func TestWithRepoName(t *testing.T) {
+	t.Parallel()
+
+	line := "Created release tag v1.2.4 at deadbee (minor bump from v1.2.3)."
+	if got := WithRepoName(line, "payments-api"); got != "payments-api: "+line {
+		t.Fatalf("expected the display name prefix, got %q", got)
+	}
+	if got := WithRepoName(line, ""); got != line {
+		t.Fatalf("expected the line unchanged, got %q", got)
+	}
+}
@@ -0,0 +1,29 @@
+package output
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+// Table is a batch result flattened into rows with stable columns for csv and tsv output.
+type Table struct {
+	Header []string
+	Rows   [][]string
+}
+
+// WriteTable prints the header and rows as CSV, or tab-separated for FormatTSV. Fields are
+// quoted only when they contain the separator, a quote, or a newline.
+func WriteTable(w io.Writer, format Format, table Table) error {
+	writer := csv.NewWriter(w)
+	if format == FormatTSV {
+		writer.Comma = '\t'
+	}
+	if err := writer.Write(table.Header); err != nil {
+		return fmt.Errorf("writing %s header: %w", format, err)
+	}
+	if err := writer.WriteAll(table.Rows); err != nil {
+		return fmt.Errorf("writing %s rows: %w", format, err)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package output
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+// Sections of the list-tags output, in the order they are printed.
+const (
+	tagSectionRelease    = "release"
+	tagSectionPrerelease = "prerelease"
+	tagSectionFloating   = "floating"
+	tagSectionUnparsed   = "unparsed"
+)
+
+// TagEntry is one tag in the list-tags JSON document. Version is empty for floating and
+// unparsed tags.
+type TagEntry struct {
+	Tag     string `json:"tag"`
+	Version string `json:"version,omitempty"`
+	Commit  string `json:"commit"`
+}
+
+// TagListResult is the JSON document printed by list-tags.
+type TagListResult struct {
+	Outcome     Outcome    `json:"outcome"`
+	Releases    []TagEntry `json:"releases"`
+	Prereleases []TagEntry `json:"prereleases"`
+	Floating    []TagEntry `json:"floating"`
+	Unparsed    []TagEntry `json:"unparsed"`
+	Operator    string     `json:"operator,omitempty"`
+}
+
+// NewTagListResult converts a tag inventory into its JSON representation, keeping its order.
+func NewTagListResult(inventory tagplan.Inventory) TagListResult {
+	return TagListResult{
+		Outcome:     OutcomeTagsListed,
+		Releases:    tagEntries(inventory.Releases, true),
+		Prereleases: tagEntries(inventory.Prereleases, true),
+		Floating:    tagEntries(inventory.Floating, false),
+		Unparsed:    tagEntries(inventory.Unparsed, false),
+	}
+}
+
+func tagEntries(tags []tagplan.InventoryTag, versioned bool) []TagEntry {
+	entries := make([]TagEntry, 0, len(tags))
+	for _, tag := range tags {
+		entry := TagEntry{Tag: tag.TagName, Commit: tag.Tag.ObjectID}
+		if versioned {
+			entry.Version = tag.Version.String()
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// tagSection pairs a section name with its entries.
+type tagSection struct {
+	name    string
+	entries []TagEntry
+}
+
+// sections returns the result's sections in print order.
+func (r TagListResult) sections() []tagSection {
+	return []tagSection{
+		{tagSectionRelease, r.Releases},
+		{tagSectionPrerelease, r.Prereleases},
+		{tagSectionFloating, r.Floating},
+		{tagSectionUnparsed, r.Unparsed},
+	}
+}
+
+// TagListTable flattens the inventory into one row per tag, section by section.
+func TagListTable(result TagListResult) Table {
+	table := Table{Header: []string{"section", "tag", "version", "commit"}}
+	for _, section := range result.sections() {
+		for _, entry := range section.entries {
+			table.Rows = append(table.Rows, []string{section.name, entry.Tag, entry.Version, entry.Commit})
+		}
+	}
+	return table
+}
+
+// WriteTagList prints one tab-separated line per tag: the section ("release", "prerelease",
+// "floating", or "unparsed"), the tag, and the commit it points at.
+func WriteTagList(w io.Writer, result TagListResult) error {
+	for _, section := range result.sections() {
+		for _, entry := range section.entries {
+			if _, err := fmt.Fprintf(w, "%s\t%s\t%s\n", section.name, entry.Tag, entry.Commit); err != nil {
+				return fmt.Errorf("writing tag list: %w", err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+func sampleInventory(t *testing.T) tagplan.Inventory {
+	t.Helper()
+
+	inventory, err := tagplan.NewPlanner("v").Inventory([]tagplan.Tag{
+		{Name: "refs/tags/v1.2.0", ObjectID: "c120"},
+		{Name: "refs/tags/v1.3.0-rc.1", ObjectID: "c130"},
+		{Name: "refs/tags/v1", ObjectID: "c120"},
+		{Name: "refs/tags/latest", ObjectID: "c120"},
+	}, tagplan.InventoryFilter{})
+	if err != nil {
+		t.Fatalf("inventory: %v", err)
+	}
+	return inventory
+}
+
+func TestWriteTagList(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := WriteTagList(&buf, NewTagListResult(sampleInventory(t))); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	want := "release\tv1.2.0\tc120\nprerelease\tv1.3.0-rc.1\tc130\nfloating\tv1\tc120\nunparsed\tlatest\tc120\n"
+	if buf.String() != want {
+		t.Fatalf("expected %q, got %q", want, buf.String())
+	}
+}
+
+func TestTagListResultJSON(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, NewTagListResult(tagplan.Inventory{})); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	// Empty sections print as arrays so consumers can iterate without nil checks.
+	var decoded map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if decoded["outcome"] != string(OutcomeTagsListed) {
+		t.Fatalf("expected outcome %s, got %v", OutcomeTagsListed, decoded["outcome"])
+	}
+	for _, key := range []string{"releases", "prereleases", "floating", "unparsed"} {
+		if _, ok := decoded[key].([]any); !ok {
+			t.Fatalf("expected %s to be an array, got %v", key, decoded[key])
+		}
+	}
+
+	result := NewTagListResult(sampleInventory(t))
+	if result.Releases[0].Version != "1.2.0" || result.Floating[0].Version != "" {
+		t.Fatalf("expected versions on releases only, got %+v and %+v", result.Releases[0], result.Floating[0])
+	}
+}
+
+func TestTagListTable(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := WriteTable(&buf, FormatCSV, TagListTable(NewTagListResult(sampleInventory(t)))); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 5 || lines[0] != "section,tag,version,commit" || lines[1] != "release,v1.2.0,1.2.0,c120" {
+		t.Fatalf("unexpected table:\n%s", buf.String())
+	}
+}
@@ -0,0 +1,44 @@
+// Package output renders command results into the formats consumed by downstream tooling.
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+const filePermissions = 0o600
+
+// TerraformValues flattens a tag plan into the string-only map required by Terraform's
+// external data source, so modules can jsondecode the file without type conversions.
+func TerraformValues(result tagplan.Result) map[string]string {
+	return map[string]string{
+		"tag_name":      result.TagName,
+		"version":       result.Version.String(),
+		"major":         strconv.FormatUint(result.Version.Major, 10),
+		"minor":         strconv.FormatUint(result.Version.Minor, 10),
+		"patch":         strconv.FormatUint(result.Version.Patch, 10),
+		"is_prerelease": strconv.FormatBool(len(result.Version.Pre) > 0),
+	}
+}
+
+// WriteJSONFile encodes value as indented JSON and writes it to path, replacing any existing file.
+func WriteJSONFile(path string, value any) error {
+	target := strings.TrimSpace(path)
+	if target == "" {
+		return fmt.Errorf("output: file path is empty")
+	}
+	payload, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding %s: %w", target, err)
+	}
+	payload = append(payload, '\n')
+	if err := os.WriteFile(target, payload, filePermissions); err != nil {
+		return fmt.Errorf("writing %s: %w", target, err)
+	}
+	return nil
+}
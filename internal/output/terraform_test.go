@@ -0,0 +1,97 @@
+package output
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	semver "github.com/blang/semver/v4"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+func TestTerraformValues(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		tagName string
+		version string
+		want    map[string]string
+	}{
+		{
+			name:    "release",
+			tagName: "v1.2.4",
+			version: "1.2.4",
+			want: map[string]string{
+				"tag_name":      "v1.2.4",
+				"version":       "1.2.4",
+				"major":         "1",
+				"minor":         "2",
+				"patch":         "4",
+				"is_prerelease": "false",
+			},
+		},
+		{
+			name:    "release candidate",
+			tagName: "2.0.0-rc.3",
+			version: "2.0.0-rc.3",
+			want: map[string]string{
+				"tag_name":      "2.0.0-rc.3",
+				"version":       "2.0.0-rc.3",
+				"major":         "2",
+				"minor":         "0",
+				"patch":         "0",
+				"is_prerelease": "true",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			result := tagplan.Result{TagName: tt.tagName, Version: semver.MustParse(tt.version)}
+			got := TerraformValues(result)
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %d keys got %d: %v", len(tt.want), len(got), got)
+			}
+			for key, want := range tt.want {
+				if got[key] != want {
+					t.Fatalf("%s: want %q got %q", key, want, got[key])
+				}
+			}
+		})
+	}
+}
+
+func TestWriteJSONFileProducesStringMap(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "version.json")
+	result := tagplan.Result{TagName: "v1.0.0", Version: semver.MustParse("1.0.0")}
+
+	if err := WriteJSONFile(path, TerraformValues(result)); err != nil {
+		t.Fatalf("write json file: %v", err)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read json file: %v", err)
+	}
+	var decoded map[string]string
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		t.Fatalf("expected string-only JSON object: %v", err)
+	}
+	if decoded["tag_name"] != "v1.0.0" || decoded["major"] != "1" {
+		t.Fatalf("unexpected decoded values %v", decoded)
+	}
+}
+
+func TestWriteJSONFileRejectsEmptyPath(t *testing.T) {
+	t.Parallel()
+
+	if err := WriteJSONFile("  ", map[string]string{}); err == nil {
+		t.Fatalf("expected error for empty path")
+	}
+}
@@ -0,0 +1,14 @@
+package output
+
+import "io"
+
+// WriteValue prints the bare result of a text-format command, such as a tag name or bump
+// level. The trailing newline is omitted when newline is false so $(...) captures the value
+// exactly as printed.
+func WriteValue(w io.Writer, value string, newline bool) error {
+	if newline {
+		value += "\n"
+	}
+	_, err := io.WriteString(w, value)
+	return err
+}
@@ -0,0 +1,33 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteValue(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		newline  bool
+		expected string
+	}{
+		{name: "with trailing newline", newline: true, expected: "v1.2.4\n"},
+		{name: "without trailing newline", newline: false, expected: "v1.2.4"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			if err := WriteValue(&buf, "v1.2.4", tc.newline); err != nil {
+				t.Fatalf("WriteValue returned error: %v", err)
+			}
+			if buf.String() != tc.expected {
+				t.Fatalf("expected %q, got %q", tc.expected, buf.String())
+			}
+		})
+	}
+}
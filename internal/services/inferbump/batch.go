@@ -0,0 +1,104 @@
+package inferbump
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+)
+
+// DefaultBatchConcurrency is the number of commits ResolveBatch resolves at once when the
+// caller does not choose.
+const DefaultBatchConcurrency = 4
+
+// BatchEntry is the outcome for one commit of a batch; Err is set when it could not be resolved.
+type BatchEntry struct {
+	CommitSHA string
+	Result    Result
+	Err       error
+}
+
+// BatchResult reports every commit of a batch, in input order, and the combined bump of the
+// commits that resolved.
+type BatchResult struct {
+	Entries []BatchEntry
+	// Bump is the highest-impact bump across resolved entries; empty when none resolved.
+	Bump     bump.Bump
+	Resolved int
+	Failed   int
+}
+
+// ResolveBatch resolves each commit with Resolve, running up to concurrency lookups at once.
+// cfg.CommitSHA is ignored. A commit that fails is recorded with its error instead of aborting
+// the batch; blank and repeated commits are skipped.
+func (s Service) ResolveBatch(ctx context.Context, commits []string, cfg Config, concurrency int) BatchResult {
+	unique := uniqueCommits(commits)
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency
+	}
+
+	entries := make([]BatchEntry, len(unique))
+	slots := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, commit := range unique {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(i int, commit string) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			commitCfg := cfg
+			commitCfg.CommitSHA = commit
+			result, err := s.Resolve(ctx, commitCfg)
+			entries[i] = BatchEntry{CommitSHA: commit, Result: result, Err: err}
+		}(i, commit)
+	}
+	wg.Wait()
+
+	batch := BatchResult{Entries: entries}
+	var resolved []bump.Bump
+	for _, entry := range entries {
+		if entry.Err != nil {
+			batch.Failed++
+			continue
+		}
+		resolved = append(resolved, entry.Result.Bump)
+	}
+	batch.Resolved = len(resolved)
+	if len(resolved) > 0 {
+		batch.Bump = bump.Max(resolved...)
+	}
+	return batch
+}
+
+// ParseCommitList reads commit SHAs from a file body: one or more per line, separated by
+// whitespace or commas, with blank lines and "#" comments ignored.
+func ParseCommitList(data []byte) []string {
+	var commits []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		commits = append(commits, strings.FieldsFunc(line, func(r rune) bool {
+			return r == ',' || unicode.IsSpace(r)
+		})...)
+	}
+	return commits
+}
+
+func uniqueCommits(commits []string) []string {
+	seen := make(map[string]bool, len(commits))
+	out := make([]string, 0, len(commits))
+	for _, commit := range commits {
+		trimmed := strings.TrimSpace(commit)
+		key := strings.ToLower(trimmed)
+		if trimmed == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, trimmed)
+	}
+	return out
+}
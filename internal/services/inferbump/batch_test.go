@@ -0,0 +1,135 @@
+package inferbump
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/labels"
+)
+
+// batchClient maps merge commits to PRs and PRs to labels, and is safe for concurrent use.
+type batchClient struct {
+	*fakeClient
+	mu       sync.Mutex
+	prs      map[string]int
+	prLabels map[int][]string
+	failing  map[string]error
+	active   int
+	peak     int
+}
+
+func (c *batchClient) FindPullRequestByMergeCommit(_ context.Context, commit string) (int, error) {
+	c.mu.Lock()
+	c.active++
+	if c.active > c.peak {
+		c.peak = c.active
+	}
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		c.active--
+		c.mu.Unlock()
+	}()
+
+	if err := c.failing[commit]; err != nil {
+		return 0, err
+	}
+	if prID, ok := c.prs[commit]; ok {
+		return prID, nil
+	}
+	return 0, ado.ErrPullRequestNotFound
+}
+
+func (c *batchClient) ListPRLabels(_ context.Context, prID int) ([]string, error) {
+	return c.prLabels[prID], nil
+}
+
+func TestResolveBatchAggregatesBumps(t *testing.T) {
+	t.Parallel()
+
+	client := &batchClient{
+		fakeClient: &fakeClient{},
+		prs:        map[string]int{"c1": 1, "c2": 2, "c3": 3},
+		prLabels:   map[int][]string{1: {"semver-patch"}, 2: {"semver-minor"}, 3: {"docs"}},
+		failing:    map[string]error{"c5": errors.New("boom")},
+	}
+	svc := NewService(client, labels.NewResolver(labels.Config{}))
+
+	batch := svc.ResolveBatch(context.Background(), []string{"c1", "c2", " ", "c3", "c4", "c5", "C1"}, Config{}, 2)
+
+	if batch.Bump != bump.BumpMinor || batch.Resolved != 4 || batch.Failed != 1 {
+		t.Fatalf("expected minor across 4 resolved and 1 failed, got %s (%d/%d)", batch.Bump, batch.Resolved, batch.Failed)
+	}
+	want := []struct {
+		commit string
+		prID   int
+		bump   bump.Bump
+		reason DefaultReason
+		failed bool
+	}{
+		{commit: "c1", prID: 1, bump: bump.BumpPatch},
+		{commit: "c2", prID: 2, bump: bump.BumpMinor},
+		{commit: "c3", prID: 3, bump: bump.BumpPatch, reason: DefaultReasonNoSemverLabels},
+		{commit: "c4", bump: bump.BumpPatch, reason: DefaultReasonNoPullRequest},
+		{commit: "c5", failed: true},
+	}
+	if len(batch.Entries) != len(want) {
+		t.Fatalf("expected %d entries in input order, got %+v", len(want), batch.Entries)
+	}
+	for i, w := range want {
+		entry := batch.Entries[i]
+		if entry.CommitSHA != w.commit || (entry.Err != nil) != w.failed {
+			t.Fatalf("entry %d: want %s (failed=%v), got %+v", i, w.commit, w.failed, entry)
+		}
+		if w.failed {
+			continue
+		}
+		if entry.Result.PRID != w.prID || entry.Result.Bump != w.bump || entry.Result.DefaultReason != w.reason {
+			t.Fatalf("entry %d: want pr %d %s (%q), got %+v", i, w.prID, w.bump, w.reason, entry.Result)
+		}
+	}
+	if client.peak > 2 {
+		t.Fatalf("expected at most 2 concurrent lookups, saw %d", client.peak)
+	}
+}
+
+func TestResolveBatchStrictRecordsMissingPR(t *testing.T) {
+	t.Parallel()
+
+	client := &batchClient{fakeClient: &fakeClient{}, prs: map[string]int{"c1": 1}, prLabels: map[int][]string{1: {"semver-major"}}}
+	svc := NewService(client, labels.NewResolver(labels.Config{}))
+
+	batch := svc.ResolveBatch(context.Background(), []string{"c1", "c2"}, Config{Strict: true}, 0)
+	if batch.Bump != bump.BumpMajor || batch.Failed != 1 {
+		t.Fatalf("expected major with one failure, got %s (%d failed)", batch.Bump, batch.Failed)
+	}
+	if !errors.Is(batch.Entries[1].Err, ado.ErrPullRequestNotFound) {
+		t.Fatalf("expected the missing PR to be recorded, got %v", batch.Entries[1].Err)
+	}
+}
+
+func TestResolveBatchAllFailed(t *testing.T) {
+	t.Parallel()
+
+	client := &batchClient{fakeClient: &fakeClient{}, failing: map[string]error{"c1": errors.New("boom")}}
+	batch := NewService(client, labels.NewResolver(labels.Config{})).ResolveBatch(context.Background(), []string{"c1"}, Config{}, 1)
+	if batch.Bump != "" || batch.Resolved != 0 || batch.Failed != 1 {
+		t.Fatalf("expected no aggregate bump, got %+v", batch)
+	}
+}
+
+func TestParseCommitList(t *testing.T) {
+	t.Parallel()
+
+	data := []byte("# release train 42\nabc123\n\n def456, 789abc  # hotfix\r\n")
+	got := ParseCommitList(data)
+	want := []string{"abc123", "def456", "789abc"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,21 @@
+package inferbump
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/conventional"
+)
+
+// commitMessageBump classifies the merge commit's message, header and footers both, reporting
+// false when it carries no bump-producing conventional-commit type.
+func (s Service) commitMessageBump(ctx context.Context, result *Result) (bump.Bump, bool, error) {
+	message, err := s.client.GetCommitMessage(ctx, result.CommitSHA)
+	if err != nil {
+		return "", false, fmt.Errorf("getting merge commit message: %w", err)
+	}
+
+	intent, ok := conventional.Classify(message)
+	return intent, ok, nil
+}
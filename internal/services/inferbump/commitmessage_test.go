@@ -0,0 +1,80 @@
+package inferbump
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/labels"
+)
+
+func TestResolveInfersBumpFromCommitMessage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		labels       []string
+		title        string
+		message      string
+		expectBump   bump.Bump
+		expectReason DefaultReason
+		defaulted    bool
+	}{
+		{name: "feat message", message: "feat: add range support", expectBump: bump.BumpMinor, expectReason: DefaultReasonConventionalCommit},
+		{name: "fix message", message: "fix(cli): trim prefix\n\nCloses #12", expectBump: bump.BumpPatch, expectReason: DefaultReasonConventionalCommit},
+		{name: "breaking bang", message: "feat!: drop legacy flags", expectBump: bump.BumpMajor, expectReason: DefaultReasonConventionalCommit},
+		{name: "breaking footer", message: "refactor: move config\n\nBREAKING CHANGE: env names changed", expectBump: bump.BumpMajor, expectReason: DefaultReasonConventionalCommit},
+		{name: "breaking hyphen footer", message: "fix: rename output\n\nBREAKING-CHANGE: prId is now prIds", expectBump: bump.BumpMajor, expectReason: DefaultReasonConventionalCommit},
+		{name: "labels win over message", labels: []string{"semver-patch"}, message: "feat!: drop legacy flags", expectBump: bump.BumpPatch},
+		{name: "title wins over message", title: "fix: trim prefix", message: "feat: add range support", expectBump: bump.BumpPatch, expectReason: DefaultReasonTitle},
+		{name: "merge message defaults", labels: []string{"needs-review"}, message: "Merged PR 17: Update docs", expectBump: bump.BumpPatch, expectReason: DefaultReasonNoSemverLabels, defaulted: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &fakeClient{prID: 17, labels: tc.labels, title: tc.title, message: tc.message}
+			svc := NewService(client, labels.NewResolver(labels.Config{}))
+
+			result, err := svc.Resolve(context.Background(), Config{CommitSHA: "abc", InferFromTitle: true, CommitMessageFallback: true})
+			if err != nil {
+				t.Fatalf(resolveErrFormat, err)
+			}
+			if result.Bump != tc.expectBump {
+				t.Fatalf("bump: want %s got %s", tc.expectBump, result.Bump)
+			}
+			if result.DefaultReason != tc.expectReason || result.Defaulted != tc.defaulted {
+				t.Fatalf("reason: want %q (defaulted=%v) got %q (defaulted=%v)", tc.expectReason, tc.defaulted, result.DefaultReason, result.Defaulted)
+			}
+		})
+	}
+}
+
+func TestResolveIgnoresCommitMessageWithoutOptIn(t *testing.T) {
+	t.Parallel()
+
+	client := &fakeClient{prID: 17, message: "feat: add range support", messageErr: errors.New("should not be called")}
+	svc := NewService(client, labels.NewResolver(labels.Config{}))
+
+	result, err := svc.Resolve(context.Background(), Config{CommitSHA: "abc"})
+	if err != nil {
+		t.Fatalf(resolveErrFormat, err)
+	}
+	if result.Bump != bump.BumpPatch || result.DefaultReason != DefaultReasonNoSemverLabels {
+		t.Fatalf("expected patch default, got %+v", result)
+	}
+}
+
+func TestResolveSurfacesCommitMessageErrors(t *testing.T) {
+	t.Parallel()
+
+	boom := errors.New("boom")
+	client := &fakeClient{prID: 17, messageErr: boom}
+	svc := NewService(client, labels.NewResolver(labels.Config{}))
+
+	if _, err := svc.Resolve(context.Background(), Config{CommitSHA: "abc", CommitMessageFallback: true}); !errors.Is(err, boom) {
+		t.Fatalf("expected commit message error, got %v", err)
+	}
+}
@@ -0,0 +1,46 @@
+package inferbump
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado"
+)
+
+// ResolvePullRequest infers the bump for a completed pull request and reports its merge commit
+// in Result.CommitSHA, so callers holding only a PR ID can tag the merge directly. The
+// CommitSHA and lookup settings in cfg are ignored; Strict and ConflictPolicy still apply.
+func (s Service) ResolvePullRequest(ctx context.Context, prID int, cfg Config) (Result, error) {
+	commit, err := s.MergeCommit(ctx, prID)
+	if err != nil {
+		return Result{}, err
+	}
+	return s.bumpFromSources(ctx, Result{CommitSHA: commit, PRID: prID}, cfg)
+}
+
+// MergeCommit returns the merge commit of a completed pull request without reading its labels,
+// for callers that need the commit before they can decide how to read them.
+func (s Service) MergeCommit(ctx context.Context, prID int) (string, error) {
+	if s.client == nil {
+		return "", ErrNilClient
+	}
+	if prID <= 0 {
+		return "", ErrInvalidPR
+	}
+
+	pr, err := s.client.GetPullRequest(ctx, prID)
+	if err != nil {
+		return "", fmt.Errorf("getting pull request: %w", err)
+	}
+
+	commit := strings.TrimSpace(pr.LastMergeCommit)
+	if pr.Status != ado.PullRequestStatusCompleted || commit == "" {
+		status := string(pr.Status)
+		if status == "" {
+			status = "unknown"
+		}
+		return "", fmt.Errorf("%w: pull request %d is %s", ErrPRNotMerged, prID, status)
+	}
+	return commit, nil
+}
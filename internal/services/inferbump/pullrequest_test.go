@@ -0,0 +1,89 @@
+package inferbump
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/labels"
+)
+
+func TestResolvePullRequestUsesMergeCommitAndLabels(t *testing.T) {
+	t.Parallel()
+
+	client := &fakeClient{
+		pullRequest: ado.PullRequest{ID: 17, Status: ado.PullRequestStatusCompleted, LastMergeCommit: "merge-sha"},
+		labels:      []string{"needs-review", "semver-minor"},
+	}
+	svc := NewService(client, labels.NewResolver(labels.Config{}))
+
+	result, err := svc.ResolvePullRequest(context.Background(), 17, Config{})
+	if err != nil {
+		t.Fatalf(resolveErrFormat, err)
+	}
+
+	if result.CommitSHA != "merge-sha" || result.PRID != 17 {
+		t.Fatalf("expected merge commit and PR ID from the pull request, got %+v", result)
+	}
+	if result.Bump != bump.BumpMinor || result.Defaulted {
+		t.Fatalf("expected minor bump from labels, got %+v", result)
+	}
+	if client.lookups != 0 {
+		t.Fatalf("expected no merge-commit lookups, got %d", client.lookups)
+	}
+
+	commit, err := svc.MergeCommit(context.Background(), 17)
+	if err != nil || commit != "merge-sha" {
+		t.Fatalf("expected merge commit merge-sha, got %q err=%v", commit, err)
+	}
+}
+
+func TestResolvePullRequestDefaultsWithoutSemverLabels(t *testing.T) {
+	t.Parallel()
+
+	client := &fakeClient{
+		pullRequest: ado.PullRequest{ID: 3, Status: ado.PullRequestStatusCompleted, LastMergeCommit: "merge-sha"},
+	}
+	svc := NewService(client, labels.NewResolver(labels.Config{}))
+
+	result, err := svc.ResolvePullRequest(context.Background(), 3, Config{})
+	if err != nil {
+		t.Fatalf(resolveErrFormat, err)
+	}
+	if !result.Defaulted || result.DefaultReason != DefaultReasonNoSemverLabels || result.Bump != bump.Default() {
+		t.Fatalf("expected default bump, got %+v", result)
+	}
+}
+
+func TestResolvePullRequestErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		pr     ado.PullRequest
+		prID   int
+		expect error
+	}{
+		{name: "invalid id", prID: 0, expect: ErrInvalidPR},
+		{name: "not found", prID: 9, expect: ado.ErrPullRequestNotFound},
+		{name: "active", prID: 5, pr: ado.PullRequest{ID: 5, Status: ado.PullRequestStatusActive, LastMergeCommit: "preview-merge"}, expect: ErrPRNotMerged},
+		{name: "abandoned", prID: 5, pr: ado.PullRequest{ID: 5, Status: ado.PullRequestStatusAbandoned}, expect: ErrPRNotMerged},
+		{name: "completed without merge commit", prID: 5, pr: ado.PullRequest{ID: 5, Status: ado.PullRequestStatusCompleted}, expect: ErrPRNotMerged},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			svc := NewService(&fakeClient{pullRequest: tc.pr, labels: []string{"semver-major"}}, labels.NewResolver(labels.Config{}))
+			if _, err := svc.ResolvePullRequest(context.Background(), tc.prID, Config{}); !errors.Is(err, tc.expect) {
+				t.Fatalf("expected %v, got %v", tc.expect, err)
+			}
+			if _, err := svc.MergeCommit(context.Background(), tc.prID); !errors.Is(err, tc.expect) {
+				t.Fatalf("merge commit: expected %v, got %v", tc.expect, err)
+			}
+		})
+	}
+}
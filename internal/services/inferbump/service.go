@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado"
 	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
@@ -12,10 +13,43 @@ import (
 )
 
 var (
-	ErrNilClient   = errors.New("inferbump service: nil ado client")
-	ErrEmptyCommit = errors.New("inferbump service: empty commit sha")
+	ErrNilClient         = errors.New("inferbump service: nil ado client")
+	ErrEmptyCommit       = errors.New("inferbump service: empty commit sha")
+	ErrConflictingLabels = errors.New("inferbump service: conflicting semver labels")
+	ErrBranchNotFound    = errors.New("inferbump service: required branch not found")
+	ErrCommitNotOnBranch = errors.New("inferbump service: commit is not on the required branch")
+	ErrInvalidPR         = errors.New("inferbump service: invalid pr id")
+	ErrPRNotMerged       = errors.New("inferbump service: pull request is not completed")
+	// ErrDefaulted is returned under Config.FailOnDefault when the default bump was applied.
+	ErrDefaulted = errors.New("inferbump service: default bump applied")
 )
 
+// ConflictPolicy selects the bump applied when a PR carries semver labels of different impact.
+type ConflictPolicy string
+
+const (
+	// ConflictMax applies the highest-impact bump (the historical behavior).
+	ConflictMax ConflictPolicy = "max"
+	// ConflictMin applies the lowest-impact bump.
+	ConflictMin ConflictPolicy = "min"
+	// ConflictError fails resolution.
+	ConflictError ConflictPolicy = "error"
+)
+
+// ParseConflictPolicy converts a string into a ConflictPolicy. Empty values map to ConflictMax.
+func ParseConflictPolicy(value string) (ConflictPolicy, error) {
+	switch ConflictPolicy(strings.ToLower(strings.TrimSpace(value))) {
+	case "", ConflictMax:
+		return ConflictMax, nil
+	case ConflictMin:
+		return ConflictMin, nil
+	case ConflictError:
+		return ConflictError, nil
+	default:
+		return "", fmt.Errorf("invalid conflict bump policy %q", value)
+	}
+}
+
 // DefaultReason explains why a default bump was chosen.
 type DefaultReason string
 
@@ -23,12 +57,49 @@ const (
 	DefaultReasonNone           DefaultReason = ""
 	DefaultReasonNoPullRequest  DefaultReason = "no-pull-request"
 	DefaultReasonNoSemverLabels DefaultReason = "no-semver-labels"
+	DefaultReasonNotOnBranch    DefaultReason = "commit-not-on-branch"
+	// DefaultReasonTitle records that the PR had no semver labels and the bump came from its
+	// conventional-commit title instead of the patch default.
+	DefaultReasonTitle DefaultReason = "pr-title"
+	// DefaultReasonConventionalCommit records that the bump came from the merge commit's
+	// conventional-commit message rather than the PR's labels or title.
+	DefaultReasonConventionalCommit DefaultReason = "conventional-commit"
 )
 
 // Config captures the inputs required to infer a bump intent.
 type Config struct {
 	CommitSHA string
 	Strict    bool
+	// ConflictPolicy controls how conflicting semver labels are resolved. Defaults to ConflictMax.
+	ConflictPolicy ConflictPolicy
+	// StrictConflict, together with Strict, fails on conflicting semver labels with
+	// ErrConflictingLabels whatever ConflictPolicy says, so strict runs need unambiguous labels.
+	StrictConflict bool
+	// LookupRetries is how many extra PR lookups to attempt when the merge commit is not yet
+	// indexed by ADO; LookupDelay is the pause between attempts.
+	LookupRetries int
+	LookupDelay   time.Duration
+	// RequireOnBranch, when set, requires the commit to be reachable from this branch.
+	RequireOnBranch string
+	// InferFromTitle classifies the PR title with conventional-commit rules when the PR has no
+	// semver labels. Labels take precedence, then the title, then the patch default.
+	InferFromTitle bool
+	// CommitMessageFallback classifies the merge commit message (feat:, fix:, feat!:, and
+	// BREAKING CHANGE footers) when neither the labels nor, under InferFromTitle, the title
+	// produced a bump.
+	CommitMessageFallback bool
+	// Sources orders the inference sources consulted for the PR; empty means labels, followed
+	// by the title under InferFromTitle and the merge commit message under
+	// CommitMessageFallback. Combine selects first-match or max across them.
+	Sources []Source
+	Combine CombineStrategy
+	// FailOnDefault turns any defaulted result (no PR, no semver labels, commit not on the
+	// required branch) into ErrDefaulted. Unlike Strict, it does not change how lookups behave.
+	FailOnDefault bool
+	// AggregatePRs considers every pull request whose merge commit is CommitSHA, such as an
+	// original and its cherry-pick, instead of the first one found: semver labels are collected
+	// from all of them and the highest bump wins.
+	AggregatePRs bool
 }
 
 // Result summarizes the resolution outcome.
@@ -40,21 +111,44 @@ type Result struct {
 	SemverLabels  []string
 	Defaulted     bool
 	DefaultReason DefaultReason
+	// LookupAttempts is the number of PR lookups performed.
+	LookupAttempts int
+	// Conflict reports whether SemverLabels mapped to more than one bump; ConflictPolicy records
+	// the policy that resolved it.
+	Conflict       bool
+	ConflictPolicy ConflictPolicy
+	// Title is the PR title consulted by the title source.
+	Title string
+	// Source is the inference source that produced Bump; empty when Defaulted.
+	Source Source
+	// PRIDs lists every pull request considered under Config.AggregatePRs, ascending; PRID is
+	// the first of them.
+	PRIDs []int
 }
 
 // Service determines bump intent for a merge commit by inspecting PR labels.
 type Service struct {
 	client ado.Client
 	labels labels.Resolver
+	wait   func(ctx context.Context, d time.Duration) error
 }
 
 // NewService constructs a Service instance.
 func NewService(client ado.Client, labels labels.Resolver) Service {
-	return Service{client: client, labels: labels}
+	return Service{client: client, labels: labels, wait: sleepContext}
 }
 
-// Resolve returns the bump intent for the merge commit reference.
+// Resolve returns the bump intent for the merge commit reference. Under cfg.FailOnDefault a
+// defaulted result is returned along with ErrDefaulted naming the DefaultReason.
 func (s Service) Resolve(ctx context.Context, cfg Config) (Result, error) {
+	result, err := s.resolve(ctx, cfg)
+	if err == nil && cfg.FailOnDefault && result.Defaulted {
+		return result, fmt.Errorf("%w (%s)", ErrDefaulted, result.DefaultReason)
+	}
+	return result, err
+}
+
+func (s Service) resolve(ctx context.Context, cfg Config) (Result, error) {
 	if s.client == nil {
 		return Result{}, ErrNilClient
 	}
@@ -66,7 +160,24 @@ func (s Service) Resolve(ctx context.Context, cfg Config) (Result, error) {
 
 	result := Result{CommitSHA: commit}
 
-	prID, err := s.client.FindPullRequestByMergeCommit(ctx, commit)
+	if branch := strings.TrimSpace(cfg.RequireOnBranch); branch != "" {
+		onBranch, err := s.commitOnBranch(ctx, commit, branch)
+		if err != nil {
+			return Result{}, err
+		}
+		if !onBranch {
+			if cfg.Strict {
+				return Result{}, fmt.Errorf("%w: %s is not reachable from %s", ErrCommitNotOnBranch, commit, branch)
+			}
+			result.Bump = bump.Default()
+			result.Defaulted = true
+			result.DefaultReason = DefaultReasonNotOnBranch
+			return result, nil
+		}
+	}
+
+	prIDs, attempts, err := s.findPullRequests(ctx, commit, cfg)
+	result.LookupAttempts = attempts
 	if err != nil {
 		if errors.Is(err, ado.ErrPullRequestNotFound) && !cfg.Strict {
 			result.Bump = bump.Default()
@@ -77,32 +188,166 @@ func (s Service) Resolve(ctx context.Context, cfg Config) (Result, error) {
 		return Result{}, fmt.Errorf("finding pull request by merge commit: %w", err)
 	}
 
-	result.PRID = prID
+	result.PRID = prIDs[0]
+	if cfg.AggregatePRs {
+		result.PRIDs = prIDs
+	}
+	return s.bumpFromSources(ctx, result, cfg)
+}
+
+// labelsBump lists result.PRID's labels and resolves the bump their semver labels imply,
+// applying cfg.ConflictPolicy when they disagree. It reports false when none are semver labels.
+// With result.PRIDs set, each pull request is resolved that way and the highest bump wins.
+func (s Service) labelsBump(ctx context.Context, result *Result, cfg Config) (bump.Bump, bool, error) {
+	prIDs := result.PRIDs
+	if len(prIDs) == 0 {
+		prIDs = []int{result.PRID}
+	}
+
+	var picks []bump.Bump
+	for _, prID := range prIDs {
+		intent, ok, err := s.prLabelsBump(ctx, prID, result, cfg)
+		if err != nil {
+			return "", false, err
+		}
+		if ok {
+			picks = append(picks, intent)
+		}
+	}
+	if len(picks) == 0 {
+		return "", false, nil
+	}
+	return bump.Max(picks...), true, nil
+}
 
+// prLabelsBump resolves one pull request's semver labels, adding its labels to result.
+func (s Service) prLabelsBump(ctx context.Context, prID int, result *Result, cfg Config) (bump.Bump, bool, error) {
 	prLabels, err := s.client.ListPRLabels(ctx, prID)
 	if err != nil {
-		return result, fmt.Errorf("listing pull request labels: %w", err)
+		return "", false, fmt.Errorf("listing pull request labels: %w", err)
 	}
 
-	if len(prLabels) > 0 {
-		result.Labels = append([]string(nil), prLabels...)
-	}
+	result.Labels = append(result.Labels, prLabels...)
 
 	var bumpCandidates []bump.Bump
+	var semverLabels []string
 	for _, lbl := range prLabels {
 		if b, ok := s.labels.BumpForLabel(lbl); ok {
-			result.SemverLabels = append(result.SemverLabels, lbl)
+			semverLabels = append(semverLabels, lbl)
 			bumpCandidates = append(bumpCandidates, b)
 		}
 	}
+	result.SemverLabels = append(result.SemverLabels, semverLabels...)
 
 	if len(bumpCandidates) == 0 {
-		result.Bump = bump.Default()
-		result.Defaulted = true
-		result.DefaultReason = DefaultReasonNoSemverLabels
-		return result, nil
+		return "", false, nil
 	}
+	if !hasConflict(bumpCandidates) {
+		return bumpCandidates[0], true, nil
+	}
+
+	policy := cfg.ConflictPolicy
+	switch {
+	case cfg.Strict && cfg.StrictConflict:
+		policy = ConflictError
+	case policy == "":
+		policy = ConflictMax
+	}
+	result.Conflict = true
+	result.ConflictPolicy = policy
 
-	result.Bump = bump.Max(bumpCandidates...)
-	return result, nil
+	switch policy {
+	case ConflictMax:
+		return bump.Max(bumpCandidates...), true, nil
+	case ConflictMin:
+		return bump.Min(bumpCandidates...), true, nil
+	case ConflictError:
+		return "", false, fmt.Errorf("%w: %s", ErrConflictingLabels, strings.Join(semverLabels, ", "))
+	default:
+		return "", false, fmt.Errorf("invalid conflict bump policy %q", policy)
+	}
+}
+
+// commitOnBranch resolves the branch head and checks that commit is one of its ancestors.
+func (s Service) commitOnBranch(ctx context.Context, commit, branch string) (bool, error) {
+	refName := "refs/heads/" + strings.TrimPrefix(branch, "refs/heads/")
+	refs, err := s.client.ListRefsWithPrefix(ctx, refName)
+	if err != nil {
+		return false, fmt.Errorf("listing branch refs: %w", err)
+	}
+
+	head := ""
+	for _, ref := range refs {
+		if ref.Name == refName {
+			head = strings.TrimSpace(ref.ObjectID)
+			break
+		}
+	}
+	if head == "" {
+		return false, fmt.Errorf("%w: %s", ErrBranchNotFound, refName)
+	}
+	if strings.EqualFold(head, commit) {
+		return true, nil
+	}
+
+	onBranch, err := s.client.IsAncestor(ctx, commit, head)
+	if err != nil {
+		return false, fmt.Errorf("checking ancestry against %s: %w", refName, err)
+	}
+	return onBranch, nil
+}
+
+// findPullRequests retries the merge-commit lookup on ErrPullRequestNotFound, which ADO returns
+// transiently until it has indexed a fresh merge. Other errors are returned immediately. Only
+// cfg.AggregatePRs asks for more than the first pull request.
+func (s Service) findPullRequests(ctx context.Context, commit string, cfg Config) ([]int, int, error) {
+	attempts := 0
+	for {
+		attempts++
+		prIDs, err := s.lookupPullRequests(ctx, commit, cfg.AggregatePRs)
+		if err == nil || !errors.Is(err, ado.ErrPullRequestNotFound) || attempts > cfg.LookupRetries {
+			return prIDs, attempts, err
+		}
+		wait := s.wait
+		if wait == nil {
+			wait = sleepContext
+		}
+		if werr := wait(ctx, cfg.LookupDelay); werr != nil {
+			return nil, attempts, werr
+		}
+	}
+}
+
+func (s Service) lookupPullRequests(ctx context.Context, commit string, all bool) ([]int, error) {
+	if all {
+		return s.client.FindPullRequestsByMergeCommit(ctx, commit)
+	}
+	prID, err := s.client.FindPullRequestByMergeCommit(ctx, commit)
+	if err != nil {
+		return nil, err
+	}
+	return []int{prID}, nil
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func hasConflict(candidates []bump.Bump) bool {
+	for _, b := range candidates[1:] {
+		if b != candidates[0] {
+			return true
+		}
+	}
+	return false
 }
@@ -3,9 +3,13 @@ package inferbump
 import (
 	"context"
 	"errors"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado/adotest"
 	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
 	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/labels"
 )
@@ -41,6 +45,287 @@ func TestResolveSelectsHighestImpactLabel(t *testing.T) {
 	}
 }
 
+func TestResolveNoneLabel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		labels    []string
+		expected  bump.Bump
+		conflict  bool
+		defaulted bool
+	}{
+		{name: "none label skips the release", labels: []string{"semver-none"}, expected: bump.BumpNone},
+		{name: "any other label outranks none", labels: []string{"semver-none", "semver-patch"}, expected: bump.BumpPatch, conflict: true},
+		{name: "unlabeled PR still defaults to patch", labels: []string{"ci"}, expected: bump.BumpPatch, defaulted: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			svc := NewService(&fakeClient{prID: 8, labels: tc.labels}, labels.NewResolver(labels.Config{}))
+			result, err := svc.Resolve(context.Background(), Config{CommitSHA: "abc123"})
+			if err != nil {
+				t.Fatalf(resolveErrFormat, err)
+			}
+			if result.Bump != tc.expected || result.Conflict != tc.conflict || result.Defaulted != tc.defaulted {
+				t.Fatalf("expected %s (conflict=%v defaulted=%v), got %s (conflict=%v defaulted=%v)", tc.expected, tc.conflict, tc.defaulted, result.Bump, result.Conflict, result.Defaulted)
+			}
+		})
+	}
+}
+
+func TestResolveConflictPolicies(t *testing.T) {
+	t.Parallel()
+
+	conflicting := []string{"semver-minor", "needs-review", "semver-major", "semver-patch"}
+
+	cases := []struct {
+		name           string
+		policy         ConflictPolicy
+		strict         bool
+		strictConflict bool
+		want           bump.Bump
+		wantErr        bool
+		recorded       ConflictPolicy
+	}{
+		{name: "default is max", policy: "", want: bump.BumpMajor, recorded: ConflictMax},
+		{name: "max", policy: ConflictMax, want: bump.BumpMajor, recorded: ConflictMax},
+		{name: "min", policy: ConflictMin, want: bump.BumpPatch, recorded: ConflictMin},
+		{name: "error", policy: ConflictError, wantErr: true, recorded: ConflictError},
+		{name: "strict alone keeps max", policy: ConflictMax, strict: true, want: bump.BumpMajor, recorded: ConflictMax},
+		{name: "strict conflict fails", policy: ConflictMax, strict: true, strictConflict: true, wantErr: true, recorded: ConflictError},
+		{name: "strict conflict needs strict", policy: ConflictMin, strictConflict: true, want: bump.BumpPatch, recorded: ConflictMin},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &fakeClient{prID: 9, labels: conflicting}
+			svc := NewService(client, labels.NewResolver(labels.Config{}))
+
+			result, err := svc.Resolve(context.Background(), Config{
+				CommitSHA:      "abc",
+				ConflictPolicy: tc.policy,
+				Strict:         tc.strict,
+				StrictConflict: tc.strictConflict,
+			})
+			if tc.wantErr {
+				if !errors.Is(err, ErrConflictingLabels) {
+					t.Fatalf("expected ErrConflictingLabels, got %v", err)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf(resolveErrFormat, err)
+				}
+				if result.Bump != tc.want {
+					t.Fatalf("expected %v bump, got %v", tc.want, result.Bump)
+				}
+			}
+			if !result.Conflict || result.ConflictPolicy != tc.recorded {
+				t.Fatalf("expected conflict recorded with policy %q, got %v/%q", tc.recorded, result.Conflict, result.ConflictPolicy)
+			}
+			if len(result.SemverLabels) != 3 {
+				t.Fatalf("expected 3 considered semver labels, got %v", result.SemverLabels)
+			}
+		})
+	}
+}
+
+func TestResolveAggregatePRs(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	client.MergeCommits = map[string]int{"abc123": 42}
+	client.MergeCommitPRs = map[string][]int{"abc123": {17}}
+	client.PRLabels = map[int][]string{
+		17: {"semver-minor", "team-a"},
+		42: {"semver-patch"},
+	}
+	svc := NewService(client, labels.NewResolver(labels.Config{}))
+
+	single, err := svc.Resolve(context.Background(), Config{CommitSHA: "abc123"})
+	if err != nil {
+		t.Fatalf(resolveErrFormat, err)
+	}
+	if single.Bump != bump.BumpPatch || single.PRID != 42 || single.PRIDs != nil {
+		t.Fatalf("expected the single-PR default to use PR 42, got %+v", single)
+	}
+
+	aggregated, err := svc.Resolve(context.Background(), Config{CommitSHA: "abc123", AggregatePRs: true})
+	if err != nil {
+		t.Fatalf(resolveErrFormat, err)
+	}
+	if aggregated.Bump != bump.BumpMinor || aggregated.Source != SourceLabels {
+		t.Fatalf("expected the highest bump across PRs, got %+v", aggregated)
+	}
+	if !reflect.DeepEqual(aggregated.PRIDs, []int{17, 42}) || aggregated.PRID != 17 {
+		t.Fatalf("expected contributing PRs [17 42], got %v (first %d)", aggregated.PRIDs, aggregated.PRID)
+	}
+	if !reflect.DeepEqual(aggregated.SemverLabels, []string{"semver-minor", "semver-patch"}) || aggregated.Conflict {
+		t.Fatalf("expected labels from both PRs without a per-PR conflict, got %+v", aggregated)
+	}
+}
+
+func TestResolveDuplicateLabelsAreNotConflicts(t *testing.T) {
+	t.Parallel()
+
+	client := &fakeClient{prID: 5, labels: []string{"semver-minor", "SEMVER-MINOR"}}
+	svc := NewService(client, labels.NewResolver(labels.Config{}))
+
+	result, err := svc.Resolve(context.Background(), Config{CommitSHA: "abc", ConflictPolicy: ConflictError})
+	if err != nil {
+		t.Fatalf(resolveErrFormat, err)
+	}
+	if result.Bump != bump.BumpMinor || result.Conflict || result.ConflictPolicy != "" {
+		t.Fatalf("unexpected result %+v", result)
+	}
+}
+
+func TestParseConflictPolicy(t *testing.T) {
+	t.Parallel()
+
+	for input, want := range map[string]ConflictPolicy{"": ConflictMax, "MAX": ConflictMax, "min": ConflictMin, " error ": ConflictError} {
+		got, err := ParseConflictPolicy(input)
+		if err != nil || got != want {
+			t.Fatalf("ParseConflictPolicy(%q) = %q, %v", input, got, err)
+		}
+	}
+	if _, err := ParseConflictPolicy("lowest"); err == nil {
+		t.Fatalf("expected error for unknown policy")
+	}
+}
+
+func TestResolveRetriesPullRequestLookup(t *testing.T) {
+	t.Parallel()
+
+	client := &fakeClient{prID: 17, labels: []string{"semver-minor"}, notFoundTimes: 2}
+	svc := NewService(client, labels.NewResolver(labels.Config{}))
+	var waits []time.Duration
+	svc.wait = func(_ context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+
+	result, err := svc.Resolve(context.Background(), Config{
+		CommitSHA:     "abc",
+		Strict:        true,
+		LookupRetries: 3,
+		LookupDelay:   time.Second,
+	})
+	if err != nil {
+		t.Fatalf(resolveErrFormat, err)
+	}
+	if result.PRID != 17 || result.Bump != bump.BumpMinor {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	if result.LookupAttempts != 3 || len(waits) != 2 || waits[0] != time.Second {
+		t.Fatalf("expected 3 attempts with 2 waits, got %d attempts, waits %v", result.LookupAttempts, waits)
+	}
+}
+
+func TestResolveGivesUpAfterLookupRetries(t *testing.T) {
+	t.Parallel()
+
+	client := &fakeClient{prID: 17, notFoundTimes: 5}
+	svc := NewService(client, labels.NewResolver(labels.Config{}))
+	svc.wait = func(context.Context, time.Duration) error { return nil }
+
+	_, err := svc.Resolve(context.Background(), Config{CommitSHA: "abc", Strict: true, LookupRetries: 2})
+	if !errors.Is(err, ado.ErrPullRequestNotFound) {
+		t.Fatalf("expected ErrPullRequestNotFound, got %v", err)
+	}
+	if client.lookups != 3 {
+		t.Fatalf("expected 3 lookups, got %d", client.lookups)
+	}
+}
+
+func TestResolveDoesNotRetryOtherErrors(t *testing.T) {
+	t.Parallel()
+
+	client := &fakeClient{prErr: errors.New("boom")}
+	svc := NewService(client, labels.NewResolver(labels.Config{}))
+	svc.wait = func(context.Context, time.Duration) error {
+		t.Fatalf("unexpected wait")
+		return nil
+	}
+
+	if _, err := svc.Resolve(context.Background(), Config{CommitSHA: "abc", LookupRetries: 3}); err == nil {
+		t.Fatalf("expected error")
+	}
+	if client.lookups != 1 {
+		t.Fatalf("expected a single lookup, got %d", client.lookups)
+	}
+}
+
+func TestResolveRequireOnBranch(t *testing.T) {
+	t.Parallel()
+
+	mainRef := ado.Ref{Name: "refs/heads/main", ObjectID: "head123"}
+	cases := []struct {
+		name        string
+		branch      string
+		notAncestor bool
+		strict      bool
+		wantErr     error
+		wantReason  DefaultReason
+		wantBump    bump.Bump
+	}{
+		{name: "reachable", branch: "main", wantBump: bump.BumpMajor},
+		{name: "full ref name", branch: "refs/heads/main", wantBump: bump.BumpMajor},
+		{name: "not reachable defaults", branch: "main", notAncestor: true, wantReason: DefaultReasonNotOnBranch, wantBump: bump.BumpPatch},
+		{name: "not reachable strict", branch: "main", notAncestor: true, strict: true, wantErr: ErrCommitNotOnBranch},
+		{name: "missing branch", branch: "release/9", wantErr: ErrBranchNotFound},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &fakeClient{
+				prID:        3,
+				labels:      []string{"semver-major"},
+				refs:        []ado.Ref{mainRef, {Name: "refs/heads/main-old", ObjectID: "other"}},
+				notAncestor: tc.notAncestor,
+			}
+			svc := NewService(client, labels.NewResolver(labels.Config{}))
+
+			result, err := svc.Resolve(context.Background(), Config{CommitSHA: "abc", Strict: tc.strict, RequireOnBranch: tc.branch})
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("expected %v, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf(resolveErrFormat, err)
+			}
+			if result.Bump != tc.wantBump || result.DefaultReason != tc.wantReason {
+				t.Fatalf("unexpected result %+v", result)
+			}
+			if tc.wantReason != "" && client.lookups != 0 {
+				t.Fatalf("expected no PR lookup for off-branch commit")
+			}
+		})
+	}
+}
+
+func TestResolveRequireOnBranchSurfacesAncestryErrors(t *testing.T) {
+	t.Parallel()
+
+	client := &fakeClient{
+		refs:        []ado.Ref{{Name: "refs/heads/main", ObjectID: "head123"}},
+		ancestorErr: errors.New("boom"),
+	}
+	svc := NewService(client, labels.NewResolver(labels.Config{}))
+
+	if _, err := svc.Resolve(context.Background(), Config{CommitSHA: "abc", RequireOnBranch: "main"}); err == nil {
+		t.Fatalf("expected ancestry error")
+	}
+}
+
 func TestResolveDefaultsWhenNoSemverLabels(t *testing.T) {
 	t.Parallel()
 
@@ -93,6 +378,50 @@ func TestResolveStrictErrorWhenNoPullRequest(t *testing.T) {
 	}
 }
 
+func TestResolveFailOnDefault(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name   string
+		client *fakeClient
+		reason DefaultReason
+	}{
+		{name: "no semver labels", client: &fakeClient{prID: 71, labels: []string{"needs-review"}}, reason: DefaultReasonNoSemverLabels},
+		{name: "no pull request", client: &fakeClient{}, reason: DefaultReasonNoPullRequest},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			svc := NewService(tc.client, labels.NewResolver(labels.Config{}))
+			result, err := svc.Resolve(context.Background(), Config{CommitSHA: "deadbeef", FailOnDefault: true})
+			if !errors.Is(err, ErrDefaulted) {
+				t.Fatalf("expected ErrDefaulted, got %v", err)
+			}
+			if !strings.Contains(err.Error(), string(tc.reason)) {
+				t.Fatalf("expected error to name %s, got %v", tc.reason, err)
+			}
+			if !result.Defaulted || result.DefaultReason != tc.reason {
+				t.Fatalf("expected defaulted result for %s, got %+v", tc.reason, result)
+			}
+		})
+	}
+}
+
+func TestResolveFailOnDefaultAllowsLabeledPR(t *testing.T) {
+	t.Parallel()
+
+	svc := NewService(&fakeClient{prID: 3, labels: []string{"semver-minor"}}, labels.NewResolver(labels.Config{}))
+	result, err := svc.Resolve(context.Background(), Config{CommitSHA: "deadbeef", FailOnDefault: true})
+	if err != nil {
+		t.Fatalf(resolveErrFormat, err)
+	}
+	if result.Bump != bump.BumpMinor {
+		t.Fatalf("expected minor bump, got %v", result.Bump)
+	}
+}
+
 func TestResolveClientErrors(t *testing.T) {
 	t.Parallel()
 
@@ -119,17 +448,51 @@ func TestResolveValidations(t *testing.T) {
 }
 
 type fakeClient struct {
+	ado.Client
 	prID      int
 	prErr     error
 	labels    []string
 	labelsErr error
+	// notFoundTimes makes the first N PR lookups return ErrPullRequestNotFound.
+	notFoundTimes int
+	lookups       int
+	refs          []ado.Ref
+	notAncestor   bool
+	ancestorErr   error
+	// pullRequest is returned by GetPullRequest when its ID matches.
+	pullRequest ado.PullRequest
+	title       string
+	titleErr    error
+	message     string
+	messageErr  error
 }
 
-func (f *fakeClient) ListRefsWithPrefix(context.Context, string) ([]ado.Ref, error) {
-	return nil, nil
+func (f *fakeClient) ListRefsWithPrefix(_ context.Context, prefix string) ([]ado.Ref, error) {
+	var out []ado.Ref
+	for _, ref := range f.refs {
+		if strings.HasPrefix(ref.Name, prefix) {
+			out = append(out, ref)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeClient) GetCommitMessage(context.Context, string) (string, error) {
+	return f.message, f.messageErr
+}
+
+func (f *fakeClient) IsAncestor(context.Context, string, string) (bool, error) {
+	if f.ancestorErr != nil {
+		return false, f.ancestorErr
+	}
+	return !f.notAncestor, nil
 }
 
 func (f *fakeClient) FindPullRequestByMergeCommit(_ context.Context, _ string) (int, error) {
+	f.lookups++
+	if f.lookups <= f.notFoundTimes {
+		return 0, ado.ErrPullRequestNotFound
+	}
 	if f.prErr != nil {
 		return 0, f.prErr
 	}
@@ -139,6 +502,25 @@ func (f *fakeClient) FindPullRequestByMergeCommit(_ context.Context, _ string) (
 	return f.prID, nil
 }
 
+func (f *fakeClient) FindPullRequestsByMergeCommit(ctx context.Context, commit string) ([]int, error) {
+	prID, err := f.FindPullRequestByMergeCommit(ctx, commit)
+	if err != nil {
+		return nil, err
+	}
+	return []int{prID}, nil
+}
+
+func (f *fakeClient) GetPullRequest(_ context.Context, prID int) (ado.PullRequest, error) {
+	if f.pullRequest.ID != prID {
+		return ado.PullRequest{}, ado.ErrPullRequestNotFound
+	}
+	return f.pullRequest, nil
+}
+
+func (f *fakeClient) GetPullRequestTitle(context.Context, int) (string, error) {
+	return f.title, f.titleErr
+}
+
 func (f *fakeClient) ListPRLabels(context.Context, int) ([]string, error) {
 	if f.labelsErr != nil {
 		return nil, f.labelsErr
@@ -150,15 +532,3 @@ func (f *fakeClient) ListPRLabels(context.Context, int) ([]string, error) {
 	copy(out, f.labels)
 	return out, nil
 }
-
-func (f *fakeClient) AddPRLabel(context.Context, int, string) error {
-	return nil
-}
-
-func (f *fakeClient) CreateAnnotatedTag(context.Context, ado.TagSpec) error {
-	return nil
-}
-
-func (f *fakeClient) DeleteRef(context.Context, string, string) error {
-	return nil
-}
@@ -0,0 +1,130 @@
+package inferbump
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+)
+
+// Source names a place a pull request's bump can be inferred from.
+type Source string
+
+const (
+	// SourceLabels maps the PR's semver labels to a bump, applying Config.ConflictPolicy.
+	SourceLabels Source = "labels"
+	// SourceTitle classifies the PR title with conventional-commit rules.
+	SourceTitle Source = "title"
+	// SourceCommitMessage classifies the merge commit message, footers included, with
+	// conventional-commit rules.
+	SourceCommitMessage Source = "commit-message"
+)
+
+// ParseSources converts a comma-separated list into an ordered source list. Empty values
+// return nil, which selects the sources implied by Config.InferFromTitle and
+// Config.CommitMessageFallback.
+func ParseSources(value string) ([]Source, error) {
+	var sources []Source
+	seen := make(map[Source]bool)
+	for _, part := range strings.Split(value, ",") {
+		name := Source(strings.ToLower(strings.TrimSpace(part)))
+		if name == "" {
+			continue
+		}
+		switch name {
+		case SourceLabels, SourceTitle, SourceCommitMessage:
+		default:
+			return nil, fmt.Errorf("invalid inference source %q", strings.TrimSpace(part))
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate inference source %q", name)
+		}
+		seen[name] = true
+		sources = append(sources, name)
+	}
+	return sources, nil
+}
+
+// CombineStrategy selects how the bumps from several sources combine.
+type CombineStrategy string
+
+const (
+	// CombineFirst uses the first source, in order, that yields a bump.
+	CombineFirst CombineStrategy = "first"
+	// CombineMax consults every source and uses the highest-impact bump.
+	CombineMax CombineStrategy = "max"
+)
+
+// ParseCombineStrategy converts a string into a CombineStrategy. Empty values map to CombineFirst.
+func ParseCombineStrategy(value string) (CombineStrategy, error) {
+	switch CombineStrategy(strings.ToLower(strings.TrimSpace(value))) {
+	case "", CombineFirst:
+		return CombineFirst, nil
+	case CombineMax:
+		return CombineMax, nil
+	default:
+		return "", fmt.Errorf("invalid inference combine strategy %q", value)
+	}
+}
+
+// sources returns the configured source order, defaulting to labels followed by the title
+// under InferFromTitle and the merge commit message under CommitMessageFallback.
+func (cfg Config) sources() []Source {
+	if len(cfg.Sources) > 0 {
+		return cfg.Sources
+	}
+	sources := []Source{SourceLabels}
+	if cfg.InferFromTitle {
+		sources = append(sources, SourceTitle)
+	}
+	if cfg.CommitMessageFallback {
+		sources = append(sources, SourceCommitMessage)
+	}
+	return sources
+}
+
+// bumpFromSources consults result.PRID's sources in order and combines their bumps per
+// cfg.Combine, applying the default bump when none yields one.
+func (s Service) bumpFromSources(ctx context.Context, result Result, cfg Config) (Result, error) {
+	for _, source := range cfg.sources() {
+		var intent bump.Bump
+		var ok bool
+		var err error
+		switch source {
+		case SourceLabels:
+			intent, ok, err = s.labelsBump(ctx, &result, cfg)
+		case SourceTitle:
+			intent, ok, err = s.titleBump(ctx, &result)
+		case SourceCommitMessage:
+			intent, ok, err = s.commitMessageBump(ctx, &result)
+		default:
+			err = fmt.Errorf("invalid inference source %q", source)
+		}
+		if err != nil {
+			return result, err
+		}
+		if !ok {
+			continue
+		}
+		if result.Source == "" || intent.HigherImpactThan(result.Bump) {
+			result.Bump = intent
+			result.Source = source
+		}
+		if cfg.Combine != CombineMax {
+			break
+		}
+	}
+
+	switch result.Source {
+	case "":
+		result.Bump = bump.Default()
+		result.Defaulted = true
+		result.DefaultReason = DefaultReasonNoSemverLabels
+	case SourceTitle:
+		result.DefaultReason = DefaultReasonTitle
+	case SourceCommitMessage:
+		result.DefaultReason = DefaultReasonConventionalCommit
+	}
+	return result, nil
+}
@@ -0,0 +1,118 @@
+package inferbump
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/labels"
+)
+
+func TestParseSources(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value   string
+		want    []Source
+		wantErr bool
+	}{
+		{value: "", want: nil},
+		{value: "labels", want: []Source{SourceLabels}},
+		{value: " Title , labels ", want: []Source{SourceTitle, SourceLabels}},
+		{value: "labels,,title", want: []Source{SourceLabels, SourceTitle}},
+		{value: "labels,commit-message", want: []Source{SourceLabels, SourceCommitMessage}},
+		{value: "labels,paths", wantErr: true},
+		{value: "labels,labels", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.value, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseSources(tc.value)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected error for %q", tc.value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("want %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestParseCombineStrategy(t *testing.T) {
+	t.Parallel()
+
+	for value, want := range map[string]CombineStrategy{"": CombineFirst, "first": CombineFirst, "MAX": CombineMax} {
+		got, err := ParseCombineStrategy(value)
+		if err != nil || got != want {
+			t.Fatalf("%q: want %s, got %s (%v)", value, want, got, err)
+		}
+	}
+	if _, err := ParseCombineStrategy("sum"); err == nil {
+		t.Fatal("expected error for unknown strategy")
+	}
+}
+
+func TestResolveSourceOrderAndCombine(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		labels     []string
+		title      string
+		sources    []Source
+		combine    CombineStrategy
+		wantBump   bump.Bump
+		wantSource Source
+		defaulted  bool
+	}{
+		{name: "labels first", labels: []string{"semver-patch"}, title: "feat: add", sources: []Source{SourceLabels, SourceTitle}, wantBump: bump.BumpPatch, wantSource: SourceLabels},
+		{name: "title first", labels: []string{"semver-patch"}, title: "feat: add", sources: []Source{SourceTitle, SourceLabels}, wantBump: bump.BumpMinor, wantSource: SourceTitle},
+		{name: "first falls through to the next source", title: "feat!: drop", sources: []Source{SourceLabels, SourceTitle}, wantBump: bump.BumpMajor, wantSource: SourceTitle},
+		{name: "max picks the higher impact", labels: []string{"semver-patch"}, title: "feat: add", sources: []Source{SourceLabels, SourceTitle}, combine: CombineMax, wantBump: bump.BumpMinor, wantSource: SourceTitle},
+		{name: "max keeps the earlier source on ties", labels: []string{"semver-minor"}, title: "feat: add", sources: []Source{SourceLabels, SourceTitle}, combine: CombineMax, wantBump: bump.BumpMinor, wantSource: SourceLabels},
+		{name: "title only ignores labels", labels: []string{"semver-major"}, title: "fix: trim", sources: []Source{SourceTitle}, wantBump: bump.BumpPatch, wantSource: SourceTitle},
+		{name: "no source yields a bump", labels: []string{"needs-review"}, title: "Update docs", sources: []Source{SourceTitle, SourceLabels}, combine: CombineMax, wantBump: bump.BumpPatch, defaulted: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &fakeClient{prID: 17, labels: tc.labels, title: tc.title}
+			svc := NewService(client, labels.NewResolver(labels.Config{}))
+
+			result, err := svc.Resolve(context.Background(), Config{CommitSHA: "abc", Sources: tc.sources, Combine: tc.combine})
+			if err != nil {
+				t.Fatalf(resolveErrFormat, err)
+			}
+			if result.Bump != tc.wantBump || result.Source != tc.wantSource || result.Defaulted != tc.defaulted {
+				t.Fatalf("want %s from %q (defaulted=%v), got %s from %q (defaulted=%v)", tc.wantBump, tc.wantSource, tc.defaulted, result.Bump, result.Source, result.Defaulted)
+			}
+		})
+	}
+}
+
+func TestResolveFirstSkipsLaterSources(t *testing.T) {
+	t.Parallel()
+
+	client := &fakeClient{prID: 17, labels: []string{"semver-minor"}, titleErr: errors.New("should not be called")}
+	svc := NewService(client, labels.NewResolver(labels.Config{}))
+
+	result, err := svc.Resolve(context.Background(), Config{CommitSHA: "abc", Sources: []Source{SourceLabels, SourceTitle}})
+	if err != nil {
+		t.Fatalf(resolveErrFormat, err)
+	}
+	if result.Source != SourceLabels || result.Title != "" {
+		t.Fatalf("expected labels to win without reading the title, got %+v", result)
+	}
+}
@@ -0,0 +1,22 @@
+package inferbump
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/conventional"
+)
+
+// titleBump classifies result.PRID's title, reporting false when the title carries no
+// bump-producing conventional-commit type.
+func (s Service) titleBump(ctx context.Context, result *Result) (bump.Bump, bool, error) {
+	title, err := s.client.GetPullRequestTitle(ctx, result.PRID)
+	if err != nil {
+		return "", false, fmt.Errorf("getting pull request title: %w", err)
+	}
+	result.Title = title
+
+	intent, ok := conventional.Classify(title)
+	return intent, ok, nil
+}
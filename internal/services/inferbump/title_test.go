@@ -0,0 +1,76 @@
+package inferbump
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/labels"
+)
+
+func TestResolveInfersBumpFromTitle(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		labels       []string
+		title        string
+		expectBump   bump.Bump
+		expectReason DefaultReason
+		defaulted    bool
+	}{
+		{name: "feat title", title: "feat: add range support", expectBump: bump.BumpMinor, expectReason: DefaultReasonTitle},
+		{name: "fix title", title: "fix(cli): trim prefix", expectBump: bump.BumpPatch, expectReason: DefaultReasonTitle},
+		{name: "breaking title", title: "feat!: drop legacy flags", expectBump: bump.BumpMajor, expectReason: DefaultReasonTitle},
+		{name: "labels win over title", labels: []string{"semver-patch"}, title: "feat!: drop legacy flags", expectBump: bump.BumpPatch},
+		{name: "unconventional title defaults", labels: []string{"needs-review"}, title: "Update docs", expectBump: bump.BumpPatch, expectReason: DefaultReasonNoSemverLabels, defaulted: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &fakeClient{prID: 17, labels: tc.labels, title: tc.title}
+			svc := NewService(client, labels.NewResolver(labels.Config{}))
+
+			result, err := svc.Resolve(context.Background(), Config{CommitSHA: "abc", InferFromTitle: true})
+			if err != nil {
+				t.Fatalf(resolveErrFormat, err)
+			}
+			if result.Bump != tc.expectBump {
+				t.Fatalf("bump: want %s got %s", tc.expectBump, result.Bump)
+			}
+			if result.DefaultReason != tc.expectReason || result.Defaulted != tc.defaulted {
+				t.Fatalf("reason: want %q (defaulted=%v) got %q (defaulted=%v)", tc.expectReason, tc.defaulted, result.DefaultReason, result.Defaulted)
+			}
+		})
+	}
+}
+
+func TestResolveIgnoresTitleWithoutOptIn(t *testing.T) {
+	t.Parallel()
+
+	client := &fakeClient{prID: 17, title: "feat: add range support", titleErr: errors.New("should not be called")}
+	svc := NewService(client, labels.NewResolver(labels.Config{}))
+
+	result, err := svc.Resolve(context.Background(), Config{CommitSHA: "abc"})
+	if err != nil {
+		t.Fatalf(resolveErrFormat, err)
+	}
+	if result.Bump != bump.BumpPatch || result.DefaultReason != DefaultReasonNoSemverLabels {
+		t.Fatalf("expected patch default, got %+v", result)
+	}
+}
+
+func TestResolveSurfacesTitleErrors(t *testing.T) {
+	t.Parallel()
+
+	boom := errors.New("boom")
+	client := &fakeClient{prID: 17, titleErr: boom}
+	svc := NewService(client, labels.NewResolver(labels.Config{}))
+
+	if _, err := svc.Resolve(context.Background(), Config{CommitSHA: "abc", InferFromTitle: true}); !errors.Is(err, boom) {
+		t.Fatalf("expected title error, got %v", err)
+	}
+}
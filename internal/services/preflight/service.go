@@ -0,0 +1,64 @@
+// Package preflight verifies the ADO token holds the permissions a command needs before it writes.
+package preflight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado"
+)
+
+var (
+	ErrNilClient         = errors.New("preflight service: nil ado client")
+	ErrMissingPermission = errors.New("preflight service: token lacks required permission")
+)
+
+// permissionNames renders permissions the way the Azure DevOps security UI labels them.
+var permissionNames = map[ado.Permission]string{
+	ado.PermissionContribute:            "Code (Contribute)",
+	ado.PermissionForcePush:             "Code (Force push)",
+	ado.PermissionCreateTag:             "Code (Create tag)",
+	ado.PermissionPullRequestContribute: "Code (Contribute to pull requests)",
+}
+
+// Service probes repository permissions without performing writes.
+type Service struct {
+	client ado.Client
+}
+
+// NewService constructs a Service instance.
+func NewService(client ado.Client) Service {
+	return Service{client: client}
+}
+
+// Check evaluates every required permission and returns ErrMissingPermission naming all that are denied.
+func (s Service) Check(ctx context.Context, required ...ado.Permission) error {
+	if s.client == nil {
+		return ErrNilClient
+	}
+
+	var missing []string
+	for _, permission := range required {
+		granted, err := s.client.HasPermission(ctx, permission)
+		if err != nil {
+			return fmt.Errorf("checking %s permission: %w", permission, err)
+		}
+		if !granted {
+			missing = append(missing, describe(permission))
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %s", ErrMissingPermission, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+func describe(permission ado.Permission) string {
+	if name, ok := permissionNames[permission]; ok {
+		return name
+	}
+	return string(permission)
+}
@@ -0,0 +1,69 @@
+package preflight
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado/adotest"
+)
+
+func TestCheckPassesWhenGranted(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	svc := NewService(client)
+
+	if err := svc.Check(context.Background(), ado.PermissionCreateTag, ado.PermissionForcePush); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(client.CheckedPermissions) != 2 {
+		t.Fatalf("expected both permissions to be checked, got %v", client.CheckedPermissions)
+	}
+}
+
+func TestCheckReportsAllMissingPermissions(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	client.Denied = map[ado.Permission]bool{
+		ado.PermissionCreateTag:             true,
+		ado.PermissionPullRequestContribute: true,
+	}
+	svc := NewService(client)
+
+	err := svc.Check(context.Background(), ado.PermissionCreateTag, ado.PermissionContribute, ado.PermissionPullRequestContribute)
+	if !errors.Is(err, ErrMissingPermission) {
+		t.Fatalf("expected ErrMissingPermission, got %v", err)
+	}
+	msg := err.Error()
+	if !strings.Contains(msg, "Code (Create tag)") || !strings.Contains(msg, "Code (Contribute to pull requests)") {
+		t.Fatalf("expected missing permissions to be named, got %q", msg)
+	}
+	if strings.Contains(msg, "Code (Contribute),") {
+		t.Fatalf("granted permission reported as missing: %q", msg)
+	}
+}
+
+func TestCheckSurfacesLookupErrors(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	client.PermissionErr = errors.New("unauthorized")
+	svc := NewService(client)
+
+	err := svc.Check(context.Background(), ado.PermissionCreateTag)
+	if err == nil || errors.Is(err, ErrMissingPermission) {
+		t.Fatalf("expected lookup error, got %v", err)
+	}
+}
+
+func TestCheckNilClient(t *testing.T) {
+	t.Parallel()
+
+	if err := NewService(nil).Check(context.Background()); !errors.Is(err, ErrNilClient) {
+		t.Fatalf("expected ErrNilClient, got %v", err)
+	}
+}
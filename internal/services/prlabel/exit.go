@@ -0,0 +1,42 @@
+package prlabel
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NoopExitCode is the process exit code ExitOnChanged reports for a run that changed nothing.
+// It differs from the exit code 1 of a failed run.
+const NoopExitCode = 3
+
+// ExitPolicy selects how a successful Apply maps to the process exit code.
+type ExitPolicy string
+
+const (
+	// ExitAlwaysZero exits 0 after every successful run.
+	ExitAlwaysZero ExitPolicy = "always-zero"
+	// ExitOnChanged exits 0 only when a label was added and NoopExitCode otherwise, so
+	// pipelines can skip follow-up steps when the pull request was already labeled.
+	ExitOnChanged ExitPolicy = "changed"
+)
+
+// ParseExitPolicy converts a string into an ExitPolicy. Empty values map to ExitAlwaysZero.
+func ParseExitPolicy(value string) (ExitPolicy, error) {
+	switch ExitPolicy(strings.ToLower(strings.TrimSpace(value))) {
+	case "", ExitAlwaysZero:
+		return ExitAlwaysZero, nil
+	case ExitOnChanged:
+		return ExitOnChanged, nil
+	default:
+		return "", fmt.Errorf("invalid exit policy %q", value)
+	}
+}
+
+// ExitCode returns the exit code for a successful result. Skipped, conflicting, and
+// already-labeled pull requests are all no-ops.
+func (p ExitPolicy) ExitCode(result Result) int {
+	if p == ExitOnChanged && !result.LabelAdded {
+		return NoopExitCode
+	}
+	return 0
+}
@@ -0,0 +1,47 @@
+package prlabel
+
+import (
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/labels"
+)
+
+func TestExitPolicyExitCode(t *testing.T) {
+	t.Parallel()
+
+	added := Result{Decision: labels.DecisionAddExpected, LabelAdded: true}
+	present := Result{Decision: labels.DecisionNoop}
+	skipped := Result{SkipReason: "branch matches no prefix"}
+
+	tests := []struct {
+		name   string
+		policy string
+		result Result
+		expect int
+	}{
+		{name: "default label added", result: added, expect: 0},
+		{name: "default no-op", result: present, expect: 0},
+		{name: "always-zero no-op", policy: "always-zero", result: present, expect: 0},
+		{name: "changed label added", policy: "changed", result: added, expect: 0},
+		{name: "changed no-op", policy: "changed", result: present, expect: NoopExitCode},
+		{name: "changed skip", policy: "Changed", result: skipped, expect: NoopExitCode},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			policy, err := ParseExitPolicy(tc.policy)
+			if err != nil {
+				t.Fatalf("parse policy: %v", err)
+			}
+			if got := policy.ExitCode(tc.result); got != tc.expect {
+				t.Fatalf("expected exit code %d, got %d", tc.expect, got)
+			}
+		})
+	}
+
+	if _, err := ParseExitPolicy("never"); err == nil {
+		t.Fatal("expected an error for an unknown policy")
+	}
+}
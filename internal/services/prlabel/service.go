@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado"
 	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/branchmap"
@@ -16,12 +18,29 @@ var (
 	ErrNilClient   = errors.New("prlabel service: nil ado client")
 	ErrInvalidPR   = errors.New("prlabel service: invalid pr id")
 	ErrEmptyBranch = errors.New("prlabel service: empty branch")
+	// ErrUnmatchedBranch is returned under UnmatchedFail when no branch prefix matches.
+	ErrUnmatchedBranch = errors.New("prlabel service: branch matches no configured prefix")
 )
 
+// headsRefPrefix is the ref namespace pipelines prepend to source branch names.
+const headsRefPrefix = "refs/heads/"
+
+// maxSettleRechecks bounds how many times labels are re-listed under Config.LabelSettle while
+// other labelers keep changing them.
+const maxSettleRechecks = 3
+
 // Config captures the inputs required to label a pull request.
 type Config struct {
 	PRID   int
 	Branch string
+	// MatchFullRef disables stripping a leading refs/heads/ before prefix matching.
+	MatchFullRef bool
+	// OnUnmatched decides what happens when the branch matches no prefix; empty behaves as
+	// UnmatchedLabelPatch.
+	OnUnmatched UnmatchedPolicy
+	// LabelSettle, when positive, re-lists the labels after this delay, and again while they
+	// keep changing, so labels added by other automation are seen before deciding.
+	LabelSettle time.Duration
 }
 
 // Result summarizes the decision applied to the pull request.
@@ -33,6 +52,11 @@ type Result struct {
 	ExpectedLabel  string
 	ExistingSemver []string
 	LabelAdded     bool
+	// SkipReason explains why no label was considered when the unmatched policy skipped the PR.
+	SkipReason string
+	// SettleChanges counts the re-listings under Config.LabelSettle that returned a different
+	// label set than the one before.
+	SettleChanges int
 }
 
 // Service drives the PR labeling workflow.
@@ -40,11 +64,12 @@ type Service struct {
 	client   ado.Client
 	branches branchmap.Resolver
 	labels   labels.Resolver
+	wait     func(ctx context.Context, d time.Duration) error
 }
 
 // NewService constructs a Service instance.
 func NewService(client ado.Client, branches branchmap.Resolver, labels labels.Resolver) Service {
-	return Service{client: client, branches: branches, labels: labels}
+	return Service{client: client, branches: branches, labels: labels, wait: sleepContext}
 }
 
 // Apply ensures the expected semver label is present on the pull request.
@@ -60,12 +85,26 @@ func (s Service) Apply(ctx context.Context, cfg Config) (Result, error) {
 		return Result{}, ErrEmptyBranch
 	}
 
+	if !cfg.MatchFullRef {
+		branch = normalizeBranch(branch)
+	}
+
 	bumpIntent, matchedPrefix, matched := s.branches.Resolve(branch)
 	result := Result{Bump: bumpIntent, BranchMatched: matched, MatchedPrefix: matchedPrefix}
+	if !matched {
+		switch cfg.OnUnmatched {
+		case UnmatchedSkip:
+			result.SkipReason = fmt.Sprintf("branch %q matches no configured prefix", branch)
+			return result, nil
+		case UnmatchedFail:
+			return result, fmt.Errorf("%w: %q", ErrUnmatchedBranch, branch)
+		}
+	}
 
-	existing, err := s.client.ListPRLabels(ctx, cfg.PRID)
+	existing, changes, err := s.listSettledLabels(ctx, cfg)
+	result.SettleChanges = changes
 	if err != nil {
-		return result, fmt.Errorf("listing pr labels: %w", err)
+		return result, err
 	}
 
 	decision := s.labels.Decide(existing, bumpIntent)
@@ -84,3 +123,79 @@ func (s Service) Apply(ctx context.Context, cfg Config) (Result, error) {
 
 	return result, nil
 }
+
+// listSettledLabels lists the pull request's labels and, under cfg.LabelSettle, re-lists them
+// until two consecutive listings agree or maxSettleRechecks is reached. The latest listing is
+// returned along with the number of re-listings that changed the set.
+func (s Service) listSettledLabels(ctx context.Context, cfg Config) ([]string, int, error) {
+	existing, err := s.client.ListPRLabels(ctx, cfg.PRID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("listing pr labels: %w", err)
+	}
+	if cfg.LabelSettle <= 0 {
+		return existing, 0, nil
+	}
+
+	wait := s.wait
+	if wait == nil {
+		wait = sleepContext
+	}
+	changes := 0
+	for recheck := 0; recheck < maxSettleRechecks; recheck++ {
+		if err := wait(ctx, cfg.LabelSettle); err != nil {
+			return nil, changes, err
+		}
+		settled, err := s.client.ListPRLabels(ctx, cfg.PRID)
+		if err != nil {
+			return nil, changes, fmt.Errorf("re-listing pr labels: %w", err)
+		}
+		same := sameLabelSet(existing, settled)
+		existing = settled
+		if same {
+			break
+		}
+		changes++
+	}
+	return existing, changes, nil
+}
+
+// sameLabelSet compares label names case-insensitively, ignoring order.
+func sameLabelSet(left, right []string) bool {
+	if len(left) != len(right) {
+		return false
+	}
+	normalize := func(values []string) []string {
+		out := make([]string, len(values))
+		for i, value := range values {
+			out[i] = strings.ToLower(strings.TrimSpace(value))
+		}
+		sort.Strings(out)
+		return out
+	}
+	a, b := normalize(left), normalize(right)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// normalizeBranch strips a leading refs/heads/ so full refs match short prefixes.
+func normalizeBranch(branch string) string {
+	return strings.TrimPrefix(branch, headsRefPrefix)
+}
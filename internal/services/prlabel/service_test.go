@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado"
 	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/branchmap"
@@ -67,6 +68,217 @@ func TestApplyConflictDoesNotAdd(t *testing.T) {
 	}
 }
 
+func TestApplyLabelSettle(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		settle         time.Duration
+		listings       [][]string
+		expectDecision labels.Decision
+		expectLists    int
+		expectChanges  int
+	}{
+		{
+			name:           "settled set reveals conflict",
+			settle:         time.Second,
+			listings:       [][]string{{"needs-review"}, {"needs-review", "semver-major"}},
+			expectDecision: labels.DecisionConflict,
+			expectLists:    3,
+			expectChanges:  1,
+		},
+		{
+			name:           "stable set decides after one recheck",
+			settle:         time.Second,
+			listings:       [][]string{{"semver-minor"}, {"Semver-Minor"}},
+			expectDecision: labels.DecisionNoop,
+			expectLists:    2,
+		},
+		{
+			name:           "disabled decides from first listing",
+			listings:       [][]string{{"needs-review"}, {"semver-major"}},
+			expectDecision: labels.DecisionAddExpected,
+			expectLists:    1,
+		},
+		{
+			name:           "keeps changing stops at the recheck limit",
+			settle:         time.Second,
+			listings:       [][]string{{"a"}, {"b"}, {"c"}, {"d"}, {"semver-minor"}},
+			expectDecision: labels.DecisionAddExpected,
+			expectLists:    1 + maxSettleRechecks,
+			expectChanges:  maxSettleRechecks,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &fakeClient{listings: tc.listings}
+			svc := NewService(client, branchmap.NewResolver(branchmap.DefaultMapping()), labels.NewResolver(labels.Config{}))
+			var waits []time.Duration
+			svc.wait = func(_ context.Context, d time.Duration) error {
+				waits = append(waits, d)
+				return nil
+			}
+
+			result, err := svc.Apply(context.Background(), Config{PRID: 7, Branch: "feature/foo", LabelSettle: tc.settle})
+			if err != nil {
+				t.Fatalf("apply: %v", err)
+			}
+			if result.Decision != tc.expectDecision {
+				t.Fatalf("decision: want %v got %v", tc.expectDecision, result.Decision)
+			}
+			if client.lists != tc.expectLists || len(waits) != tc.expectLists-1 {
+				t.Fatalf("expected %d listings, got %d with waits %v", tc.expectLists, client.lists, waits)
+			}
+			if result.SettleChanges != tc.expectChanges {
+				t.Fatalf("settle changes: want %d got %d", tc.expectChanges, result.SettleChanges)
+			}
+		})
+	}
+}
+
+func TestApplyNormalizesFullRefBranch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		branch        string
+		matchFullRef  bool
+		expectMatched bool
+		expectPrefix  string
+		expectLabel   string
+	}{
+		{
+			name:          "short branch matches prefix",
+			branch:        "feature/foo",
+			expectMatched: true,
+			expectPrefix:  "feature/",
+			expectLabel:   "semver-minor",
+		},
+		{
+			name:          "full ref is stripped before matching",
+			branch:        "refs/heads/feature/foo",
+			expectMatched: true,
+			expectPrefix:  "feature/",
+			expectLabel:   "semver-minor",
+		},
+		{
+			name:          "full ref kept when matching full refs",
+			branch:        "refs/heads/feature/foo",
+			matchFullRef:  true,
+			expectMatched: false,
+			expectLabel:   "semver-patch",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &fakeClient{}
+			svc := NewService(client, branchmap.NewResolver(branchmap.DefaultMapping()), labels.NewResolver(labels.Config{}))
+			result, err := svc.Apply(context.Background(), Config{PRID: 7, Branch: tc.branch, MatchFullRef: tc.matchFullRef})
+			if err != nil {
+				t.Fatalf("apply: %v", err)
+			}
+			if result.BranchMatched != tc.expectMatched || result.MatchedPrefix != tc.expectPrefix {
+				t.Fatalf("expected matched=%t prefix=%q, got matched=%t prefix=%q", tc.expectMatched, tc.expectPrefix, result.BranchMatched, result.MatchedPrefix)
+			}
+			if result.ExpectedLabel != tc.expectLabel {
+				t.Fatalf("expected label %s, got %s", tc.expectLabel, result.ExpectedLabel)
+			}
+		})
+	}
+}
+
+func TestApplyMatchesFullRefPrefixWhenConfigured(t *testing.T) {
+	t.Parallel()
+
+	client := &fakeClient{}
+	branches := branchmap.NewResolver(branchmap.Mapping{MajorPrefixes: []string{"refs/heads/release/"}})
+	svc := NewService(client, branches, labels.NewResolver(labels.Config{}))
+
+	result, err := svc.Apply(context.Background(), Config{PRID: 3, Branch: "refs/heads/release/2.0", MatchFullRef: true})
+	if err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if !result.BranchMatched || result.MatchedPrefix != "refs/heads/release/" {
+		t.Fatalf("expected full-ref prefix match, got %+v", result)
+	}
+}
+
+func TestApplyUnmatchedPolicies(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		policy      UnmatchedPolicy
+		branch      string
+		expectErr   bool
+		expectAdded string
+		expectSkip  bool
+	}{
+		{name: "default labels patch", branch: "docs/readme", expectAdded: "semver-patch"},
+		{name: "label-patch labels patch", policy: UnmatchedLabelPatch, branch: "docs/readme", expectAdded: "semver-patch"},
+		{name: "skip leaves pr unlabeled", policy: UnmatchedSkip, branch: "docs/readme", expectSkip: true},
+		{name: "fail errors", policy: UnmatchedFail, branch: "docs/readme", expectErr: true},
+		{name: "matched branch ignores skip", policy: UnmatchedSkip, branch: "feature/x", expectAdded: "semver-minor"},
+		{name: "matched branch ignores fail", policy: UnmatchedFail, branch: "fix/x", expectAdded: "semver-patch"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &fakeClient{}
+			svc := NewService(client, branchmap.NewResolver(branchmap.DefaultMapping()), labels.NewResolver(labels.Config{}))
+
+			result, err := svc.Apply(context.Background(), Config{PRID: 9, Branch: tc.branch, OnUnmatched: tc.policy})
+			if tc.expectErr {
+				if !errors.Is(err, ErrUnmatchedBranch) {
+					t.Fatalf("expected ErrUnmatchedBranch, got %v", err)
+				}
+				if len(client.added) != 0 {
+					t.Fatalf("expected no label on failure, got %#v", client.added)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("apply: %v", err)
+			}
+			if (result.SkipReason != "") != tc.expectSkip {
+				t.Fatalf("skip reason: expected skip=%v, got %q", tc.expectSkip, result.SkipReason)
+			}
+			if tc.expectSkip {
+				if len(client.added) != 0 || result.LabelAdded {
+					t.Fatalf("expected no label when skipped, got %#v", client.added)
+				}
+				return
+			}
+			if len(client.added) != 1 || client.added[0].label != tc.expectAdded {
+				t.Fatalf("expected %s to be added, got %#v", tc.expectAdded, client.added)
+			}
+		})
+	}
+}
+
+func TestParseUnmatchedPolicy(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]UnmatchedPolicy{"": UnmatchedLabelPatch, "label-patch": UnmatchedLabelPatch, " Skip ": UnmatchedSkip, "FAIL": UnmatchedFail}
+	for input, want := range cases {
+		got, err := ParseUnmatchedPolicy(input)
+		if err != nil || got != want {
+			t.Fatalf("ParseUnmatchedPolicy(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+	if _, err := ParseUnmatchedPolicy("label-minor"); err == nil {
+		t.Fatal("expected error for unknown policy")
+	}
+}
+
 func TestApplyValidations(t *testing.T) {
 	t.Parallel()
 
@@ -102,10 +314,14 @@ func TestApplyClientErrors(t *testing.T) {
 }
 
 type fakeClient struct {
-	labels  []string
-	listErr error
-	addErr  error
-	added   []addedCall
+	ado.Client
+	labels []string
+	// listings, when set, is served by successive ListPRLabels calls; the last entry repeats.
+	listings [][]string
+	lists    int
+	listErr  error
+	addErr   error
+	added    []addedCall
 }
 
 type addedCall struct {
@@ -113,14 +329,18 @@ type addedCall struct {
 	label string
 }
 
-func (f *fakeClient) ListRefsWithPrefix(context.Context, string) ([]ado.Ref, error) {
-	return nil, nil
-}
-
 func (f *fakeClient) ListPRLabels(context.Context, int) ([]string, error) {
 	if f.listErr != nil {
 		return nil, f.listErr
 	}
+	f.lists++
+	if len(f.listings) > 0 {
+		index := f.lists - 1
+		if index >= len(f.listings) {
+			index = len(f.listings) - 1
+		}
+		return append([]string(nil), f.listings[index]...), nil
+	}
 	if len(f.labels) == 0 {
 		return nil, nil
 	}
@@ -136,15 +356,3 @@ func (f *fakeClient) AddPRLabel(_ context.Context, prID int, label string) error
 	f.added = append(f.added, addedCall{prID: prID, label: label})
 	return nil
 }
-
-func (f *fakeClient) FindPullRequestByMergeCommit(context.Context, string) (int, error) {
-	return 0, ado.ErrPullRequestNotFound
-}
-
-func (f *fakeClient) CreateAnnotatedTag(context.Context, ado.TagSpec) error {
-	return nil
-}
-
-func (f *fakeClient) DeleteRef(context.Context, string, string) error {
-	return nil
-}
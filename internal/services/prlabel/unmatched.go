@@ -0,0 +1,33 @@
+package prlabel
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UnmatchedPolicy selects what Apply does when the branch matches no configured prefix.
+type UnmatchedPolicy string
+
+const (
+	// UnmatchedLabelPatch labels the pull request with the patch label.
+	UnmatchedLabelPatch UnmatchedPolicy = "label-patch"
+	// UnmatchedSkip leaves the pull request unlabeled and records why.
+	UnmatchedSkip UnmatchedPolicy = "skip"
+	// UnmatchedFail returns ErrUnmatchedBranch.
+	UnmatchedFail UnmatchedPolicy = "fail"
+)
+
+// ParseUnmatchedPolicy converts a string into an UnmatchedPolicy. Empty values map to
+// UnmatchedLabelPatch.
+func ParseUnmatchedPolicy(value string) (UnmatchedPolicy, error) {
+	switch UnmatchedPolicy(strings.ToLower(strings.TrimSpace(value))) {
+	case "", UnmatchedLabelPatch:
+		return UnmatchedLabelPatch, nil
+	case UnmatchedSkip:
+		return UnmatchedSkip, nil
+	case UnmatchedFail:
+		return UnmatchedFail, nil
+	default:
+		return "", fmt.Errorf("invalid unmatched branch policy %q", value)
+	}
+}
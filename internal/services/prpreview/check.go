@@ -0,0 +1,131 @@
+package prpreview
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/labels"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/tagging"
+)
+
+// LabelStatus describes the pull request's semver labels relative to the label its branch
+// implies.
+type LabelStatus string
+
+const (
+	// LabelPresent means the expected label is already on the pull request.
+	LabelPresent LabelStatus = "present"
+	// LabelMissing means no semver label is present; pr-label would add the expected one.
+	LabelMissing LabelStatus = "missing"
+	// LabelConflict means other semver labels are present; pr-label would warn and leave them.
+	LabelConflict LabelStatus = "conflict"
+)
+
+// CheckConfig captures the inputs of a combined pr-label and infer-bump preview.
+type CheckConfig struct {
+	PRID   int
+	Branch string
+	// MatchFullRef disables stripping a leading refs/heads/ before prefix matching.
+	MatchFullRef bool
+	// WithVersion also plans the release version the merge would produce.
+	WithVersion bool
+}
+
+// Check reports what pr-label would decide for a pull request and the bump it would carry on
+// merge, without writing labels, comments, or refs.
+type Check struct {
+	PRID   int
+	Branch string
+	// BranchBump is the bump the source branch implies; BranchMatched is false when no prefix
+	// matched and the patch default applied.
+	BranchBump    bump.Bump
+	BranchMatched bool
+	MatchedPrefix string
+	ExpectedLabel string
+	LabelStatus   LabelStatus
+	SemverLabels  []string
+	// Bump and Source are the bump the merge would carry: semver labels win over the branch.
+	Bump   bump.Bump
+	Source BumpSource
+	// Plan is the planned release when CheckConfig.WithVersion is set, and nil otherwise.
+	Plan *tagplan.Result
+}
+
+// Check combines pr-label's label decision with the bump and, optionally, the release version
+// the pull request would produce once merged.
+func (s Service) Check(ctx context.Context, cfg CheckConfig) (Check, error) {
+	if s.client == nil {
+		return Check{}, ErrNilClient
+	}
+	if cfg.PRID <= 0 {
+		return Check{}, ErrInvalidPR
+	}
+	branch := strings.TrimSpace(cfg.Branch)
+	if branch == "" {
+		return Check{}, ErrEmptyBranch
+	}
+	if !cfg.MatchFullRef {
+		branch = strings.TrimPrefix(branch, headsRefPrefix)
+	}
+
+	prLabels, err := s.client.ListPRLabels(ctx, cfg.PRID)
+	if err != nil {
+		return Check{}, fmt.Errorf("listing pr labels: %w", err)
+	}
+
+	branchBump, prefix, matched := s.branches.Resolve(branch)
+	decision := s.labels.Decide(prLabels, branchBump)
+	check := Check{
+		PRID:          cfg.PRID,
+		Branch:        branch,
+		BranchBump:    branchBump,
+		BranchMatched: matched,
+		MatchedPrefix: prefix,
+		ExpectedLabel: decision.ExpectedLabel,
+		LabelStatus:   labelStatus(decision.Decision),
+		Bump:          branchBump,
+		Source:        BumpSourceBranch,
+	}
+	if len(decision.Existing) > 0 {
+		check.SemverLabels = append([]string(nil), decision.Existing...)
+	}
+
+	var candidates []bump.Bump
+	for _, lbl := range check.SemverLabels {
+		if b, ok := s.labels.BumpForLabel(lbl); ok {
+			candidates = append(candidates, b)
+		}
+	}
+	switch {
+	case len(candidates) > 0:
+		check.Bump, check.Source = bump.Max(candidates...), BumpSourceLabels
+	case !matched:
+		check.Source = BumpSourceDefault
+	}
+
+	if cfg.WithVersion {
+		plan, err := tagging.NewService(s.client, s.planner).Plan(ctx, tagging.Config{
+			Mode: tagplan.ModeRelease,
+			Bump: check.Bump,
+		})
+		if err != nil {
+			return Check{}, fmt.Errorf("planning release: %w", err)
+		}
+		check.Plan = &plan
+	}
+	return check, nil
+}
+
+func labelStatus(decision labels.Decision) LabelStatus {
+	switch decision {
+	case labels.DecisionNoop:
+		return LabelPresent
+	case labels.DecisionConflict:
+		return LabelConflict
+	default:
+		return LabelMissing
+	}
+}
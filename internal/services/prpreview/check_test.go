@@ -0,0 +1,92 @@
+package prpreview
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado/adotest"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+)
+
+func TestCheckReportsLabelDecision(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		branch        string
+		prLabels      []string
+		expectBranch  bump.Bump
+		expectMatched bool
+		expectLabel   string
+		expectStatus  LabelStatus
+		expectBump    bump.Bump
+		expectSource  BumpSource
+	}{
+		{name: "matched branch without labels", branch: "refs/heads/feature/login", expectBranch: bump.BumpMinor, expectMatched: true, expectLabel: "semver-minor", expectStatus: LabelMissing, expectBump: bump.BumpMinor, expectSource: BumpSourceBranch},
+		{name: "matched branch with expected label", branch: "fix/typo", prLabels: []string{"docs", "semver-patch"}, expectBranch: bump.BumpPatch, expectMatched: true, expectLabel: "semver-patch", expectStatus: LabelPresent, expectBump: bump.BumpPatch, expectSource: BumpSourceLabels},
+		{name: "conflicting label overrides branch", branch: "fix/typo", prLabels: []string{"semver-major"}, expectBranch: bump.BumpPatch, expectMatched: true, expectLabel: "semver-patch", expectStatus: LabelConflict, expectBump: bump.BumpMajor, expectSource: BumpSourceLabels},
+		{name: "no-match branch defaults to patch", branch: "spike/idea", expectBranch: bump.BumpPatch, expectLabel: "semver-patch", expectStatus: LabelMissing, expectBump: bump.BumpPatch, expectSource: BumpSourceDefault},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := adotest.NewClient()
+			client.SeedAnnotatedTag("v1.2.3", "release-tag-object", "c123")
+			client.PRLabels = map[int][]string{samplePR: tc.prLabels}
+
+			check, err := newTestService(client).Check(context.Background(), CheckConfig{PRID: samplePR, Branch: tc.branch})
+			if err != nil {
+				t.Fatalf("check: %v", err)
+			}
+			if check.BranchBump != tc.expectBranch || check.BranchMatched != tc.expectMatched {
+				t.Fatalf("expected branch %s matched=%t, got %s matched=%t", tc.expectBranch, tc.expectMatched, check.BranchBump, check.BranchMatched)
+			}
+			if check.ExpectedLabel != tc.expectLabel || check.LabelStatus != tc.expectStatus {
+				t.Fatalf("expected %s %s, got %s %s", tc.expectLabel, tc.expectStatus, check.ExpectedLabel, check.LabelStatus)
+			}
+			if check.Bump != tc.expectBump || check.Source != tc.expectSource {
+				t.Fatalf("expected %s from %s, got %s from %s", tc.expectBump, tc.expectSource, check.Bump, check.Source)
+			}
+			if check.Plan != nil {
+				t.Fatalf("expected no plan without WithVersion, got %+v", check.Plan)
+			}
+			if len(client.Comments) != 0 || len(client.CreatedTags) != 0 {
+				t.Fatalf("expected a read-only check, got comments=%d tags=%d", len(client.Comments), len(client.CreatedTags))
+			}
+		})
+	}
+}
+
+func TestCheckWithVersionPlansRelease(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	client.SeedAnnotatedTag("v1.2.3", "release-tag-object", "c123")
+	client.PRLabels = map[int][]string{samplePR: {"semver-minor"}}
+
+	check, err := newTestService(client).Check(context.Background(), CheckConfig{PRID: samplePR, Branch: "fix/typo", WithVersion: true})
+	if err != nil {
+		t.Fatalf("check: %v", err)
+	}
+	if check.Plan == nil || check.Plan.TagName != "v1.3.0" {
+		t.Fatalf("expected v1.3.0 from the label bump, got %+v", check.Plan)
+	}
+	if len(client.CreatedTags) != 0 {
+		t.Fatalf("expected no tags created, got %+v", client.CreatedTags)
+	}
+}
+
+func TestCheckValidatesInput(t *testing.T) {
+	t.Parallel()
+
+	svc := newTestService(adotest.NewClient())
+	if _, err := svc.Check(context.Background(), CheckConfig{Branch: "fix/typo"}); !errors.Is(err, ErrInvalidPR) {
+		t.Fatalf("expected ErrInvalidPR, got %v", err)
+	}
+	if _, err := svc.Check(context.Background(), CheckConfig{PRID: samplePR}); !errors.Is(err, ErrEmptyBranch) {
+		t.Fatalf("expected ErrEmptyBranch, got %v", err)
+	}
+}
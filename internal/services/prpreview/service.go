@@ -0,0 +1,178 @@
+package prpreview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/branchmap"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/labels"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/services/tagging"
+)
+
+var (
+	ErrNilClient   = errors.New("prpreview service: nil ado client")
+	ErrInvalidPR   = errors.New("prpreview service: invalid pr id")
+	ErrEmptyBranch = errors.New("prpreview service: empty branch")
+)
+
+// headsRefPrefix is the ref namespace pipelines prepend to source branch names.
+const headsRefPrefix = "refs/heads/"
+
+// BumpSource identifies what decided the previewed bump.
+type BumpSource string
+
+const (
+	// BumpSourceLabels means semver labels on the pull request decided the bump.
+	BumpSourceLabels BumpSource = "labels"
+	// BumpSourceBranch means the source branch prefix decided the bump.
+	BumpSourceBranch BumpSource = "branch"
+	// BumpSourceDefault means neither matched and the patch default applied.
+	BumpSourceDefault BumpSource = "default"
+)
+
+// Config captures the inputs required to preview a pull request's release.
+type Config struct {
+	PRID   int
+	Branch string
+	// MatchFullRef disables stripping a leading refs/heads/ before prefix matching.
+	MatchFullRef bool
+	// Post comments the preview on the pull request. Comment failures are reported on the
+	// result rather than failing the preview.
+	Post bool
+	// Operator, when set, is credited at the end of the comment for auditing.
+	Operator string
+}
+
+// Result describes the release a pull request would produce once merged.
+type Result struct {
+	PRID          int
+	Bump          bump.Bump
+	Source        BumpSource
+	MatchedPrefix string
+	SemverLabels  []string
+	Plan          tagplan.Result
+	Comment       string
+	Posted        bool
+	// PostError explains why the comment could not be posted when Post was requested.
+	PostError string
+}
+
+// Service previews the next release for an open pull request without writing any refs.
+type Service struct {
+	client   ado.Client
+	branches branchmap.Resolver
+	labels   labels.Resolver
+	planner  tagplan.Planner
+}
+
+// NewService constructs a Service instance.
+func NewService(client ado.Client, branches branchmap.Resolver, labels labels.Resolver, planner tagplan.Planner) Service {
+	return Service{client: client, branches: branches, labels: labels, planner: planner}
+}
+
+// Preview resolves the pull request's bump and plans the release it would produce. Semver
+// labels win over the branch prefix, since they are how reviewers override the branch; the
+// highest label applies when several are present.
+func (s Service) Preview(ctx context.Context, cfg Config) (Result, error) {
+	if s.client == nil {
+		return Result{}, ErrNilClient
+	}
+	if cfg.PRID <= 0 {
+		return Result{}, ErrInvalidPR
+	}
+	branch := strings.TrimSpace(cfg.Branch)
+	if branch == "" {
+		return Result{}, ErrEmptyBranch
+	}
+	if !cfg.MatchFullRef {
+		branch = strings.TrimPrefix(branch, headsRefPrefix)
+	}
+
+	result := Result{PRID: cfg.PRID}
+	if err := s.resolveBump(ctx, branch, &result); err != nil {
+		return Result{}, err
+	}
+
+	plan, err := tagging.NewService(s.client, s.planner).Plan(ctx, tagging.Config{
+		Mode: tagplan.ModeRelease,
+		Bump: result.Bump,
+	})
+	if err != nil {
+		return Result{}, fmt.Errorf("planning release: %w", err)
+	}
+	result.Plan = plan
+	result.Comment = comment(result)
+	if operator := strings.TrimSpace(cfg.Operator); operator != "" {
+		result.Comment += fmt.Sprintf(" (Requested by %s.)", operator)
+	}
+
+	if cfg.Post {
+		if err := s.client.AddPRComment(ctx, cfg.PRID, result.Comment); err != nil {
+			result.PostError = err.Error()
+		} else {
+			result.Posted = true
+		}
+	}
+	return result, nil
+}
+
+func (s Service) resolveBump(ctx context.Context, branch string, result *Result) error {
+	prLabels, err := s.client.ListPRLabels(ctx, result.PRID)
+	if err != nil {
+		return fmt.Errorf("listing pr labels: %w", err)
+	}
+
+	var candidates []bump.Bump
+	for _, lbl := range prLabels {
+		if b, ok := s.labels.BumpForLabel(lbl); ok {
+			result.SemverLabels = append(result.SemverLabels, lbl)
+			candidates = append(candidates, b)
+		}
+	}
+	if len(candidates) > 0 {
+		result.Bump, result.Source = bump.Max(candidates...), BumpSourceLabels
+		return nil
+	}
+
+	intent, prefix, matched := s.branches.Resolve(branch)
+	result.Bump, result.MatchedPrefix = intent, prefix
+	result.Source = BumpSourceBranch
+	if !matched {
+		result.Source = BumpSourceDefault
+	}
+	return nil
+}
+
+// comment renders the preview posted to the pull request, e.g.
+// "Merging this pull request will produce v1.3.0 (minor bump from v1.2.5, via label semver-minor)."
+// A none bump says no release will be produced instead.
+func comment(result Result) string {
+	from := "with no previous release"
+	if result.Plan.BaseSource != tagplan.BaseSourceZero {
+		from = "from " + strings.TrimPrefix(result.Plan.BaseTag.Name, "refs/tags/")
+		if result.Plan.BaseTag.Name == "" {
+			from = "from " + result.Plan.ReleaseBase.String()
+		}
+	}
+
+	var via string
+	switch result.Source {
+	case BumpSourceLabels:
+		via = "label " + strings.Join(result.SemverLabels, ", ")
+	case BumpSourceBranch:
+		via = "branch prefix " + result.MatchedPrefix
+	default:
+		via = "default; no label or branch prefix matched"
+	}
+
+	if result.Bump == bump.BumpNone {
+		return fmt.Sprintf("Merging this pull request will not produce a release (%s bump, via %s).", result.Bump, via)
+	}
+	return fmt.Sprintf("Merging this pull request will produce %s (%s bump %s, via %s).",
+		result.Plan.TagName, result.Bump, from, via)
+}
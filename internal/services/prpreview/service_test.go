@@ -0,0 +1,154 @@
+package prpreview
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado/adotest"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/branchmap"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/labels"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+const samplePR = 42
+
+func newTestService(client *adotest.Client) Service {
+	return NewService(client, branchmap.NewResolver(branchmap.DefaultMapping()), labels.NewResolver(labels.Config{}), tagplan.NewPlanner("v"))
+}
+
+func TestPreviewResolvesBump(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		branch       string
+		prLabels     []string
+		expectBump   bump.Bump
+		expectSource BumpSource
+		expectTag    string
+		expectVia    string
+	}{
+		{name: "branch prefix", branch: "refs/heads/feature/login", expectBump: bump.BumpMinor, expectSource: BumpSourceBranch, expectTag: "v1.3.0", expectVia: "via branch prefix feature/"},
+		{name: "labels override branch", branch: "feature/login", prLabels: []string{"docs", "semver-major"}, expectBump: bump.BumpMajor, expectSource: BumpSourceLabels, expectTag: "v2.0.0", expectVia: "via label semver-major"},
+		{name: "conflicting labels take the highest", branch: "fix/typo", prLabels: []string{"semver-patch", "semver-minor"}, expectBump: bump.BumpMinor, expectSource: BumpSourceLabels, expectTag: "v1.3.0"},
+		{name: "unmatched branch defaults to patch", branch: "spike/idea", expectBump: bump.BumpPatch, expectSource: BumpSourceDefault, expectTag: "v1.2.4", expectVia: "via default"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := adotest.NewClient()
+			client.SeedAnnotatedTag("v1.2.3", "release-tag-object", "c123")
+			client.PRLabels = map[int][]string{samplePR: tc.prLabels}
+
+			result, err := newTestService(client).Preview(context.Background(), Config{PRID: samplePR, Branch: tc.branch})
+			if err != nil {
+				t.Fatalf("preview: %v", err)
+			}
+			if result.Bump != tc.expectBump || result.Source != tc.expectSource {
+				t.Fatalf("expected %s from %s, got %s from %s", tc.expectBump, tc.expectSource, result.Bump, result.Source)
+			}
+			if result.Plan.TagName != tc.expectTag {
+				t.Fatalf("expected %s, got %s", tc.expectTag, result.Plan.TagName)
+			}
+			if !strings.HasPrefix(result.Comment, "Merging this pull request will produce "+tc.expectTag+" ") || !strings.Contains(result.Comment, tc.expectVia) {
+				t.Fatalf("unexpected comment %q", result.Comment)
+			}
+			if result.Posted || len(client.Comments) != 0 || len(client.CreatedTags) != 0 {
+				t.Fatalf("expected a read-only preview, got posted=%v comments=%d tags=%d", result.Posted, len(client.Comments), len(client.CreatedTags))
+			}
+		})
+	}
+}
+
+func TestPreviewPostsComment(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	client.SeedAnnotatedTag("v1.2.3", "release-tag-object", "c123")
+
+	result, err := newTestService(client).Preview(context.Background(), Config{PRID: samplePR, Branch: "fix/typo", Post: true})
+	if err != nil {
+		t.Fatalf("preview: %v", err)
+	}
+	if !result.Posted || len(client.Comments) != 1 {
+		t.Fatalf("expected one comment, got posted=%v comments=%+v", result.Posted, client.Comments)
+	}
+	if got := client.Comments[0]; got.PRID != samplePR || got.Content != result.Comment {
+		t.Fatalf("unexpected comment %+v", got)
+	}
+	if want := "Merging this pull request will produce v1.2.4 (patch bump from v1.2.3, via branch prefix fix/)."; result.Comment != want {
+		t.Fatalf("expected %q, got %q", want, result.Comment)
+	}
+}
+
+func TestPreviewNoneLabelProducesNoRelease(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	client.SeedAnnotatedTag("v1.2.3", "release-tag-object", "c123")
+	client.PRLabels = map[int][]string{samplePR: {"semver-none"}}
+
+	result, err := newTestService(client).Preview(context.Background(), Config{PRID: samplePR, Branch: "feature/ci"})
+	if err != nil {
+		t.Fatalf("preview: %v", err)
+	}
+	if result.Bump != bump.BumpNone || !result.Plan.Skipped {
+		t.Fatalf("expected a skipped none plan, got %s skipped=%v", result.Bump, result.Plan.Skipped)
+	}
+	if want := "Merging this pull request will not produce a release (none bump, via label semver-none)."; result.Comment != want {
+		t.Fatalf("expected %q, got %q", want, result.Comment)
+	}
+}
+
+func TestPreviewCommentCreditsOperator(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	client.SeedAnnotatedTag("v1.2.3", "release-tag-object", "c123")
+
+	result, err := newTestService(client).Preview(context.Background(), Config{PRID: samplePR, Branch: "fix/typo", Operator: "octocat"})
+	if err != nil {
+		t.Fatalf("preview: %v", err)
+	}
+	if !strings.HasSuffix(result.Comment, "via branch prefix fix/). (Requested by octocat.)") {
+		t.Fatalf("expected the operator credited, got %q", result.Comment)
+	}
+}
+
+func TestPreviewDegradesOnCommentFailure(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	client.CommentErr = errors.New("forbidden")
+
+	result, err := newTestService(client).Preview(context.Background(), Config{PRID: samplePR, Branch: "feature/x", Post: true})
+	if err != nil {
+		t.Fatalf("expected the preview to survive a comment failure, got %v", err)
+	}
+	if result.Posted || result.PostError != "forbidden" {
+		t.Fatalf("expected post error recorded, got posted=%v error=%q", result.Posted, result.PostError)
+	}
+	if result.Plan.TagName != "v0.1.0" || !strings.Contains(result.Comment, "with no previous release") {
+		t.Fatalf("unexpected plan %s / comment %q", result.Plan.TagName, result.Comment)
+	}
+}
+
+func TestPreviewValidatesInput(t *testing.T) {
+	t.Parallel()
+
+	svc := newTestService(adotest.NewClient())
+	if _, err := svc.Preview(context.Background(), Config{Branch: "feature/x"}); !errors.Is(err, ErrInvalidPR) {
+		t.Fatalf("expected ErrInvalidPR, got %v", err)
+	}
+	if _, err := svc.Preview(context.Background(), Config{PRID: samplePR, Branch: " "}); !errors.Is(err, ErrEmptyBranch) {
+		t.Fatalf("expected ErrEmptyBranch, got %v", err)
+	}
+	if _, err := NewService(nil, branchmap.Resolver{}, labels.Resolver{}, tagplan.Planner{}).Preview(context.Background(), Config{}); !errors.Is(err, ErrNilClient) {
+		t.Fatalf("expected ErrNilClient, got %v", err)
+	}
+}
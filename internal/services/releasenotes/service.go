@@ -0,0 +1,114 @@
+// Package releasenotes collects the commits included in a release and groups them by bump intent.
+package releasenotes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/labels"
+)
+
+var (
+	ErrNilClient  = errors.New("releasenotes service: nil ado client")
+	ErrEmptyRange = errors.New("releasenotes service: commit range is empty")
+)
+
+// groupOrder lists bump groups from highest impact to lowest; unlabelled commits come last.
+var groupOrder = []bump.Bump{bump.BumpMajor, bump.BumpMinor, bump.BumpPatch, bump.BumpNone, ""}
+
+// Config identifies the commit range to collect.
+type Config struct {
+	FromCommit string
+	ToCommit   string
+}
+
+// Entry is a single commit in the release.
+type Entry struct {
+	Commit  string `json:"commit"`
+	Subject string `json:"subject"`
+	PRID    int    `json:"prId,omitempty"`
+}
+
+// Group collects commits sharing the bump inferred from their pull request labels. An empty
+// Bump holds commits without a pull request or semver label.
+type Group struct {
+	Bump    bump.Bump `json:"bump,omitempty"`
+	Commits []Entry   `json:"commits"`
+}
+
+// Result is the grouped commit list for a release.
+type Result struct {
+	FromCommit string  `json:"fromCommit"`
+	ToCommit   string  `json:"toCommit"`
+	Groups     []Group `json:"groups"`
+}
+
+// Service lists commits between two points and classifies them through PR labels.
+type Service struct {
+	client ado.Client
+	labels labels.Resolver
+}
+
+// NewService constructs a Service instance.
+func NewService(client ado.Client, labels labels.Resolver) Service {
+	return Service{client: client, labels: labels}
+}
+
+// Collect lists the commits in the range and groups them by bump. Pull request lookups that
+// fail leave the commit unlabelled rather than failing the collection.
+func (s Service) Collect(ctx context.Context, cfg Config) (Result, error) {
+	if s.client == nil {
+		return Result{}, ErrNilClient
+	}
+
+	from := strings.TrimSpace(cfg.FromCommit)
+	to := strings.TrimSpace(cfg.ToCommit)
+	if from == "" || to == "" {
+		return Result{}, ErrEmptyRange
+	}
+
+	commits, err := s.client.ListCommitsBetween(ctx, from, to)
+	if err != nil {
+		return Result{}, fmt.Errorf("listing commits between %s and %s: %w", from, to, err)
+	}
+
+	grouped := make(map[bump.Bump][]Entry, len(groupOrder))
+	for _, commit := range commits {
+		entry := Entry{Commit: commit.ID, Subject: commit.Subject}
+		b := bump.Bump("")
+		if prID, err := s.client.FindPullRequestByMergeCommit(ctx, commit.ID); err == nil {
+			entry.PRID = prID
+			b = s.bumpForPR(ctx, prID)
+		}
+		grouped[b] = append(grouped[b], entry)
+	}
+
+	result := Result{FromCommit: from, ToCommit: to, Groups: []Group{}}
+	for _, b := range groupOrder {
+		if entries := grouped[b]; len(entries) > 0 {
+			result.Groups = append(result.Groups, Group{Bump: b, Commits: entries})
+		}
+	}
+	return result, nil
+}
+
+func (s Service) bumpForPR(ctx context.Context, prID int) bump.Bump {
+	prLabels, err := s.client.ListPRLabels(ctx, prID)
+	if err != nil {
+		return ""
+	}
+	var candidates []bump.Bump
+	for _, lbl := range prLabels {
+		if b, ok := s.labels.BumpForLabel(lbl); ok {
+			candidates = append(candidates, b)
+		}
+	}
+	if len(candidates) == 0 {
+		return ""
+	}
+	return bump.Max(candidates...)
+}
@@ -0,0 +1,134 @@
+package releasenotes
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/labels"
+)
+
+func TestCollectGroupsCommitsByBump(t *testing.T) {
+	t.Parallel()
+
+	client := &fakeClient{
+		commits: []ado.Commit{
+			{ID: "c4", Subject: "Merged PR 4: fix typo"},
+			{ID: "c3", Subject: "Merged PR 3: new api"},
+			{ID: "c2", Subject: "direct push"},
+			{ID: "c1", Subject: "Merged PR 1: breaking"},
+		},
+		prs: map[string]int{"c4": 4, "c3": 3, "c1": 1},
+		labels: map[int][]string{
+			4: {"semver-patch"},
+			3: {"semver-minor", "docs"},
+			1: {"semver-major"},
+		},
+	}
+	svc := NewService(client, labels.NewResolver(labels.Config{}))
+
+	result, err := svc.Collect(context.Background(), Config{FromCommit: "base", ToCommit: "c4"})
+	if err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+
+	want := []struct {
+		bump    bump.Bump
+		commits []string
+	}{
+		{bump.BumpMajor, []string{"c1"}},
+		{bump.BumpMinor, []string{"c3"}},
+		{bump.BumpPatch, []string{"c4"}},
+		{"", []string{"c2"}},
+	}
+	if len(result.Groups) != len(want) {
+		t.Fatalf("expected %d groups, got %+v", len(want), result.Groups)
+	}
+	for i, w := range want {
+		group := result.Groups[i]
+		if group.Bump != w.bump || len(group.Commits) != len(w.commits) || group.Commits[0].Commit != w.commits[0] {
+			t.Fatalf("group %d: expected %v %v, got %+v", i, w.bump, w.commits, group)
+		}
+	}
+	if result.Groups[0].Commits[0].PRID != 1 {
+		t.Fatalf("expected pr id to be recorded, got %+v", result.Groups[0].Commits[0])
+	}
+}
+
+func TestCollectTreatsLabelFailuresAsUnlabelled(t *testing.T) {
+	t.Parallel()
+
+	client := &fakeClient{
+		commits:   []ado.Commit{{ID: "c1", Subject: "change"}},
+		prs:       map[string]int{"c1": 1},
+		labelsErr: errors.New("boom"),
+	}
+	svc := NewService(client, labels.NewResolver(labels.Config{}))
+
+	result, err := svc.Collect(context.Background(), Config{FromCommit: "base", ToCommit: "c1"})
+	if err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	if len(result.Groups) != 1 || result.Groups[0].Bump != "" || result.Groups[0].Commits[0].PRID != 1 {
+		t.Fatalf("expected a single unlabelled group, got %+v", result.Groups)
+	}
+}
+
+func TestCollectErrors(t *testing.T) {
+	t.Parallel()
+
+	resolver := labels.NewResolver(labels.Config{})
+
+	if _, err := NewService(nil, resolver).Collect(context.Background(), Config{FromCommit: "a", ToCommit: "b"}); !errors.Is(err, ErrNilClient) {
+		t.Fatalf("expected ErrNilClient, got %v", err)
+	}
+	if _, err := NewService(&fakeClient{}, resolver).Collect(context.Background(), Config{ToCommit: "b"}); !errors.Is(err, ErrEmptyRange) {
+		t.Fatalf("expected ErrEmptyRange, got %v", err)
+	}
+
+	listErr := errors.New("list failed")
+	_, err := NewService(&fakeClient{commitsErr: listErr}, resolver).Collect(context.Background(), Config{FromCommit: "a", ToCommit: "b"})
+	if !errors.Is(err, listErr) {
+		t.Fatalf("expected list error, got %v", err)
+	}
+}
+
+type fakeClient struct {
+	ado.Client
+	commits    []ado.Commit
+	commitsErr error
+	prs        map[string]int
+	labels     map[int][]string
+	labelsErr  error
+}
+
+func (f *fakeClient) FindPullRequestByMergeCommit(_ context.Context, commit string) (int, error) {
+	if prID, ok := f.prs[commit]; ok {
+		return prID, nil
+	}
+	return 0, ado.ErrPullRequestNotFound
+}
+
+func (f *fakeClient) FindPullRequestsByMergeCommit(ctx context.Context, commit string) ([]int, error) {
+	prID, err := f.FindPullRequestByMergeCommit(ctx, commit)
+	if err != nil {
+		return nil, err
+	}
+	return []int{prID}, nil
+}
+
+func (f *fakeClient) ListPRLabels(_ context.Context, prID int) ([]string, error) {
+	if f.labelsErr != nil {
+		return nil, f.labelsErr
+	}
+	return f.labels[prID], nil
+}
+
+func (f *fakeClient) ListCommitsBetween(context.Context, string, string) ([]ado.Commit, error) {
+	if f.commitsErr != nil {
+		return nil, f.commitsErr
+	}
+	return f.commits, nil
+}
@@ -0,0 +1,86 @@
+package tagging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+// ErrAliasSourceUnresolved is returned when the alias source tag has no commit to point at.
+var ErrAliasSourceUnresolved = errors.New("tagging service: alias source has no commit")
+
+// AliasConfig captures the inputs for tagging an existing release's commit under a new name.
+type AliasConfig struct {
+	// SourceTag is the release tag (or version) whose commit the alias points at.
+	SourceTag string
+	// TagName is the literal name of the alias tag to create.
+	TagName     string
+	Message     string
+	TaggerName  string
+	TaggerEmail string
+	// TagKind selects an annotated or lightweight alias; empty values mean annotated.
+	TagKind TagKind
+}
+
+// PlanAlias resolves the source release's commit and validates the alias name without
+// issuing any mutating ADO calls.
+func (s Service) PlanAlias(ctx context.Context, cfg AliasConfig) (tagplan.AliasPlan, error) {
+	if s.client == nil {
+		return tagplan.AliasPlan{}, ErrNilClient
+	}
+
+	refs, err := s.client.ListRefsWithPrefix(ctx, tagRefPrefix)
+	if err != nil {
+		return tagplan.AliasPlan{}, fmt.Errorf("listing refs: %w", err)
+	}
+
+	plan, err := s.planner.PlanAlias(toPlannerTags(refs), cfg.SourceTag, cfg.TagName)
+	if err != nil {
+		return tagplan.AliasPlan{}, err
+	}
+	if plan.Commit == "" {
+		return tagplan.AliasPlan{}, fmt.Errorf("%w: %s", ErrAliasSourceUnresolved, plan.Source.Name)
+	}
+	return plan, nil
+}
+
+// CreateAlias creates the alias tag at the source release's peeled commit.
+func (s Service) CreateAlias(ctx context.Context, cfg AliasConfig) (tagplan.AliasPlan, error) {
+	plan, err := s.PlanAlias(ctx, cfg)
+	if err != nil {
+		return tagplan.AliasPlan{}, err
+	}
+
+	if cfg.TagKind == TagKindLightweight {
+		if err := s.client.CreateLightweightTag(ctx, plan.TagName, plan.Commit); err != nil {
+			return tagplan.AliasPlan{}, fmt.Errorf("creating lightweight alias tag: %w", err)
+		}
+		return plan, nil
+	}
+
+	taggerName := strings.TrimSpace(cfg.TaggerName)
+	if taggerName == "" {
+		return tagplan.AliasPlan{}, ErrEmptyTagger
+	}
+	taggerEmail := strings.TrimSpace(cfg.TaggerEmail)
+	if taggerEmail == "" {
+		return tagplan.AliasPlan{}, ErrEmptyEmail
+	}
+
+	spec := ado.TagSpec{
+		Name:        plan.TagName,
+		ObjectID:    plan.Commit,
+		ObjectType:  ado.TagObjectTypeCommit,
+		Message:     strings.TrimSpace(cfg.Message),
+		TaggerName:  taggerName,
+		TaggerEmail: taggerEmail,
+	}
+	if err := s.client.CreateAnnotatedTag(ctx, spec); err != nil {
+		return tagplan.AliasPlan{}, fmt.Errorf("creating annotated alias tag: %w", err)
+	}
+	return plan, nil
+}
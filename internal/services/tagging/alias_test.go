@@ -0,0 +1,119 @@
+package tagging
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado/adotest"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+func TestCreateAliasTagsSourceCommit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		kind TagKind
+	}{
+		{name: "annotated", kind: TagKindAnnotated},
+		{name: "lightweight", kind: TagKindLightweight},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := adotest.NewClient()
+			client.SeedAnnotatedTag("v1.2.3", "tag-123", "commit-123")
+			client.SeedAnnotatedTag("v1.2.4", "tag-124", "commit-124")
+			svc := NewService(client, tagplan.NewPlanner("v"))
+
+			plan, err := svc.CreateAlias(context.Background(), AliasConfig{
+				SourceTag:   "v1.2.4",
+				TagName:     "stable",
+				TaggerName:  taggerNameDefault,
+				TaggerEmail: taggerEmailDefault,
+				TagKind:     tc.kind,
+			})
+			if err != nil {
+				t.Fatalf("create alias: %v", err)
+			}
+			if plan.TagName != "stable" || plan.Commit != "commit-124" {
+				t.Fatalf("expected stable at commit-124, got %s at %s", plan.TagName, plan.Commit)
+			}
+			ref, ok := client.Ref("stable")
+			if !ok || refTargetObjectID(ref) != "commit-124" {
+				t.Fatalf("expected stable to peel to commit-124, got %+v", ref)
+			}
+			// The source tag's object is never reused, so the alias is a tag of the commit.
+			if ref.ObjectID == "tag-124" {
+				t.Fatalf("alias must not point at the source tag object")
+			}
+		})
+	}
+}
+
+func TestPlanAliasDoesNotWrite(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	client.SeedAnnotatedTag("v1.2.4", "tag-124", "commit-124")
+	svc := NewService(client, tagplan.NewPlanner("v"))
+
+	plan, err := svc.PlanAlias(context.Background(), AliasConfig{SourceTag: "1.2.4", TagName: "stable"})
+	if err != nil {
+		t.Fatalf("plan alias: %v", err)
+	}
+	if plan.Commit != "commit-124" {
+		t.Fatalf("expected commit-124, got %s", plan.Commit)
+	}
+	if len(client.CreatedTags) != 0 {
+		t.Fatalf("plan must not create tags, got %+v", client.CreatedTags)
+	}
+}
+
+func TestCreateAliasErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		cfg     AliasConfig
+		wantErr error
+	}{
+		{
+			name:    "source missing",
+			cfg:     AliasConfig{SourceTag: "v9.9.9", TagName: "stable", TaggerName: taggerNameDefault, TaggerEmail: taggerEmailDefault},
+			wantErr: tagplan.ErrAliasSourceNotFound,
+		},
+		{
+			name:    "source is a release candidate",
+			cfg:     AliasConfig{SourceTag: "v1.3.0-rc.1", TagName: "stable", TaggerName: taggerNameDefault, TaggerEmail: taggerEmailDefault},
+			wantErr: tagplan.ErrAliasSourceNotRelease,
+		},
+		{
+			name:    "annotated alias without tagger",
+			cfg:     AliasConfig{SourceTag: "v1.2.4", TagName: "stable", TaggerEmail: taggerEmailDefault},
+			wantErr: ErrEmptyTagger,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := adotest.NewClient()
+			client.SeedAnnotatedTag("v1.2.4", "tag-124", "commit-124")
+			client.SeedAnnotatedTag("v1.3.0-rc.1", "tag-130rc1", "commit-130")
+			svc := NewService(client, tagplan.NewPlanner("v"))
+
+			_, err := svc.CreateAlias(context.Background(), tc.cfg)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected %v, got %v", tc.wantErr, err)
+			}
+			if len(client.CreatedTags) != 0 {
+				t.Fatalf("no tags should be created, got %+v", client.CreatedTags)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package tagging
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+)
+
+// ErrBumpRequired is returned by ResolveBump when no bump was given and defaults are disabled.
+var ErrBumpRequired = errors.New("tagging service: bump is required")
+
+// BumpSource reports where the bump intent for a run came from.
+type BumpSource string
+
+const (
+	// BumpSourceExplicit means the caller set the bump.
+	BumpSourceExplicit BumpSource = "explicit"
+	// BumpSourceDefault means the bump was omitted and the default bump applied.
+	BumpSourceDefault BumpSource = "default"
+)
+
+// ResolveBump returns value as a bump when it is set. Otherwise it falls back to defaultBump
+// (patch when empty), or fails with ErrBumpRequired when require is set.
+func ResolveBump(value, defaultBump string, require bool) (bump.Bump, BumpSource, error) {
+	if trimmed := strings.ToLower(strings.TrimSpace(value)); trimmed != "" {
+		intent, err := bump.Parse(trimmed)
+		if err != nil {
+			return "", "", err
+		}
+		return intent, BumpSourceExplicit, nil
+	}
+	if require {
+		return "", "", ErrBumpRequired
+	}
+
+	fallback := strings.ToLower(strings.TrimSpace(defaultBump))
+	if fallback == "" {
+		return bump.Default(), BumpSourceDefault, nil
+	}
+	intent, err := bump.Parse(fallback)
+	if err != nil {
+		return "", "", fmt.Errorf("default bump: %w", err)
+	}
+	return intent, BumpSourceDefault, nil
+}
@@ -0,0 +1,57 @@
+package tagging
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+)
+
+func TestResolveBump(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		value       string
+		defaultBump string
+		require     bool
+		want        bump.Bump
+		wantSource  BumpSource
+		wantErr     error
+		wantAnyErr  bool
+	}{
+		{name: "explicit bump", value: "minor", want: bump.BumpMinor, wantSource: BumpSourceExplicit},
+		{name: "explicit bump wins over default", value: " Major ", defaultBump: "minor", want: bump.BumpMajor, wantSource: BumpSourceExplicit},
+		{name: "explicit bump allowed when required", value: "patch", require: true, want: bump.BumpPatch, wantSource: BumpSourceExplicit},
+		{name: "omitted bump defaults to patch", want: bump.BumpPatch, wantSource: BumpSourceDefault},
+		{name: "omitted bump uses configured default", defaultBump: "minor", want: bump.BumpMinor, wantSource: BumpSourceDefault},
+		{name: "require bump errors when omitted", defaultBump: "minor", require: true, wantErr: ErrBumpRequired},
+		{name: "invalid explicit bump", value: "huge", wantAnyErr: true},
+		{name: "invalid default bump", defaultBump: "huge", wantAnyErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, source, err := ResolveBump(tc.value, tc.defaultBump, tc.require)
+			switch {
+			case tc.wantErr != nil:
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("expected %v, got %v", tc.wantErr, err)
+				}
+				return
+			case tc.wantAnyErr:
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			case err != nil:
+				t.Fatalf("resolve: %v", err)
+			}
+			if got != tc.want || source != tc.wantSource {
+				t.Fatalf("want %s/%s, got %s/%s", tc.want, tc.wantSource, got, source)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package tagging
+
+import (
+	"context"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado/adotest"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+func TestPlanRCUsesConfiguredChannel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		channel string
+		want    string
+	}{
+		{name: "default rc", want: "v1.2.4-rc.2"},
+		// The beta.3 and rc.1 tags do not advance the alpha counter.
+		{name: "alpha", channel: "alpha", want: "v1.2.4-alpha.2"},
+		{name: "new channel", channel: " Preview ", want: "v1.2.4-preview.1"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := adotest.NewClient()
+			client.SeedAnnotatedTag(sampleReleaseTag, "release-tag-object", sampleReleaseObjectID)
+			for _, name := range []string{"refs/tags/v1.2.4-alpha.1", "refs/tags/v1.2.4-beta.3", "refs/tags/v1.2.4-rc.1"} {
+				client.SeedAnnotatedTag(name, name+"-object", "c124")
+			}
+
+			plan, err := NewService(client, tagplan.NewPlanner("v")).Plan(context.Background(), Config{Mode: tagplan.ModeRC, Bump: bump.BumpPatch, Channel: tc.channel})
+			if err != nil {
+				t.Fatalf("plan: %v", err)
+			}
+			if plan.TagName != tc.want {
+				t.Fatalf("expected %s, got %s", tc.want, plan.TagName)
+			}
+		})
+	}
+}
+
+func TestPlanRejectsInvalidChannel(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	if _, err := NewService(client, tagplan.NewPlanner("v")).Plan(context.Background(), Config{Mode: tagplan.ModeRC, Bump: bump.BumpPatch, Channel: "rc.1"}); err == nil {
+		t.Fatal("expected an invalid channel to be rejected")
+	}
+}
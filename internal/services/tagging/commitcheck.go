@@ -0,0 +1,70 @@
+package tagging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+// ErrCommitNotFound is returned under MissingCommitError when the target commit does not exist.
+var ErrCommitNotFound = errors.New("tagging service: commit not found")
+
+// MissingCommitPolicy selects how a target commit that cannot be found is handled.
+type MissingCommitPolicy string
+
+const (
+	// MissingCommitError fails before any ref is written.
+	MissingCommitError MissingCommitPolicy = "error"
+	// MissingCommitWarnCreate records the problem on the plan and still creates the tags, for
+	// pipelines where the commit is known to exist but the lookup is unreliable.
+	MissingCommitWarnCreate MissingCommitPolicy = "warn-create"
+	// MissingCommitSkip writes nothing and reports the plan as skipped.
+	MissingCommitSkip MissingCommitPolicy = "skip"
+)
+
+// ParseMissingCommitPolicy converts a string into a MissingCommitPolicy. Empty values map to
+// MissingCommitError.
+func ParseMissingCommitPolicy(value string) (MissingCommitPolicy, error) {
+	switch MissingCommitPolicy(strings.ToLower(strings.TrimSpace(value))) {
+	case "", MissingCommitError:
+		return MissingCommitError, nil
+	case MissingCommitWarnCreate:
+		return MissingCommitWarnCreate, nil
+	case MissingCommitSkip:
+		return MissingCommitSkip, nil
+	default:
+		return "", fmt.Errorf("invalid missing commit policy %q", value)
+	}
+}
+
+// checkCommit verifies the target commit exists and applies cfg.OnMissingCommit when it does
+// not. A failed lookup is only tolerated under MissingCommitWarnCreate, since skip and error
+// both need to know the commit is really absent.
+func (s Service) checkCommit(ctx context.Context, cfg CreateConfig, plan *tagplan.Result) error {
+	commit := strings.TrimSpace(cfg.CommitSHA)
+	exists, err := s.client.CommitExists(ctx, commit)
+	if err != nil {
+		if cfg.OnMissingCommit == MissingCommitWarnCreate {
+			plan.CommitUnverified = fmt.Sprintf("commit lookup failed: %v", err)
+			return nil
+		}
+		return fmt.Errorf("checking commit %s: %w", commit, err)
+	}
+	if exists {
+		return nil
+	}
+
+	switch cfg.OnMissingCommit {
+	case MissingCommitWarnCreate:
+		plan.CommitUnverified = fmt.Sprintf("commit %s not found", commit)
+		return nil
+	case MissingCommitSkip:
+		plan.Skipped = true
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrCommitNotFound, commit)
+	}
+}
@@ -0,0 +1,139 @@
+package tagging
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado/adotest"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+func TestParseMissingCommitPolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input   string
+		want    MissingCommitPolicy
+		wantErr bool
+	}{
+		{input: "", want: MissingCommitError},
+		{input: "error", want: MissingCommitError},
+		{input: " Warn-Create ", want: MissingCommitWarnCreate},
+		{input: "skip", want: MissingCommitSkip},
+		{input: "ignore", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		got, err := ParseMissingCommitPolicy(tc.input)
+		if tc.wantErr {
+			if err == nil {
+				t.Fatalf("expected error for %q", tc.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("parse %q: %v", tc.input, err)
+		}
+		if got != tc.want {
+			t.Fatalf("parse %q: expected %q, got %q", tc.input, tc.want, got)
+		}
+	}
+}
+
+func TestPlanAndCreateMissingCommitPolicies(t *testing.T) {
+	t.Parallel()
+
+	lookupErr := errors.New("service unavailable")
+
+	tests := []struct {
+		name           string
+		policy         MissingCommitPolicy
+		missing        bool
+		lookupErr      error
+		wantErr        error
+		wantCreated    bool
+		wantSkipped    bool
+		wantUnverified bool
+	}{
+		{name: "present commit", policy: MissingCommitError, wantCreated: true},
+		{name: "error policy missing", policy: MissingCommitError, missing: true, wantErr: ErrCommitNotFound},
+		{name: "default policy missing", missing: true, wantErr: ErrCommitNotFound},
+		{name: "error policy lookup failure", policy: MissingCommitError, lookupErr: lookupErr, wantErr: lookupErr},
+		{name: "warn-create missing", policy: MissingCommitWarnCreate, missing: true, wantCreated: true, wantUnverified: true},
+		{name: "warn-create lookup failure", policy: MissingCommitWarnCreate, lookupErr: lookupErr, wantCreated: true, wantUnverified: true},
+		{name: "skip missing", policy: MissingCommitSkip, missing: true, wantSkipped: true},
+		{name: "skip lookup failure", policy: MissingCommitSkip, lookupErr: lookupErr, wantErr: lookupErr},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := adotest.NewClient()
+			client.SeedAnnotatedTag(sampleReleaseTag, "release-tag-object", sampleReleaseObjectID)
+			if tc.missing {
+				client.MissingCommits = map[string]bool{"deadbeef": true}
+			}
+			client.CommitErr = tc.lookupErr
+			svc := NewService(client, tagplan.NewPlanner("v"))
+
+			result, err := svc.PlanAndCreate(context.Background(), CreateConfig{
+				Config:          Config{Mode: tagplan.ModeRelease, Bump: bump.BumpPatch, UseFloatingTags: true},
+				CommitSHA:       "deadbeef",
+				TaggerName:      taggerNameDefault,
+				TaggerEmail:     taggerEmailDefault,
+				FloatingTagKind: TagKindAnnotated,
+				OnMissingCommit: tc.policy,
+			})
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("expected %v, got %v", tc.wantErr, err)
+				}
+				if len(client.CreatedTags) != 0 {
+					t.Fatalf("expected no tags on error, got %+v", client.CreatedTags)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("plan and create: %v", err)
+			}
+			if created := len(client.CreatedTags) > 0; created != tc.wantCreated {
+				t.Fatalf("expected created=%v, got tags %+v", tc.wantCreated, client.CreatedTags)
+			}
+			if result.Skipped != tc.wantSkipped {
+				t.Fatalf("expected skipped=%v, got %v", tc.wantSkipped, result.Skipped)
+			}
+			if (result.CommitUnverified != "") != tc.wantUnverified {
+				t.Fatalf("unexpected commit unverified reason %q", result.CommitUnverified)
+			}
+			if tc.wantSkipped && (result.Floating.Created || len(client.UpdatedRefs) != 0) {
+				t.Fatalf("expected no floating ref changes when skipped, got %+v", result.Floating)
+			}
+		})
+	}
+}
+
+func TestPreviewSkipsMissingCommit(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	client.SeedAnnotatedTag(sampleReleaseTag, "release-tag-object", sampleReleaseObjectID)
+	client.MissingCommits = map[string]bool{"deadbeef": true}
+	svc := NewService(client, tagplan.NewPlanner("v"))
+
+	result, err := svc.Preview(context.Background(), CreateConfig{
+		Config:          Config{Mode: tagplan.ModeRelease, Bump: bump.BumpPatch},
+		CommitSHA:       "deadbeef",
+		TaggerName:      taggerNameDefault,
+		TaggerEmail:     taggerEmailDefault,
+		OnMissingCommit: MissingCommitSkip,
+	})
+	if err != nil {
+		t.Fatalf("preview: %v", err)
+	}
+	if !result.Skipped {
+		t.Fatal("expected preview to report the skip")
+	}
+}
@@ -0,0 +1,44 @@
+package tagging
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// deleteRef removes the named ref using the object ID it points at right now, since a value
+// cached from an earlier listing fails DeleteRef's compare-and-swap once the ref has moved.
+// When the delete is still rejected and a fresh lookup shows the ref moved again, the delete
+// is retried once with the new ID. Protected tags are refused before any lookup.
+func (s Service) deleteRef(ctx context.Context, name string) error {
+	if err := s.guardProtected(name); err != nil {
+		return err
+	}
+	objectID, err := s.currentObjectID(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	deleteErr := s.client.DeleteRef(ctx, name, objectID)
+	if deleteErr == nil {
+		return nil
+	}
+
+	refreshed, err := s.currentObjectID(ctx, name)
+	if err != nil || refreshed == objectID {
+		return deleteErr
+	}
+	return s.client.DeleteRef(ctx, name, refreshed)
+}
+
+func (s Service) currentObjectID(ctx context.Context, name string) (string, error) {
+	objectID, err := s.client.GetRefObjectID(ctx, name)
+	if err != nil {
+		return "", fmt.Errorf("resolving object id for %s: %w", name, err)
+	}
+	objectID = strings.TrimSpace(objectID)
+	if objectID == "" {
+		return "", fmt.Errorf("ref %s missing object id", name)
+	}
+	return objectID, nil
+}
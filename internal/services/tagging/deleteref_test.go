@@ -0,0 +1,104 @@
+package tagging
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado/adotest"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+func TestDeleteRefUsesCurrentObjectID(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	client.SeedAnnotatedTag("v1", "tag-object", "commit-object")
+	svc := NewService(client, tagplan.NewPlanner("v"))
+
+	if err := svc.deleteRef(context.Background(), "refs/tags/v1"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if len(client.DeletedRefs) != 1 || client.DeletedRefs[0].OldObjectID != "tag-object" {
+		t.Fatalf("expected delete with the tag object id, got %+v", client.DeletedRefs)
+	}
+}
+
+func TestDeleteRefRefreshesAfterMismatch(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	client.SeedAnnotatedTag("v1", "tag-object", "commit-object")
+	client.MoveAfterLookup = map[string]string{"refs/tags/v1": "moved-object"}
+	svc := NewService(client, tagplan.NewPlanner("v"))
+
+	if err := svc.deleteRef(context.Background(), "refs/tags/v1"); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if len(client.RefLookups) != 2 {
+		t.Fatalf("expected a refresh lookup after the mismatch, got %v", client.RefLookups)
+	}
+	if len(client.DeletedRefs) != 1 || client.DeletedRefs[0].OldObjectID != "moved-object" {
+		t.Fatalf("expected retry with the refreshed object id, got %+v", client.DeletedRefs)
+	}
+	if _, ok := client.Ref("v1"); ok {
+		t.Fatal("expected v1 to be deleted")
+	}
+}
+
+func TestDeleteRefDoesNotRetryUnchangedRef(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	client.SeedAnnotatedTag("v1", "tag-object", "commit-object")
+	client.DeleteErr = errors.New("rejected")
+	svc := NewService(client, tagplan.NewPlanner("v"))
+
+	err := svc.deleteRef(context.Background(), "refs/tags/v1")
+	if !errors.Is(err, client.DeleteErr) {
+		t.Fatalf("expected the delete error, got %v", err)
+	}
+	if len(client.RefLookups) != 2 {
+		t.Fatalf("expected one refresh lookup, got %v", client.RefLookups)
+	}
+}
+
+func TestDeleteRefMissingRef(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	svc := NewService(client, tagplan.NewPlanner("v"))
+
+	if err := svc.deleteRef(context.Background(), "refs/tags/v9"); !errors.Is(err, ado.ErrRefNotFound) {
+		t.Fatalf("expected ErrRefNotFound, got %v", err)
+	}
+}
+
+func TestPlanAndCreateRefreshesMovedFloatingTag(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	client.SeedAnnotatedTag(sampleReleaseTag, "release-tag-object", sampleReleaseObjectID)
+	client.SeedAnnotatedTag("v1", "floating-tag-object", sampleReleaseObjectID)
+	client.MoveAfterLookup = map[string]string{"refs/tags/v1": "concurrent-object"}
+	svc := NewService(client, tagplan.NewPlanner("v"))
+
+	result, err := svc.PlanAndCreate(context.Background(), CreateConfig{
+		Config:          Config{Mode: tagplan.ModeRelease, Bump: bump.BumpPatch, UseFloatingTags: true},
+		CommitSHA:       "deadbeef",
+		TaggerName:      taggerNameDefault,
+		TaggerEmail:     taggerEmailDefault,
+		FloatingTagKind: TagKindAnnotated,
+	})
+	if err != nil {
+		t.Fatalf("plan and create: %v", err)
+	}
+	if !result.Floating.DeletedExisting || !result.Floating.Created {
+		t.Fatalf("expected floating tag to be replaced, got %+v", result.Floating)
+	}
+	if len(client.DeletedRefs) != 1 || client.DeletedRefs[0].OldObjectID != "concurrent-object" {
+		t.Fatalf("expected delete with the refreshed object id, got %+v", client.DeletedRefs)
+	}
+}
@@ -0,0 +1,68 @@
+package tagging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrTagNotFound is returned when the tag named for deletion does not exist.
+var ErrTagNotFound = errors.New("tagging service: tag not found")
+
+// ExistingTag is a tag ref as it is right now: ObjectID is the ref's own object (the tag
+// object when annotated) and Commit the commit it peels to.
+type ExistingTag struct {
+	Name     string
+	ObjectID string
+	Commit   string
+}
+
+// ShortName returns the tag name without refs/tags/.
+func (t ExistingTag) ShortName() string {
+	return strings.TrimPrefix(t.Name, tagRefPrefix)
+}
+
+// LookupTag finds the tag called name, given as v1.2.3 or refs/tags/v1.2.3. A prefix listing
+// also returns longer names such as v1.2.30, so only an exact match counts.
+func (s Service) LookupTag(ctx context.Context, name string) (ExistingTag, error) {
+	if s.client == nil {
+		return ExistingTag{}, ErrNilClient
+	}
+	short := strings.TrimPrefix(strings.TrimSpace(name), tagRefPrefix)
+	if short == "" {
+		return ExistingTag{}, fmt.Errorf("%w: empty name", ErrTagNotFound)
+	}
+	full := tagRefPrefix + short
+
+	refs, err := s.client.ListRefsWithPrefix(ctx, full)
+	if err != nil {
+		return ExistingTag{}, fmt.Errorf("listing refs: %w", err)
+	}
+	for _, ref := range refs {
+		if ref.Name != full {
+			continue
+		}
+		objectID := strings.TrimSpace(ref.ObjectID)
+		if objectID == "" {
+			return ExistingTag{}, fmt.Errorf("ref %s missing object id", full)
+		}
+		return ExistingTag{Name: full, ObjectID: objectID, Commit: refTargetObjectID(ref)}, nil
+	}
+	return ExistingTag{}, fmt.Errorf("%w: %s", ErrTagNotFound, short)
+}
+
+// DeleteTag removes tag, passing the object ID LookupTag saw so ADO rejects the delete when
+// the tag moved in between. Protected tags are refused.
+func (s Service) DeleteTag(ctx context.Context, tag ExistingTag) error {
+	if s.client == nil {
+		return ErrNilClient
+	}
+	if err := s.guardProtected(tag.Name); err != nil {
+		return err
+	}
+	if err := s.client.DeleteRef(ctx, tag.Name, tag.ObjectID); err != nil {
+		return fmt.Errorf("deleting tag %s: %w", tag.ShortName(), err)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package tagging
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado/adotest"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+func TestLookupTagMatchesExactName(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	client.SeedAnnotatedTag("refs/tags/v1.2.30", "other-tag-object", "other-commit")
+	client.SeedAnnotatedTag(sampleReleaseTag, "release-tag-object", sampleReleaseObjectID)
+	service := NewService(client, tagplan.NewPlanner("v"))
+
+	for _, name := range []string{"v1.2.3", "refs/tags/v1.2.3"} {
+		tag, err := service.LookupTag(context.Background(), name)
+		if err != nil {
+			t.Fatalf("lookup %s: %v", name, err)
+		}
+		if tag.Name != sampleReleaseTag || tag.ObjectID != "release-tag-object" || tag.Commit != sampleReleaseObjectID {
+			t.Fatalf("lookup %s: unexpected tag %+v", name, tag)
+		}
+	}
+	if _, err := service.LookupTag(context.Background(), "v1.2"); !errors.Is(err, ErrTagNotFound) {
+		t.Fatalf("expected a prefix of an existing tag to be missing, got %v", err)
+	}
+}
+
+func TestDeleteTagUsesLookedUpObjectID(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	client.SeedAnnotatedTag(sampleReleaseTag, "release-tag-object", sampleReleaseObjectID)
+	service := NewService(client, tagplan.NewPlanner("v"))
+
+	tag, err := service.LookupTag(context.Background(), "v1.2.3")
+	if err != nil {
+		t.Fatalf("lookup: %v", err)
+	}
+	if err := service.DeleteTag(context.Background(), tag); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if len(client.DeletedRefs) != 1 || client.DeletedRefs[0].Name != sampleReleaseTag || client.DeletedRefs[0].OldObjectID != "release-tag-object" {
+		t.Fatalf("expected v1.2.3 deleted with its object id, got %+v", client.DeletedRefs)
+	}
+}
+
+func TestDeleteTagRefusesProtected(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	client.SeedAnnotatedTag(sampleReleaseTag, "release-tag-object", sampleReleaseObjectID)
+	service := NewService(client, tagplan.NewPlanner("v")).WithProtectedTags([]string{"v1.*"})
+
+	tag, err := service.LookupTag(context.Background(), "v1.2.3")
+	if err != nil {
+		t.Fatalf("lookup: %v", err)
+	}
+	if err := service.DeleteTag(context.Background(), tag); !errors.Is(err, ErrProtectedTag) {
+		t.Fatalf("expected protected tag refused, got %v", err)
+	}
+	if len(client.DeletedRefs) > 0 {
+		t.Fatalf("expected nothing deleted, got %+v", client.DeletedRefs)
+	}
+}
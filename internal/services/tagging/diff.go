@@ -0,0 +1,85 @@
+package tagging
+
+import (
+	"strings"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+// RefRole identifies why a ref is relevant to a tag operation.
+type RefRole string
+
+const (
+	// RefRoleHighestRelease is the highest stable release tag in the repository.
+	RefRoleHighestRelease RefRole = "highest-release"
+	// RefRoleHotfixBase is the release tag a hotfix is cut from.
+	RefRoleHotfixBase RefRole = "hotfix-base"
+	// RefRoleFloating is the floating major tag affected by the release.
+	RefRoleFloating RefRole = "floating"
+	// RefRoleMinorFloating is the floating v<major>.<minor> tag affected by the release.
+	RefRoleMinorFloating RefRole = "minor-floating"
+	// RefRoleNewTag is the tag created by the operation.
+	RefRoleNewTag RefRole = "new-tag"
+)
+
+// RefSnapshot records a ref name and the commit it points at.
+type RefSnapshot struct {
+	Role   RefRole `json:"role"`
+	Name   string  `json:"name"`
+	Commit string  `json:"commit"`
+}
+
+// Diff captures the relevant refs before and after a tag operation. Refs absent on one
+// side are omitted from that side.
+type Diff struct {
+	Before []RefSnapshot `json:"before"`
+	After  []RefSnapshot `json:"after"`
+}
+
+// BuildDiff derives the before/after ref state from a plan produced by Preview or
+// PlanAndCreate and the commit the new tag references. The new tag is listed once, as
+// RefRoleNewTag; the highest release stays in After unless the new tag supersedes it.
+func BuildDiff(plan tagplan.Result, commit string) Diff {
+	target := strings.TrimSpace(commit)
+	diff := Diff{Before: []RefSnapshot{}, After: []RefSnapshot{}}
+
+	if name := shortTagName(plan.HighestRelease.Name); name != "" {
+		highest := RefSnapshot{Role: RefRoleHighestRelease, Name: name, Commit: plan.HighestRelease.ObjectID}
+		diff.Before = append(diff.Before, highest)
+		if !plan.NewHighest {
+			diff.After = append(diff.After, highest)
+		}
+	}
+	if name := shortTagName(plan.BaseTag.Name); name != "" && plan.BaseSource == tagplan.BaseSourceHotfix {
+		base := RefSnapshot{Role: RefRoleHotfixBase, Name: name, Commit: plan.BaseTag.ObjectID}
+		diff.Before = append(diff.Before, base)
+		diff.After = append(diff.After, base)
+	}
+
+	diff.addFloating(RefRoleFloating, plan.Floating, target)
+	if minor := plan.Floating.Minor; minor != nil && minor.Enabled {
+		diff.addFloating(RefRoleMinorFloating, *minor, target)
+	}
+
+	diff.After = append(diff.After, RefSnapshot{Role: RefRoleNewTag, Name: plan.TagName, Commit: target})
+	return diff
+}
+
+// addFloating records a floating tag: its existing ref before, and after either the same ref
+// or, when the floating tag is maintained, the ref at target.
+func (d *Diff) addFloating(role RefRole, floating tagplan.FloatingPlan, target string) {
+	if name := shortTagName(floating.Existing.Name); name != "" {
+		existing := RefSnapshot{Role: role, Name: name, Commit: floating.Existing.ObjectID}
+		d.Before = append(d.Before, existing)
+		if !floating.Enabled {
+			d.After = append(d.After, existing)
+		}
+	}
+	if floating.Enabled {
+		d.After = append(d.After, RefSnapshot{Role: role, Name: floating.TagName, Commit: target})
+	}
+}
+
+func shortTagName(name string) string {
+	return strings.TrimPrefix(strings.TrimSpace(name), tagRefPrefix)
+}
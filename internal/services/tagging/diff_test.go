@@ -0,0 +1,121 @@
+package tagging
+
+import (
+	"testing"
+
+	semver "github.com/blang/semver/v4"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+func TestBuildDiff(t *testing.T) {
+	t.Parallel()
+
+	baseTag := tagplan.Tag{Name: "refs/tags/v1.2.3", ObjectID: "base-commit"}
+	floating := tagplan.FloatingPlan{
+		TagName:  "v1",
+		Existing: tagplan.Tag{Name: "refs/tags/v1", ObjectID: "base-commit"},
+		Enabled:  true,
+	}
+
+	tests := []struct {
+		name       string
+		plan       tagplan.Result
+		wantBefore []RefSnapshot
+		wantAfter  []RefSnapshot
+	}{
+		{
+			name: "release supersedes highest release and moves floating tag",
+			plan: tagplan.Result{Mode: tagplan.ModeRelease, TagName: "v1.2.4", Version: semver.MustParse("1.2.4"), BaseTag: baseTag, HighestRelease: baseTag, NewHighest: true, Floating: floating},
+			wantBefore: []RefSnapshot{
+				{Role: RefRoleHighestRelease, Name: "v1.2.3", Commit: "base-commit"},
+				{Role: RefRoleFloating, Name: "v1", Commit: "base-commit"},
+			},
+			wantAfter: []RefSnapshot{
+				{Role: RefRoleFloating, Name: "v1", Commit: "new-commit"},
+				{Role: RefRoleNewTag, Name: "v1.2.4", Commit: "new-commit"},
+			},
+		},
+		{
+			name: "rc keeps highest release and floating tag",
+			plan: tagplan.Result{Mode: tagplan.ModeRC, TagName: "v1.2.4-rc.1", BaseTag: baseTag, HighestRelease: baseTag, Floating: tagplan.FloatingPlan{Existing: floating.Existing}},
+			wantBefore: []RefSnapshot{
+				{Role: RefRoleHighestRelease, Name: "v1.2.3", Commit: "base-commit"},
+				{Role: RefRoleFloating, Name: "v1", Commit: "base-commit"},
+			},
+			wantAfter: []RefSnapshot{
+				{Role: RefRoleHighestRelease, Name: "v1.2.3", Commit: "base-commit"},
+				{Role: RefRoleFloating, Name: "v1", Commit: "base-commit"},
+				{Role: RefRoleNewTag, Name: "v1.2.4-rc.1", Commit: "new-commit"},
+			},
+		},
+		{
+			name: "hotfix keeps base tag and superseded floating tag",
+			plan: tagplan.Result{
+				Mode:       tagplan.ModeRelease,
+				TagName:    "v1.2.4",
+				BaseSource: tagplan.BaseSourceHotfix,
+				BaseTag:    baseTag,
+				Floating:   tagplan.FloatingPlan{TagName: "v1", Existing: floating.Existing, Superseded: true},
+			},
+			wantBefore: []RefSnapshot{
+				{Role: RefRoleHotfixBase, Name: "v1.2.3", Commit: "base-commit"},
+				{Role: RefRoleFloating, Name: "v1", Commit: "base-commit"},
+			},
+			wantAfter: []RefSnapshot{
+				{Role: RefRoleHotfixBase, Name: "v1.2.3", Commit: "base-commit"},
+				{Role: RefRoleFloating, Name: "v1", Commit: "base-commit"},
+				{Role: RefRoleNewTag, Name: "v1.2.4", Commit: "new-commit"},
+			},
+		},
+		{
+			name: "release on an older line keeps highest release",
+			plan: tagplan.Result{
+				Mode:           tagplan.ModeRelease,
+				TagName:        "v1.2.4",
+				Version:        semver.MustParse("1.2.4"),
+				BaseTag:        baseTag,
+				HighestRelease: tagplan.Tag{Name: "refs/tags/v2.0.0", ObjectID: "major-two"},
+				Floating:       floating,
+			},
+			wantBefore: []RefSnapshot{
+				{Role: RefRoleHighestRelease, Name: "v2.0.0", Commit: "major-two"},
+				{Role: RefRoleFloating, Name: "v1", Commit: "base-commit"},
+			},
+			wantAfter: []RefSnapshot{
+				{Role: RefRoleHighestRelease, Name: "v2.0.0", Commit: "major-two"},
+				{Role: RefRoleFloating, Name: "v1", Commit: "new-commit"},
+				{Role: RefRoleNewTag, Name: "v1.2.4", Commit: "new-commit"},
+			},
+		},
+		{
+			name:       "first release in empty repository",
+			plan:       tagplan.Result{Mode: tagplan.ModeRelease, TagName: "v0.0.1", NewHighest: true},
+			wantBefore: []RefSnapshot{},
+			wantAfter: []RefSnapshot{
+				{Role: RefRoleNewTag, Name: "v0.0.1", Commit: "new-commit"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			diff := BuildDiff(tt.plan, "new-commit")
+			assertSnapshots(t, "before", diff.Before, tt.wantBefore)
+			assertSnapshots(t, "after", diff.After, tt.wantAfter)
+		})
+	}
+}
+
+func assertSnapshots(t *testing.T, side string, got, want []RefSnapshot) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%s: want %d refs got %d: %+v", side, len(want), len(got), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("%s[%d]: want %+v got %+v", side, i, want[i], got[i])
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package tagging
+
+import (
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/events"
+)
+
+// WithEvents returns a copy of the service that records refs listed, plans computed, and tags
+// written to log. A nil log records nothing.
+func (s Service) WithEvents(log *events.Log) Service {
+	s.events = log
+	return s
+}
+
+func (s Service) recordPlan(plan tagplan.Result) {
+	s.events.Record(events.PlanComputed, map[string]any{
+		"mode":       string(plan.Mode),
+		"tag":        plan.TagName,
+		"version":    plan.Version.String(),
+		"baseSource": string(plan.BaseSource),
+		"baseTag":    plan.BaseTag.Name,
+	})
+}
+
+func (s Service) recordFloating(floating *tagplan.FloatingPlan, action string) {
+	s.events.Record(events.FloatingUpdated, map[string]any{
+		"tag":            floating.TagName,
+		"commit":         floating.TargetCommit,
+		"previousCommit": floating.PreviousCommit,
+		"action":         action,
+	})
+}
@@ -0,0 +1,55 @@
+package tagging
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado/adotest"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/events"
+)
+
+func TestPlanAndCreateRecordsEvents(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	client.SeedAnnotatedTag(sampleReleaseTag, "release-tag-object", sampleReleaseObjectID)
+	client.SeedAnnotatedTag("refs/tags/v1", "major-floating-object", sampleReleaseObjectID)
+
+	var buf bytes.Buffer
+	service := NewService(client, tagplan.NewPlanner("v")).WithEvents(events.New(&buf))
+	cfg := baseCreateConfig("deadbeef")
+	cfg.UseFloatingTags, cfg.FloatingLevel = true, FloatingLevelBoth
+	if _, err := service.PlanAndCreate(context.Background(), cfg); err != nil {
+		t.Fatalf("plan and create: %v", err)
+	}
+
+	recorded, err := events.Decode(&buf)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	want := []struct {
+		event string
+		key   string
+		value any
+	}{
+		{events.RefsListed, "count", float64(2)},
+		{events.PlanComputed, "tag", "v1.2.4"},
+		{events.TagCreated, "tag", "v1.2.4"},
+		{events.FloatingUpdated, "action", "recreated"},
+		{events.FloatingUpdated, "action", "created"},
+	}
+	if len(recorded) != len(want) {
+		t.Fatalf("expected %d events, got %+v", len(want), recorded)
+	}
+	for i, w := range want {
+		got := recorded[i]
+		if got.Seq != i+1 || got.Event != w.event || got.Data[w.key] != w.value {
+			t.Fatalf("event %d: expected %s with %s=%v, got %+v", i, w.event, w.key, w.value, got)
+		}
+	}
+	if recorded[3].Data["tag"] != "v1" || recorded[4].Data["tag"] != "v1.2" {
+		t.Fatalf("expected v1 then v1.2 to float, got %+v and %+v", recorded[3].Data, recorded[4].Data)
+	}
+}
@@ -0,0 +1,42 @@
+package tagging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+// ErrTagExistsDifferentCommit is returned when the planned tag already exists but points at
+// another commit than the one being tagged.
+var ErrTagExistsDifferentCommit = errors.New("tagging service: tag already exists at a different commit")
+
+// checkExisting looks up the planned tag before it is created, so a create-tag retried after a
+// partial failure does not fail on the tag its first attempt wrote. A tag already at the target
+// commit marks the plan AlreadyExists and is not written again; one at another commit is an
+// error, except for an RC under cfg.RetryRCOnCollision, which createTag replans past.
+func (s Service) checkExisting(ctx context.Context, cfg CreateConfig, plan *tagplan.Result) error {
+	name := tagRefPrefix + plan.TagName
+	refs, err := s.client.ListRefsWithPrefix(ctx, name)
+	if err != nil {
+		return fmt.Errorf("checking tag %s: %w", plan.TagName, err)
+	}
+	commit := strings.TrimSpace(cfg.CommitSHA)
+	for _, ref := range refs {
+		if ref.Name != name {
+			continue
+		}
+		existing := refTargetObjectID(ref)
+		if strings.EqualFold(existing, commit) {
+			plan.AlreadyExists = true
+			return nil
+		}
+		if cfg.RetryRCOnCollision && plan.Mode == tagplan.ModeRC {
+			return nil
+		}
+		return fmt.Errorf("%w: %s points at %s, want %s", ErrTagExistsDifferentCommit, plan.TagName, existing, commit)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package tagging
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+const retriedCommit = "2222222222222222222222222222222222222222"
+
+func TestPlanAndCreateExistingTag(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		// tags are seeded next to v1.2.3; v1.2.4 is the tag planned again.
+		tags            map[string]string
+		floatingCurrent bool
+		wantErr         error
+	}{
+		{
+			name:            "at the commit is a no-op",
+			tags:            map[string]string{"refs/tags/v1.2.4": retriedCommit, "refs/tags/v1": retriedCommit},
+			floatingCurrent: true,
+		},
+		{
+			name: "at the commit still moves floating",
+			tags: map[string]string{"refs/tags/v1.2.4": retriedCommit, "refs/tags/v1": sampleReleaseObjectID},
+		},
+		{
+			name:    "at another commit",
+			tags:    map[string]string{"refs/tags/v1.2.4": "3333333333333333333333333333333333333333"},
+			wantErr: ErrTagExistsDifferentCommit,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tags := map[string]string{sampleReleaseTag: sampleReleaseObjectID}
+			for name, commit := range tc.tags {
+				tags[name] = commit
+			}
+			client := seededClient(tags)
+			// The version source plans v1.2.4 so the tag left by an earlier attempt is the one planned again.
+			cfg := baseCreateConfig(retriedCommit)
+			cfg.UseFloatingTags = true
+			cfg.VersionSource = staticVersionSource("1.2.3")
+
+			result, err := NewService(client, tagplan.NewPlanner("v")).PlanAndCreate(context.Background(), cfg)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("expected %v, got %v", tc.wantErr, err)
+				}
+				if len(client.CreatedTags) > 0 || len(client.DeletedRefs) > 0 {
+					t.Fatalf("expected no writes, got created=%+v deleted=%+v", client.CreatedTags, client.DeletedRefs)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("plan and create: %v", err)
+			}
+			if result.TagName != "v1.2.4" || !result.AlreadyExists || result.Skipped {
+				t.Fatalf("expected v1.2.4 reported as already existing, got %+v", result)
+			}
+			if !result.Floating.Enabled || result.Floating.AlreadyExists != tc.floatingCurrent || result.Floating.Created == tc.floatingCurrent {
+				t.Fatalf("expected the floating tag written only when stale, got %+v", result.Floating)
+			}
+			if slices.ContainsFunc(client.CreatedTags, func(spec ado.TagSpec) bool { return spec.Name == "v1.2.4" }) {
+				t.Fatalf("release tag created again: %+v", client.CreatedTags)
+			}
+			if tc.floatingCurrent && (len(client.CreatedTags) > 0 || len(client.DeletedRefs) > 0 || len(client.UpdatedRefs) > 0) {
+				t.Fatalf("expected no writes, got created=%+v deleted=%+v updated=%+v", client.CreatedTags, client.DeletedRefs, client.UpdatedRefs)
+			}
+		})
+	}
+}
+
+type staticVersionSource string
+
+func (v staticVersionSource) CurrentVersion(context.Context) (string, error) {
+	return string(v), nil
+}
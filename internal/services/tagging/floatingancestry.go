@@ -0,0 +1,63 @@
+package tagging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+// ErrFloatingAhead is returned when the existing floating tag points at a descendant of the
+// release commit, so moving it would take the major line backwards.
+var ErrFloatingAhead = errors.New("tagging service: floating tag is ahead of the release commit")
+
+// checkFloatingAncestry refuses to move an existing floating tag onto an older commit. A tag
+// whose commit is already an ancestor of the release moves forward as usual; one whose commit
+// descends from the release fails with ErrFloatingAhead unless cfg.ForceFloating is set, in
+// which case the regression is recorded on the plan so it can be reported. It runs before the
+// release tag is created so a refusal does not leave the run half done.
+func (s Service) checkFloatingAncestry(ctx context.Context, cfg CreateConfig, plan *tagplan.Result) error {
+	if !plan.FloatingEligible() {
+		return nil
+	}
+	for _, floating := range floatingRefs(cfg, plan) {
+		if floating.Superseded || (!cfg.UseFloatingTags && !floating.AutoDetected) {
+			continue
+		}
+		if err := s.checkFloatingRefAncestry(ctx, cfg, floating); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s Service) checkFloatingRefAncestry(ctx context.Context, cfg CreateConfig, floating *tagplan.FloatingPlan) error {
+	existing := strings.TrimSpace(floating.Existing.ObjectID)
+	commit := strings.TrimSpace(cfg.CommitSHA)
+	if existing == "" || strings.EqualFold(existing, commit) {
+		return nil
+	}
+
+	behind, err := s.client.IsAncestor(ctx, existing, commit)
+	if err != nil {
+		return fmt.Errorf("checking floating tag ancestry: %w", err)
+	}
+	if behind {
+		return nil
+	}
+	ahead, err := s.client.IsAncestor(ctx, commit, existing)
+	if err != nil {
+		return fmt.Errorf("checking floating tag ancestry: %w", err)
+	}
+	if !ahead {
+		return nil
+	}
+	if !cfg.ForceFloating {
+		return fmt.Errorf("%w: %s points at %s, which descends from %s",
+			ErrFloatingAhead, floating.Existing.Name, existing, commit)
+	}
+	floating.Regressed = true
+	return nil
+}
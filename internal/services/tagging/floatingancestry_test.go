@@ -0,0 +1,78 @@
+package tagging
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado/adotest"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+func TestPlanAndCreateFloatingAncestry(t *testing.T) {
+	t.Parallel()
+
+	const (
+		commit   = "deadbeef"
+		floating = "cafef00d"
+	)
+
+	tests := []struct {
+		name          string
+		floatingAhead bool
+		force         bool
+		expectErr     error
+		expectRegress bool
+	}{
+		{name: "floating behind release moves forward"},
+		{name: "floating ahead refused", floatingAhead: true, expectErr: ErrFloatingAhead},
+		{name: "floating ahead forced", floatingAhead: true, force: true, expectRegress: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := adotest.NewClient()
+			client.SeedAnnotatedTag(sampleReleaseTag, "release-tag-object", sampleReleaseObjectID)
+			client.SeedAnnotatedTag("v1", "floating-tag-object", floating)
+			if tc.floatingAhead {
+				// The floating commit is not reachable from the release commit, while the
+				// release commit is reachable from it.
+				client.NotAncestors = map[string]bool{floating: true}
+			}
+			svc := NewService(client, tagplan.NewPlanner("v"))
+
+			result, err := svc.PlanAndCreate(context.Background(), CreateConfig{
+				Config:        Config{Mode: tagplan.ModeRelease, Bump: bump.BumpPatch, UseFloatingTags: true},
+				CommitSHA:     commit,
+				TaggerName:    taggerNameDefault,
+				TaggerEmail:   taggerEmailDefault,
+				ForceFloating: tc.force,
+			})
+
+			if tc.expectErr != nil {
+				if !errors.Is(err, tc.expectErr) {
+					t.Fatalf("expected %v, got %v", tc.expectErr, err)
+				}
+				if len(client.CreatedTags) != 0 {
+					t.Fatalf("expected no tags to be created, got %d", len(client.CreatedTags))
+				}
+				if len(client.DeletedRefs) != 0 {
+					t.Fatalf("expected floating tag to be kept, deleted %v", client.DeletedRefs)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("plan and create: %v", err)
+			}
+			if !result.Floating.Created {
+				t.Fatalf("expected floating tag to be updated")
+			}
+			if result.Floating.Regressed != tc.expectRegress {
+				t.Fatalf("expected Regressed=%v, got %v", tc.expectRegress, result.Floating.Regressed)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package tagging
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+// FloatingLevel selects which floating tags follow a release: v<major>, v<major>.<minor>, or
+// both.
+type FloatingLevel string
+
+const (
+	// FloatingLevelMajor maintains the v<major> tag only.
+	FloatingLevelMajor FloatingLevel = "major"
+	// FloatingLevelMinor maintains the v<major>.<minor> tag only.
+	FloatingLevelMinor FloatingLevel = "minor"
+	// FloatingLevelBoth maintains both tags.
+	FloatingLevelBoth FloatingLevel = "both"
+)
+
+// ParseFloatingLevel converts a string into a FloatingLevel. Empty values map to
+// FloatingLevelMajor.
+func ParseFloatingLevel(value string) (FloatingLevel, error) {
+	switch FloatingLevel(strings.ToLower(strings.TrimSpace(value))) {
+	case "", FloatingLevelMajor:
+		return FloatingLevelMajor, nil
+	case FloatingLevelMinor:
+		return FloatingLevelMinor, nil
+	case FloatingLevelBoth:
+		return FloatingLevelBoth, nil
+	default:
+		return "", fmt.Errorf("invalid floating level %q", value)
+	}
+}
+
+// MaintainsMajor reports whether the level includes the v<major> tag; empty values do.
+func (l FloatingLevel) MaintainsMajor() bool {
+	return l != FloatingLevelMinor
+}
+
+// MaintainsMinor reports whether the level includes the v<major>.<minor> tag.
+func (l FloatingLevel) MaintainsMinor() bool {
+	return l == FloatingLevelMinor || l == FloatingLevelBoth
+}
+
+// floatingRefs returns the floating plans cfg.FloatingLevel maintains, major first. Each is
+// still subject to --use-floating-tags or auto-detection and to Superseded.
+func floatingRefs(cfg CreateConfig, plan *tagplan.Result) []*tagplan.FloatingPlan {
+	var refs []*tagplan.FloatingPlan
+	if cfg.FloatingLevel.MaintainsMajor() {
+		refs = append(refs, &plan.Floating)
+	}
+	if cfg.FloatingLevel.MaintainsMinor() && plan.Floating.Minor != nil {
+		refs = append(refs, plan.Floating.Minor)
+	}
+	return refs
+}
@@ -0,0 +1,126 @@
+package tagging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado/adotest"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+func TestParseFloatingLevel(t *testing.T) {
+	t.Parallel()
+
+	for input, want := range map[string]FloatingLevel{"": FloatingLevelMajor, "major": FloatingLevelMajor, "Minor": FloatingLevelMinor, " both ": FloatingLevelBoth} {
+		got, err := ParseFloatingLevel(input)
+		if err != nil || got != want {
+			t.Fatalf("ParseFloatingLevel(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+	if _, err := ParseFloatingLevel("patch"); err == nil {
+		t.Fatal("expected an error for an unknown level")
+	}
+}
+
+// floatingTagsWritten returns the floating tag names written to client, however they were created.
+func floatingTagsWritten(client *adotest.Client) map[string]bool {
+	written := map[string]bool{}
+	for _, spec := range client.CreatedTags {
+		written[spec.Name] = true
+	}
+	for _, name := range client.LightweightTags {
+		written[name[len("refs/tags/"):]] = true
+	}
+	delete(written, "v1.2.4")
+	return written
+}
+
+func TestPlanAndCreateFloatingLevels(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		level       FloatingLevel
+		useFloating bool
+		// existingMinor seeds v1.2 so it is found without floating tags being requested.
+		existingMinor bool
+		want          []string
+	}{
+		{level: FloatingLevelMajor, useFloating: true, want: []string{"v1"}},
+		{level: FloatingLevelMinor, useFloating: true, want: []string{"v1.2"}},
+		{level: FloatingLevelBoth, useFloating: true, want: []string{"v1", "v1.2"}},
+		{level: FloatingLevelBoth, existingMinor: true, want: []string{"v1.2"}},
+	}
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("%s/requested=%t", tc.level, tc.useFloating), func(t *testing.T) {
+			t.Parallel()
+
+			client := adotest.NewClient()
+			client.SeedAnnotatedTag(sampleReleaseTag, "release-tag-object", sampleReleaseObjectID)
+			if tc.existingMinor {
+				client.SeedAnnotatedTag("refs/tags/v1.2", "minor-floating-object", sampleReleaseObjectID)
+			}
+
+			cfg := baseCreateConfig("deadbeef")
+			cfg.UseFloatingTags, cfg.FloatingLevel = tc.useFloating, tc.level
+
+			result, err := NewService(client, tagplan.NewPlanner("v")).PlanAndCreate(context.Background(), cfg)
+			if err != nil {
+				t.Fatalf("plan and create: %v", err)
+			}
+			written := floatingTagsWritten(client)
+			if len(written) != len(tc.want) {
+				t.Fatalf("expected floating tags %v, got %v", tc.want, written)
+			}
+			for _, name := range tc.want {
+				if !written[name] {
+					t.Fatalf("expected floating tag %s, got %v", name, written)
+				}
+			}
+			if tc.level.MaintainsMinor() && (result.Floating.Minor == nil || !result.Floating.Minor.Created) {
+				t.Fatalf("expected the minor floating tag reported as created, got %+v", result.Floating.Minor)
+			}
+			if tc.existingMinor && (!result.Floating.Minor.AutoDetected || !result.Floating.Minor.DeletedExisting) {
+				t.Fatalf("expected v1.2 auto-detected and replaced, got %+v", result.Floating.Minor)
+			}
+		})
+	}
+}
+
+func TestApplyPlanReplaysMinorFloating(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	client.SeedAnnotatedTag(sampleReleaseTag, "release-tag-object", sampleReleaseObjectID)
+	client.SeedAnnotatedTag("refs/tags/v1.2", "minor-floating-object", sampleReleaseObjectID)
+
+	cfg := baseCreateConfig("deadbeef")
+	cfg.UseFloatingTags, cfg.FloatingLevel = true, FloatingLevelMinor
+	plan, err := NewService(client, tagplan.NewPlanner("v")).Preview(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("preview: %v", err)
+	}
+	data, err := json.Marshal(NewSavedPlan(cfg, plan))
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	saved, err := ParseSavedPlan(data)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if saved.Floating != nil || saved.MinorFloating == nil || saved.MinorFloating.TagName != "v1.2" {
+		t.Fatalf("expected only the minor floating tag saved, got floating=%+v minor=%+v", saved.Floating, saved.MinorFloating)
+	}
+
+	result, err := NewService(client, tagplan.NewPlanner("v")).ApplyPlan(context.Background(), saved)
+	if err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if result.Floating.Minor == nil || !result.Floating.Minor.Created {
+		t.Fatalf("expected v1.2 moved on apply, got %+v", result.Floating.Minor)
+	}
+	if len(client.DeletedRefs) != 1 || client.DeletedRefs[0].OldObjectID != "minor-floating-object" {
+		t.Fatalf("expected v1.2 replaced with its current object id, got %+v", client.DeletedRefs)
+	}
+}
@@ -0,0 +1,22 @@
+package tagging
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+// VerifyFloatingMajors lists the repository's tags and compares its floating major tags with
+// the release majors.
+func (s Service) VerifyFloatingMajors(ctx context.Context) (tagplan.FloatingMajors, error) {
+	if s.client == nil {
+		return tagplan.FloatingMajors{}, ErrNilClient
+	}
+
+	refs, err := s.client.ListRefsWithPrefix(ctx, tagRefPrefix)
+	if err != nil {
+		return tagplan.FloatingMajors{}, fmt.Errorf("listing refs: %w", err)
+	}
+	return s.planner.FloatingMajors(toPlannerTags(refs)), nil
+}
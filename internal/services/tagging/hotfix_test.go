@@ -0,0 +1,81 @@
+package tagging
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado/adotest"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+func TestPlanAndCreateHotfixTargetsBaseMajorFloating(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	client.SeedAnnotatedTag("v1.2.3", "tag-123", "commit-123")
+	client.SeedAnnotatedTag("v2.0.0", "tag-200", "commit-200")
+	client.SeedAnnotatedTag("v1", "floating-v1", "commit-123")
+	client.SeedAnnotatedTag("v2", "floating-v2", "commit-200")
+	svc := NewService(client, tagplan.NewPlanner("v"))
+
+	result, err := svc.PlanAndCreate(context.Background(), CreateConfig{
+		Config:      Config{Mode: tagplan.ModeRelease, Bump: bump.BumpMajor, HotfixBase: "v1.2.3", UseFloatingTags: true},
+		CommitSHA:   "hotfix-commit",
+		TaggerName:  taggerNameDefault,
+		TaggerEmail: taggerEmailDefault,
+	})
+	if err != nil {
+		t.Fatalf("plan and create: %v", err)
+	}
+
+	if result.TagName != "v1.2.4" || result.BaseSource != tagplan.BaseSourceHotfix {
+		t.Fatalf("expected hotfix v1.2.4, got %s (%s)", result.TagName, result.BaseSource)
+	}
+	if result.Floating.TagName != "v1" || !result.Floating.Created {
+		t.Fatalf("expected v1 floating tag to move, got %+v", result.Floating)
+	}
+	if ref, _ := client.Ref("v2"); ref.PeeledObjectID != "commit-200" {
+		t.Fatalf("v2 floating tag must not move, got %+v", ref)
+	}
+	if ref, _ := client.Ref("v1"); ref.PeeledObjectID != "hotfix-commit" {
+		t.Fatalf("expected v1 to point at the hotfix, got %+v", ref)
+	}
+}
+
+func TestPlanAndCreateHotfixDoesNotRegressFloating(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	client.SeedAnnotatedTag("v1.2.3", "tag-123", "commit-123")
+	client.SeedAnnotatedTag("v1.3.0", "tag-130", "commit-130")
+	client.SeedAnnotatedTag("v1", "floating-v1", "commit-130")
+	svc := NewService(client, tagplan.NewPlanner("v"))
+
+	result, err := svc.PlanAndCreate(context.Background(), CreateConfig{
+		Config:      Config{Mode: tagplan.ModeRelease, HotfixBase: "v1.2.3", UseFloatingTags: true},
+		CommitSHA:   "hotfix-commit",
+		TaggerName:  taggerNameDefault,
+		TaggerEmail: taggerEmailDefault,
+	})
+	if err != nil {
+		t.Fatalf("plan and create: %v", err)
+	}
+	if result.Floating.Enabled || !result.Floating.Superseded {
+		t.Fatalf("expected superseded floating tag to stay put, got %+v", result.Floating)
+	}
+	if ref, _ := client.Ref("v1"); ref.PeeledObjectID != "commit-130" {
+		t.Fatalf("v1 must keep tracking v1.3.0, got %+v", ref)
+	}
+}
+
+func TestPlanHotfixRequiresReleaseMode(t *testing.T) {
+	t.Parallel()
+
+	svc := NewService(adotest.NewClient(), tagplan.NewPlanner("v"))
+	_, err := svc.Plan(context.Background(), Config{Mode: tagplan.ModeRC, HotfixBase: "v1.2.3"})
+	if !errors.Is(err, ErrHotfixRequiresRelease) {
+		t.Fatalf("expected ErrHotfixRequiresRelease, got %v", err)
+	}
+}
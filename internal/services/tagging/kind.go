@@ -0,0 +1,37 @@
+package tagging
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TagKind selects whether a tag is written as an annotated tag object or a lightweight ref.
+type TagKind string
+
+const (
+	// TagKindAnnotated creates a tag object carrying the tagger and message.
+	TagKindAnnotated TagKind = "annotated"
+	// TagKindLightweight creates a ref pointing directly at the commit.
+	TagKindLightweight TagKind = "lightweight"
+)
+
+// ParseTagKind converts a string into a TagKind. Empty values map to TagKindAnnotated.
+func ParseTagKind(value string) (TagKind, error) {
+	switch TagKind(strings.ToLower(strings.TrimSpace(value))) {
+	case "", TagKindAnnotated:
+		return TagKindAnnotated, nil
+	case TagKindLightweight:
+		return TagKindLightweight, nil
+	default:
+		return "", fmt.Errorf("invalid tag kind %q", value)
+	}
+}
+
+// floatingKind resolves the kind used for the floating ref; FloatingSkipCILightweight implies
+// lightweight regardless of FloatingTagKind.
+func floatingKind(cfg CreateConfig) TagKind {
+	if cfg.FloatingSkipCI == FloatingSkipCILightweight || cfg.FloatingTagKind == TagKindLightweight {
+		return TagKindLightweight
+	}
+	return TagKindAnnotated
+}
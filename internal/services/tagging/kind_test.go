@@ -0,0 +1,110 @@
+package tagging
+
+import (
+	"context"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado/adotest"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+func TestPlanAndCreateMovesLightweightFloatingTag(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	client.SeedAnnotatedTag(sampleReleaseTag, "release-tag-object", sampleReleaseObjectID)
+	client.SeedAnnotatedTag("v1", "floating-tag-object", sampleReleaseObjectID)
+	svc := NewService(client, tagplan.NewPlanner("v"))
+
+	result, err := svc.PlanAndCreate(context.Background(), CreateConfig{
+		Config:          Config{Mode: tagplan.ModeRelease, Bump: bump.BumpPatch},
+		CommitSHA:       "deadbeef",
+		TaggerName:      taggerNameDefault,
+		TaggerEmail:     taggerEmailDefault,
+		FloatingTagKind: TagKindLightweight,
+	})
+	if err != nil {
+		t.Fatalf("plan and create: %v", err)
+	}
+
+	if !result.Floating.Moved || result.Floating.DeletedExisting || !result.Floating.Created {
+		t.Fatalf("expected floating tag to be moved in place: %+v", result.Floating)
+	}
+	if len(client.DeletedRefs) != 0 || len(client.CreatedTags) != 1 {
+		t.Fatalf("expected only the release tag to be created, deleted %v created %d", client.DeletedRefs, len(client.CreatedTags))
+	}
+	if len(client.UpdatedRefs) != 1 || client.UpdatedRefs[0].OldObjectID != "floating-tag-object" {
+		t.Fatalf("expected a compare-and-swap from the current ref object, got %+v", client.UpdatedRefs)
+	}
+	if f := result.Floating; f.PreviousObjectID != "floating-tag-object" || f.PreviousCommit != sampleReleaseObjectID || f.TargetCommit != "deadbeef" {
+		t.Fatalf("unexpected floating audit fields %+v", f)
+	}
+	ref, _ := client.Ref("v1")
+	if ref.ObjectID != "deadbeef" || ref.PeeledObjectID != "" {
+		t.Fatalf("unexpected floating ref %+v", ref)
+	}
+}
+
+func TestPlanAndCreateCreatesLightweightFloatingTag(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	client.SeedAnnotatedTag(sampleReleaseTag, "release-tag-object", sampleReleaseObjectID)
+	svc := NewService(client, tagplan.NewPlanner("v"))
+
+	result, err := svc.PlanAndCreate(context.Background(), CreateConfig{
+		Config:          Config{Mode: tagplan.ModeRelease, Bump: bump.BumpMajor, UseFloatingTags: true},
+		CommitSHA:       "deadbeef",
+		TaggerName:      taggerNameDefault,
+		TaggerEmail:     taggerEmailDefault,
+		FloatingTagKind: TagKindLightweight,
+	})
+	if err != nil {
+		t.Fatalf("plan and create: %v", err)
+	}
+	if result.Floating.Moved || !result.Floating.Created {
+		t.Fatalf("expected new floating ref: %+v", result.Floating)
+	}
+	if len(client.LightweightTags) != 1 || client.LightweightTags[0] != "refs/tags/v2" {
+		t.Fatalf("expected lightweight v2, got %v", client.LightweightTags)
+	}
+}
+
+func TestPlanAndCreateLightweightReleaseTag(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	svc := NewService(client, tagplan.NewPlanner("v"))
+
+	result, err := svc.PlanAndCreate(context.Background(), CreateConfig{
+		Config:      Config{Mode: tagplan.ModeRC, Bump: bump.BumpMinor, BaseVersion: "1.0.0"},
+		CommitSHA:   "deadbeef",
+		TaggerName:  taggerNameDefault,
+		TaggerEmail: taggerEmailDefault,
+		TagKind:     TagKindLightweight,
+	})
+	if err != nil {
+		t.Fatalf("plan and create: %v", err)
+	}
+	if len(client.CreatedTags) != 0 || len(client.LightweightTags) != 1 {
+		t.Fatalf("expected only a lightweight tag, got annotated %d lightweight %v", len(client.CreatedTags), client.LightweightTags)
+	}
+	if ref, ok := client.Ref(result.TagName); !ok || ref.ObjectID != "deadbeef" {
+		t.Fatalf("unexpected release ref %+v", ref)
+	}
+}
+
+func TestParseTagKind(t *testing.T) {
+	t.Parallel()
+
+	for input, want := range map[string]TagKind{"": TagKindAnnotated, "Annotated": TagKindAnnotated, " lightweight ": TagKindLightweight} {
+		got, err := ParseTagKind(input)
+		if err != nil || got != want {
+			t.Fatalf("ParseTagKind(%q) = %q, %v", input, got, err)
+		}
+	}
+	if _, err := ParseTagKind("signed"); err == nil {
+		t.Fatalf("expected error for unknown kind")
+	}
+}
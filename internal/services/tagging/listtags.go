@@ -0,0 +1,22 @@
+package tagging
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+// ListTags lists the repository's tags and classifies them with the planner, keeping the tags
+// planning would skip.
+func (s Service) ListTags(ctx context.Context, filter tagplan.InventoryFilter) (tagplan.Inventory, error) {
+	if s.client == nil {
+		return tagplan.Inventory{}, ErrNilClient
+	}
+
+	refs, err := s.client.ListRefsWithPrefix(ctx, tagRefPrefix)
+	if err != nil {
+		return tagplan.Inventory{}, fmt.Errorf("listing refs: %w", err)
+	}
+	return s.planner.Inventory(toPlannerTags(refs), filter)
+}
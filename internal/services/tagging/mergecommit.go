@@ -0,0 +1,28 @@
+package tagging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrNotMergeCommit is returned under RequireMergeCommit when the target commit has a single parent.
+var ErrNotMergeCommit = errors.New("tagging service: commit is not a merge commit")
+
+// checkMergeCommit requires the target commit to have more than one parent when
+// cfg.RequireMergeCommit is set.
+func (s Service) checkMergeCommit(ctx context.Context, cfg CreateConfig) error {
+	if !cfg.RequireMergeCommit {
+		return nil
+	}
+	sha := strings.TrimSpace(cfg.CommitSHA)
+	commit, err := s.client.GetCommit(ctx, sha)
+	if err != nil {
+		return fmt.Errorf("reading commit %s: %w", sha, err)
+	}
+	if len(commit.Parents) < 2 {
+		return fmt.Errorf("%w: %s has %d parent(s)", ErrNotMergeCommit, sha, len(commit.Parents))
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package tagging
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado/adotest"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+func TestPlanAndCreateRequireMergeCommit(t *testing.T) {
+	t.Parallel()
+
+	const commit = "deadbeef"
+
+	tests := []struct {
+		name      string
+		require   bool
+		parents   []string
+		expectErr error
+	}{
+		{name: "merge commit", require: true, parents: []string{"base", "topic"}},
+		{name: "single parent", require: true, parents: []string{"base"}, expectErr: ErrNotMergeCommit},
+		{name: "guard disabled", parents: []string{"base"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := adotest.NewClient()
+			client.SeedAnnotatedTag(sampleReleaseTag, "release-tag-object", sampleReleaseObjectID)
+			client.CommitParents = map[string][]string{commit: tc.parents}
+			svc := NewService(client, tagplan.NewPlanner("v"))
+
+			_, err := svc.PlanAndCreate(context.Background(), CreateConfig{
+				Config:             Config{Mode: tagplan.ModeRelease, Bump: bump.BumpPatch},
+				CommitSHA:          commit,
+				TaggerName:         taggerNameDefault,
+				TaggerEmail:        taggerEmailDefault,
+				RequireMergeCommit: tc.require,
+			})
+
+			if tc.expectErr != nil {
+				if !errors.Is(err, tc.expectErr) {
+					t.Fatalf("expected %v, got %v", tc.expectErr, err)
+				}
+				if len(client.CreatedTags) != 0 {
+					t.Fatalf("expected no tags to be created, got %d", len(client.CreatedTags))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("plan and create: %v", err)
+			}
+			if len(client.CreatedTags) != 1 {
+				t.Fatalf("expected one tag to be created, got %d", len(client.CreatedTags))
+			}
+		})
+	}
+}
+
+func TestPreviewRequiresMergeCommit(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	client.SeedAnnotatedTag(sampleReleaseTag, "release-tag-object", sampleReleaseObjectID)
+	client.CommitParents = map[string][]string{"deadbeef": {"base"}}
+	svc := NewService(client, tagplan.NewPlanner("v"))
+
+	_, err := svc.Preview(context.Background(), CreateConfig{
+		Config:             Config{Mode: tagplan.ModeRelease, Bump: bump.BumpPatch},
+		CommitSHA:          "deadbeef",
+		TaggerName:         taggerNameDefault,
+		TaggerEmail:        taggerEmailDefault,
+		RequireMergeCommit: true,
+	})
+	if !errors.Is(err, ErrNotMergeCommit) {
+		t.Fatalf("expected %v, got %v", ErrNotMergeCommit, err)
+	}
+}
@@ -0,0 +1,12 @@
+package tagging
+
+import (
+	"strings"
+	"unicode"
+)
+
+// ParseTagMessage returns the contents of a tag message file as the annotated tag message.
+// Line breaks and leading indentation are kept; only trailing whitespace is dropped.
+func ParseTagMessage(data []byte) string {
+	return strings.TrimRightFunc(string(data), unicode.IsSpace)
+}
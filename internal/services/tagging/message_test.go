@@ -0,0 +1,42 @@
+package tagging
+
+import (
+	"context"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado/adotest"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+func TestParseTagMessageKeepsLines(t *testing.T) {
+	t.Parallel()
+
+	got := ParseTagMessage([]byte("Release notes\n\n  - fix login\n  - faster builds\n\n"))
+	want := "Release notes\n\n  - fix login\n  - faster builds"
+	if got != want {
+		t.Fatalf("ParseTagMessage = %q, want %q", got, want)
+	}
+}
+
+func TestPlanAndCreateUsesMultiLineMessage(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	client.SeedAnnotatedTag(sampleReleaseTag, "release-tag-object", sampleReleaseObjectID)
+
+	message := ParseTagMessage([]byte("v1.2.4\n\n- fix login\n- faster builds\n"))
+	cfg := CreateConfig{
+		Config:      Config{Mode: tagplan.ModeRelease, Bump: bump.BumpPatch},
+		CommitSHA:   "deadbeef",
+		Message:     message,
+		TaggerName:  taggerNameDefault,
+		TaggerEmail: taggerEmailDefault,
+	}
+	if _, err := NewService(client, tagplan.NewPlanner("v")).PlanAndCreate(context.Background(), cfg); err != nil {
+		t.Fatalf("plan and create: %v", err)
+	}
+	if len(client.CreatedTags) != 1 || client.CreatedTags[0].Message != "v1.2.4\n\n- fix login\n- faster builds" {
+		t.Fatalf("expected the multi-line message on the tag, got %+v", client.CreatedTags)
+	}
+}
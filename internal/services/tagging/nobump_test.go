@@ -0,0 +1,48 @@
+package tagging
+
+import (
+	"context"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado/adotest"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+func TestPlanAndCreateNoBumpIsNoop(t *testing.T) {
+	t.Parallel()
+
+	for _, mode := range []tagplan.Mode{tagplan.ModeRelease, tagplan.ModeRC} {
+		t.Run(string(mode), func(t *testing.T) {
+			t.Parallel()
+
+			client := adotest.NewClient()
+			client.SeedAnnotatedTag(sampleReleaseTag, "release-tag-object", sampleReleaseObjectID)
+			svc := NewService(client, tagplan.NewPlanner("v"))
+
+			cfg := CreateConfig{
+				Config:      Config{Mode: mode, Bump: bump.BumpNone, UseFloatingTags: true},
+				CommitSHA:   "deadbeef",
+				TaggerName:  taggerNameDefault,
+				TaggerEmail: taggerEmailDefault,
+			}
+			preview, err := svc.Preview(context.Background(), cfg)
+			if err != nil {
+				t.Fatalf("preview: %v", err)
+			}
+			result, err := svc.PlanAndCreate(context.Background(), cfg)
+			if err != nil {
+				t.Fatalf("plan and create: %v", err)
+			}
+
+			for _, plan := range []tagplan.Result{preview, result} {
+				if !plan.Skipped || plan.SkipReason != tagplan.NoBumpReason || plan.TagName != "v1.2.3" {
+					t.Fatalf("expected no-bump skip naming v1.2.3, got %s skipped=%t reason=%q", plan.TagName, plan.Skipped, plan.SkipReason)
+				}
+			}
+			if len(client.CreatedTags) > 0 || len(client.LightweightTags) > 0 || len(client.DeletedRefs) > 0 || len(client.UpdatedRefs) > 0 {
+				t.Fatalf("expected no writes, got created=%v lightweight=%v deleted=%v updated=%v", client.CreatedTags, client.LightweightTags, client.DeletedRefs, client.UpdatedRefs)
+			}
+		})
+	}
+}
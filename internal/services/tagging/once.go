@@ -0,0 +1,39 @@
+package tagging
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+// checkOnce turns a release plan into a skip when cfg.Once is set and the target commit already
+// carries a release tag. The result then names that tag, so callers read the existing release as
+// the outcome instead of a second version on the same commit.
+func (s Service) checkOnce(ctx context.Context, cfg CreateConfig, plan *tagplan.Result) error {
+	if !cfg.Once || plan.Skipped || plan.Mode != tagplan.ModeRelease {
+		return nil
+	}
+	refs, err := s.client.ListRefsWithPrefix(ctx, tagRefPrefix)
+	if err != nil {
+		return fmt.Errorf("listing refs: %w", err)
+	}
+	commit := strings.TrimSpace(cfg.CommitSHA)
+	tag, version, ok := s.planner.ReleaseAtCommit(toPlannerTags(refs), commit)
+	if !ok {
+		return nil
+	}
+
+	name := shortTagName(tag.Name)
+	*plan = tagplan.Result{
+		Mode:            tagplan.ModeRelease,
+		TagName:         name,
+		Version:         version,
+		TargetRelease:   version,
+		Skipped:         true,
+		AlreadyReleased: true,
+		SkipReason:      fmt.Sprintf("commit %s is already released as %s", commit, name),
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package tagging
+
+import (
+	"context"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado/adotest"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+func TestPlanAndCreateOnce(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		once        bool
+		commit      string
+		expectTag   string
+		expectSkip  bool
+		expectWrite bool
+	}{
+		{name: "commit already released", once: true, commit: sampleReleaseObjectID, expectTag: "v1.2.3", expectSkip: true},
+		{name: "guard disabled tags the commit again", commit: sampleReleaseObjectID, expectTag: "v1.2.4", expectWrite: true},
+		{name: "untagged commit is released", once: true, commit: "deadbeef", expectTag: "v1.2.4", expectWrite: true},
+		{name: "rc on the commit does not count", once: true, commit: "rc-commit", expectTag: "v1.2.4", expectWrite: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := adotest.NewClient()
+			client.SeedAnnotatedTag(sampleReleaseTag, "release-tag-object", sampleReleaseObjectID)
+			client.SeedAnnotatedTag("v1.2.4-rc.1", "rc-tag-object", "rc-commit")
+			svc := NewService(client, tagplan.NewPlanner("v"))
+
+			result, err := svc.PlanAndCreate(context.Background(), CreateConfig{
+				Config:      Config{Mode: tagplan.ModeRelease, Bump: bump.BumpPatch, UseFloatingTags: true},
+				CommitSHA:   tc.commit,
+				TaggerName:  taggerNameDefault,
+				TaggerEmail: taggerEmailDefault,
+				Once:        tc.once,
+			})
+			if err != nil {
+				t.Fatalf("plan and create: %v", err)
+			}
+			if result.TagName != tc.expectTag || result.Skipped != tc.expectSkip {
+				t.Fatalf("expected %s skipped=%t, got %s skipped=%t", tc.expectTag, tc.expectSkip, result.TagName, result.Skipped)
+			}
+			if tc.expectSkip && (result.SkipReason == "" || !result.AlreadyReleased) {
+				t.Fatal("expected a skip reason naming the existing release")
+			}
+			if wrote := len(client.CreatedTags) > 0; wrote != tc.expectWrite {
+				t.Fatalf("expected writes=%t, got %+v", tc.expectWrite, client.CreatedTags)
+			}
+		})
+	}
+}
+
+func TestPreviewOnceReportsExistingRelease(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	client.SeedAnnotatedTag(sampleReleaseTag, "release-tag-object", sampleReleaseObjectID)
+
+	result, err := NewService(client, tagplan.NewPlanner("v")).Preview(context.Background(), CreateConfig{
+		Config:      Config{Mode: tagplan.ModeRelease, Bump: bump.BumpMinor},
+		CommitSHA:   sampleReleaseObjectID,
+		TaggerName:  taggerNameDefault,
+		TaggerEmail: taggerEmailDefault,
+		Once:        true,
+	})
+	if err != nil {
+		t.Fatalf("preview: %v", err)
+	}
+	if !result.Skipped || result.TagName != "v1.2.3" {
+		t.Fatalf("expected the existing release to be reported, got %+v", result)
+	}
+}
@@ -0,0 +1,22 @@
+package tagging
+
+import (
+	"strings"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+// RequiredPermissions lists the repository permissions needed to write plan, as returned by
+// Preview or PreviewPromotion for cfg. Replacing an existing floating tag deletes or moves a
+// tag, which Azure DevOps gates behind Force push; this includes floating tags that were
+// auto-detected rather than requested with UseFloatingTags.
+func RequiredPermissions(cfg CreateConfig, plan tagplan.Result) []ado.Permission {
+	required := []ado.Permission{ado.PermissionCreateTag}
+	for _, floating := range floatingRefs(cfg, &plan) {
+		if floating.Enabled && !floating.AlreadyExists && strings.TrimSpace(floating.Existing.Name) != "" {
+			return append(required, ado.PermissionForcePush)
+		}
+	}
+	return required
+}
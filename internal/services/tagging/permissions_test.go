@@ -0,0 +1,49 @@
+package tagging
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado/adotest"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+func TestRequiredPermissions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		floatingTag string
+		useFloating bool
+		want        []ado.Permission
+	}{
+		{name: "no floating tags", want: []ado.Permission{ado.PermissionCreateTag}},
+		{name: "new floating tag", useFloating: true, want: []ado.Permission{ado.PermissionCreateTag}},
+		{name: "requested floating tag replaced", floatingTag: "refs/tags/v1", useFloating: true, want: []ado.Permission{ado.PermissionCreateTag, ado.PermissionForcePush}},
+		// v1 is moved because it exists, even though floating tags were not requested.
+		{name: "auto-detected floating tag replaced", floatingTag: "refs/tags/v1", want: []ado.Permission{ado.PermissionCreateTag, ado.PermissionForcePush}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := adotest.NewClient()
+			client.SeedAnnotatedTag(sampleReleaseTag, "release-tag-object", sampleReleaseObjectID)
+			if tc.floatingTag != "" {
+				client.SeedAnnotatedTag(tc.floatingTag, "floating-tag-object", sampleReleaseObjectID)
+			}
+			cfg := baseCreateConfig("deadbeef")
+			cfg.UseFloatingTags = tc.useFloating
+
+			plan, err := NewService(client, tagplan.NewPlanner("v")).Preview(context.Background(), cfg)
+			if err != nil {
+				t.Fatalf("preview: %v", err)
+			}
+			if got := RequiredPermissions(cfg, plan); !slices.Equal(got, tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,247 @@
+package tagging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	semver "github.com/blang/semver/v4"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+// planFormat versions the SavedPlan layout so older binaries refuse plans they cannot replay.
+const planFormat = 1
+
+var (
+	// ErrInvalidPlan is returned when a saved plan cannot be decoded or is incomplete.
+	ErrInvalidPlan = errors.New("tagging service: invalid saved plan")
+	// ErrPlanStale is returned when the planned tag was created after the plan was saved.
+	ErrPlanStale = errors.New("tagging service: planned tag already exists")
+)
+
+// SavedPlan is the applyable artifact written by create-tag --plan-only: the computed tag, the
+// tags that informed it, the floating action, and the tag metadata needed to replay it.
+type SavedPlan struct {
+	Format        int    `json:"format"`
+	Mode          string `json:"mode"`
+	Bump          string `json:"bump"`
+	TagName       string `json:"tagName"`
+	Version       string `json:"version"`
+	Commit        string `json:"commit"`
+	ReleaseBase   string `json:"releaseBase"`
+	BaseSource    string `json:"baseSource"`
+	BaseTag       string `json:"baseTag,omitempty"`
+	TargetRelease string `json:"targetRelease"`
+	RCNumber      int    `json:"rcNumber,omitempty"`
+
+	Message        string `json:"message,omitempty"`
+	TaggerName     string `json:"taggerName"`
+	TaggerEmail    string `json:"taggerEmail"`
+	TagKind        string `json:"tagKind,omitempty"`
+	FloatingKind   string `json:"floatingKind,omitempty"`
+	FloatingSkipCI string `json:"floatingSkipCI,omitempty"`
+	SkipCIMarker   string `json:"skipCIMarker,omitempty"`
+	// Operator records who planned the release, for review only.
+	Operator string `json:"operator,omitempty"`
+
+	// Floating is set when the plan moves or creates a floating tag; Replaces names the ref it
+	// pointed at planning time, for review only, since apply re-reads it.
+	Floating *SavedFloating `json:"floating,omitempty"`
+	// MinorFloating is the same for the v<major>.<minor> floating tag.
+	MinorFloating *SavedFloating `json:"minorFloating,omitempty"`
+}
+
+// SavedFloating records the floating tag action of a saved plan.
+type SavedFloating struct {
+	TagName  string `json:"tagName"`
+	Replaces string `json:"replaces,omitempty"`
+}
+
+// NewSavedPlan captures a Preview result and the config it was computed with.
+func NewSavedPlan(cfg CreateConfig, plan tagplan.Result) SavedPlan {
+	saved := SavedPlan{
+		Format:         planFormat,
+		Mode:           string(plan.Mode),
+		Bump:           cfg.Bump.String(),
+		TagName:        plan.TagName,
+		Version:        plan.Version.String(),
+		Commit:         strings.TrimSpace(cfg.CommitSHA),
+		ReleaseBase:    plan.ReleaseBase.String(),
+		BaseSource:     string(plan.BaseSource),
+		BaseTag:        plan.BaseTag.Name,
+		TargetRelease:  plan.TargetRelease.String(),
+		RCNumber:       plan.RCNumber,
+		Message:        strings.TrimSpace(cfg.Message),
+		TaggerName:     strings.TrimSpace(cfg.TaggerName),
+		TaggerEmail:    strings.TrimSpace(cfg.TaggerEmail),
+		TagKind:        string(cfg.TagKind),
+		FloatingKind:   string(cfg.FloatingTagKind),
+		FloatingSkipCI: string(cfg.FloatingSkipCI),
+		SkipCIMarker:   cfg.SkipCIMarker,
+	}
+	if plan.FloatingEligible() && plan.Floating.Enabled {
+		saved.Floating = &SavedFloating{TagName: plan.Floating.TagName, Replaces: plan.Floating.Existing.Name}
+	}
+	if minor := plan.Floating.Minor; plan.FloatingEligible() && minor != nil && minor.Enabled {
+		saved.MinorFloating = &SavedFloating{TagName: minor.TagName, Replaces: minor.Existing.Name}
+	}
+	return saved
+}
+
+// ParseSavedPlan decodes a plan written by NewSavedPlan and checks it is complete.
+func ParseSavedPlan(data []byte) (SavedPlan, error) {
+	var saved SavedPlan
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return SavedPlan{}, fmt.Errorf("%w: %v", ErrInvalidPlan, err)
+	}
+	if saved.Format != planFormat {
+		return SavedPlan{}, fmt.Errorf("%w: unsupported format %d", ErrInvalidPlan, saved.Format)
+	}
+	if mode := tagplan.Mode(saved.Mode); mode != tagplan.ModeRelease && mode != tagplan.ModeRC {
+		return SavedPlan{}, fmt.Errorf("%w: unknown mode %q", ErrInvalidPlan, saved.Mode)
+	}
+	if saved.TagName == "" || saved.Commit == "" || saved.TaggerName == "" || saved.TaggerEmail == "" {
+		return SavedPlan{}, fmt.Errorf("%w: tag name, commit, and tagger are required", ErrInvalidPlan)
+	}
+	if _, err := saved.result(); err != nil {
+		return SavedPlan{}, err
+	}
+	return saved, nil
+}
+
+// config rebuilds the CreateConfig the plan was computed with.
+func (p SavedPlan) config() CreateConfig {
+	return CreateConfig{
+		Config: Config{
+			Mode:            tagplan.Mode(p.Mode),
+			Bump:            bump.Bump(p.Bump),
+			UseFloatingTags: p.Floating != nil || p.MinorFloating != nil,
+			FloatingLevel:   p.floatingLevel(),
+		},
+		CommitSHA:       p.Commit,
+		Message:         p.Message,
+		TaggerName:      p.TaggerName,
+		TaggerEmail:     p.TaggerEmail,
+		FloatingSkipCI:  FloatingSkipCI(p.FloatingSkipCI),
+		SkipCIMarker:    p.SkipCIMarker,
+		TagKind:         TagKind(p.TagKind),
+		FloatingTagKind: TagKind(p.FloatingKind),
+	}
+}
+
+// floatingLevel derives the floating level from the floating tags the plan saved.
+func (p SavedPlan) floatingLevel() FloatingLevel {
+	switch {
+	case p.Floating != nil && p.MinorFloating != nil:
+		return FloatingLevelBoth
+	case p.MinorFloating != nil:
+		return FloatingLevelMinor
+	default:
+		return FloatingLevelMajor
+	}
+}
+
+// result rebuilds the planned tag; the floating plan is filled in by ApplyPlan.
+func (p SavedPlan) result() (tagplan.Result, error) {
+	versions := make([]semver.Version, 3)
+	for i, value := range []string{p.Version, p.ReleaseBase, p.TargetRelease} {
+		parsed, err := semver.Parse(value)
+		if err != nil {
+			return tagplan.Result{}, fmt.Errorf("%w: %v", ErrInvalidPlan, err)
+		}
+		versions[i] = parsed
+	}
+	return tagplan.Result{
+		Mode:          tagplan.Mode(p.Mode),
+		TagName:       p.TagName,
+		Version:       versions[0],
+		ReleaseBase:   versions[1],
+		BaseSource:    tagplan.BaseSource(p.BaseSource),
+		BaseTag:       tagplan.Tag{Name: p.BaseTag},
+		TargetRelease: versions[2],
+		RCNumber:      p.RCNumber,
+	}, nil
+}
+
+// ApplyPlan replays a saved plan without re-planning: it verifies the commit still exists and
+// the tag is still free, creates the tag, and then points the floating tag at the commit,
+// replacing whatever that ref holds at apply time.
+func (s Service) ApplyPlan(ctx context.Context, saved SavedPlan) (tagplan.Result, error) {
+	if s.client == nil {
+		return tagplan.Result{}, ErrNilClient
+	}
+	plan, err := saved.result()
+	if err != nil {
+		return tagplan.Result{}, err
+	}
+	cfg := saved.config()
+
+	exists, err := s.client.CommitExists(ctx, cfg.CommitSHA)
+	if err != nil {
+		return tagplan.Result{}, fmt.Errorf("checking commit %s: %w", cfg.CommitSHA, err)
+	}
+	if !exists {
+		return tagplan.Result{}, fmt.Errorf("%w: %s", ErrCommitNotFound, cfg.CommitSHA)
+	}
+
+	if _, err := s.client.GetRefObjectID(ctx, tagRefPrefix+plan.TagName); err == nil {
+		return tagplan.Result{}, fmt.Errorf("%w: %s", ErrPlanStale, plan.TagName)
+	} else if !errors.Is(err, ado.ErrRefNotFound) {
+		return tagplan.Result{}, fmt.Errorf("checking tag %s: %w", plan.TagName, err)
+	}
+
+	spec := ado.TagSpec{
+		Name:        plan.TagName,
+		ObjectID:    cfg.CommitSHA,
+		ObjectType:  ado.TagObjectTypeCommit,
+		Message:     cfg.Message,
+		TaggerName:  cfg.TaggerName,
+		TaggerEmail: cfg.TaggerEmail,
+	}
+	if cfg.TagKind == TagKindLightweight {
+		if err := s.client.CreateLightweightTag(ctx, spec.Name, spec.ObjectID); err != nil {
+			return tagplan.Result{}, fmt.Errorf("creating lightweight tag: %w", err)
+		}
+	} else if err := s.client.CreateAnnotatedTag(ctx, spec); err != nil {
+		return tagplan.Result{}, fmt.Errorf("creating annotated tag: %w", err)
+	}
+
+	if saved.Floating == nil && saved.MinorFloating == nil {
+		return plan, nil
+	}
+	if saved.Floating != nil {
+		if plan.Floating, err = s.currentFloating(ctx, *saved.Floating); err != nil {
+			return tagplan.Result{}, err
+		}
+	}
+	if saved.MinorFloating != nil {
+		minor, err := s.currentFloating(ctx, *saved.MinorFloating)
+		if err != nil {
+			return tagplan.Result{}, err
+		}
+		plan.Floating.Minor = &minor
+	}
+	if err := s.applyFloatingTag(ctx, cfg, &plan, spec); err != nil {
+		return tagplan.Result{}, err
+	}
+	return plan, nil
+}
+
+// currentFloating reads the ref a saved floating tag replaces as it is at apply time.
+func (s Service) currentFloating(ctx context.Context, saved SavedFloating) (tagplan.FloatingPlan, error) {
+	floating := tagplan.FloatingPlan{TagName: saved.TagName}
+	name := tagRefPrefix + saved.TagName
+	objectID, err := s.client.GetRefObjectID(ctx, name)
+	switch {
+	case err == nil:
+		floating.Existing = tagplan.Tag{Name: name, RefObjectID: objectID}
+	case !errors.Is(err, ado.ErrRefNotFound):
+		return tagplan.FloatingPlan{}, fmt.Errorf("resolving floating tag %s: %w", name, err)
+	}
+	return floating, nil
+}
@@ -0,0 +1,164 @@
+package tagging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado/adotest"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+// savePlan previews a floating release on client and round-trips it through JSON.
+func savePlan(t *testing.T, client *adotest.Client) SavedPlan {
+	t.Helper()
+
+	cfg := CreateConfig{
+		Config:      Config{Mode: tagplan.ModeRelease, Bump: bump.BumpMinor, UseFloatingTags: true},
+		CommitSHA:   "deadbeef",
+		Message:     "release",
+		TaggerName:  taggerNameDefault,
+		TaggerEmail: taggerEmailDefault,
+	}
+	plan, err := NewService(client, tagplan.NewPlanner("v")).Preview(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("preview: %v", err)
+	}
+	data, err := json.Marshal(NewSavedPlan(cfg, plan))
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	saved, err := ParseSavedPlan(data)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	return saved
+}
+
+func TestSavedPlanRecordsInputs(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	client.SeedAnnotatedTag(sampleReleaseTag, "release-tag-object", sampleReleaseObjectID)
+	client.SeedAnnotatedTag("v1", "floating-tag-object", sampleReleaseObjectID)
+
+	saved := savePlan(t, client)
+	if saved.TagName != "v1.3.0" || saved.Commit != "deadbeef" || saved.BaseTag != sampleReleaseTag {
+		t.Fatalf("unexpected saved plan: %+v", saved)
+	}
+	if saved.Floating == nil || saved.Floating.TagName != "v1" || saved.Floating.Replaces != "refs/tags/v1" {
+		t.Fatalf("expected floating replacement of v1, got %+v", saved.Floating)
+	}
+	if len(client.CreatedTags) != 0 {
+		t.Fatalf("planning must not write, got %+v", client.CreatedTags)
+	}
+}
+
+func TestApplyPlanCreatesPlannedTags(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	client.SeedAnnotatedTag(sampleReleaseTag, "release-tag-object", sampleReleaseObjectID)
+	client.SeedAnnotatedTag("v1", "floating-tag-object", sampleReleaseObjectID)
+	saved := savePlan(t, client)
+
+	// Another release lands between planning and applying; the plan is replayed as reviewed.
+	client.SeedAnnotatedTag("v1.2.4", "other-tag-object", "other-commit")
+
+	result, err := NewService(client, tagplan.NewPlanner("v")).ApplyPlan(context.Background(), saved)
+	if err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if result.TagName != "v1.3.0" || !result.Floating.Created || !result.Floating.DeletedExisting {
+		t.Fatalf("unexpected apply result: %+v", result)
+	}
+	if len(client.CreatedTags) != 2 || client.CreatedTags[0].Name != "v1.3.0" || client.CreatedTags[0].Message != "release" {
+		t.Fatalf("expected release and floating tags, got %+v", client.CreatedTags)
+	}
+	if len(client.DeletedRefs) != 1 || client.DeletedRefs[0].OldObjectID != "floating-tag-object" {
+		t.Fatalf("expected floating tag replaced with its current object id, got %+v", client.DeletedRefs)
+	}
+}
+
+func TestApplyPlanCreatesFloatingTagMissingAtPlanTime(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	client.SeedAnnotatedTag(sampleReleaseTag, "release-tag-object", sampleReleaseObjectID)
+	saved := savePlan(t, client)
+	if saved.Floating == nil || saved.Floating.Replaces != "" {
+		t.Fatalf("expected a new floating tag, got %+v", saved.Floating)
+	}
+
+	client.SeedAnnotatedTag("v1", "late-floating-object", sampleReleaseObjectID)
+	result, err := NewService(client, tagplan.NewPlanner("v")).ApplyPlan(context.Background(), saved)
+	if err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if !result.Floating.DeletedExisting || len(client.DeletedRefs) != 1 || client.DeletedRefs[0].OldObjectID != "late-floating-object" {
+		t.Fatalf("expected the late floating tag to be replaced, got %+v / %+v", result.Floating, client.DeletedRefs)
+	}
+}
+
+func TestApplyPlanValidation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		mutate  func(*adotest.Client)
+		wantErr error
+	}{
+		{
+			name:    "commit no longer exists",
+			mutate:  func(c *adotest.Client) { c.MissingCommits = map[string]bool{"deadbeef": true} },
+			wantErr: ErrCommitNotFound,
+		},
+		{
+			name:    "tag created since planning",
+			mutate:  func(c *adotest.Client) { c.SeedAnnotatedTag("v1.3.0", "raced-object", "other-commit") },
+			wantErr: ErrPlanStale,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := adotest.NewClient()
+			client.SeedAnnotatedTag(sampleReleaseTag, "release-tag-object", sampleReleaseObjectID)
+			saved := savePlan(t, client)
+			tc.mutate(client)
+
+			_, err := NewService(client, tagplan.NewPlanner("v")).ApplyPlan(context.Background(), saved)
+			if !errors.Is(err, tc.wantErr) {
+				t.Fatalf("expected %v, got %v", tc.wantErr, err)
+			}
+			if len(client.CreatedTags) != 0 {
+				t.Fatalf("expected no writes, got %+v", client.CreatedTags)
+			}
+		})
+	}
+}
+
+func TestParseSavedPlanRejectsInvalid(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"not json":       `{`,
+		"unknown format": `{"format": 2, "mode": "release", "tagName": "v1.0.0", "commit": "c", "taggerName": "n", "taggerEmail": "e", "version": "1.0.0", "releaseBase": "0.0.0", "targetRelease": "1.0.0"}`,
+		"unknown mode":   `{"format": 1, "mode": "beta", "tagName": "v1.0.0", "commit": "c", "taggerName": "n", "taggerEmail": "e", "version": "1.0.0", "releaseBase": "0.0.0", "targetRelease": "1.0.0"}`,
+		"missing commit": `{"format": 1, "mode": "release", "tagName": "v1.0.0", "taggerName": "n", "taggerEmail": "e", "version": "1.0.0", "releaseBase": "0.0.0", "targetRelease": "1.0.0"}`,
+		"bad version":    `{"format": 1, "mode": "release", "tagName": "v1.0.0", "commit": "c", "taggerName": "n", "taggerEmail": "e", "version": "one", "releaseBase": "0.0.0", "targetRelease": "1.0.0"}`,
+	}
+
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			if _, err := ParseSavedPlan([]byte(input)); !errors.Is(err, ErrInvalidPlan) {
+				t.Fatalf("expected ErrInvalidPlan, got %v", err)
+			}
+		})
+	}
+}
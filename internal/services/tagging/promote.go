@@ -0,0 +1,83 @@
+package tagging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/events"
+)
+
+// ErrPromoteSourceUnresolved is returned when the promoted RC has no commit to point at.
+var ErrPromoteSourceUnresolved = errors.New("tagging service: release candidate has no commit")
+
+// PreviewPromotion plans the release of the highest RC for target without issuing any mutating
+// ADO calls; see tagplan.Planner.PlanPromotion. cfg.Mode, cfg.Bump, and cfg.CommitSHA are
+// ignored: the release is tagged at the RC's commit, reported as BaseTag.ObjectID.
+func (s Service) PreviewPromotion(ctx context.Context, cfg CreateConfig, target string) (tagplan.Result, error) {
+	plan, cfg, _, err := s.preparePromotion(ctx, cfg, target)
+	if err != nil {
+		return tagplan.Result{}, err
+	}
+	resolveFloating(cfg, &plan)
+	return plan, nil
+}
+
+// Promote creates the release tag for the highest RC for target at the RC's commit and moves
+// the floating tags to it, exactly as create-tag would after a release.
+func (s Service) Promote(ctx context.Context, cfg CreateConfig, target string) (tagplan.Result, error) {
+	plan, cfg, spec, err := s.preparePromotion(ctx, cfg, target)
+	if err != nil {
+		return tagplan.Result{}, err
+	}
+	if err := s.writeTag(ctx, cfg, spec); err != nil {
+		return tagplan.Result{}, err
+	}
+	if err := s.verifyTag(ctx, cfg, &plan); err != nil {
+		return tagplan.Result{}, err
+	}
+	if err := s.applyFloatingTag(ctx, cfg, &plan, spec); err != nil {
+		return tagplan.Result{}, err
+	}
+	return plan, nil
+}
+
+// preparePromotion plans the promotion and returns cfg pointed at the RC's commit.
+func (s Service) preparePromotion(ctx context.Context, cfg CreateConfig, target string) (tagplan.Result, CreateConfig, ado.TagSpec, error) {
+	if s.client == nil {
+		return tagplan.Result{}, CreateConfig{}, ado.TagSpec{}, ErrNilClient
+	}
+
+	refs, err := s.client.ListRefsWithPrefix(ctx, tagRefPrefix)
+	if err != nil {
+		return tagplan.Result{}, CreateConfig{}, ado.TagSpec{}, fmt.Errorf("listing refs: %w", err)
+	}
+	s.events.Record(events.RefsListed, map[string]any{"prefix": tagRefPrefix, "count": len(refs)})
+	plan, err := s.planner.PlanPromotion(toPlannerTags(refs), target)
+	if err != nil {
+		return tagplan.Result{}, CreateConfig{}, ado.TagSpec{}, err
+	}
+	s.recordPlan(plan)
+
+	commit := strings.TrimSpace(plan.BaseTag.ObjectID)
+	if commit == "" {
+		return tagplan.Result{}, CreateConfig{}, ado.TagSpec{}, fmt.Errorf("%w: %s", ErrPromoteSourceUnresolved, plan.BaseTag.Name)
+	}
+	cfg.Mode = tagplan.ModeRelease
+	cfg.CommitSHA = commit
+
+	spec, err := tagSpec(cfg, plan)
+	if err != nil {
+		return tagplan.Result{}, CreateConfig{}, ado.TagSpec{}, err
+	}
+	if err := s.checkProtectedFloating(cfg, plan); err != nil {
+		return tagplan.Result{}, CreateConfig{}, ado.TagSpec{}, err
+	}
+	if err := s.checkFloatingAncestry(ctx, cfg, &plan); err != nil {
+		return tagplan.Result{}, CreateConfig{}, ado.TagSpec{}, err
+	}
+	return plan, cfg, spec, nil
+}
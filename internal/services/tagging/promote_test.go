@@ -0,0 +1,77 @@
+package tagging
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+const promotedCommit = "3333333333333333333333333333333333333333"
+
+func TestPromote(t *testing.T) {
+	t.Parallel()
+
+	withRCs := map[string]string{
+		"refs/tags/v2.0.0":      sampleReleaseObjectID,
+		"refs/tags/v2":          sampleReleaseObjectID,
+		"refs/tags/v2.1.0-rc.2": "rc2-commit",
+		"refs/tags/v2.1.0-rc.3": promotedCommit,
+	}
+	tests := []struct {
+		name    string
+		tags    map[string]string
+		version string
+		preview bool
+		wantErr error
+	}{
+		{name: "tags the latest rc commit", tags: withRCs},
+		{name: "preview does not write", tags: withRCs, version: "2.1.0", preview: true},
+		{name: "no rc to promote", tags: map[string]string{sampleReleaseTag: sampleReleaseObjectID}, wantErr: tagplan.ErrNoRCToPromote},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := seededClient(tc.tags)
+			svc := NewService(client, tagplan.NewPlanner("v"))
+			cfg := baseCreateConfig("")
+			cfg.UseFloatingTags = true
+			cfg.Message = "release 2.1.0"
+
+			promote := svc.Promote
+			if tc.preview {
+				promote = svc.PreviewPromotion
+			}
+			result, err := promote(context.Background(), cfg, tc.version)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("expected %v, got %v", tc.wantErr, err)
+				}
+				if len(client.CreatedTags) > 0 {
+					t.Fatalf("expected no tags created, got %+v", client.CreatedTags)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("promote: %v", err)
+			}
+			if result.TagName != "v2.1.0" || result.BaseTag.Name != "refs/tags/v2.1.0-rc.3" || !result.Floating.Enabled {
+				t.Fatalf("expected v2.1.0 promoted from rc.3, got %+v", result)
+			}
+			if tc.preview {
+				if len(client.CreatedTags) > 0 || len(client.DeletedRefs) > 0 || len(client.UpdatedRefs) > 0 {
+					t.Fatalf("preview must not write, got created=%+v deleted=%+v updated=%+v", client.CreatedTags, client.DeletedRefs, client.UpdatedRefs)
+				}
+				return
+			}
+			if len(client.CreatedTags) == 0 || client.CreatedTags[0].Name != "v2.1.0" || client.CreatedTags[0].ObjectID != promotedCommit {
+				t.Fatalf("expected v2.1.0 at the RC commit, got %+v", client.CreatedTags)
+			}
+			if !result.Floating.Created || result.Floating.TargetCommit != promotedCommit {
+				t.Fatalf("expected v2 moved to the promoted release, got %+v", result.Floating)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package tagging
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+var (
+	// ErrProtectedTag is returned when a delete or move targets a tag matching a protected
+	// pattern.
+	ErrProtectedTag = errors.New("tagging service: tag is protected")
+	// ErrInvalidProtectedTag is returned when a protected tag pattern is not a valid glob.
+	ErrInvalidProtectedTag = errors.New("tagging service: invalid protected tag pattern")
+)
+
+// ParseProtectedTags trims and validates protected tag patterns. Patterns match short tag
+// names (v1, latest, release-*); a refs/tags/ prefix is accepted and dropped.
+func ParseProtectedTags(values []string) ([]string, error) {
+	patterns := make([]string, 0, len(values))
+	for _, value := range values {
+		pattern := strings.TrimPrefix(strings.TrimSpace(value), tagRefPrefix)
+		if pattern == "" {
+			continue
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidProtectedTag, pattern)
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, nil
+}
+
+// WithProtectedTags returns a copy of the service that refuses to delete or move tags matching
+// patterns, as returned by ParseProtectedTags.
+func (s Service) WithProtectedTags(patterns []string) Service {
+	s.protected = append([]string(nil), patterns...)
+	return s
+}
+
+// guardProtected fails with ErrProtectedTag when name matches a protected pattern. Every path
+// that deletes or rewrites an existing tag goes through it.
+func (s Service) guardProtected(name string) error {
+	short := strings.TrimPrefix(strings.TrimSpace(name), tagRefPrefix)
+	for _, pattern := range s.protected {
+		if ok, _ := path.Match(pattern, short); ok {
+			return fmt.Errorf("%w: %s matches %q", ErrProtectedTag, short, pattern)
+		}
+	}
+	return nil
+}
+
+// checkProtectedFloating rejects a plan that would move a protected floating tag before the
+// release tag is created, so the run does not stop halfway.
+func (s Service) checkProtectedFloating(cfg CreateConfig, plan tagplan.Result) error {
+	if !plan.FloatingEligible() {
+		return nil
+	}
+	for _, floating := range floatingRefs(cfg, &plan) {
+		existing := strings.TrimSpace(floating.Existing.Name)
+		if existing == "" {
+			continue
+		}
+		if err := s.guardProtected(existing); err != nil {
+			return err
+		}
+	}
+	return nil
+}
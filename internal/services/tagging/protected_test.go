@@ -0,0 +1,119 @@
+package tagging
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado/adotest"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+func TestPlanAndCreateProtectedFloatingTag(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		protected []string
+		kind      TagKind
+		expectErr bool
+	}{
+		{name: "protected annotated floating is refused", protected: []string{"v1", "latest"}, expectErr: true},
+		{name: "protected lightweight floating is refused", protected: []string{"v*"}, kind: TagKindLightweight, expectErr: true},
+		{name: "unprotected floating moves", protected: []string{"v2", "stable"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := adotest.NewClient()
+			client.SeedAnnotatedTag(sampleReleaseTag, "release-tag-object", sampleReleaseObjectID)
+			client.SeedAnnotatedTag("v1", "floating-tag-object", sampleReleaseObjectID)
+
+			protected, err := ParseProtectedTags(tc.protected)
+			if err != nil {
+				t.Fatalf("parse protected tags: %v", err)
+			}
+			svc := NewService(client, tagplan.NewPlanner("v")).WithProtectedTags(protected)
+
+			cfg := CreateConfig{
+				Config:          Config{Mode: tagplan.ModeRelease, Bump: bump.BumpPatch, UseFloatingTags: true},
+				CommitSHA:       "deadbeef",
+				Message:         "release v1.2.4",
+				TaggerName:      taggerNameDefault,
+				TaggerEmail:     taggerEmailDefault,
+				FloatingTagKind: tc.kind,
+			}
+
+			result, err := svc.PlanAndCreate(context.Background(), cfg)
+			if tc.expectErr {
+				if !errors.Is(err, ErrProtectedTag) {
+					t.Fatalf("expected ErrProtectedTag, got %v", err)
+				}
+				if len(client.CreatedTags) != 0 || len(client.DeletedRefs) != 0 {
+					t.Fatalf("expected no mutations, got created %+v deleted %+v", client.CreatedTags, client.DeletedRefs)
+				}
+				ref, ok := client.Ref("v1")
+				if !ok || ref.ObjectID != "floating-tag-object" {
+					t.Fatalf("expected protected floating tag to be untouched, got %+v", ref)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("plan and create: %v", err)
+			}
+			if !result.Floating.Created || len(client.DeletedRefs) != 1 {
+				t.Fatalf("expected unprotected floating tag to be replaced, got %+v", result.Floating)
+			}
+		})
+	}
+}
+
+func TestDeleteRefRefusesProtectedTag(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	client.SeedAnnotatedTag("v1", "floating-tag-object", sampleReleaseObjectID)
+	svc := NewService(client, tagplan.NewPlanner("v")).WithProtectedTags([]string{"v1"})
+
+	// The guard in deleteRef holds even when the plan bypassed the up-front check.
+	if err := svc.deleteRef(context.Background(), "refs/tags/v1"); !errors.Is(err, ErrProtectedTag) {
+		t.Fatalf("expected ErrProtectedTag, got %v", err)
+	}
+	if len(client.DeletedRefs) != 0 {
+		t.Fatalf("expected no DeleteRef calls, got %+v", client.DeletedRefs)
+	}
+}
+
+func TestListStaleRCsStopsAtProtectedTag(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	seedStaleRCs(client)
+	svc := NewService(client, tagplan.NewPlanner("v")).WithProtectedTags([]string{"v1.2.0-rc.2"})
+
+	report, err := svc.ListStaleRCs(context.Background(), StaleRCConfig{DeleteStale: true})
+	if !errors.Is(err, ErrProtectedTag) {
+		t.Fatalf("expected ErrProtectedTag, got %v", err)
+	}
+	if len(report.Deleted) != 1 || report.Deleted[0] != "v1.2.0-rc.1" {
+		t.Fatalf("expected deletion to stop at the protected tag, got %v", report.Deleted)
+	}
+}
+
+func TestParseProtectedTags(t *testing.T) {
+	t.Parallel()
+
+	patterns, err := ParseProtectedTags([]string{" refs/tags/latest ", "", "v*"})
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(patterns) != 2 || patterns[0] != "latest" || patterns[1] != "v*" {
+		t.Fatalf("unexpected patterns %v", patterns)
+	}
+	if _, err := ParseProtectedTags([]string{"v["}); !errors.Is(err, ErrInvalidProtectedTag) {
+		t.Fatalf("expected ErrInvalidProtectedTag, got %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package tagging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+var (
+	// ErrRCTooRecent is returned when the newest RC for the release is younger than RCMinAge.
+	ErrRCTooRecent = errors.New("tagging service: release candidate cooldown has not elapsed")
+	// ErrRCAgeUnknown is returned when RCs for the release exist but none carries a creation date.
+	ErrRCAgeUnknown = errors.New("tagging service: release candidate creation date unknown")
+)
+
+// checkRCAge enforces cfg.RCMinAge on release plans: when RC tags for the target release
+// exist, the newest of them must be at least RCMinAge old. Releases without RCs pass. Only
+// annotated RCs carry a creation date; lightweight ones are ignored.
+func (s Service) checkRCAge(ctx context.Context, cfg CreateConfig, plan tagplan.Result) error {
+	if cfg.RCMinAge <= 0 || plan.Mode != tagplan.ModeRelease {
+		return nil
+	}
+
+	refs, err := s.client.ListRefsWithPrefix(ctx, tagRefPrefix)
+	if err != nil {
+		return fmt.Errorf("listing refs: %w", err)
+	}
+
+	var newest time.Time
+	var newestTag string
+	candidates := 0
+	for _, rc := range s.planner.Prereleases(toPlannerTags(refs)) {
+		if !rc.Target.EQ(plan.TargetRelease) {
+			continue
+		}
+		candidates++
+		if rc.Tag.RefObjectID == "" || rc.Tag.RefObjectID == rc.Tag.ObjectID {
+			continue
+		}
+		created, err := s.client.GetTagDate(ctx, rc.Tag.RefObjectID)
+		if err != nil {
+			return fmt.Errorf("reading creation date of %s: %w", rc.TagName, err)
+		}
+		if created.After(newest) {
+			newest, newestTag = created, rc.TagName
+		}
+	}
+
+	if candidates == 0 {
+		return nil
+	}
+	if newest.IsZero() {
+		return fmt.Errorf("%w: %d release candidates for %s", ErrRCAgeUnknown, candidates, plan.TargetRelease)
+	}
+	if age := time.Since(newest); age < cfg.RCMinAge {
+		return fmt.Errorf("%w: %s is %s old, want at least %s", ErrRCTooRecent, newestTag, age.Truncate(time.Second), cfg.RCMinAge)
+	}
+	return nil
+}
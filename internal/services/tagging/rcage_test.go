@@ -0,0 +1,102 @@
+package tagging
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado/adotest"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+func TestPlanAndCreateRCMinAge(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		minAge      time.Duration
+		rcAges      map[string]time.Duration
+		lightweight bool
+		expectErr   error
+	}{
+		{name: "guard off", rcAges: map[string]time.Duration{"rc1-object": time.Minute}},
+		{name: "newest rc outside cooldown", minAge: 24 * time.Hour, rcAges: map[string]time.Duration{"rc1-object": 72 * time.Hour, "rc2-object": 30 * time.Hour}},
+		{name: "newest rc inside cooldown", minAge: 24 * time.Hour, rcAges: map[string]time.Duration{"rc1-object": 72 * time.Hour, "rc2-object": time.Hour}, expectErr: ErrRCTooRecent},
+		{name: "no rc for target", minAge: 24 * time.Hour},
+		{name: "only lightweight rcs", minAge: 24 * time.Hour, lightweight: true, expectErr: ErrRCAgeUnknown},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := adotest.NewClient()
+			client.SeedAnnotatedTag(sampleReleaseTag, "release-tag-object", sampleReleaseObjectID)
+			// An RC of another release never counts, however recent.
+			client.SeedAnnotatedTag("v1.3.0-rc.1", "other-rc-object", "cafe")
+			client.TagDates = map[string]time.Time{"other-rc-object": time.Now()}
+			for object, age := range tc.rcAges {
+				name := "v1.2.4-rc.1"
+				if object == "rc2-object" {
+					name = "v1.2.4-rc.2"
+				}
+				client.SeedAnnotatedTag(name, object, "beef")
+				client.TagDates[object] = time.Now().Add(-age)
+			}
+			if tc.lightweight {
+				client.SeedAnnotatedTag("v1.2.4-rc.1", "beef", "")
+			}
+			svc := NewService(client, tagplan.NewPlanner("v"))
+
+			result, err := svc.PlanAndCreate(context.Background(), CreateConfig{
+				Config:      Config{Mode: tagplan.ModeRelease, Bump: bump.BumpPatch},
+				CommitSHA:   "deadbeef",
+				TaggerName:  taggerNameDefault,
+				TaggerEmail: taggerEmailDefault,
+				RCMinAge:    tc.minAge,
+			})
+
+			if tc.expectErr != nil {
+				if !errors.Is(err, tc.expectErr) {
+					t.Fatalf("expected %v, got %v", tc.expectErr, err)
+				}
+				if len(client.CreatedTags) != 0 {
+					t.Fatalf("expected no tags to be created, got %d", len(client.CreatedTags))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("plan and create: %v", err)
+			}
+			if result.TagName != "v1.2.4" || len(client.CreatedTags) != 1 {
+				t.Fatalf("expected v1.2.4 to be created, got %s (%d tags)", result.TagName, len(client.CreatedTags))
+			}
+		})
+	}
+}
+
+func TestPlanAndCreateRCMinAgeIgnoresRCMode(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	client.SeedAnnotatedTag(sampleReleaseTag, "release-tag-object", sampleReleaseObjectID)
+	client.SeedAnnotatedTag("v1.2.4-rc.1", "rc1-object", "beef")
+	client.TagDates = map[string]time.Time{"rc1-object": time.Now()}
+	svc := NewService(client, tagplan.NewPlanner("v"))
+
+	result, err := svc.PlanAndCreate(context.Background(), CreateConfig{
+		Config:      Config{Mode: tagplan.ModeRC, Bump: bump.BumpPatch},
+		CommitSHA:   "deadbeef",
+		TaggerName:  taggerNameDefault,
+		TaggerEmail: taggerEmailDefault,
+		RCMinAge:    24 * time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("plan and create: %v", err)
+	}
+	if result.TagName != "v1.2.4-rc.2" {
+		t.Fatalf("expected v1.2.4-rc.2, got %s", result.TagName)
+	}
+}
@@ -0,0 +1,60 @@
+package tagging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/events"
+)
+
+// MaxRCCollisionRetries bounds how many times RC creation replans after finding its number taken.
+const MaxRCCollisionRetries = 5
+
+// ErrRCCollisionRetriesExhausted is returned when every replanned RC number was also taken.
+var ErrRCCollisionRetriesExhausted = errors.New("tagging service: rc number still taken after retries")
+
+// createTag creates the planned release or RC tag. With cfg.RetryRCOnCollision, an RC tag that
+// already exists is treated as reserved by a concurrent run: the refs are listed again and the
+// next free number is tried, so the returned plan and spec describe the tag actually created.
+func (s Service) createTag(ctx context.Context, cfg CreateConfig, plan tagplan.Result, spec ado.TagSpec) (tagplan.Result, ado.TagSpec, error) {
+	for {
+		err := s.writeTag(ctx, cfg, spec)
+		if err == nil {
+			return plan, spec, nil
+		}
+		if !cfg.RetryRCOnCollision || plan.Mode != tagplan.ModeRC || !errors.Is(err, ado.ErrRefExists) {
+			return tagplan.Result{}, ado.TagSpec{}, err
+		}
+		if plan.RCCollisions >= MaxRCCollisionRetries {
+			return tagplan.Result{}, ado.TagSpec{}, fmt.Errorf("%w: %s (%d retries)", ErrRCCollisionRetriesExhausted, plan.TagName, plan.RCCollisions)
+		}
+
+		replanned, replannedSpec, err := s.prepare(ctx, cfg)
+		if err != nil {
+			return tagplan.Result{}, ado.TagSpec{}, fmt.Errorf("replanning after rc collision: %w", err)
+		}
+		replanned.RCCollisions = plan.RCCollisions + 1
+		replanned.CommitUnverified = plan.CommitUnverified
+		if err := s.checkBranchCollisions(ctx, cfg, &replanned); err != nil {
+			return tagplan.Result{}, ado.TagSpec{}, err
+		}
+		plan, spec = replanned, replannedSpec
+	}
+}
+
+func (s Service) writeTag(ctx context.Context, cfg CreateConfig, spec ado.TagSpec) error {
+	kind := TagKindAnnotated
+	if cfg.TagKind == TagKindLightweight {
+		kind = TagKindLightweight
+		if err := s.client.CreateLightweightTag(ctx, spec.Name, spec.ObjectID); err != nil {
+			return fmt.Errorf("creating lightweight tag: %w", err)
+		}
+	} else if err := s.client.CreateAnnotatedTag(ctx, spec); err != nil {
+		return fmt.Errorf("creating annotated tag: %w", err)
+	}
+	s.events.Record(events.TagCreated, map[string]any{"tag": spec.Name, "commit": spec.ObjectID, "kind": string(kind)})
+	return nil
+}
@@ -0,0 +1,68 @@
+package tagging
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+func TestPlanAndCreateRCCollisions(t *testing.T) {
+	t.Parallel()
+
+	exhausted := map[string]string{}
+	for rc := 2; rc <= MaxRCCollisionRetries+2; rc++ {
+		exhausted["v1.3.0-rc."+strconv.Itoa(rc)] = "other-run-commit"
+	}
+	tests := []struct {
+		name       string
+		concurrent map[string]string
+		retry      bool
+		wantErr    error
+	}{
+		{name: "retries on collision", concurrent: map[string]string{"v1.3.0-rc.2": "other-run-commit"}, retry: true},
+		{name: "fails without retry", concurrent: map[string]string{"v1.3.0-rc.2": "other-run-commit"}, wantErr: ado.ErrRefExists},
+		{name: "retries are bounded", concurrent: exhausted, retry: true, wantErr: ErrRCCollisionRetriesExhausted},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := seededClient(map[string]string{"v1.2.3": "commit-123", "v1.3.0-rc.1": "commit-130rc1"})
+			client.ConcurrentTags = tc.concurrent
+			cfg := baseCreateConfig("rc-commit")
+			cfg.Mode, cfg.Bump = tagplan.ModeRC, bump.BumpMinor
+			cfg.RetryRCOnCollision = tc.retry
+
+			result, err := NewService(client, tagplan.NewPlanner("v")).PlanAndCreate(context.Background(), cfg)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("expected %v, got %v", tc.wantErr, err)
+				}
+				if len(client.CreatedTags) != 0 {
+					t.Fatalf("no tags should be created, got %+v", client.CreatedTags)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("plan and create: %v", err)
+			}
+			if result.TagName != "v1.3.0-rc.3" || result.RCNumber != 3 || result.RCCollisions != 1 {
+				t.Fatalf("expected v1.3.0-rc.3 after one collision, got %s (rc %d, collisions %d)", result.TagName, result.RCNumber, result.RCCollisions)
+			}
+			if ref, _ := client.Ref("v1.3.0-rc.3"); ref.PeeledObjectID != "rc-commit" {
+				t.Fatalf("expected rc.3 at rc-commit, got %+v", ref)
+			}
+			if ref, _ := client.Ref("v1.3.0-rc.2"); ref.ObjectID != "other-run-commit" {
+				t.Fatalf("the concurrent run's rc.2 must be left alone, got %+v", ref)
+			}
+			if len(client.CreatedTags) != 1 || client.CreatedTags[0].Name != "v1.3.0-rc.3" {
+				t.Fatalf("expected exactly one created tag, got %+v", client.CreatedTags)
+			}
+		})
+	}
+}
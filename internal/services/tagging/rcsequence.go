@@ -0,0 +1,21 @@
+package tagging
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+// VerifyRCSequence lists the repository's tags and reports the RC sequence of target.
+func (s Service) VerifyRCSequence(ctx context.Context, target string) (tagplan.RCSequence, error) {
+	if s.client == nil {
+		return tagplan.RCSequence{}, ErrNilClient
+	}
+
+	refs, err := s.client.ListRefsWithPrefix(ctx, tagRefPrefix)
+	if err != nil {
+		return tagplan.RCSequence{}, fmt.Errorf("listing refs: %w", err)
+	}
+	return s.planner.RCSequence(toPlannerTags(refs), target)
+}
@@ -0,0 +1,84 @@
+package tagging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+const headsRefPrefix = "refs/heads/"
+
+// ErrBranchCollision is returned in RefCheckError mode when a tag name matches an existing branch.
+var ErrBranchCollision = errors.New("tagging service: tag name collides with a branch")
+
+// RefCheck selects how tag names that collide with branch names are handled.
+type RefCheck string
+
+const (
+	// RefCheckOff skips the branch lookup.
+	RefCheckOff RefCheck = "off"
+	// RefCheckWarn records collisions on the result and continues.
+	RefCheckWarn RefCheck = "warn"
+	// RefCheckError aborts before any tag is created.
+	RefCheckError RefCheck = "error"
+)
+
+// ParseRefCheck converts a string into a RefCheck. Empty values map to RefCheckWarn.
+func ParseRefCheck(value string) (RefCheck, error) {
+	switch RefCheck(strings.ToLower(strings.TrimSpace(value))) {
+	case "", RefCheckWarn:
+		return RefCheckWarn, nil
+	case RefCheckOff:
+		return RefCheckOff, nil
+	case RefCheckError:
+		return RefCheckError, nil
+	default:
+		return "", fmt.Errorf("invalid tag ref check %q", value)
+	}
+}
+
+// checkBranchCollisions looks for branches sharing a short name with the release or floating
+// tag, since such names make "v1" ambiguous for git. Collisions are recorded on the plan.
+func (s Service) checkBranchCollisions(ctx context.Context, cfg CreateConfig, plan *tagplan.Result) error {
+	if cfg.RefCheck == RefCheckOff {
+		return nil
+	}
+
+	names := []string{plan.TagName}
+	if plan.FloatingEligible() {
+		resolveFloating(cfg, plan)
+		for _, floating := range floatingRefs(cfg, plan) {
+			if floating.Enabled {
+				names = append(names, floating.TagName)
+			}
+		}
+	}
+
+	branches := make([]string, len(names))
+	for i, name := range names {
+		branches[i] = headsRefPrefix + name
+	}
+	listed, err := ado.ListRefNamespaces(ctx, s.client, branches...)
+	if err != nil {
+		return fmt.Errorf("listing branches: %w", err)
+	}
+
+	for i, name := range names {
+		branch := branches[i]
+		for _, ref := range listed[branch] {
+			if ref.Name == branch {
+				plan.BranchCollisions = append(plan.BranchCollisions, name)
+				break
+			}
+		}
+	}
+
+	if cfg.RefCheck == RefCheckError && len(plan.BranchCollisions) > 0 {
+		return fmt.Errorf("%w: %s", ErrBranchCollision, strings.Join(plan.BranchCollisions, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package tagging
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado/adotest"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+func TestPlanAndCreateBranchCollision(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		check          RefCheck
+		expectErr      bool
+		expectCollided []string
+	}{
+		{name: "warn records collision and creates tags", check: RefCheckWarn, expectCollided: []string{"v2"}},
+		{name: "empty behaves like warn", expectCollided: []string{"v2"}},
+		{name: "error aborts before creating tags", check: RefCheckError, expectErr: true},
+		{name: "off skips the lookup", check: RefCheckOff},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := adotest.NewClient()
+			client.SeedAnnotatedTag(sampleReleaseTag, "release-tag-object", sampleReleaseObjectID)
+			client.SeedBranch("v2", "branch-object")
+			client.SeedBranch("v2-maintenance", "other-branch-object")
+			svc := NewService(client, tagplan.NewPlanner("v"))
+
+			result, err := svc.PlanAndCreate(context.Background(), CreateConfig{
+				Config:      Config{Mode: tagplan.ModeRelease, Bump: bump.BumpMajor, UseFloatingTags: true},
+				CommitSHA:   "deadbeef",
+				TaggerName:  taggerNameDefault,
+				TaggerEmail: taggerEmailDefault,
+				RefCheck:    tc.check,
+			})
+
+			if tc.expectErr {
+				if !errors.Is(err, ErrBranchCollision) {
+					t.Fatalf("expected ErrBranchCollision, got %v", err)
+				}
+				if len(client.CreatedTags) != 0 {
+					t.Fatalf("expected no tags to be created, got %d", len(client.CreatedTags))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("plan and create: %v", err)
+			}
+			if len(result.BranchCollisions) != len(tc.expectCollided) {
+				t.Fatalf("expected collisions %v, got %v", tc.expectCollided, result.BranchCollisions)
+			}
+			for i, name := range tc.expectCollided {
+				if result.BranchCollisions[i] != name {
+					t.Fatalf("expected collisions %v, got %v", tc.expectCollided, result.BranchCollisions)
+				}
+			}
+			if len(client.CreatedTags) != 2 {
+				t.Fatalf("expected release and floating tags to be created, got %d", len(client.CreatedTags))
+			}
+		})
+	}
+}
+
+func TestPreviewReportsBranchCollision(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	client.SeedAnnotatedTag(sampleReleaseTag, "release-tag-object", sampleReleaseObjectID)
+	client.SeedBranch("v1.2.4", "branch-object")
+	svc := NewService(client, tagplan.NewPlanner("v"))
+
+	result, err := svc.Preview(context.Background(), CreateConfig{
+		Config:      Config{Mode: tagplan.ModeRelease, Bump: bump.BumpPatch},
+		CommitSHA:   "deadbeef",
+		TaggerName:  taggerNameDefault,
+		TaggerEmail: taggerEmailDefault,
+		RefCheck:    RefCheckWarn,
+	})
+	if err != nil {
+		t.Fatalf("preview: %v", err)
+	}
+	if len(result.BranchCollisions) != 1 || result.BranchCollisions[0] != "v1.2.4" {
+		t.Fatalf("expected release tag collision, got %v", result.BranchCollisions)
+	}
+}
+
+func TestParseRefCheck(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input     string
+		expected  RefCheck
+		expectErr bool
+	}{
+		{input: "", expected: RefCheckWarn},
+		{input: "warn", expected: RefCheckWarn},
+		{input: " Error ", expected: RefCheckError},
+		{input: "off", expected: RefCheckOff},
+		{input: "loud", expectErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseRefCheck(tc.input)
+			if tc.expectErr {
+				if err == nil {
+					t.Fatalf("expected error for %q", tc.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			if got != tc.expected {
+				t.Fatalf("expected %s, got %s", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package tagging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+)
+
+var (
+	// ErrNotOnReleaseBranch is returned when the commit is not reachable from any allowed release branch.
+	ErrNotOnReleaseBranch = errors.New("tagging service: commit is not on a release branch")
+	// ErrInvalidReleaseBranch is returned when a release branch pattern is not a valid glob.
+	ErrInvalidReleaseBranch = errors.New("tagging service: invalid release branch pattern")
+)
+
+// checkReleaseBranches requires the commit to be reachable from at least one branch matching
+// cfg.ReleaseBranches. Patterns use path.Match syntax against the short branch name, so
+// "release/*" matches "release/1.2" but not "release/1.2/fix". No patterns disables the guard.
+func (s Service) checkReleaseBranches(ctx context.Context, cfg CreateConfig) error {
+	patterns := make([]string, 0, len(cfg.ReleaseBranches))
+	for _, pattern := range cfg.ReleaseBranches {
+		pattern = strings.TrimPrefix(strings.TrimSpace(pattern), headsRefPrefix)
+		if pattern == "" {
+			continue
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("%w: %q", ErrInvalidReleaseBranch, pattern)
+		}
+		patterns = append(patterns, pattern)
+	}
+	if len(patterns) == 0 {
+		return nil
+	}
+
+	refs, err := s.client.ListRefsWithPrefix(ctx, headsRefPrefix)
+	if err != nil {
+		return fmt.Errorf("listing branches: %w", err)
+	}
+
+	commit := strings.TrimSpace(cfg.CommitSHA)
+	for _, ref := range refs {
+		branch := strings.TrimPrefix(ref.Name, headsRefPrefix)
+		if !matchesAny(patterns, branch) {
+			continue
+		}
+		head := strings.TrimSpace(ref.ObjectID)
+		if strings.EqualFold(head, commit) {
+			return nil
+		}
+		onBranch, err := s.client.IsAncestor(ctx, commit, head)
+		if err != nil {
+			return fmt.Errorf("checking ancestry against %s: %w", branch, err)
+		}
+		if onBranch {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s not reachable from %s", ErrNotOnReleaseBranch, commit, strings.Join(patterns, ", "))
+}
+
+func matchesAny(patterns []string, branch string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, branch); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package tagging
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado/adotest"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+func TestPlanAndCreateReleaseBranches(t *testing.T) {
+	t.Parallel()
+
+	const commit = "deadbeef"
+
+	tests := []struct {
+		name        string
+		patterns    []string
+		notAncestor bool
+		expectErr   error
+	}{
+		{name: "no patterns disables the guard", notAncestor: true},
+		{name: "reachable from allowed branch", patterns: []string{"main"}},
+		{name: "unreachable from allowed branch", patterns: []string{"main"}, notAncestor: true, expectErr: ErrNotOnReleaseBranch},
+		{name: "glob matches release branch head", patterns: []string{"main", "release/*"}, notAncestor: true},
+		{name: "no branch matches the patterns", patterns: []string{"release/2.*"}, expectErr: ErrNotOnReleaseBranch},
+		{name: "full ref names are accepted", patterns: []string{"refs/heads/main"}},
+		{name: "invalid glob", patterns: []string{"release/["}, expectErr: ErrInvalidReleaseBranch},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := adotest.NewClient()
+			client.SeedAnnotatedTag(sampleReleaseTag, "release-tag-object", sampleReleaseObjectID)
+			client.SeedBranch("main", "main-head")
+			client.SeedBranch("release/1.2", commit)
+			client.SeedBranch("feature/x", "feature-head")
+			if tc.notAncestor {
+				client.NotAncestors = map[string]bool{commit: true}
+			}
+			svc := NewService(client, tagplan.NewPlanner("v"))
+
+			_, err := svc.PlanAndCreate(context.Background(), CreateConfig{
+				Config:          Config{Mode: tagplan.ModeRelease, Bump: bump.BumpPatch},
+				CommitSHA:       commit,
+				TaggerName:      taggerNameDefault,
+				TaggerEmail:     taggerEmailDefault,
+				ReleaseBranches: tc.patterns,
+			})
+
+			if tc.expectErr != nil {
+				if !errors.Is(err, tc.expectErr) {
+					t.Fatalf("expected %v, got %v", tc.expectErr, err)
+				}
+				if len(client.CreatedTags) != 0 {
+					t.Fatalf("expected no tags to be created, got %d", len(client.CreatedTags))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("plan and create: %v", err)
+			}
+			if len(client.CreatedTags) != 1 {
+				t.Fatalf("expected one tag to be created, got %d", len(client.CreatedTags))
+			}
+		})
+	}
+}
+
+func TestPreviewChecksReleaseBranches(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	client.SeedAnnotatedTag(sampleReleaseTag, "release-tag-object", sampleReleaseObjectID)
+	client.SeedBranch("main", "main-head")
+	client.NotAncestors = map[string]bool{"deadbeef": true}
+	svc := NewService(client, tagplan.NewPlanner("v"))
+
+	_, err := svc.Preview(context.Background(), CreateConfig{
+		Config:          Config{Mode: tagplan.ModeRelease, Bump: bump.BumpPatch},
+		CommitSHA:       "deadbeef",
+		TaggerName:      taggerNameDefault,
+		TaggerEmail:     taggerEmailDefault,
+		ReleaseBranches: []string{"main"},
+	})
+	if !errors.Is(err, ErrNotOnReleaseBranch) {
+		t.Fatalf("expected ErrNotOnReleaseBranch, got %v", err)
+	}
+}
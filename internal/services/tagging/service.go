@@ -5,10 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
+	"unicode"
 
 	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado"
 	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
 	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/events"
 )
 
 const tagRefPrefix = "refs/tags/"
@@ -19,6 +22,8 @@ var (
 	ErrEmptyCommit = errors.New("tagging service: commit sha is empty")
 	ErrEmptyTagger = errors.New("tagging service: tagger name is empty")
 	ErrEmptyEmail  = errors.New("tagging service: tagger email is empty")
+	// ErrHotfixRequiresRelease is returned when a hotfix base is combined with RC mode.
+	ErrHotfixRequiresRelease = errors.New("tagging service: hotfix base requires release mode")
 )
 
 // Config captures the inputs required to compute the next tag.
@@ -27,6 +32,19 @@ type Config struct {
 	Bump            bump.Bump
 	BaseVersion     string
 	UseFloatingTags bool
+	// FloatingLevel selects the floating tags maintained: v<major>, v<major>.<minor>, or both.
+	// Empty values behave like FloatingLevelMajor.
+	FloatingLevel FloatingLevel
+	// HotfixBase pins the base to an existing release tag and forces a patch bump on its line.
+	HotfixBase string
+	// VersionSource, when set, supplies the current version in place of the highest release tag.
+	VersionSource VersionSource
+	// MaxTagsScan, when positive, plans from only the highest MaxTagsScan version tags (plus
+	// floating and other non-version tags). Hotfix plans always see every tag.
+	MaxTagsScan int
+	// Channel, when set, is the prerelease identifier RC mode tags with (alpha for
+	// v2.1.0-alpha.1) in place of the planner's label; numbering only counts that channel.
+	Channel string
 }
 
 // CreateConfig extends Config with the metadata required to create the annotated tag.
@@ -36,12 +54,55 @@ type CreateConfig struct {
 	Message     string
 	TaggerName  string
 	TaggerEmail string
+	// FloatingSkipCI controls how floating tag updates avoid re-triggering CI; SkipCIMarker is
+	// the marker used in FloatingSkipCIMarker mode.
+	FloatingSkipCI FloatingSkipCI
+	SkipCIMarker   string
+	// TagKind and FloatingTagKind select annotated or lightweight refs for the release and
+	// floating tags. Empty values mean annotated.
+	TagKind         TagKind
+	FloatingTagKind TagKind
+	// RefCheck controls the branch-name collision check; empty values behave like RefCheckWarn.
+	RefCheck RefCheck
+	// OnMissingCommit controls what happens when CommitSHA does not exist; empty values behave
+	// like MissingCommitError.
+	OnMissingCommit MissingCommitPolicy
+	// RetryRCOnCollision replans and retries RC creation when a concurrent run took the
+	// planned number first, up to MaxRCCollisionRetries times.
+	RetryRCOnCollision bool
+	// ReleaseBranches, when set, requires CommitSHA to be reachable from a branch matching one
+	// of these glob patterns (e.g. "main", "release/*").
+	ReleaseBranches []string
+	// RCMinAge, when positive, requires the newest RC of a release's target version to be at
+	// least this old before the release is tagged.
+	RCMinAge time.Duration
+	// SkipIfOnlyPaths, when set, skips a release whose pull request changes only paths matching
+	// these globs (e.g. "docs/**", "*.md").
+	SkipIfOnlyPaths []string
+	// RequireMergeCommit rejects target commits with fewer than two parents.
+	RequireMergeCommit bool
+	// Once skips a release when the target commit already carries a release tag and reports
+	// that tag instead.
+	Once bool
+	// VerifyTag lists the created release or RC tag until it appears at CommitSHA, for up to
+	// VerifyTimeout, and fails with ErrTagNotVisible otherwise.
+	VerifyTag     bool
+	VerifyTimeout time.Duration
+	// ForceFloating moves the floating tag even when its current commit is newer than the
+	// release commit; see checkFloatingAncestry.
+	ForceFloating bool
 }
 
 // Service orchestrates fetching ADO refs and delegating to the tag planner.
 type Service struct {
 	client  ado.Client
 	planner tagplan.Planner
+	// protected lists glob patterns of tags that must never be deleted or moved.
+	protected []string
+	// wait pauses between tag verification listings; nil sleeps on a timer.
+	wait func(ctx context.Context, d time.Duration) error
+	// events receives the structured run events; nil records nothing.
+	events *events.Log
 }
 
 // NewService constructs a Service instance.
@@ -51,6 +112,15 @@ func NewService(client ado.Client, planner tagplan.Planner) Service {
 
 // Plan fetches refs from ADO and returns the next tag plan result.
 func (s Service) Plan(ctx context.Context, cfg Config) (tagplan.Result, error) {
+	plan, err := s.plan(ctx, cfg)
+	if err != nil {
+		return tagplan.Result{}, err
+	}
+	s.recordPlan(plan)
+	return plan, nil
+}
+
+func (s Service) plan(ctx context.Context, cfg Config) (tagplan.Result, error) {
 	if s.client == nil {
 		return tagplan.Result{}, ErrNilClient
 	}
@@ -59,61 +129,172 @@ func (s Service) Plan(ctx context.Context, cfg Config) (tagplan.Result, error) {
 	if err != nil {
 		return tagplan.Result{}, fmt.Errorf("listing refs: %w", err)
 	}
+	s.events.Record(events.RefsListed, map[string]any{"prefix": tagRefPrefix, "count": len(refs)})
 
 	tags := toPlannerTags(refs)
 
+	planner := s.planner
+	if channel := strings.TrimSpace(cfg.Channel); channel != "" {
+		label, err := tagplan.ParsePrereleaseLabel(channel)
+		if err != nil {
+			return tagplan.Result{}, err
+		}
+		planner = planner.WithPrereleaseLabel(label)
+	}
+
+	if hotfixBase := strings.TrimSpace(cfg.HotfixBase); hotfixBase != "" {
+		if cfg.Mode != tagplan.ModeRelease {
+			return tagplan.Result{}, ErrHotfixRequiresRelease
+		}
+		return planner.PlanHotfix(tags, hotfixBase)
+	}
+	tags = planner.TopTags(tags, cfg.MaxTagsScan)
+
+	if cfg.VersionSource != nil {
+		current, err := cfg.VersionSource.CurrentVersion(ctx)
+		if err != nil {
+			return tagplan.Result{}, fmt.Errorf("reading current version: %w", err)
+		}
+		planner = planner.WithCurrentVersion(current)
+	}
+
 	switch cfg.Mode {
 	case tagplan.ModeRelease:
-		return s.planner.PlanRelease(tags, cfg.Bump, cfg.BaseVersion)
+		return planner.PlanRelease(tags, cfg.Bump, cfg.BaseVersion)
 	case tagplan.ModeRC:
-		return s.planner.PlanRC(tags, cfg.Bump, cfg.BaseVersion)
+		return planner.PlanRC(tags, cfg.Bump, cfg.BaseVersion)
 	default:
 		return tagplan.Result{}, ErrInvalidMode
 	}
 }
 
-// PlanAndCreate computes the next tag and creates it in ADO as an annotated tag.
+// PlanAndCreate computes the next tag and creates it in ADO as an annotated tag. A planned tag
+// or floating tag that already points at the commit is left as is and reported AlreadyExists.
 func (s Service) PlanAndCreate(ctx context.Context, cfg CreateConfig) (tagplan.Result, error) {
-	plan, err := s.Plan(ctx, cfg.Config)
+	plan, spec, err := s.prepare(ctx, cfg)
 	if err != nil {
 		return tagplan.Result{}, err
 	}
+	if err := s.runChecks(ctx, cfg, &plan); err != nil {
+		return tagplan.Result{}, err
+	}
+	if plan.Skipped {
+		return plan, nil
+	}
+
+	if !plan.AlreadyExists {
+		plan, spec, err = s.createTag(ctx, cfg, plan, spec)
+		if err != nil {
+			return tagplan.Result{}, err
+		}
+		if err := s.verifyTag(ctx, cfg, &plan); err != nil {
+			return tagplan.Result{}, err
+		}
+	}
+
+	if plan.FloatingEligible() {
+		if err := s.applyFloatingTag(ctx, cfg, &plan, spec); err != nil {
+			return tagplan.Result{}, err
+		}
+	}
+
+	return plan, nil
+}
+
+// Preview computes the same plan as PlanAndCreate, including the floating tag actions it
+// would take, without issuing any mutating ADO calls.
+func (s Service) Preview(ctx context.Context, cfg CreateConfig) (tagplan.Result, error) {
+	plan, _, err := s.prepare(ctx, cfg)
+	if err != nil {
+		return tagplan.Result{}, err
+	}
+	if err := s.runChecks(ctx, cfg, &plan); err != nil {
+		return tagplan.Result{}, err
+	}
+	if plan.Skipped {
+		return plan, nil
+	}
+
+	if plan.FloatingEligible() {
+		resolveFloating(cfg, &plan)
+	}
+
+	return plan, nil
+}
+
+// runChecks runs the guards shared by PlanAndCreate and Preview, in order, updating plan as
+// they go. It stops after the skip-path check when plan is Skipped.
+func (s Service) runChecks(ctx context.Context, cfg CreateConfig, plan *tagplan.Result) error {
+	if err := s.checkCommit(ctx, cfg, plan); err != nil {
+		return err
+	}
+	if err := s.checkOnce(ctx, cfg, plan); err != nil {
+		return err
+	}
+	if err := s.checkSkipPaths(ctx, cfg, plan); err != nil {
+		return err
+	}
+	if plan.Skipped {
+		return nil
+	}
+	if err := s.checkReleaseBranches(ctx, cfg); err != nil {
+		return err
+	}
+	if err := s.checkMergeCommit(ctx, cfg); err != nil {
+		return err
+	}
+	if err := s.checkRCAge(ctx, cfg, *plan); err != nil {
+		return err
+	}
+	if err := s.checkBranchCollisions(ctx, cfg, plan); err != nil {
+		return err
+	}
+	if err := s.checkProtectedFloating(cfg, *plan); err != nil {
+		return err
+	}
+	if err := s.checkFloatingAncestry(ctx, cfg, plan); err != nil {
+		return err
+	}
+	return s.checkExisting(ctx, cfg, plan)
+}
+
+func (s Service) prepare(ctx context.Context, cfg CreateConfig) (tagplan.Result, ado.TagSpec, error) {
+	plan, err := s.Plan(ctx, cfg.Config)
+	if err != nil {
+		return tagplan.Result{}, ado.TagSpec{}, err
+	}
+	spec, err := tagSpec(cfg, plan)
+	if err != nil {
+		return tagplan.Result{}, ado.TagSpec{}, err
+	}
+	return plan, spec, nil
+}
 
+// tagSpec builds the release or RC tag for plan at cfg.CommitSHA.
+func tagSpec(cfg CreateConfig, plan tagplan.Result) (ado.TagSpec, error) {
 	commit := strings.TrimSpace(cfg.CommitSHA)
 	if commit == "" {
-		return tagplan.Result{}, ErrEmptyCommit
+		return ado.TagSpec{}, ErrEmptyCommit
 	}
 
 	taggerName := strings.TrimSpace(cfg.TaggerName)
 	if taggerName == "" {
-		return tagplan.Result{}, ErrEmptyTagger
+		return ado.TagSpec{}, ErrEmptyTagger
 	}
 
 	taggerEmail := strings.TrimSpace(cfg.TaggerEmail)
 	if taggerEmail == "" {
-		return tagplan.Result{}, ErrEmptyEmail
+		return ado.TagSpec{}, ErrEmptyEmail
 	}
 
-	spec := ado.TagSpec{
+	return ado.TagSpec{
 		Name:        plan.TagName,
 		ObjectID:    commit,
 		ObjectType:  ado.TagObjectTypeCommit,
-		Message:     strings.TrimSpace(cfg.Message),
+		Message:     strings.TrimRightFunc(cfg.Message, unicode.IsSpace),
 		TaggerName:  taggerName,
 		TaggerEmail: taggerEmail,
-	}
-
-	if err := s.client.CreateAnnotatedTag(ctx, spec); err != nil {
-		return tagplan.Result{}, fmt.Errorf("creating annotated tag: %w", err)
-	}
-
-	if plan.Mode == tagplan.ModeRelease {
-		if err := s.applyFloatingTag(ctx, cfg, &plan, spec); err != nil {
-			return tagplan.Result{}, err
-		}
-	}
-
-	return plan, nil
+	}, nil
 }
 
 func toPlannerTags(refs []ado.Ref) []tagplan.Tag {
@@ -144,39 +325,107 @@ func (s Service) applyFloatingTag(ctx context.Context, cfg CreateConfig, plan *t
 		return nil
 	}
 
-	enabled := cfg.UseFloatingTags || plan.Floating.AutoDetected
-	if !enabled {
-		return nil
-	}
-
-	plan.Floating.Enabled = true
-
-	floatingName := strings.TrimSpace(plan.Floating.TagName)
-	if floatingName == "" {
-		floatingName = fmt.Sprintf("v%d", plan.Version.Major)
-		plan.Floating.TagName = floatingName
+	resolveFloating(cfg, plan)
+	for _, floating := range floatingRefs(cfg, plan) {
+		if !floating.Enabled || floating.AlreadyExists {
+			continue
+		}
+		if err := s.applyFloatingRef(ctx, cfg, floating, releaseSpec); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
+// applyFloatingRef points one enabled floating tag, major or minor, at the release commit.
+func (s Service) applyFloatingRef(ctx context.Context, cfg CreateConfig, floating *tagplan.FloatingPlan, releaseSpec ado.TagSpec) error {
 	spec := releaseSpec
-	spec.Name = floatingName
+	spec.Name = floating.TagName
+	kind := floatingKind(cfg)
+	floating.TargetCommit = spec.ObjectID
 
-	if existingName := strings.TrimSpace(plan.Floating.Existing.Name); existingName != "" {
-		objectID := strings.TrimSpace(plan.Floating.Existing.RefObjectID)
-		if objectID == "" {
-			objectID = strings.TrimSpace(plan.Floating.Existing.ObjectID)
+	if existingName := strings.TrimSpace(floating.Existing.Name); existingName != "" {
+		if kind == TagKindLightweight {
+			objectID := strings.TrimSpace(floating.Existing.RefObjectID)
+			if objectID == "" {
+				objectID = strings.TrimSpace(floating.Existing.ObjectID)
+			}
+			if objectID == "" {
+				return fmt.Errorf("floating tag %s missing object id", existingName)
+			}
+			if err := s.guardProtected(existingName); err != nil {
+				return fmt.Errorf("moving floating tag %s: %w", existingName, err)
+			}
+			// A lightweight ref can be moved with one compare-and-swap update, so there is no
+			// window in which the floating tag is missing.
+			if err := s.client.UpdateRef(ctx, existingName, objectID, spec.ObjectID); err != nil {
+				return fmt.Errorf("moving floating tag %s: %w", existingName, err)
+			}
+			floating.Moved = true
+			floating.Created = true
+			s.recordFloating(floating, "moved")
+			return nil
 		}
-		if objectID == "" {
-			return fmt.Errorf("floating tag %s missing object id", existingName)
-		}
-		if err := s.client.DeleteRef(ctx, existingName, objectID); err != nil {
+		if err := s.deleteRef(ctx, existingName); err != nil {
 			return fmt.Errorf("deleting floating tag %s: %w", existingName, err)
 		}
-		plan.Floating.DeletedExisting = true
+		floating.DeletedExisting = true
 	}
 
-	if err := s.client.CreateAnnotatedTag(ctx, spec); err != nil {
+	if err := s.createFloatingRef(ctx, cfg, kind, spec); err != nil {
 		return fmt.Errorf("creating floating tag %s: %w", spec.Name, err)
 	}
-	plan.Floating.Created = true
+	floating.Created = true
+	action := "created"
+	if floating.DeletedExisting {
+		action = "recreated"
+	}
+	s.recordFloating(floating, action)
 	return nil
 }
+
+func (s Service) createFloatingRef(ctx context.Context, cfg CreateConfig, kind TagKind, spec ado.TagSpec) error {
+	if kind == TagKindLightweight {
+		return s.client.CreateLightweightTag(ctx, spec.Name, spec.ObjectID)
+	}
+	if cfg.FloatingSkipCI == FloatingSkipCIMarker {
+		spec.Message = withSkipMarker(spec.Message, cfg.SkipCIMarker)
+	}
+	return s.client.CreateAnnotatedTag(ctx, spec)
+}
+
+// resolveFloating decides which of the floating tags cfg.FloatingLevel maintains apply to the
+// release plan and fills in their names.
+func resolveFloating(cfg CreateConfig, plan *tagplan.Result) {
+	if cfg.FloatingLevel.MaintainsMajor() {
+		resolveFloatingRef(cfg, &plan.Floating, fmt.Sprintf("v%d", plan.Version.Major))
+	}
+	if cfg.FloatingLevel.MaintainsMinor() && plan.Floating.Minor != nil {
+		resolveFloatingRef(cfg, plan.Floating.Minor, fmt.Sprintf("v%d.%d", plan.Version.Major, plan.Version.Minor))
+	}
+}
+
+// resolveFloatingRef enables one floating tag when it is requested or auto-detected and not
+// superseded, naming it fallback when the plan left the name empty.
+func resolveFloatingRef(cfg CreateConfig, floating *tagplan.FloatingPlan, fallback string) {
+	enabled := cfg.UseFloatingTags || floating.AutoDetected
+	if !enabled || floating.Superseded {
+		return
+	}
+
+	floating.Enabled = true
+
+	if strings.TrimSpace(floating.TagName) == "" {
+		floating.TagName = fallback
+	}
+	if existing := floating.Existing; strings.TrimSpace(existing.Name) != "" {
+		floating.PreviousCommit = strings.TrimSpace(existing.ObjectID)
+		floating.PreviousObjectID = strings.TrimSpace(existing.RefObjectID)
+		if floating.PreviousObjectID == "" {
+			floating.PreviousObjectID = floating.PreviousCommit
+		}
+	}
+	floating.TargetCommit = strings.TrimSpace(cfg.CommitSHA)
+	floating.AlreadyExists = floating.PreviousCommit != "" &&
+		strings.EqualFold(floating.PreviousCommit, floating.TargetCommit)
+}
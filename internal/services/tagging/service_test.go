@@ -19,6 +19,26 @@ const (
 	taggerEmailDefault    = "bot@example.com"
 )
 
+// baseCreateConfig returns a patch release of commit by the default tagger; tests set the fields
+// they exercise on top of it.
+func baseCreateConfig(commit string) CreateConfig {
+	return CreateConfig{
+		Config:      Config{Mode: tagplan.ModeRelease, Bump: bump.BumpPatch},
+		CommitSHA:   commit,
+		TaggerName:  taggerNameDefault,
+		TaggerEmail: taggerEmailDefault,
+	}
+}
+
+// seededClient returns a fake client with an annotated tag for each name, peeled to its commit.
+func seededClient(tags map[string]string) *adotest.Client {
+	client := adotest.NewClient()
+	for name, commit := range tags {
+		client.SeedAnnotatedTag(name, name+"-object", commit)
+	}
+	return client
+}
+
 func TestPlanReleaseFromExistingTags(t *testing.T) {
 	t.Parallel()
 
@@ -42,6 +62,31 @@ func TestPlanReleaseFromExistingTags(t *testing.T) {
 	}
 }
 
+func TestPlanMaxTagsScan(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	client.SeedAnnotatedTag("refs/tags/v1.0.0", "old-tag-object", "old-commit")
+	client.SeedAnnotatedTag(sampleReleaseTag, "release-tag-object", sampleReleaseObjectID)
+	svc := NewService(client, tagplan.NewPlanner("v"))
+
+	result, err := svc.Plan(context.Background(), Config{Mode: tagplan.ModeRelease, Bump: bump.BumpPatch, MaxTagsScan: 1})
+	if err != nil {
+		t.Fatalf("plan release: %v", err)
+	}
+	if result.TagName != "v1.2.4" {
+		t.Fatalf("tag name: want v1.2.4 got %s", result.TagName)
+	}
+
+	hotfix, err := svc.Plan(context.Background(), Config{Mode: tagplan.ModeRelease, HotfixBase: "v1.0.0", MaxTagsScan: 1})
+	if err != nil {
+		t.Fatalf("plan hotfix: %v", err)
+	}
+	if hotfix.TagName != "v1.0.1" {
+		t.Fatalf("hotfix tag name: want v1.0.1 got %s", hotfix.TagName)
+	}
+}
+
 func TestPlanRCUsesPlanner(t *testing.T) {
 	t.Parallel()
 
@@ -155,6 +200,41 @@ func TestPlanAndCreateCreatesFloatingTagWhenEnabled(t *testing.T) {
 	}
 }
 
+func TestPreviewPlansFloatingReplacementWithoutWrites(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	client.SeedAnnotatedTag(sampleReleaseTag, "release-tag-object", sampleReleaseObjectID)
+	client.SeedAnnotatedTag("v1", "floating-tag-object", sampleReleaseObjectID)
+
+	svc := NewService(client, tagplan.NewPlanner("v"))
+	result, err := svc.Preview(context.Background(), CreateConfig{
+		Config:      Config{Mode: tagplan.ModeRelease, Bump: bump.BumpPatch, UseFloatingTags: true},
+		CommitSHA:   "deadbeef",
+		TaggerName:  taggerNameDefault,
+		TaggerEmail: taggerEmailDefault,
+	})
+	if err != nil {
+		t.Fatalf("preview: %v", err)
+	}
+
+	if result.TagName != "v1.2.4" || !result.Floating.Enabled || result.Floating.TagName != "v1" {
+		t.Fatalf("expected v1.2.4 with floating v1 planned, got %s %+v", result.TagName, result.Floating)
+	}
+	if result.Floating.PreviousObjectID != "floating-tag-object" || result.Floating.TargetCommit != "deadbeef" {
+		t.Fatalf("expected the ref it would replace to be recorded, got %+v", result.Floating)
+	}
+	if result.Floating.Created || result.Floating.DeletedExisting {
+		t.Fatalf("expected no floating actions to be reported as taken, got %+v", result.Floating)
+	}
+	if len(client.CreatedTags) > 0 || len(client.DeletedRefs) > 0 || len(client.UpdatedRefs) > 0 {
+		t.Fatalf("expected no writes, got created=%v deleted=%v updated=%v", client.CreatedTags, client.DeletedRefs, client.UpdatedRefs)
+	}
+	if ref, _ := client.Ref("v1"); ref.ObjectID != "floating-tag-object" {
+		t.Fatalf("expected v1 to be untouched, got %+v", ref)
+	}
+}
+
 func TestPlanAndCreateAutoDetectsFloatingTag(t *testing.T) {
 	t.Parallel()
 
@@ -189,6 +269,9 @@ func TestPlanAndCreateAutoDetectsFloatingTag(t *testing.T) {
 	if client.DeletedRefs[0].OldObjectID != "floating-tag-object" {
 		t.Fatalf("expected delete to use ref object id, got %s", client.DeletedRefs[0].OldObjectID)
 	}
+	if result.Floating.PreviousObjectID != "floating-tag-object" || result.Floating.TargetCommit != "deadbeef" {
+		t.Fatalf("expected the replaced ref to be recorded, got %+v", result.Floating)
+	}
 
 	ref, ok := client.Ref("v1")
 	if !ok {
@@ -254,3 +337,75 @@ func TestPlanValidations(t *testing.T) {
 		t.Fatalf("expected error for client failure")
 	}
 }
+
+func TestPreviewDoesNotWrite(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	client.SeedAnnotatedTag(sampleReleaseTag, "release-tag-object", sampleReleaseObjectID)
+	client.SeedAnnotatedTag("v1", "floating-tag-object", sampleReleaseObjectID)
+
+	svc := NewService(client, tagplan.NewPlanner("v"))
+
+	cfg := CreateConfig{
+		Config:      Config{Mode: tagplan.ModeRelease, Bump: bump.BumpPatch},
+		CommitSHA:   "deadbeef",
+		TaggerName:  taggerNameDefault,
+		TaggerEmail: taggerEmailDefault,
+	}
+
+	result, err := svc.Preview(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("preview: %v", err)
+	}
+
+	if result.TagName != "v1.2.4" {
+		t.Fatalf("expected tag name v1.2.4 got %s", result.TagName)
+	}
+	if !result.Floating.Enabled || result.Floating.TagName != "v1" {
+		t.Fatalf("expected floating tag v1 to be planned: %+v", result.Floating)
+	}
+	if result.Floating.Created || result.Floating.DeletedExisting {
+		t.Fatalf("preview must not report executed floating actions: %+v", result.Floating)
+	}
+	if len(client.CreatedTags) != 0 || len(client.DeletedRefs) != 0 {
+		t.Fatalf("preview must not write: created=%d deleted=%d", len(client.CreatedTags), len(client.DeletedRefs))
+	}
+}
+
+func TestPreviewValidatesCreateInputs(t *testing.T) {
+	t.Parallel()
+
+	svc := NewService(adotest.NewClient(), tagplan.NewPlanner("v"))
+	if _, err := svc.Preview(context.Background(), CreateConfig{Config: Config{Mode: tagplan.ModeRelease}}); !errors.Is(err, ErrEmptyCommit) {
+		t.Fatalf("expected ErrEmptyCommit got %v", err)
+	}
+}
+
+func TestPlanAndCreateRCMovesFloatingTagWhenTrackingPrereleases(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	client.SeedAnnotatedTag(sampleReleaseTag, "release-tag-object", sampleReleaseObjectID)
+	client.SeedAnnotatedTag("v1", "floating-tag-object", sampleReleaseObjectID)
+	svc := NewService(client, tagplan.NewPlanner("v").WithFloatingTrack(tagplan.FloatingTrackAny))
+
+	result, err := svc.PlanAndCreate(context.Background(), CreateConfig{
+		Config:          Config{Mode: tagplan.ModeRC, Bump: bump.BumpMinor},
+		CommitSHA:       "deadbeef",
+		TaggerName:      taggerNameDefault,
+		TaggerEmail:     taggerEmailDefault,
+		FloatingTagKind: TagKindLightweight,
+	})
+	if err != nil {
+		t.Fatalf("plan and create: %v", err)
+	}
+
+	if result.TagName != "v1.3.0-rc.1" || !result.Floating.Enabled || !result.Floating.Moved {
+		t.Fatalf("expected v1 to move to the RC, got %s %+v", result.TagName, result.Floating)
+	}
+	ref, _ := client.Ref("v1")
+	if ref.ObjectID != "deadbeef" {
+		t.Fatalf("expected v1 to point at the RC commit, got %+v", ref)
+	}
+}
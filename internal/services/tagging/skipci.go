@@ -0,0 +1,52 @@
+package tagging
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FloatingSkipCI selects how floating tag updates avoid re-triggering tag-based pipelines.
+type FloatingSkipCI string
+
+const (
+	// FloatingSkipCIOff recreates floating tags exactly like the release tag.
+	FloatingSkipCIOff FloatingSkipCI = "off"
+	// FloatingSkipCIMarker appends a skip marker (e.g. "[skip ci]") to the floating tag message.
+	FloatingSkipCIMarker FloatingSkipCI = "marker"
+	// FloatingSkipCILightweight creates floating tags as lightweight refs with no tag object.
+	FloatingSkipCILightweight FloatingSkipCI = "lightweight"
+)
+
+// DefaultSkipCIMarker is appended to floating tag messages in marker mode when none is configured.
+const DefaultSkipCIMarker = "[skip ci]"
+
+// ParseFloatingSkipCI converts a string into a FloatingSkipCI. Empty values map to FloatingSkipCIOff.
+func ParseFloatingSkipCI(value string) (FloatingSkipCI, error) {
+	switch FloatingSkipCI(strings.ToLower(strings.TrimSpace(value))) {
+	case "", FloatingSkipCIOff:
+		return FloatingSkipCIOff, nil
+	case FloatingSkipCIMarker:
+		return FloatingSkipCIMarker, nil
+	case FloatingSkipCILightweight:
+		return FloatingSkipCILightweight, nil
+	default:
+		return "", fmt.Errorf("invalid floating skip-ci mode %q", value)
+	}
+}
+
+// withSkipMarker appends marker to message on its own paragraph unless it is already present.
+func withSkipMarker(message, marker string) string {
+	marker = strings.TrimSpace(marker)
+	if marker == "" {
+		marker = DefaultSkipCIMarker
+	}
+	message = strings.TrimSpace(message)
+	switch {
+	case strings.Contains(message, marker):
+		return message
+	case message == "":
+		return marker
+	default:
+		return message + "\n\n" + marker
+	}
+}
@@ -0,0 +1,100 @@
+package tagging
+
+import (
+	"context"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado/adotest"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+func TestPlanAndCreateFloatingSkipCIModes(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name            string
+		mode            FloatingSkipCI
+		marker          string
+		wantAnnotated   int
+		wantFloatingMsg string
+		wantLightweight bool
+	}{
+		{name: "off", mode: FloatingSkipCIOff, wantAnnotated: 2, wantFloatingMsg: "release v1.2.4"},
+		{name: "marker default", mode: FloatingSkipCIMarker, wantAnnotated: 2, wantFloatingMsg: "release v1.2.4\n\n[skip ci]"},
+		{name: "marker custom", mode: FloatingSkipCIMarker, marker: "***NO_CI***", wantAnnotated: 2, wantFloatingMsg: "release v1.2.4\n\n***NO_CI***"},
+		{name: "lightweight", mode: FloatingSkipCILightweight, wantAnnotated: 1, wantLightweight: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := adotest.NewClient()
+			client.SeedAnnotatedTag(sampleReleaseTag, "release-tag-object", sampleReleaseObjectID)
+			client.SeedAnnotatedTag("v1", "floating-tag-object", sampleReleaseObjectID)
+			svc := NewService(client, tagplan.NewPlanner("v"))
+
+			result, err := svc.PlanAndCreate(context.Background(), CreateConfig{
+				Config:         Config{Mode: tagplan.ModeRelease, Bump: bump.BumpPatch, UseFloatingTags: true},
+				CommitSHA:      "deadbeef",
+				Message:        "release v1.2.4",
+				TaggerName:     taggerNameDefault,
+				TaggerEmail:    taggerEmailDefault,
+				FloatingSkipCI: tc.mode,
+				SkipCIMarker:   tc.marker,
+			})
+			if err != nil {
+				t.Fatalf("plan and create: %v", err)
+			}
+			if !result.Floating.Created || result.Floating.DeletedExisting == tc.wantLightweight || result.Floating.Moved != tc.wantLightweight {
+				t.Fatalf("expected floating tag to be replaced: %+v", result.Floating)
+			}
+			if len(client.CreatedTags) != tc.wantAnnotated {
+				t.Fatalf("expected %d annotated tags, got %d", tc.wantAnnotated, len(client.CreatedTags))
+			}
+			if client.CreatedTags[0].Message != "release v1.2.4" {
+				t.Fatalf("release tag message must not change, got %q", client.CreatedTags[0].Message)
+			}
+
+			ref, ok := client.Ref("v1")
+			if !ok {
+				t.Fatalf("expected floating ref v1 to exist")
+			}
+			if tc.wantLightweight {
+				if ref.ObjectID != "deadbeef" || ref.PeeledObjectID != "" {
+					t.Fatalf("expected lightweight ref at deadbeef, got %+v", ref)
+				}
+				return
+			}
+			if got := client.CreatedTags[1].Message; got != tc.wantFloatingMsg {
+				t.Fatalf("unexpected floating message %q", got)
+			}
+		})
+	}
+}
+
+func TestParseFloatingSkipCI(t *testing.T) {
+	t.Parallel()
+
+	for input, want := range map[string]FloatingSkipCI{"": FloatingSkipCIOff, "OFF": FloatingSkipCIOff, "marker": FloatingSkipCIMarker, " lightweight ": FloatingSkipCILightweight} {
+		got, err := ParseFloatingSkipCI(input)
+		if err != nil || got != want {
+			t.Fatalf("ParseFloatingSkipCI(%q) = %q, %v", input, got, err)
+		}
+	}
+	if _, err := ParseFloatingSkipCI("always"); err == nil {
+		t.Fatalf("expected error for unknown mode")
+	}
+}
+
+func TestWithSkipMarkerIsIdempotent(t *testing.T) {
+	t.Parallel()
+
+	if got := withSkipMarker("", ""); got != DefaultSkipCIMarker {
+		t.Fatalf("expected bare marker for empty message, got %q", got)
+	}
+	if got := withSkipMarker("release [skip ci]", "[skip ci]"); got != "release [skip ci]" {
+		t.Fatalf("expected marker not to be duplicated, got %q", got)
+	}
+}
@@ -0,0 +1,96 @@
+package tagging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+// ErrInvalidSkipPath is returned when a skip-path pattern is not a valid glob.
+var ErrInvalidSkipPath = errors.New("tagging service: invalid skip path pattern")
+
+// checkSkipPaths marks a release plan as skipped when every path changed by the commit's pull
+// request matches cfg.SkipIfOnlyPaths. When the pull request or its changes cannot be
+// resolved the release proceeds, so the guard never blocks a release it cannot judge.
+func (s Service) checkSkipPaths(ctx context.Context, cfg CreateConfig, plan *tagplan.Result) error {
+	if plan.Skipped || plan.Mode != tagplan.ModeRelease {
+		return nil
+	}
+	patterns := make([]string, 0, len(cfg.SkipIfOnlyPaths))
+	for _, pattern := range cfg.SkipIfOnlyPaths {
+		pattern = strings.Trim(strings.TrimSpace(pattern), "/")
+		if pattern == "" {
+			continue
+		}
+		if _, err := path.Match(strings.ReplaceAll(pattern, "**", "*"), ""); err != nil {
+			return fmt.Errorf("%w: %q", ErrInvalidSkipPath, pattern)
+		}
+		patterns = append(patterns, pattern)
+	}
+	if len(patterns) == 0 {
+		return nil
+	}
+
+	prID, err := s.client.FindPullRequestByMergeCommit(ctx, strings.TrimSpace(cfg.CommitSHA))
+	if err != nil || prID <= 0 {
+		return nil
+	}
+	changed, err := s.client.ListPullRequestChangedPaths(ctx, prID)
+	if err != nil || len(changed) == 0 {
+		return nil
+	}
+	for _, name := range changed {
+		if !matchesAnyPath(patterns, name) {
+			return nil
+		}
+	}
+
+	plan.Skipped = true
+	plan.SkipReason = fmt.Sprintf("pull request %d only changes paths matching %s", prID, strings.Join(patterns, ","))
+	return nil
+}
+
+func matchesAnyPath(patterns []string, name string) bool {
+	name = strings.Trim(name, "/")
+	for _, pattern := range patterns {
+		if matchPathGlob(pattern, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPathGlob matches a repository path against a gitignore-style pattern: "**" spans any
+// number of directories, other segments use path.Match, and a pattern without a slash (such
+// as "*.md") matches the file name at any depth.
+func matchPathGlob(pattern, name string) bool {
+	if !strings.Contains(pattern, "/") {
+		pattern = "**/" + pattern
+	}
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
@@ -0,0 +1,121 @@
+package tagging
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado/adotest"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+func TestPlanAndCreateSkipIfOnlyPaths(t *testing.T) {
+	t.Parallel()
+
+	const (
+		commit = "deadbeef"
+		prID   = 42
+	)
+	skipGlobs := []string{"docs/**", "*.md"}
+
+	tests := []struct {
+		name        string
+		mode        tagplan.Mode
+		patterns    []string
+		changed     []string
+		unresolved  bool
+		changedErr  error
+		expectSkip  bool
+		expectError error
+	}{
+		{name: "only skipped paths", patterns: skipGlobs, changed: []string{"docs/guide/setup.md", "README.md", "internal/NOTES.md"}, expectSkip: true},
+		{name: "mixed paths", patterns: skipGlobs, changed: []string{"docs/guide.md", "internal/cli/root.go"}},
+		{name: "no patterns disables the guard", changed: []string{"README.md"}},
+		{name: "pull request not resolved", patterns: skipGlobs, changed: []string{"README.md"}, unresolved: true},
+		{name: "changes not listed", patterns: skipGlobs, changedErr: errors.New("boom")},
+		{name: "no changes", patterns: skipGlobs},
+		{name: "rc mode ignores the guard", mode: tagplan.ModeRC, patterns: skipGlobs, changed: []string{"README.md"}},
+		{name: "invalid glob", patterns: []string{"docs/["}, changed: []string{"README.md"}, expectError: ErrInvalidSkipPath},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := adotest.NewClient()
+			client.SeedAnnotatedTag(sampleReleaseTag, "release-tag-object", sampleReleaseObjectID)
+			if !tc.unresolved {
+				client.MergeCommits = map[string]int{commit: prID}
+			}
+			client.ChangedPaths = map[int][]string{prID: tc.changed}
+			client.ChangedPathsErr = tc.changedErr
+			svc := NewService(client, tagplan.NewPlanner("v"))
+
+			mode := tc.mode
+			if mode == "" {
+				mode = tagplan.ModeRelease
+			}
+			result, err := svc.PlanAndCreate(context.Background(), CreateConfig{
+				Config:          Config{Mode: mode, Bump: bump.BumpPatch},
+				CommitSHA:       commit,
+				TaggerName:      taggerNameDefault,
+				TaggerEmail:     taggerEmailDefault,
+				SkipIfOnlyPaths: tc.patterns,
+			})
+
+			if tc.expectError != nil {
+				if !errors.Is(err, tc.expectError) {
+					t.Fatalf("expected %v, got %v", tc.expectError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("plan and create: %v", err)
+			}
+			if result.Skipped != tc.expectSkip {
+				t.Fatalf("skipped: want %t got %t (%s)", tc.expectSkip, result.Skipped, result.SkipReason)
+			}
+			if tc.expectSkip {
+				if result.SkipReason == "" {
+					t.Fatalf("expected a skip reason")
+				}
+				if len(client.CreatedTags) != 0 {
+					t.Fatalf("expected no tags to be created, got %d", len(client.CreatedTags))
+				}
+				return
+			}
+			if len(client.CreatedTags) != 1 {
+				t.Fatalf("expected one tag to be created, got %d", len(client.CreatedTags))
+			}
+		})
+	}
+}
+
+func TestMatchPathGlob(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		pattern  string
+		name     string
+		expected bool
+	}{
+		{pattern: "docs/**", name: "docs/a.md", expected: true},
+		{pattern: "docs/**", name: "docs/guide/a.png", expected: true},
+		{pattern: "docs/**", name: "src/docs/a.md", expected: false},
+		{pattern: "*.md", name: "README.md", expected: true},
+		{pattern: "*.md", name: "deep/nested/CHANGELOG.md", expected: true},
+		{pattern: "*.md", name: "main.go", expected: false},
+		{pattern: "**/testdata/*", name: "a/b/testdata/x.json", expected: true},
+		{pattern: "build/*.yml", name: "build/ci/x.yml", expected: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.pattern+" "+tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := matchPathGlob(tc.pattern, tc.name); got != tc.expected {
+				t.Fatalf("expected %t, got %t", tc.expected, got)
+			}
+		})
+	}
+}
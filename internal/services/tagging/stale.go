@@ -0,0 +1,101 @@
+package tagging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+// StaleRCConfig controls whether ListStaleRCs removes the prereleases it reports as stale.
+type StaleRCConfig struct {
+	// DeleteStale removes stale prerelease tags; with DryRun they are only reported.
+	DeleteStale bool
+	DryRun      bool
+}
+
+// StaleRCReport lists every prerelease tag and the stale ones that were (or would be) deleted.
+type StaleRCReport struct {
+	Prereleases []tagplan.PrereleaseStatus
+	// Deleted holds the short names of removed tags; under DryRun it holds the tags that would
+	// have been removed.
+	Deleted []string
+	DryRun  bool
+	// Created maps the short names of annotated prereleases to their tagger dates. Lightweight
+	// tags have no tag object, so their age is unknown and they are absent.
+	Created map[string]time.Time
+	// ListedAt is when the tags were listed; ages are measured up to it.
+	ListedAt time.Time
+}
+
+// Age returns how old the named prerelease was when the tags were listed, and false when its
+// creation date is unknown.
+func (r StaleRCReport) Age(tagName string) (time.Duration, bool) {
+	created, ok := r.Created[tagName]
+	if !ok {
+		return 0, false
+	}
+	return r.ListedAt.Sub(created), true
+}
+
+// ListStaleRCs reports prerelease tags whose target release already exists and, when
+// cfg.DeleteStale is set, deletes them. Deletion stops at the first failure; the report lists
+// the tags removed before it.
+func (s Service) ListStaleRCs(ctx context.Context, cfg StaleRCConfig) (StaleRCReport, error) {
+	if s.client == nil {
+		return StaleRCReport{}, ErrNilClient
+	}
+
+	refs, err := s.client.ListRefsWithPrefix(ctx, tagRefPrefix)
+	if err != nil {
+		return StaleRCReport{}, fmt.Errorf("listing refs: %w", err)
+	}
+
+	report := StaleRCReport{
+		Prereleases: s.planner.Prereleases(toPlannerTags(refs)),
+		DryRun:      cfg.DryRun,
+		ListedAt:    time.Now(),
+	}
+	if report.Created, err = s.prereleaseDates(ctx, report.Prereleases); err != nil {
+		return StaleRCReport{}, err
+	}
+	if !cfg.DeleteStale {
+		return report, nil
+	}
+
+	for _, status := range report.Prereleases {
+		if !status.ReleaseExists {
+			continue
+		}
+		if !cfg.DryRun {
+			if err := s.deleteRef(ctx, status.Tag.Name); err != nil {
+				return report, fmt.Errorf("deleting stale prerelease tag %s: %w", status.TagName, err)
+			}
+		}
+		report.Deleted = append(report.Deleted, status.TagName)
+	}
+	return report, nil
+}
+
+// prereleaseDates reads the tagger date of every annotated prerelease, the same way
+// checkRCAge does. A tag object that is gone by the time it is read leaves the age unknown.
+func (s Service) prereleaseDates(ctx context.Context, prereleases []tagplan.PrereleaseStatus) (map[string]time.Time, error) {
+	created := make(map[string]time.Time)
+	for _, rc := range prereleases {
+		if rc.Tag.RefObjectID == "" || rc.Tag.RefObjectID == rc.Tag.ObjectID {
+			continue
+		}
+		date, err := s.client.GetTagDate(ctx, rc.Tag.RefObjectID)
+		if errors.Is(err, ado.ErrRefNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading creation date of %s: %w", rc.TagName, err)
+		}
+		created[rc.TagName] = date
+	}
+	return created, nil
+}
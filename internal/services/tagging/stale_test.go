@@ -0,0 +1,134 @@
+package tagging
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado/adotest"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+func seedStaleRCs(client *adotest.Client) {
+	client.SeedAnnotatedTag("v1.2.0", "release-120", "commit-120")
+	client.SeedAnnotatedTag("v1.2.0-rc.1", "rc-120-1", "commit-a")
+	client.SeedAnnotatedTag("v1.2.0-rc.2", "rc-120-2", "commit-b")
+	client.SeedAnnotatedTag("v1.3.0-rc.1", "rc-130-1", "commit-c")
+}
+
+func TestListStaleRCs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		cfg           StaleRCConfig
+		expectDeleted []string
+		expectRemoved int
+	}{
+		{name: "report only"},
+		{name: "delete stale", cfg: StaleRCConfig{DeleteStale: true}, expectDeleted: []string{"v1.2.0-rc.1", "v1.2.0-rc.2"}, expectRemoved: 2},
+		{name: "dry run deletes nothing", cfg: StaleRCConfig{DeleteStale: true, DryRun: true}, expectDeleted: []string{"v1.2.0-rc.1", "v1.2.0-rc.2"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := adotest.NewClient()
+			seedStaleRCs(client)
+			svc := NewService(client, tagplan.NewPlanner("v"))
+
+			report, err := svc.ListStaleRCs(context.Background(), tc.cfg)
+			if err != nil {
+				t.Fatalf("list stale rcs: %v", err)
+			}
+			if len(report.Prereleases) != 3 {
+				t.Fatalf("expected 3 prereleases, got %+v", report.Prereleases)
+			}
+			if report.Prereleases[2].TagName != "v1.3.0-rc.1" || report.Prereleases[2].ReleaseExists {
+				t.Fatalf("expected v1.3.0-rc.1 to be active, got %+v", report.Prereleases[2])
+			}
+			if len(report.Deleted) != len(tc.expectDeleted) {
+				t.Fatalf("deleted: want %v got %v", tc.expectDeleted, report.Deleted)
+			}
+			for i, name := range tc.expectDeleted {
+				if report.Deleted[i] != name {
+					t.Fatalf("deleted: want %v got %v", tc.expectDeleted, report.Deleted)
+				}
+			}
+			if len(client.DeletedRefs) != tc.expectRemoved {
+				t.Fatalf("expected %d DeleteRef calls, got %+v", tc.expectRemoved, client.DeletedRefs)
+			}
+			for _, call := range client.DeletedRefs {
+				if !strings.HasPrefix(call.OldObjectID, "rc-") {
+					t.Fatalf("expected the tag ref object id to be used, got %+v", call)
+				}
+			}
+		})
+	}
+}
+
+func TestListStaleRCsSurfacesDeleteErrors(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	seedStaleRCs(client)
+	client.DeleteErr = errors.New("boom")
+	svc := NewService(client, tagplan.NewPlanner("v"))
+
+	report, err := svc.ListStaleRCs(context.Background(), StaleRCConfig{DeleteStale: true})
+	if !errors.Is(err, client.DeleteErr) {
+		t.Fatalf("expected delete error, got %v", err)
+	}
+	if len(report.Deleted) != 0 {
+		t.Fatalf("expected no deletions to be reported, got %v", report.Deleted)
+	}
+}
+
+func TestListStaleRCsReportsAnnotatedAges(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	seedStaleRCs(client)
+	if err := client.CreateLightweightTag(context.Background(), "v1.3.0-rc.2", "commit-d"); err != nil {
+		t.Fatalf("seed lightweight rc: %v", err)
+	}
+	created := time.Now().Add(-72 * time.Hour)
+	client.TagDates = map[string]time.Time{"rc-120-1": created}
+
+	report, err := NewService(client, tagplan.NewPlanner("v")).ListStaleRCs(context.Background(), StaleRCConfig{})
+	if err != nil {
+		t.Fatalf("list stale rcs: %v", err)
+	}
+	if age, ok := report.Age("v1.2.0-rc.1"); !ok || age < 72*time.Hour || age > 73*time.Hour {
+		t.Fatalf("expected v1.2.0-rc.1 about 72h old, got %s (%t)", age, ok)
+	}
+	// The lightweight RC has no tag object, and rc-120-2 has no readable date.
+	for _, name := range []string{"v1.3.0-rc.2", "v1.2.0-rc.2"} {
+		if _, ok := report.Age(name); ok {
+			t.Fatalf("expected the age of %s to be unknown", name)
+		}
+	}
+}
+
+func TestListStaleRCsSurfacesDateErrors(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	seedStaleRCs(client)
+	svc := NewService(&tagDateErrorClient{Client: client}, tagplan.NewPlanner("v"))
+
+	if _, err := svc.ListStaleRCs(context.Background(), StaleRCConfig{}); err == nil || !strings.Contains(err.Error(), "reading creation date") {
+		t.Fatalf("expected the date read error, got %v", err)
+	}
+}
+
+type tagDateErrorClient struct {
+	*adotest.Client
+}
+
+func (c *tagDateErrorClient) GetTagDate(context.Context, string) (time.Time, error) {
+	return time.Time{}, errors.New("boom")
+}
@@ -0,0 +1,44 @@
+package tagging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrIncompleteIdentity is returned when the authenticated identity lacks a field the tagger needs.
+var ErrIncompleteIdentity = errors.New("tagging service: authenticated identity is missing a name or email")
+
+// FillTaggerFromIdentity populates blank TaggerName/TaggerEmail fields from the identity the ADO
+// token authenticates as. Fields that are already set are preserved.
+func (s Service) FillTaggerFromIdentity(ctx context.Context, cfg CreateConfig) (CreateConfig, error) {
+	if s.client == nil {
+		return CreateConfig{}, ErrNilClient
+	}
+
+	name := strings.TrimSpace(cfg.TaggerName)
+	email := strings.TrimSpace(cfg.TaggerEmail)
+	if name != "" && email != "" {
+		return cfg, nil
+	}
+
+	identity, err := s.client.GetAuthenticatedIdentity(ctx)
+	if err != nil {
+		return CreateConfig{}, fmt.Errorf("getting authenticated identity: %w", err)
+	}
+
+	if name == "" {
+		name = strings.TrimSpace(identity.DisplayName)
+	}
+	if email == "" {
+		email = strings.TrimSpace(identity.Email)
+	}
+	if name == "" || email == "" {
+		return CreateConfig{}, ErrIncompleteIdentity
+	}
+
+	cfg.TaggerName = name
+	cfg.TaggerEmail = email
+	return cfg, nil
+}
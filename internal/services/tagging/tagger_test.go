@@ -0,0 +1,86 @@
+package tagging
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado/adotest"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+func TestFillTaggerFromIdentity(t *testing.T) {
+	t.Parallel()
+
+	buildIdentity := ado.Identity{DisplayName: "Project Build Service", Email: "build@example.com"}
+
+	cases := []struct {
+		name      string
+		identity  ado.Identity
+		lookupErr error
+		cfg       CreateConfig
+		wantName  string
+		wantEmail string
+		wantErr   error
+	}{
+		{
+			name:      "fills both fields",
+			identity:  buildIdentity,
+			wantName:  "Project Build Service",
+			wantEmail: "build@example.com",
+		},
+		{
+			name:      "keeps explicit name",
+			identity:  buildIdentity,
+			cfg:       CreateConfig{TaggerName: "release-bot"},
+			wantName:  "release-bot",
+			wantEmail: "build@example.com",
+		},
+		{
+			name:      "skips lookup when both set",
+			lookupErr: errors.New("should not be called"),
+			cfg:       CreateConfig{TaggerName: "bot", TaggerEmail: "bot@example.com"},
+			wantName:  "bot",
+			wantEmail: "bot@example.com",
+		},
+		{
+			name:     "identity without email",
+			identity: ado.Identity{DisplayName: "Project Build Service"},
+			wantErr:  ErrIncompleteIdentity,
+		},
+		{
+			name:      "lookup failure",
+			lookupErr: errors.New("boom"),
+			wantErr:   errors.New("boom"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := adotest.NewClient()
+			client.Identity = tc.identity
+			client.IdentityErr = tc.lookupErr
+			svc := NewService(client, tagplan.NewPlanner("v"))
+
+			got, err := svc.FillTaggerFromIdentity(context.Background(), tc.cfg)
+			if tc.wantErr != nil {
+				if err == nil {
+					t.Fatalf("expected error %v", tc.wantErr)
+				}
+				if errors.Is(tc.wantErr, ErrIncompleteIdentity) && !errors.Is(err, ErrIncompleteIdentity) {
+					t.Fatalf("expected ErrIncompleteIdentity, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.TaggerName != tc.wantName || got.TaggerEmail != tc.wantEmail {
+				t.Fatalf("unexpected tagger %q <%s>", got.TaggerName, got.TaggerEmail)
+			}
+		})
+	}
+}
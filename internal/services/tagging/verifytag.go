@@ -0,0 +1,76 @@
+package tagging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+// ErrTagNotVisible is returned under CreateConfig.VerifyTag when the created tag is not listed
+// at the target commit before the verification timeout.
+var ErrTagNotVisible = errors.New("tagging service: created tag not visible")
+
+// verifyTagInterval is the delay between ref listings while a created tag is verified.
+const verifyTagInterval = 2 * time.Second
+
+// verifyTag lists the created tag until ADO reports it at the target commit. ADO acknowledges
+// a create before the ref is always queryable, so with cfg.VerifyTag a job does not continue
+// on a tag later steps cannot see. Every listing bypasses the ref cache, which would otherwise
+// replay the first listing after the create. The number of listings is recorded on the plan.
+func (s Service) verifyTag(ctx context.Context, cfg CreateConfig, plan *tagplan.Result) error {
+	if !cfg.VerifyTag {
+		return nil
+	}
+	ctx = ado.WithFreshRefs(ctx)
+	name := tagRefPrefix + plan.TagName
+	commit := strings.TrimSpace(cfg.CommitSHA)
+	attempts := 1 + int(cfg.VerifyTimeout/verifyTagInterval)
+
+	observed := ""
+	for attempt := 1; ; attempt++ {
+		plan.VerifyAttempts = attempt
+		refs, err := s.client.ListRefsWithPrefix(ctx, name)
+		if err != nil {
+			return fmt.Errorf("verifying tag %s: %w", plan.TagName, err)
+		}
+		for _, ref := range refs {
+			if ref.Name != name {
+				continue
+			}
+			observed = refTargetObjectID(ref)
+			if strings.EqualFold(observed, commit) {
+				return nil
+			}
+		}
+		if attempt >= attempts {
+			break
+		}
+		if err := s.sleep(ctx, verifyTagInterval); err != nil {
+			return err
+		}
+	}
+
+	if observed != "" {
+		return fmt.Errorf("%w: %s points at %s, want %s", ErrTagNotVisible, plan.TagName, observed, commit)
+	}
+	return fmt.Errorf("%w: %s not listed after %s", ErrTagNotVisible, plan.TagName, cfg.VerifyTimeout)
+}
+
+func (s Service) sleep(ctx context.Context, d time.Duration) error {
+	if s.wait != nil {
+		return s.wait(ctx, d)
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,100 @@
+package tagging
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado/adotest"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+func TestPlanAndCreateVerifyTag(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		verify    bool
+		hidden    int
+		timeout   time.Duration
+		attempts  int
+		waits     int
+		expectErr error
+	}{
+		{name: "verification disabled", hidden: 5},
+		{name: "visible immediately", verify: true, timeout: 10 * time.Second, attempts: 1},
+		{name: "visible after a delay", verify: true, hidden: 2, timeout: 10 * time.Second, attempts: 3, waits: 2},
+		{name: "not visible before timeout", verify: true, hidden: 10, timeout: 4 * time.Second, attempts: 3, waits: 2, expectErr: ErrTagNotVisible},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := adotest.NewClient()
+			client.SeedAnnotatedTag(sampleReleaseTag, "release-tag-object", sampleReleaseObjectID)
+			client.HiddenListings = map[string]int{"refs/tags/v1.2.4": tc.hidden}
+			svc := NewService(client, tagplan.NewPlanner("v"))
+			var waits []time.Duration
+			svc.wait = func(_ context.Context, d time.Duration) error {
+				waits = append(waits, d)
+				return nil
+			}
+
+			result, err := svc.PlanAndCreate(context.Background(), CreateConfig{
+				Config:        Config{Mode: tagplan.ModeRelease, Bump: bump.BumpPatch},
+				CommitSHA:     "deadbeef",
+				TaggerName:    taggerNameDefault,
+				TaggerEmail:   taggerEmailDefault,
+				VerifyTag:     tc.verify,
+				VerifyTimeout: tc.timeout,
+			})
+
+			if len(waits) != tc.waits {
+				t.Fatalf("expected %d waits, got %v", tc.waits, waits)
+			}
+			if tc.expectErr != nil {
+				if !errors.Is(err, tc.expectErr) {
+					t.Fatalf("expected %v, got %v", tc.expectErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("plan and create: %v", err)
+			}
+			if result.VerifyAttempts != tc.attempts {
+				t.Fatalf("expected %d verification listings, got %d", tc.attempts, result.VerifyAttempts)
+			}
+		})
+	}
+}
+
+func TestVerifyTagBypassesRefCache(t *testing.T) {
+	t.Parallel()
+
+	inner := adotest.NewClient()
+	inner.SeedAnnotatedTag(sampleReleaseTag, "release-tag-object", sampleReleaseObjectID)
+	inner.HiddenListings = map[string]int{"refs/tags/v1.2.4": 2}
+	// --ref-cache-ttl wraps the client in the cache; a cached first listing would hide the tag
+	// for every later poll.
+	svc := NewService(ado.NewRefCacheClient(inner, time.Hour), tagplan.NewPlanner("v"))
+	svc.wait = func(context.Context, time.Duration) error { return nil }
+
+	result, err := svc.PlanAndCreate(context.Background(), CreateConfig{
+		Config:        Config{Mode: tagplan.ModeRelease, Bump: bump.BumpPatch},
+		CommitSHA:     "deadbeef",
+		TaggerName:    taggerNameDefault,
+		TaggerEmail:   taggerEmailDefault,
+		VerifyTag:     true,
+		VerifyTimeout: 10 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("plan and create: %v", err)
+	}
+	if result.VerifyAttempts != 3 {
+		t.Fatalf("expected the tag seen on the third listing, got %d", result.VerifyAttempts)
+	}
+}
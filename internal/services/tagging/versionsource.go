@@ -0,0 +1,121 @@
+package tagging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultVersionSourceTimeout bounds a single HTTPVersionSource request.
+	DefaultVersionSourceTimeout = 30 * time.Second
+
+	maxVersionResponseBytes = 64 << 10
+)
+
+var (
+	// ErrVersionSourceResponse is returned when a version source answers without a usable version.
+	ErrVersionSourceResponse = errors.New("tagging service: invalid version source response")
+	// ErrVersionSourceTimeout is returned when the version source does not answer within its
+	// timeout.
+	ErrVersionSourceTimeout = errors.New("tagging service: version source timed out")
+)
+
+// VersionSource supplies the current release version that the next version is bumped from.
+// A nil VersionSource, the default, uses the highest release tag in the repository.
+type VersionSource interface {
+	CurrentVersion(ctx context.Context) (string, error)
+}
+
+// HTTPVersionSource reads the current version with a GET request. The response body is either
+// the bare version ("1.2.3") or a JSON object with a "version" field.
+type HTTPVersionSource struct {
+	URL    string
+	Client *http.Client
+	// Timeout, when positive, bounds the whole request including reading the body. The
+	// caller's context still applies, so cancelling it aborts the request sooner.
+	Timeout time.Duration
+}
+
+// NewHTTPVersionSource constructs an HTTPVersionSource using DefaultVersionSourceTimeout.
+func NewHTTPVersionSource(url string) HTTPVersionSource {
+	return HTTPVersionSource{
+		URL:    strings.TrimSpace(url),
+		Client: &http.Client{Timeout: DefaultVersionSourceTimeout},
+	}
+}
+
+// WithTimeout returns a copy of the source whose requests are bounded by timeout.
+func (s HTTPVersionSource) WithTimeout(timeout time.Duration) HTTPVersionSource {
+	s.Timeout = timeout
+	return s
+}
+
+// CurrentVersion fetches and extracts the version from the configured URL.
+func (s HTTPVersionSource) CurrentVersion(ctx context.Context) (version string, err error) {
+	if s.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.Timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.URL, nil)
+	if err != nil {
+		return "", fmt.Errorf("building version source request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json, text/plain")
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", s.requestError(ctx, "querying version source", err)
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil && err == nil {
+			version, err = "", fmt.Errorf("closing version source response: %w", closeErr)
+		}
+	}()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxVersionResponseBytes))
+	if err != nil {
+		return "", s.requestError(ctx, "reading version source response", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", fmt.Errorf("%w: status %d", ErrVersionSourceResponse, resp.StatusCode)
+	}
+	return parseVersionResponse(body)
+}
+
+// requestError reports an expired Timeout as ErrVersionSourceTimeout; cancellation by the
+// caller keeps context.Canceled in the chain.
+func (s HTTPVersionSource) requestError(ctx context.Context, step string, err error) error {
+	if s.Timeout > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%s: %w after %s", step, ErrVersionSourceTimeout, s.Timeout)
+	}
+	return fmt.Errorf("%s: %w", step, err)
+}
+
+func parseVersionResponse(body []byte) (string, error) {
+	text := strings.TrimSpace(string(body))
+	if strings.HasPrefix(text, "{") {
+		var payload struct {
+			Version string `json:"version"`
+		}
+		if err := json.Unmarshal([]byte(text), &payload); err != nil {
+			return "", fmt.Errorf("%w: %v", ErrVersionSourceResponse, err)
+		}
+		text = strings.TrimSpace(payload.Version)
+	}
+	if text == "" {
+		return "", fmt.Errorf("%w: no version", ErrVersionSourceResponse)
+	}
+	return text, nil
+}
@@ -0,0 +1,231 @@
+package tagging
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/ado/adotest"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/bump"
+	"github.com/launchbynttdata/launch-ado-automatic-versioner/internal/domain/tagplan"
+)
+
+func TestHTTPVersionSourceBumpsCurrentVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		bump        bump.Bump
+		expectTag   string
+	}{
+		{name: "plain text", contentType: "text/plain", body: "4.1.0\n", bump: bump.BumpMinor, expectTag: "v4.2.0"},
+		{name: "json", contentType: "application/json", body: `{"version":"v4.1.0"}`, bump: bump.BumpMajor, expectTag: "v5.0.0"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet {
+					t.Errorf("expected GET, got %s", r.Method)
+				}
+				w.Header().Set("Content-Type", tc.contentType)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			t.Cleanup(server.Close)
+
+			client := adotest.NewClient()
+			client.SeedAnnotatedTag(sampleReleaseTag, "release-tag-object", sampleReleaseObjectID)
+			svc := NewService(client, tagplan.NewPlanner("v"))
+
+			result, err := svc.PlanAndCreate(context.Background(), CreateConfig{
+				Config: Config{
+					Mode:          tagplan.ModeRelease,
+					Bump:          tc.bump,
+					VersionSource: NewHTTPVersionSource(server.URL),
+				},
+				CommitSHA:   "deadbeef",
+				TaggerName:  taggerNameDefault,
+				TaggerEmail: taggerEmailDefault,
+			})
+			if err != nil {
+				t.Fatalf("plan and create: %v", err)
+			}
+			if result.TagName != tc.expectTag || result.BaseSource != tagplan.BaseSourceExternal {
+				t.Fatalf("expected %s from external source, got %s (%s)", tc.expectTag, result.TagName, result.BaseSource)
+			}
+			if len(client.CreatedTags) != 1 || client.CreatedTags[0].Name != tc.expectTag {
+				t.Fatalf("expected %s to be created in ADO, got %+v", tc.expectTag, client.CreatedTags)
+			}
+		})
+	}
+}
+
+func TestHTTPVersionSourceErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{name: "non-2xx status", status: http.StatusInternalServerError, body: "1.2.3"},
+		{name: "empty body", status: http.StatusOK},
+		{name: "json without version", status: http.StatusOK, body: `{"current":"1.2.3"}`},
+		{name: "malformed json", status: http.StatusOK, body: `{"version":`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			t.Cleanup(server.Close)
+
+			_, err := NewHTTPVersionSource(server.URL).CurrentVersion(context.Background())
+			if !errors.Is(err, ErrVersionSourceResponse) {
+				t.Fatalf("expected ErrVersionSourceResponse, got %v", err)
+			}
+		})
+	}
+}
+
+func TestPlanSkipsVersionSourceForHotfix(t *testing.T) {
+	t.Parallel()
+
+	client := adotest.NewClient()
+	client.SeedAnnotatedTag(sampleReleaseTag, "release-tag-object", sampleReleaseObjectID)
+	svc := NewService(client, tagplan.NewPlanner("v"))
+
+	result, err := svc.Plan(context.Background(), Config{
+		Mode:          tagplan.ModeRelease,
+		HotfixBase:    sampleReleaseTag,
+		VersionSource: failingVersionSource{},
+	})
+	if err != nil {
+		t.Fatalf("plan: %v", err)
+	}
+	if result.BaseSource != tagplan.BaseSourceHotfix {
+		t.Fatalf("expected hotfix base, got %s", result.BaseSource)
+	}
+}
+
+type failingVersionSource struct{}
+
+func (failingVersionSource) CurrentVersion(context.Context) (string, error) {
+	return "", errors.New("version source should not be queried")
+}
+
+// newSlowServer answers only after delay or once the client gives up, whichever comes first.
+func newSlowServer(t *testing.T, delay time.Duration) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(delay):
+			_, _ = w.Write([]byte("1.2.3"))
+		case <-r.Context().Done():
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestHTTPVersionSourceTimeout(t *testing.T) {
+	t.Parallel()
+
+	server := newSlowServer(t, 5*time.Second)
+	source := NewHTTPVersionSource(server.URL).WithTimeout(50 * time.Millisecond)
+
+	start := time.Now()
+	_, err := source.CurrentVersion(context.Background())
+	if !errors.Is(err, ErrVersionSourceTimeout) {
+		t.Fatalf("expected ErrVersionSourceTimeout, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("expected the timeout to fire promptly, took %s", elapsed)
+	}
+}
+
+func TestHTTPVersionSourceHonorsCancellation(t *testing.T) {
+	t.Parallel()
+
+	server := newSlowServer(t, 5*time.Second)
+	source := NewHTTPVersionSource(server.URL).WithTimeout(time.Minute)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	_, err := source.CurrentVersion(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if errors.Is(err, ErrVersionSourceTimeout) {
+		t.Fatalf("cancellation must not be reported as a timeout: %v", err)
+	}
+}
+
+// closeFailingTransport answers every request with body, whose Close fails with closeErr.
+type closeFailingTransport struct {
+	body     string
+	closeErr error
+}
+
+func (t closeFailingTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	body := struct {
+		io.Reader
+		io.Closer
+	}{strings.NewReader(t.body), closeFunc(func() error { return t.closeErr })}
+	return &http.Response{StatusCode: http.StatusOK, Body: body}, nil
+}
+
+type closeFunc func() error
+
+func (f closeFunc) Close() error { return f() }
+
+func TestHTTPVersionSourceReportsCloseError(t *testing.T) {
+	t.Parallel()
+
+	closeErr := errors.New("connection reset")
+	source := NewHTTPVersionSource("https://versions.example.com/current")
+	source.Client = &http.Client{Transport: closeFailingTransport{body: "1.4.2", closeErr: closeErr}}
+
+	version, err := source.CurrentVersion(context.Background())
+	if !errors.Is(err, closeErr) || version != "" {
+		t.Fatalf("expected the close error, got version %q err=%v", version, err)
+	}
+}
+
+func TestPlanAndCreateSlowVersionSourceCreatesNothing(t *testing.T) {
+	t.Parallel()
+
+	server := newSlowServer(t, 5*time.Second)
+	client := adotest.NewClient()
+	client.SeedAnnotatedTag(sampleReleaseTag, "release-tag-object", sampleReleaseObjectID)
+
+	_, err := NewService(client, tagplan.NewPlanner("v")).PlanAndCreate(context.Background(), CreateConfig{
+		Config: Config{
+			Mode:          tagplan.ModeRelease,
+			Bump:          bump.BumpPatch,
+			VersionSource: NewHTTPVersionSource(server.URL).WithTimeout(50 * time.Millisecond),
+		},
+		CommitSHA:   "deadbeef",
+		TaggerName:  taggerNameDefault,
+		TaggerEmail: taggerEmailDefault,
+	})
+	if !errors.Is(err, ErrVersionSourceTimeout) {
+		t.Fatalf("expected ErrVersionSourceTimeout, got %v", err)
+	}
+	if len(client.CreatedTags) != 0 {
+		t.Fatalf("expected no tags after a timed-out version source, got %+v", client.CreatedTags)
+	}
+}